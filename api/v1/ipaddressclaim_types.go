@@ -21,14 +21,84 @@ import (
 )
 
 // IpAddressClaimSpec defines the desired state of IpAddressClaim
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.dualStack) || has(self.dualStack)",message="Field 'dualStack' is required once set"
+// +kubebuilder:validation:XValidation:rule="!has(self.count) || self.count == 1 || (!has(self.dualStack) && !has(self.preferredAddress))",message="Fields 'dualStack' and 'preferredAddress' can not be combined with a 'count' greater than 1"
+// +kubebuilder:validation:XValidation:rule="[has(self.parentPrefix), has(self.parentPrefixSelector), has(self.parentPrefixes), has(self.parentIpRange)].filter(x, x).size() == 1",message="Exactly one of 'parentPrefix', 'parentPrefixSelector', 'parentPrefixes' and 'parentIpRange' must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.connection) == has(oldSelf.connection)",message="Field 'connection' is immutable"
 type IpAddressClaimSpec struct {
 	// The NetBox Prefix from which this IP Address should be claimed from
-	// Field is immutable, required
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector`, `parentPrefixes` and `parentIpRange` are mutually exclusive)
 	// Example: "192.168.0.0/20"
-	//+kubebuilder:validation:Required
 	//+kubebuilder:validation:Format=cidr
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'parentPrefix' is immutable"
-	ParentPrefix string `json:"parentPrefix"`
+	ParentPrefix string `json:"parentPrefix,omitempty"`
+
+	// An ordered list of up to 10 NetBox Prefixes from which this IP Address should be claimed from.
+	// The entries are tried in order, entries which are exhausted or not found in NetBox are skipped.
+	// The entry which is used is stored in `.status.parentPrefix`
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector`, `parentPrefixes` and `parentIpRange` are mutually exclusive)
+	// Example: ["192.168.0.0/20", "192.168.16.0/20"]
+	//+kubebuilder:validation:MinItems=1
+	//+kubebuilder:validation:MaxItems=10
+	//+kubebuilder:validation:items:Format=cidr
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'parentPrefixes' is immutable"
+	ParentPrefixes []string `json:"parentPrefixes,omitempty"`
+
+	// The `parentPrefixSelector` is a key-value map, where all the entries are of data type `<string-string>` The map contains a set of query conditions for selecting a set of prefixes that can be used as the parent prefix The query conditions will be chained by the AND operator, and exact match of the keys and values will be performed The built-in fields `tenant`, `site`, `family` and `vlan_id` (the NetBox ID of a VLAN), along with custom fields, can be used. Only prefixes with at least one available IP Address are considered. For more information, please see ParentPrefixSelectorGuide.md
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector`, `parentPrefixes` and `parentIpRange` are mutually exclusive)
+	// Example:
+	//   customfield1: "Production"
+	//   family: "IPv4"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'parentPrefixSelector' is immutable"
+	//+kubebuilder:validation:XValidation:rule="!has(self.family) || (self.family == 'IPv4' || self.family == 'IPv6')"
+	ParentPrefixSelector map[string]string `json:"parentPrefixSelector,omitempty"`
+
+	// The NetBox IP Range from which this IP Address should be claimed from, e.g. a DHCP-like pool.
+	// The IP Range is either referenced by the `name` of an IpRange CR in the namespace of the
+	// IpAddressClaim or by its `startAddress` and `endAddress`. The IP Range which is used is
+	// stored in `.status.parentIpRange`
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector`, `parentPrefixes` and `parentIpRange` are mutually exclusive)
+	// Example:
+	//   name: "iprange-sample"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'parentIpRange' is immutable"
+	ParentIpRange *IpAddressClaimParentIpRange `json:"parentIpRange,omitempty"`
+
+	// The IP Address which should preferably be claimed, without prefix length. The
+	// IP Address is only claimed if it is available in the parent prefix, otherwise
+	// the `preferredAllocationPolicy` applies.
+	// Field is immutable, not required
+	// Example: "192.168.0.10" or "2001:db8::10"
+	//+kubebuilder:validation:XValidation:rule="isIP(self)",message="Field 'preferredAddress' must be an IP address"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'preferredAddress' is immutable"
+	PreferredAddress string `json:"preferredAddress,omitempty"`
+
+	// Defines what happens if the `preferredAddress` is not available in the parent prefix
+	// - Fail: the IP Address is not claimed and the IpAddressClaim reports the failure
+	// - FallbackToDynamic: the next available IP Address of the parent prefix is claimed instead
+	// Only used together with `preferredAddress`, defaults to Fail
+	// Field is mutable, not required
+	PreferredAllocationPolicy PreferredAllocationPolicy `json:"preferredAllocationPolicy,omitempty"`
+
+	// Enables the dual-stack mode. In addition to the IP Address claimed from `parentPrefix`,
+	// `parentPrefixSelector` or `parentPrefixes`, an IP Address of the other IP family is
+	// claimed from the parent prefix defined here. It is stored in a second IpAddress CR
+	// named `<name>-dualstack` and reported in `.status.dualStack`. The IpAddressClaim is
+	// only Ready once the IP Addresses of both IP families are claimed.
+	// Field is immutable, not required
+	// Example:
+	//   parentPrefix: "2001:db8::/64"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'dualStack' is immutable"
+	DualStack *IpAddressClaimDualStackSpec `json:"dualStack,omitempty"`
+
+	// The number of IP Addresses to claim from the parent prefix. The first IpAddress CR
+	// is named after the IpAddressClaim, the following ones `<name>-1` to `<name>-<count-1>`.
+	// Each IpAddress has its own restoration hash. Increasing the count claims additional
+	// IP Addresses, decreasing it deletes the IpAddress CRs with the highest indexes.
+	// All IP Addresses are claimed from the same parent prefix and listed in `.status.ipAddresses`.
+	// Field is mutable, not required, defaults to 1
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=256
+	Count int32 `json:"count,omitempty"`
 
 	// The NetBox Tenant to be assigned to this resource in NetBox. Use the `name` value instead of the `slug` value
 	// Field is immutable, not required
@@ -36,16 +106,64 @@ type IpAddressClaimSpec struct {
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'tenant' is immutable"
 	Tenant string `json:"tenant,omitempty"`
 
+	// The NetBox VRF to be assigned to the claimed IP Addresses in NetBox. Use the `name` value of the VRF.
+	// The parent prefix or IP Range and the restorable IP Addresses are only looked up in this VRF.
+	// If not set, the parent is looked up regardless of its VRF and the claimed IP Addresses inherit
+	// the VRF of the parent prefix or IP Range.
+	// Field is immutable, not required
+	// Example: "customer-a"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'vrf' is immutable"
+	Vrf string `json:"vrf,omitempty"`
+
+	// The status of the claimed IP Addresses in NetBox, one of active, reserved, deprecated, dhcp or slaac.
+	// If not set, the status is active
+	// Field is mutable, not required
+	// Example: "reserved"
+	//+kubebuilder:validation:Enum=active;reserved;deprecated;dhcp;slaac
+	Status string `json:"status,omitempty"`
+
+	// The role of the claimed IP Addresses in NetBox, one of loopback, secondary, anycast, vip, vrrp,
+	// hsrp, glbp or carp. If not set, the role of the claimed IP Addresses in NetBox is not changed
+	// Field is mutable, not required
+	// Example: "vip"
+	//+kubebuilder:validation:Enum=loopback;secondary;anycast;vip;vrrp;hsrp;glbp;carp
+	Role string `json:"role,omitempty"`
+
+	// The DNS name of the claimed IP Addresses in NetBox. The DNS name is a Go template which is
+	// executed with the metadata of the IpAddressClaim, e.g. its `.Name`, `.Namespace` and `.Labels`,
+	// the result must be a fully qualified domain name as defined in RFC 1123.
+	// If not set, the DNS name of the IP Addresses in NetBox is not changed, a DNS name which was
+	// set by the operator before is cleared
+	// Field is mutable, not required
+	// Example: "{{.Name}}.{{.Namespace}}.k8s.example.com"
+	DnsName string `json:"dnsName,omitempty"`
+
 	// The NetBox Custom Fields that should be added to the resource in NetBox.
-	// Note that currently only Text Type is supported (GitHub #129)
+	// The values are converted to the type of the custom field in NetBox, e.g. "100" for an
+	// Integer, "true" for a Boolean or a comma separated list for a Multiple selection.
 	// More info on NetBox Custom Fields:
 	// https://github.com/netbox-community/netbox/blob/main/docs/customization/custom-fields.md
 	// Field is mutable, not required
 	// Example:
 	//   customfield1: "Production"
 	//   customfield2: "This is a string"
+	//   vlan_id: "100"
 	CustomFields map[string]string `json:"customFields,omitempty"`
 
+	// The NetBox Tags that should be assigned to the resource in NetBox, referenced by their
+	// name or slug. Tags which are removed from the list are removed from the resource in NetBox,
+	// tags which were assigned in NetBox are kept.
+	// The tags are passed on to the IpAddresses of the claim.
+	// More info on NetBox Tags:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/extras/tag.md
+	// Field is mutable, not required
+	// Example:
+	//   - "production"
+	//   - "team-network"
+	//+listType=set
+	//+kubebuilder:validation:items:MinLength=1
+	Tags []string `json:"tags,omitempty"`
+
 	// Comment that should be added to the resource in NetBox
 	// Field is mutable, not required
 	Comments string `json:"comments,omitempty"`
@@ -65,10 +183,66 @@ type IpAddressClaimSpec struct {
 	// recreated in Kubernetes)
 	// Field is mutable, not required
 	PreserveInNetbox bool `json:"preserveInNetbox,omitempty"`
+
+	// The name of the NetBoxConnection of the NetBox instance the resource is managed in.
+	// If not set, the NetBox instance of the operator configuration is used
+	// Field is immutable, not required
+	// Example: "netbox-lab"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'connection' is immutable"
+	Connection string `json:"connection,omitempty"`
+}
+
+// IpAddressClaimParentIpRange references the NetBox IP Range an IP Address is claimed from
+// +kubebuilder:validation:XValidation:rule="has(self.startAddress) == has(self.endAddress)",message="Fields 'startAddress' and 'endAddress' must be set together"
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.startAddress)",message="Exactly one of 'name' and 'startAddress'/'endAddress' must be set"
+type IpAddressClaimParentIpRange struct {
+	// The name of an IpRange CR in the namespace of the IpAddressClaim
+	// Example: "iprange-sample"
+	Name string `json:"name,omitempty"`
+
+	// The first IP of the NetBox IP Range in CIDR notation
+	// Example: "192.168.0.1/24"
+	//+kubebuilder:validation:Format=cidr
+	StartAddress string `json:"startAddress,omitempty"`
+
+	// The last IP of the NetBox IP Range in CIDR notation
+	// Example: "192.168.0.20/24"
+	//+kubebuilder:validation:Format=cidr
+	EndAddress string `json:"endAddress,omitempty"`
+}
+
+// IpAddressClaimDualStackSpec defines from where the IP Address of the other IP family
+// is claimed in dual-stack mode
+// +kubebuilder:validation:XValidation:rule="has(self.parentPrefix) != has(self.parentPrefixSelector)",message="Exactly one of 'parentPrefix' and 'parentPrefixSelector' must be set"
+type IpAddressClaimDualStackSpec struct {
+	// The NetBox Prefix from which the IP Address of the other IP family should be claimed from
+	// Example: "2001:db8::/64"
+	//+kubebuilder:validation:Format=cidr
+	ParentPrefix string `json:"parentPrefix,omitempty"`
+
+	// Selects the parent prefix of the IP Address of the other IP family, works the same
+	// way as `.spec.parentPrefixSelector`
+	// Example:
+	//   customfield1: "Production"
+	//   family: "IPv6"
+	//+kubebuilder:validation:XValidation:rule="!has(self.family) || (self.family == 'IPv4' || self.family == 'IPv6')"
+	ParentPrefixSelector map[string]string `json:"parentPrefixSelector,omitempty"`
 }
 
 // IpAddressClaimStatus defines the observed state of IpAddressClaim
 type IpAddressClaimStatus struct {
+	// Due to the fact that the parent prefix can be specified directly in
+	// `.spec.parentPrefix` or selected from `.spec.parentPrefixSelector` or `.spec.parentPrefixes`,
+	// we use this field to store exactly which parent prefix we are using
+	// for all subsequent reconcile loop calls.
+	// For `.spec.parentIpRange` it is the NetBox Prefix containing the IP Range,
+	// whose lease is locked while IP Addresses are claimed from the IP Range.
+	SelectedParentPrefix string `json:"parentPrefix,omitempty"`
+
+	// The NetBox IP Range referenced in `.spec.parentIpRange` the IP Address is claimed from,
+	// in the format `<startAddress>-<endAddress>`
+	SelectedParentIpRange string `json:"parentIpRange,omitempty"`
+
 	// The assigned IP Address in CIDR notation
 	IpAddress string `json:"ipAddress,omitempty"`
 
@@ -78,22 +252,49 @@ type IpAddressClaimStatus struct {
 	// The name of the IpAddress CR created by the IpAddressClaim Controller
 	IpAddressName string `json:"ipAddressName,omitempty"`
 
+	// All assigned IP Addresses in CIDR notation, ordered by index, if `count` is greater than 1
+	IpAddresses []string `json:"ipAddresses,omitempty"`
+
+	// The IP Address of the other IP family claimed in dual-stack mode
+	DualStack *IpAddressClaimDualStackStatus `json:"dualStack,omitempty"`
+
 	// Conditions represent the latest available observations of an object's state
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
+// IpAddressClaimDualStackStatus defines the observed state of the IP Address
+// of the other IP family in dual-stack mode
+type IpAddressClaimDualStackStatus struct {
+	// The parent prefix the IP Address of the other IP family is claimed from
+	SelectedParentPrefix string `json:"parentPrefix,omitempty"`
+
+	// The assigned IP Address of the other IP family in CIDR notation
+	IpAddress string `json:"ipAddress,omitempty"`
+
+	// The assigned IP Address of the other IP family in Dot Decimal notation
+	IpAddressDotDecimal string `json:"ipAddressDotDecimal,omitempty"`
+
+	// The name of the second IpAddress CR created by the IpAddressClaim Controller
+	IpAddressName string `json:"ipAddressName,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="IpAddress",type=string,JSONPath=`.status.ipAddress`
+//+kubebuilder:printcolumn:name="DualStackIpAddress",type=string,JSONPath=`.status.dualStack.ipAddress`,priority=1
+//+kubebuilder:printcolumn:name="Count",type=integer,JSONPath=`.spec.count`,priority=1
 //+kubebuilder:printcolumn:name="IpAssigned",type=string,JSONPath=`.status.conditions[?(@.type=="IPAssigned")].status`
 //+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.spec.status`,priority=1
+//+kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.role`,priority=1
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:resource:shortName=ipac
 
 // IpAddressClaim allows to claim a NetBox IP Address from an existing Prefix.
 // The IpAddressClaim Controller will try to assign an available IP Address
-// from the Prefix that is defined in the spec and if successful it will create
+// from the Prefix that is defined in the spec (or selected with the parent
+// prefix selector) and if successful it will create
 // the IpAddress CR. More info about NetBox IP Addresses:
 // https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/ipaddress.md
 type IpAddressClaim struct {
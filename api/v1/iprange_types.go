@@ -21,6 +21,7 @@ import (
 )
 
 // IpRangeSpec defines the desired state of IpRange
+// +kubebuilder:validation:XValidation:rule="has(self.connection) == has(oldSelf.connection)",message="Field 'connection' is immutable"
 type IpRangeSpec struct {
 	// The first IP in CIDR notation that should be included in the NetBox IP Range
 	// Field is immutable, required
@@ -44,16 +45,54 @@ type IpRangeSpec struct {
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'tenant' is immutable"
 	Tenant string `json:"tenant,omitempty"`
 
+	// The NetBox VRF to be assigned to this resource in NetBox. Use the `name` value of the VRF.
+	// The IP Range is only looked up in this VRF in NetBox. If not set, it is created in the
+	// global table and looked up regardless of its VRF.
+	// Field is immutable, not required
+	// Example: "customer-a"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'vrf' is immutable"
+	Vrf string `json:"vrf,omitempty"`
+
+	// The status of the IP Range in NetBox, one of active, reserved or deprecated.
+	// If not set, the status is active
+	// Field is mutable, not required
+	// Example: "reserved"
+	//+kubebuilder:validation:Enum=active;reserved;deprecated
+	Status string `json:"status,omitempty"`
+
+	// The NetBox Role to be assigned to the IP Range in NetBox, referenced by its name or slug.
+	// If not set, the role of the IP Range in NetBox is not changed
+	// More info on NetBox Roles:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/role.md
+	// Field is mutable, not required
+	// Example: "k8s-pods"
+	Role string `json:"role,omitempty"`
+
 	// The NetBox Custom Fields that should be added to the resource in NetBox.
-	// Note that currently only Text Type is supported (GitHub #129)
+	// The values are converted to the type of the custom field in NetBox, e.g. "100" for an
+	// Integer, "true" for a Boolean or a comma separated list for a Multiple selection.
 	// More info on NetBox Custom Fields:
 	// https://github.com/netbox-community/netbox/blob/main/docs/customization/custom-fields.md
 	// Field is mutable, not required
 	// Example:
 	//   customfield1: "Production"
 	//   customfield2: "This is a string"
+	//   vlan_id: "100"
 	CustomFields map[string]string `json:"customFields,omitempty"`
 
+	// The NetBox Tags that should be assigned to the resource in NetBox, referenced by their
+	// name or slug. Tags which are removed from the list are removed from the resource in NetBox,
+	// tags which were assigned in NetBox are kept.
+	// More info on NetBox Tags:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/extras/tag.md
+	// Field is mutable, not required
+	// Example:
+	//   - "production"
+	//   - "team-network"
+	//+listType=set
+	//+kubebuilder:validation:items:MinLength=1
+	Tags []string `json:"tags,omitempty"`
+
 	// Comment that should be added to the resource in NetBox
 	// Field is mutable, not required
 	Comments string `json:"comments,omitempty"`
@@ -73,6 +112,13 @@ type IpRangeSpec struct {
 	// recreated in Kubernetes)
 	// Field is mutable, not required
 	PreserveInNetbox bool `json:"preserveInNetbox,omitempty"`
+
+	// The name of the NetBoxConnection of the NetBox instance the resource is managed in.
+	// If not set, the NetBox instance of the operator configuration is used
+	// Field is immutable, not required
+	// Example: "netbox-lab"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'connection' is immutable"
+	Connection string `json:"connection,omitempty"`
 }
 
 // IpRangeStatus defines the observed state of IpRange
@@ -97,6 +143,8 @@ type IpRangeStatus struct {
 //+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="StartAddress",type=string,JSONPath=`.spec.startAddress`
 //+kubebuilder:printcolumn:name="EndAddress",type=string,JSONPath=`.spec.endAddress`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.spec.status`
+//+kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.role`
 //+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 //+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`
 //+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`
@@ -21,14 +21,35 @@ import (
 )
 
 // IpRangeClaimSpec defines the desired state of IpRangeClaim
+// +kubebuilder:validation:XValidation:rule="[has(self.parentPrefix), has(self.parentPrefixSelector), has(self.parentPrefixes)].filter(x, x).size() == 1",message="Exactly one of 'parentPrefix', 'parentPrefixSelector' and 'parentPrefixes' must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.connection) == has(oldSelf.connection)",message="Field 'connection' is immutable"
 type IpRangeClaimSpec struct {
 	// The NetBox Prefix from which this IP Range should be claimed from
-	// Field is immutable, required
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector` and `parentPrefixes` are mutually exclusive)
 	// Example: "192.168.0.0/20"
-	//+kubebuilder:validation:Required
 	//+kubebuilder:validation:Format=cidr
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'parentPrefix' is immutable"
-	ParentPrefix string `json:"parentPrefix"`
+	ParentPrefix string `json:"parentPrefix,omitempty"`
+
+	// An ordered list of up to 10 NetBox Prefixes from which this IP Range should be claimed from.
+	// The entries are tried in order, entries which are exhausted or not found in NetBox are skipped.
+	// The entry which is used is stored in `.status.parentPrefix`
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector` and `parentPrefixes` are mutually exclusive)
+	// Example: ["192.168.0.0/20", "192.168.16.0/20"]
+	//+kubebuilder:validation:MinItems=1
+	//+kubebuilder:validation:MaxItems=10
+	//+kubebuilder:validation:items:Format=cidr
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'parentPrefixes' is immutable"
+	ParentPrefixes []string `json:"parentPrefixes,omitempty"`
+
+	// The `parentPrefixSelector` is a key-value map, where all the entries are of data type `<string-string>` The map contains a set of query conditions for selecting a set of prefixes that can be used as the parent prefix The query conditions will be chained by the AND operator, and exact match of the keys and values will be performed The built-in fields `tenant`, `site`, `family` and `vlan_id` (the NetBox ID of a VLAN), along with custom fields, can be used. Only prefixes with `size` consecutive available IP Addresses are considered. For more information, please see ParentPrefixSelectorGuide.md
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector` and `parentPrefixes` are mutually exclusive)
+	// Example:
+	//   customfield1: "Production"
+	//   family: "IPv4"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'parentPrefixSelector' is immutable"
+	//+kubebuilder:validation:XValidation:rule="!has(self.family) || (self.family == 'IPv4' || self.family == 'IPv6')"
+	ParentPrefixSelector map[string]string `json:"parentPrefixSelector,omitempty"`
 
 	// The amount of consecutive IP Addresses you wish to reserve.
 	// Currently only sizes up to 50 are supported due to pagination of the
@@ -47,16 +68,56 @@ type IpRangeClaimSpec struct {
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'tenant' is immutable"
 	Tenant string `json:"tenant,omitempty"`
 
+	// The NetBox VRF to be assigned to the claimed IP Range in NetBox. Use the `name` value of the VRF.
+	// The parent prefix and the restorable IP Range are only looked up in this VRF. If not set,
+	// the parent prefix is looked up regardless of its VRF and the claimed IP Range inherits
+	// the VRF of the parent prefix.
+	// Field is immutable, not required
+	// Example: "customer-a"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'vrf' is immutable"
+	Vrf string `json:"vrf,omitempty"`
+
+	// The status of the claimed IP Range in NetBox, one of active, reserved or deprecated.
+	// If not set, the status is active
+	// Field is mutable, not required
+	// Example: "reserved"
+	//+kubebuilder:validation:Enum=active;reserved;deprecated
+	Status string `json:"status,omitempty"`
+
+	// The NetBox Role to be assigned to the claimed IP Range in NetBox, referenced by its name or slug.
+	// If not set, the role of the claimed IP Range in NetBox is not changed
+	// More info on NetBox Roles:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/role.md
+	// Field is mutable, not required
+	// Example: "k8s-pods"
+	Role string `json:"role,omitempty"`
+
 	// The NetBox Custom Fields that should be added to the resource in NetBox.
-	// Note that currently only Text Type is supported (GitHub #129)
+	// The values are converted to the type of the custom field in NetBox, e.g. "100" for an
+	// Integer, "true" for a Boolean or a comma separated list for a Multiple selection.
 	// More info on NetBox Custom Fields:
 	// https://github.com/netbox-community/netbox/blob/main/docs/customization/custom-fields.md
 	// Field is mutable, not required
 	// Example:
 	//   customfield1: "Production"
 	//   customfield2: "This is a string"
+	//   vlan_id: "100"
 	CustomFields map[string]string `json:"customFields,omitempty"`
 
+	// The NetBox Tags that should be assigned to the resource in NetBox, referenced by their
+	// name or slug. Tags which are removed from the list are removed from the resource in NetBox,
+	// tags which were assigned in NetBox are kept.
+	// The tags are passed on to the IpRange of the claim.
+	// More info on NetBox Tags:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/extras/tag.md
+	// Field is mutable, not required
+	// Example:
+	//   - "production"
+	//   - "team-network"
+	//+listType=set
+	//+kubebuilder:validation:items:MinLength=1
+	Tags []string `json:"tags,omitempty"`
+
 	// Comment that should be added to the resource in NetBox
 	// Field is mutable, not required
 	Comments string `json:"comments,omitempty"`
@@ -76,10 +137,23 @@ type IpRangeClaimSpec struct {
 	// recreated in Kubernetes)
 	// Field is mutable, not required
 	PreserveInNetbox bool `json:"preserveInNetbox,omitempty"`
+
+	// The name of the NetBoxConnection of the NetBox instance the resource is managed in.
+	// If not set, the NetBox instance of the operator configuration is used
+	// Field is immutable, not required
+	// Example: "netbox-lab"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'connection' is immutable"
+	Connection string `json:"connection,omitempty"`
 }
 
 // IpRangeClaimStatus defines the observed state of IpRangeClaim
 type IpRangeClaimStatus struct {
+	// Due to the fact that the parent prefix can be specified directly in
+	// `.spec.parentPrefix` or selected from `.spec.parentPrefixSelector` or `.spec.parentPrefixes`,
+	// we use this field to store exactly which parent prefix we are using
+	// for all subsequent reconcile loop calls.
+	SelectedParentPrefix string `json:"parentPrefix,omitempty"`
+
 	// The assigned IP Range in CIDR notation (e.g. 192.168.0.1/32-192.168.0.123/32)
 	IpRange string `json:"ipRange,omitempty"`
 
@@ -117,13 +191,16 @@ type IpRangeClaimStatus struct {
 //+kubebuilder:printcolumn:name="IpRange",type=string,JSONPath=`.status.ipRange`
 //+kubebuilder:printcolumn:name="IpRangeAssigned",type=string,JSONPath=`.status.conditions[?(@.type=="IPRangeAssigned")].status`
 //+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.spec.status`,priority=1
+//+kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.role`,priority=1
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:resource:shortName=iprc
 
 // IpRangeClaim allows to claim a NetBox IP Range from an existing Prefix.
 // The IpRangeClaim Controller will try to assign an available IP Range
-// from the Prefix that is defined in the spec and if successful it will create
-// the IpRange CR. More info about NetBox IP Ranges:
+// from the Prefix that is defined in the spec (or selected with the parent
+// prefix selector) and if successful it will create the IpRange CR. More info
+// about NetBox IP Ranges:
 // https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/iprange.md
 type IpRangeClaim struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetBoxConnectionSpec defines the desired state of NetBoxConnection
+type NetBoxConnectionSpec struct {
+	// The host of the NetBox instance, optionally with the port, without the scheme
+	// Field is mutable, required
+	// Example: "netbox.example.com" or "netbox.lab.example.com:8080"
+	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+
+	// Defines whether NetBox is reached over HTTPS
+	// Field is mutable, not required, defaults to true
+	//+kubebuilder:default=true
+	HttpsEnable *bool `json:"httpsEnable,omitempty"`
+
+	// The key of a Secret holding the PEM encoded CA certificate used to verify
+	// the TLS certificate of NetBox. If not set, the system CA certificates are used
+	// Field is mutable, not required
+	CaCertSecretRef *SecretKeyReference `json:"caCertSecretRef,omitempty"`
+
+	// The key of a Secret holding the NetBox API token
+	// Field is mutable, required
+	//+kubebuilder:validation:Required
+	TokenSecretRef SecretKeyReference `json:"tokenSecretRef"`
+}
+
+// SecretKeyReference references a key in the data of a Secret
+type SecretKeyReference struct {
+	// The name of the Secret
+	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The namespace of the Secret, Secrets are only read from the namespace of the operator
+	// Field is mutable, not required, defaults to the namespace of the operator
+	Namespace string `json:"namespace,omitempty"`
+
+	// The key in the data of the Secret
+	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
+
+// NetBoxConnectionStatus defines the observed state of NetBoxConnection
+type NetBoxConnectionStatus struct {
+	// Conditions represent the latest available observations of an object's state
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,shortName=nbc
+//+kubebuilder:printcolumn:name="Host",type=string,JSONPath=`.spec.host`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NetBoxConnection defines how NetBox Operator connects to a NetBox instance.
+// The other resources reference a NetBoxConnection by name in `.spec.connection`,
+// resources without a connection use the NetBox instance of the operator configuration.
+type NetBoxConnection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NetBoxConnectionSpec   `json:"spec,omitempty"`
+	Status NetBoxConnectionStatus `json:"status,omitempty"`
+}
+
+func (c *NetBoxConnection) Conditions() *[]metav1.Condition {
+	return &c.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+
+// NetBoxConnectionList contains a list of NetBoxConnection
+type NetBoxConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NetBoxConnection `json:"items"`
+}
+
+func init() {
+	register(&NetBoxConnection{}, &NetBoxConnectionList{})
+}
+
+var ConditionNetBoxConnectionReadyTrue = metav1.Condition{
+	Type:    "Ready",
+	Status:  "True",
+	Reason:  "NetBoxConnectionVerified",
+	Message: "Connection to NetBox was verified",
+}
+
+var ConditionNetBoxConnectionReadyFalse = metav1.Condition{
+	Type:    "Ready",
+	Status:  "False",
+	Reason:  "FailedToConnectToNetBox",
+	Message: "Failed to connect to NetBox",
+}
+
+var ConditionNetBoxConnectionReadyFalseTokenRejected = metav1.Condition{
+	Type:    "Ready",
+	Status:  "False",
+	Reason:  "NetBoxTokenRejected",
+	Message: "NetBox rejected the API token",
+}
@@ -22,6 +22,7 @@ import (
 
 // PrefixSpec defines the desired state of Prefix
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.site) || has(self.site)", message="Site is required once set"
+// +kubebuilder:validation:XValidation:rule="has(self.connection) == has(oldSelf.connection)",message="Field 'connection' is immutable"
 type PrefixSpec struct {
 	// The Prefix in CIDR notation that should be reserved in NetBox
 	// Field is immutable, required
@@ -42,16 +43,65 @@ type PrefixSpec struct {
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'tenant' is immutable"
 	Tenant string `json:"tenant,omitempty"`
 
+	// The NetBox VRF to be assigned to this resource in NetBox. Use the `name` value of the VRF.
+	// The Prefix is only looked up in this VRF in NetBox. If not set, it is created in the
+	// global table and looked up regardless of its VRF.
+	// Field is immutable, not required
+	// Example: "customer-a"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'vrf' is immutable"
+	Vrf string `json:"vrf,omitempty"`
+
+	// The status of the Prefix in NetBox, one of container, active, reserved or deprecated.
+	// If not set, the status is active
+	// Field is mutable, not required
+	// Example: "reserved"
+	//+kubebuilder:validation:Enum=container;active;reserved;deprecated
+	Status string `json:"status,omitempty"`
+
+	// The NetBox Role to be assigned to the Prefix in NetBox, referenced by its name or slug.
+	// If not set, the role of the Prefix in NetBox is not changed
+	// More info on NetBox Roles:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/role.md
+	// Field is mutable, not required
+	// Example: "k8s-pods"
+	Role string `json:"role,omitempty"`
+
+	// The NetBox VLAN the Prefix is assigned to, referenced by its VID and VLAN Group or by its name.
+	// If not set, the VLAN of the Prefix in NetBox is not changed, a VLAN which was set by the
+	// operator before is removed from the Prefix
+	// More info on NetBox VLANs:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/vlan.md
+	// Field is mutable, not required
+	// Example:
+	//   vid: 100
+	//   vlanGroup: "prod-vlans"
+	Vlan *VlanReference `json:"vlan,omitempty"`
+
 	// The NetBox Custom Fields that should be added to the resource in NetBox.
-	// Note that currently only Text Type is supported (GitHub #129)
+	// The values are converted to the type of the custom field in NetBox, e.g. "100" for an
+	// Integer, "true" for a Boolean or a comma separated list for a Multiple selection.
 	// More info on NetBox Custom Fields:
 	// https://github.com/netbox-community/netbox/blob/main/docs/customization/custom-fields.md
 	// Field is mutable, not required
 	// Example:
 	//   customfield1: "Production"
 	//   customfield2: "This is a string"
+	//   vlan_id: "100"
 	CustomFields map[string]string `json:"customFields,omitempty"`
 
+	// The NetBox Tags that should be assigned to the resource in NetBox, referenced by their
+	// name or slug. Tags which are removed from the list are removed from the resource in NetBox,
+	// tags which were assigned in NetBox are kept.
+	// More info on NetBox Tags:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/extras/tag.md
+	// Field is mutable, not required
+	// Example:
+	//   - "production"
+	//   - "team-network"
+	//+listType=set
+	//+kubebuilder:validation:items:MinLength=1
+	Tags []string `json:"tags,omitempty"`
+
 	// Description that should be added to the resource in NetBox
 	// Field is mutable, not required
 	Description string `json:"description,omitempty"`
@@ -71,6 +121,34 @@ type PrefixSpec struct {
 	// recreated in Kubernetes)
 	// Field is mutable, not required
 	PreserveInNetbox bool `json:"preserveInNetbox,omitempty"`
+
+	// The name of the NetBoxConnection of the NetBox instance the resource is managed in.
+	// If not set, the NetBox instance of the operator configuration is used
+	// Field is immutable, not required
+	// Example: "netbox-lab"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'connection' is immutable"
+	Connection string `json:"connection,omitempty"`
+}
+
+// VlanReference references a NetBox VLAN, either by its VID or by its name. The VLAN is
+// looked up in the VLAN Group if it is set. A VID without VLAN Group references the VLAN
+// without VLAN Group, a name without VLAN Group must be unique among all VLANs in NetBox.
+// +kubebuilder:validation:XValidation:rule="has(self.vid) != has(self.name)",message="Exactly one of 'vid' and 'name' must be set"
+type VlanReference struct {
+	// The VID of the VLAN
+	// Example: 100
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=4094
+	Vid int32 `json:"vid,omitempty"`
+
+	// The name of the VLAN
+	// Example: "k8s-nodes"
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name,omitempty"`
+
+	// The NetBox VLAN Group of the VLAN, referenced by its name or slug
+	// Example: "prod-vlans"
+	VlanGroup string `json:"vlanGroup,omitempty"`
 }
 
 // PrefixStatus defines the observed state of Prefix
@@ -86,15 +164,44 @@ type PrefixStatus struct {
 	// URL depends on the runtime config of NetBox Operator
 	PrefixUrl string `json:"url,omitempty"`
 
+	// The utilization of the Prefix in NetBox
+	Utilization *PrefixUtilization `json:"utilization,omitempty"`
+
 	// Conditions represent the latest available observations of an object's state
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
+// PrefixUtilization describes how much of the address space of a Prefix is in use in NetBox.
+// The free addresses of a Prefix with child prefixes are the addresses of its available prefixes,
+// the ones of a Prefix without child prefixes are its usable addresses without an IP Address.
+type PrefixUtilization struct {
+	// The number of child prefixes of the Prefix in NetBox
+	ChildPrefixes int64 `json:"childPrefixes"`
+
+	// The number of IP Addresses within the Prefix in NetBox
+	ChildIpAddresses int64 `json:"childIpAddresses"`
+
+	// The number of free addresses of the Prefix, as a decimal string
+	// since it can exceed the range of an integer for IPv6 prefixes
+	FreeAddresses string `json:"freeAddresses"`
+
+	// The share of the address space of the Prefix which is in use, in percent rounded down
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	UsedPercent int32 `json:"usedPercent"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Prefix",type=string,JSONPath=`.spec.prefix`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.spec.status`
+//+kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.role`
 //+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
 //+kubebuilder:printcolumn:name="ID",type=string,JSONPath=`.status.id`
+//+kubebuilder:printcolumn:name="Used%",type=integer,JSONPath=`.status.utilization.usedPercent`
+//+kubebuilder:printcolumn:name="ChildPrefixes",type=integer,JSONPath=`.status.utilization.childPrefixes`,priority=1
+//+kubebuilder:printcolumn:name="ChildIPs",type=integer,JSONPath=`.status.utilization.childIpAddresses`,priority=1
+//+kubebuilder:printcolumn:name="FreeAddresses",type=string,JSONPath=`.status.utilization.freeAddresses`,priority=1
 //+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.status.url`
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:resource:shortName=px
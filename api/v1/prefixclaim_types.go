@@ -23,17 +23,31 @@ import (
 // PrefixClaimSpec defines the desired state of PrefixClaim
 // TODO: The reason for using a workaround please see https://github.com/netbox-community/netbox-operator/pull/90#issuecomment-2402112475
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.site) || has(self.site)", message="Site is required once set"
-// +kubebuilder:validation:XValidation:rule="(!has(self.parentPrefix) && has(self.parentPrefixSelector)) || (has(self.parentPrefix) && !has(self.parentPrefixSelector))"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.dualStack) || has(self.dualStack)",message="Field 'dualStack' is required once set"
+// +kubebuilder:validation:XValidation:rule="!has(self.count) || self.count == 1 || (!has(self.dualStack) && !has(self.preferredPrefix))",message="Fields 'dualStack' and 'preferredPrefix' can not be combined with a 'count' greater than 1"
+// +kubebuilder:validation:XValidation:rule="[has(self.parentPrefix), has(self.parentPrefixSelector), has(self.parentPrefixes)].filter(x, x).size() == 1",message="Exactly one of 'parentPrefix', 'parentPrefixSelector' and 'parentPrefixes' must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.connection) == has(oldSelf.connection)",message="Field 'connection' is immutable"
 type PrefixClaimSpec struct {
 	// The NetBox Prefix from which this Prefix should be claimed from
-	// Field is immutable, required (`parentPrefix` and `parentPrefixSelector` are mutually exclusive)
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector` and `parentPrefixes` are mutually exclusive)
 	// Example: "192.168.0.0/20"
 	//+kubebuilder:validation:Format=cidr
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'parentPrefix' is immutable"
 	ParentPrefix string `json:"parentPrefix,omitempty"`
 
-	// The `parentPrefixSelector` is a key-value map, where all the entries are of data type `<string-string>` The map contains a set of query conditions for selecting a set of prefixes that can be used as the parent prefix The query conditions will be chained by the AND operator, and exact match of the keys and values will be performed The built-in fields `tenant`, `site`, and `family`, along with custom fields, can be used. Note that since the key value pairs in this map are used to generate the URL for the query in NetBox, this also supports non-Text Custom Field types. For more information, please see ParentPrefixSelectorGuide.md
-	// Field is immutable, required (`parentPrefix` and `parentPrefixSelector` are mutually exclusive)
+	// An ordered list of up to 10 NetBox Prefixes from which this Prefix should be claimed from.
+	// The entries are tried in order, entries which are exhausted or not found in NetBox are skipped.
+	// The entry which is used is stored in `.status.parentPrefix`
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector` and `parentPrefixes` are mutually exclusive)
+	// Example: ["192.168.0.0/20", "192.168.16.0/20"]
+	//+kubebuilder:validation:MinItems=1
+	//+kubebuilder:validation:MaxItems=10
+	//+kubebuilder:validation:items:Format=cidr
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'parentPrefixes' is immutable"
+	ParentPrefixes []string `json:"parentPrefixes,omitempty"`
+
+	// The `parentPrefixSelector` is a key-value map, where all the entries are of data type `<string-string>` The map contains a set of query conditions for selecting a set of prefixes that can be used as the parent prefix The query conditions will be chained by the AND operator, and exact match of the keys and values will be performed The built-in fields `tenant`, `site`, `family` and `vlan_id` (the NetBox ID of a VLAN), along with custom fields, can be used. Note that since the key value pairs in this map are used to generate the URL for the query in NetBox, this also supports non-Text Custom Field types. For more information, please see ParentPrefixSelectorGuide.md
+	// Field is immutable, required (`parentPrefix`, `parentPrefixSelector` and `parentPrefixes` are mutually exclusive)
 	// Example:
 	//   customfield1: "Production"
 	//   family: "IPv4"
@@ -41,6 +55,16 @@ type PrefixClaimSpec struct {
 	//+kubebuilder:validation:XValidation:rule="!has(self.family) || (self.family == 'IPv4' || self.family == 'IPv6')"
 	ParentPrefixSelector map[string]string `json:"parentPrefixSelector,omitempty"`
 
+	// The strategy used to pick the parent prefix out of the prefixes matching the `parentPrefixSelector`
+	// - FirstFit: the first matching prefix (in the order returned by NetBox) that can hold the requested prefix
+	// - BestFit: the matching prefix with the smallest free block that can hold the requested prefix
+	// - LeastUtilized: the matching prefix with the lowest utilization
+	// - MostUtilized: the matching prefix with the highest utilization, packing claims into as few parent prefixes as possible
+	// The chosen strategy and the scores of all candidates are reported in the `ParentPrefixSelected` condition.
+	// Only used together with `parentPrefixSelector`, defaults to FirstFit
+	// Field is mutable, not required
+	ParentPrefixSelectionStrategy ParentPrefixSelectionStrategy `json:"parentPrefixSelectionStrategy,omitempty"`
+
 	// The desired prefix length of your Prefix using slash notation. Example: `/24` for an IPv4 Prefix or `/64` for an IPv6 Prefix
 	// Field is immutable, required
 	// Example: "/24"
@@ -49,6 +73,44 @@ type PrefixClaimSpec struct {
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'prefixLength' is immutable"
 	PrefixLength string `json:"prefixLength"`
 
+	// The Prefix which should preferably be claimed, in CIDR notation. The Prefix is
+	// only claimed if it is available in the parent prefix and its length matches
+	// `prefixLength`, otherwise the `preferredAllocationPolicy` applies.
+	// Field is immutable, not required
+	// Example: "192.168.1.0/24"
+	//+kubebuilder:validation:Format=cidr
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'preferredPrefix' is immutable"
+	PreferredPrefix string `json:"preferredPrefix,omitempty"`
+
+	// Defines what happens if the `preferredPrefix` is not available in the parent prefix
+	// - Fail: the Prefix is not claimed and the PrefixClaim reports the failure
+	// - FallbackToDynamic: the next available Prefix of the parent prefix is claimed instead
+	// Only used together with `preferredPrefix`, defaults to Fail
+	// Field is mutable, not required
+	PreferredAllocationPolicy PreferredAllocationPolicy `json:"preferredAllocationPolicy,omitempty"`
+
+	// Enables the dual-stack mode. In addition to the Prefix claimed from `parentPrefix`,
+	// `parentPrefixSelector` or `parentPrefixes`, a Prefix of the other IP family is claimed
+	// from the parent prefix defined here. It is stored in a second Prefix CR named
+	// `<name>-dualstack` and reported in `.status.dualStack`. The PrefixClaim is only
+	// Ready once the Prefixes of both IP families are claimed.
+	// Field is immutable, not required
+	// Example:
+	//   parentPrefix: "2001:db8::/48"
+	//   prefixLength: "/64"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'dualStack' is immutable"
+	DualStack *PrefixClaimDualStackSpec `json:"dualStack,omitempty"`
+
+	// The number of Prefixes to claim from the parent prefix. The first Prefix CR is named
+	// after the PrefixClaim, the following ones `<name>-1` to `<name>-<count-1>`. Each Prefix
+	// has its own restoration hash. Increasing the count claims additional Prefixes,
+	// decreasing it deletes the Prefix CRs with the highest indexes.
+	// All Prefixes are claimed from the same parent prefix and listed in `.status.prefixes`.
+	// Field is mutable, not required, defaults to 1
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=256
+	Count int32 `json:"count,omitempty"`
+
 	// The NetBox Site to be assigned to this resource in NetBox. Use the `name` value instead of the `slug` value
 	// Field is immutable, not required
 	// Example: "DM-Buffalo"
@@ -61,6 +123,30 @@ type PrefixClaimSpec struct {
 	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'tenant' is immutable"
 	Tenant string `json:"tenant,omitempty"`
 
+	// The NetBox VRF to be assigned to the claimed Prefixes in NetBox. Use the `name` value of the VRF.
+	// The parent prefix and the restorable Prefixes are only looked up in this VRF. If not set,
+	// the parent prefix is looked up regardless of its VRF and the claimed Prefixes inherit
+	// the VRF of the parent prefix.
+	// Field is immutable, not required
+	// Example: "customer-a"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'vrf' is immutable"
+	Vrf string `json:"vrf,omitempty"`
+
+	// The status of the claimed Prefixes in NetBox, one of container, active, reserved or deprecated.
+	// If not set, the status is active
+	// Field is mutable, not required
+	// Example: "reserved"
+	//+kubebuilder:validation:Enum=container;active;reserved;deprecated
+	Status string `json:"status,omitempty"`
+
+	// The NetBox Role to be assigned to the claimed Prefixes in NetBox, referenced by its name or slug.
+	// If not set, the role of the claimed Prefixes in NetBox is not changed
+	// More info on NetBox Roles:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/role.md
+	// Field is mutable, not required
+	// Example: "k8s-pods"
+	Role string `json:"role,omitempty"`
+
 	// Description that should be added to the resource in NetBox
 	// Field is mutable, not required
 	Description string `json:"description,omitempty"`
@@ -69,16 +155,43 @@ type PrefixClaimSpec struct {
 	// Field is mutable, not required
 	Comments string `json:"comments,omitempty"`
 
+	// The NetBox VLAN the claimed Prefixes are assigned to, referenced by its VID and VLAN Group
+	// or by its name. If not set, the VLAN of the claimed Prefixes in NetBox is not changed, a VLAN
+	// which was set by the operator before is removed from the claimed Prefixes
+	// More info on NetBox VLANs:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/vlan.md
+	// Field is mutable, not required
+	// Example:
+	//   vid: 100
+	//   vlanGroup: "prod-vlans"
+	Vlan *VlanReference `json:"vlan,omitempty"`
+
 	// The NetBox Custom Fields that should be added to the resource in NetBox.
-	// Note that currently only Text Type is supported (GitHub #129)
+	// The values are converted to the type of the custom field in NetBox, e.g. "100" for an
+	// Integer, "true" for a Boolean or a comma separated list for a Multiple selection.
 	// More info on NetBox Custom Fields:
 	// https://github.com/netbox-community/netbox/blob/main/docs/customization/custom-fields.md
 	// Field is mutable, not required
 	// Example:
 	//   customfield1: "Production"
 	//   customfield2: "This is a string"
+	//   vlan_id: "100"
 	CustomFields map[string]string `json:"customFields,omitempty"`
 
+	// The NetBox Tags that should be assigned to the resource in NetBox, referenced by their
+	// name or slug. Tags which are removed from the list are removed from the resource in NetBox,
+	// tags which were assigned in NetBox are kept.
+	// The tags are passed on to the Prefixes of the claim.
+	// More info on NetBox Tags:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/extras/tag.md
+	// Field is mutable, not required
+	// Example:
+	//   - "production"
+	//   - "team-network"
+	//+listType=set
+	//+kubebuilder:validation:items:MinLength=1
+	Tags []string `json:"tags,omitempty"`
+
 	// Defines whether the Resource should be preserved in NetBox when the
 	// Kubernetes Resource is deleted.
 	// - When set to true, the resource will not be deleted but preserved in
@@ -90,12 +203,55 @@ type PrefixClaimSpec struct {
 	// recreated in Kubernetes)
 	// Field is mutable, not required
 	PreserveInNetbox bool `json:"preserveInNetbox,omitempty"`
+
+	// The name of the NetBoxConnection of the NetBox instance the resource is managed in.
+	// If not set, the NetBox instance of the operator configuration is used
+	// Field is immutable, not required
+	// Example: "netbox-lab"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'connection' is immutable"
+	Connection string `json:"connection,omitempty"`
+}
+
+// ParentPrefixSelectionStrategy defines how the parent prefix is picked from the
+// prefixes matching the parentPrefixSelector of a PrefixClaim
+// +kubebuilder:validation:Enum=FirstFit;BestFit;LeastUtilized;MostUtilized
+type ParentPrefixSelectionStrategy string
+
+const (
+	ParentPrefixSelectionStrategyFirstFit      ParentPrefixSelectionStrategy = "FirstFit"
+	ParentPrefixSelectionStrategyBestFit       ParentPrefixSelectionStrategy = "BestFit"
+	ParentPrefixSelectionStrategyLeastUtilized ParentPrefixSelectionStrategy = "LeastUtilized"
+	ParentPrefixSelectionStrategyMostUtilized  ParentPrefixSelectionStrategy = "MostUtilized"
+)
+
+// PrefixClaimDualStackSpec defines from where and with which length the Prefix
+// of the other IP family is claimed in dual-stack mode
+// +kubebuilder:validation:XValidation:rule="has(self.parentPrefix) != has(self.parentPrefixSelector)",message="Exactly one of 'parentPrefix' and 'parentPrefixSelector' must be set"
+type PrefixClaimDualStackSpec struct {
+	// The NetBox Prefix from which the Prefix of the other IP family should be claimed from
+	// Example: "2001:db8::/48"
+	//+kubebuilder:validation:Format=cidr
+	ParentPrefix string `json:"parentPrefix,omitempty"`
+
+	// Selects the parent prefix of the Prefix of the other IP family, works the same
+	// way as `.spec.parentPrefixSelector` and uses the `.spec.parentPrefixSelectionStrategy`
+	// Example:
+	//   customfield1: "Production"
+	//   family: "IPv6"
+	//+kubebuilder:validation:XValidation:rule="!has(self.family) || (self.family == 'IPv4' || self.family == 'IPv6')"
+	ParentPrefixSelector map[string]string `json:"parentPrefixSelector,omitempty"`
+
+	// The desired prefix length of the Prefix of the other IP family using slash notation
+	// Example: "/64"
+	//+kubebuilder:validation:Required
+	//+kubebuilder:validation:Pattern=`^\/([0-9]|[1-9][0-9]|1[01][0-9]|12[0-8])$`
+	PrefixLength string `json:"prefixLength"`
 }
 
 // PrefixClaimStatus defines the observed state of PrefixClaim
 type PrefixClaimStatus struct {
 	// Due to the fact that the parentPrefix can be specified directly in
-	//`.spec.parentPrefix` or selected from `.spec.parentPrefixSelector`,
+	//`.spec.parentPrefix` or selected from `.spec.parentPrefixSelector` or `.spec.parentPrefixes`,
 	//we use this field to store exactly which parent prefix we are using
 	//for all subsequent reconcile loop calls.
 	SelectedParentPrefix string `json:"parentPrefix,omitempty"`
@@ -106,15 +262,42 @@ type PrefixClaimStatus struct {
 	// The name of the Prefix CR created by the PrefixClaim Controller
 	PrefixName string `json:"prefixName,omitempty"`
 
+	// All assigned Prefixes in CIDR notation, ordered by index, if `count` is greater than 1
+	Prefixes []string `json:"prefixes,omitempty"`
+
+	// The Prefix of the other IP family claimed in dual-stack mode
+	DualStack *PrefixClaimDualStackStatus `json:"dualStack,omitempty"`
+
+	// The utilization of the assigned Prefix in NetBox, as reported by the Prefix CR
+	Utilization *PrefixUtilization `json:"utilization,omitempty"`
+
 	// Conditions represent the latest available observations of an object's state
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 }
 
+// PrefixClaimDualStackStatus defines the observed state of the Prefix
+// of the other IP family in dual-stack mode
+type PrefixClaimDualStackStatus struct {
+	// The parent prefix the Prefix of the other IP family is claimed from
+	SelectedParentPrefix string `json:"parentPrefix,omitempty"`
+
+	// The assigned Prefix of the other IP family in CIDR notation
+	Prefix string `json:"prefix,omitempty"`
+
+	// The name of the second Prefix CR created by the PrefixClaim Controller
+	PrefixName string `json:"prefixName,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Prefix",type=string,JSONPath=`.status.prefix`
+//+kubebuilder:printcolumn:name="DualStackPrefix",type=string,JSONPath=`.status.dualStack.prefix`,priority=1
+//+kubebuilder:printcolumn:name="Count",type=integer,JSONPath=`.spec.count`,priority=1
+//+kubebuilder:printcolumn:name="Used%",type=integer,JSONPath=`.status.utilization.usedPercent`,priority=1
 //+kubebuilder:printcolumn:name="PrefixAssigned",type=string,JSONPath=`.status.conditions[?(@.type=="PrefixAssigned")].status`
 //+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.spec.status`,priority=1
+//+kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.role`,priority=1
 //+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //+kubebuilder:resource:shortName=pxc
 
@@ -176,17 +359,3 @@ var ConditionPrefixAssignedFalse = metav1.Condition{
 	Reason:  "PrefixCRNotCreated",
 	Message: "Failed to assign prefix, prefix CR creation skipped",
 }
-
-var ConditionParentPrefixSelectedTrue = metav1.Condition{
-	Type:    "ParentPrefixSelected",
-	Status:  "True",
-	Reason:  "ParentPrefixSelected",
-	Message: "The parent prefix was selected successfully",
-}
-
-var ConditionParentPrefixSelectedFalse = metav1.Condition{
-	Type:    "ParentPrefixSelected",
-	Status:  "False",
-	Reason:  "ParentPrefixNotSelected",
-	Message: "The parent prefix was not able to be selected",
-}
@@ -24,3 +24,38 @@ var ConditionReadyFalseNewResource = metav1.Condition{
 	Reason:  "NewResource",
 	Message: "Pending Reconciliation",
 }
+
+var ConditionReadyFalseNetBoxUnavailable = metav1.Condition{
+	Type:    "Ready",
+	Status:  "False",
+	Reason:  "NetBoxUnavailable",
+	Message: "NetBox is unavailable, the reconciliation is retried with a backoff",
+}
+
+var ConditionReadyFalseInvalidCustomField = metav1.Condition{
+	Type:    "Ready",
+	Status:  "False",
+	Reason:  "InvalidCustomField",
+	Message: "A custom field value does not match the type of the custom field in NetBox",
+}
+
+var ConditionReadyFalseNameConflict = metav1.Condition{
+	Type:    "Ready",
+	Status:  "False",
+	Reason:  "NameConflict",
+	Message: "The name of a resource of the claim is already used by a resource which is not controlled by the claim",
+}
+
+var ConditionParentPrefixSelectedTrue = metav1.Condition{
+	Type:    "ParentPrefixSelected",
+	Status:  "True",
+	Reason:  "ParentPrefixSelected",
+	Message: "The parent prefix was selected successfully",
+}
+
+var ConditionParentPrefixSelectedFalse = metav1.Condition{
+	Type:    "ParentPrefixSelected",
+	Status:  "False",
+	Reason:  "ParentPrefixNotSelected",
+	Message: "The parent prefix was not able to be selected",
+}
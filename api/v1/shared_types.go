@@ -0,0 +1,27 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// PreferredAllocationPolicy defines how a claim behaves if its preferred
+// IP Address or Prefix is not available in the parent prefix
+// +kubebuilder:validation:Enum=Fail;FallbackToDynamic
+type PreferredAllocationPolicy string
+
+const (
+	PreferredAllocationPolicyFail              PreferredAllocationPolicy = "Fail"
+	PreferredAllocationPolicyFallbackToDynamic PreferredAllocationPolicy = "FallbackToDynamic"
+)
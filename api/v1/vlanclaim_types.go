@@ -0,0 +1,222 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VlanClaimSpec defines the desired state of VlanClaim
+// +kubebuilder:validation:XValidation:rule="[has(self.vlanGroup), has(self.vlanGroupSelector)].filter(x, x).size() == 1",message="Exactly one of 'vlanGroup' and 'vlanGroupSelector' must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.connection) == has(oldSelf.connection)",message="Field 'connection' is immutable"
+type VlanClaimSpec struct {
+	// The NetBox VLAN Group from which the VLAN should be claimed from, referenced by its name or slug
+	// Field is immutable, required (`vlanGroup` and `vlanGroupSelector` are mutually exclusive)
+	// Example: "dc1-k8s"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'vlanGroup' is immutable"
+	VlanGroup string `json:"vlanGroup,omitempty"`
+
+	// The `vlanGroupSelector` is a key-value map, where all the entries are of data type `<string-string>` The map contains a set of query conditions for selecting a set of VLAN Groups that can be used to claim the VLAN from The query conditions will be chained by the AND operator, and exact match of the keys and values will be performed The built-in fields `tenant` and `site`, along with custom fields, can be used. Only VLAN Groups with an available VLAN ID are considered, the first one in the order returned by NetBox is used.
+	// Field is immutable, required (`vlanGroup` and `vlanGroupSelector` are mutually exclusive)
+	// Example:
+	//   site: "DM-Buffalo"
+	//   environment: "Production"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'vlanGroupSelector' is immutable"
+	VlanGroupSelector map[string]string `json:"vlanGroupSelector,omitempty"`
+
+	// The name of the claimed VLAN in NetBox. If not set, the name of the VlanClaim is used
+	// Field is mutable, not required
+	// Example: "multus-storage"
+	//+kubebuilder:validation:MaxLength=64
+	Name string `json:"name,omitempty"`
+
+	// The NetBox Tenant to be assigned to this resource in NetBox. Use the `name` value instead of the `slug` value
+	// Field is immutable, not required
+	// Example: "Initech" or "Cyberdyne Systems"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'tenant' is immutable"
+	Tenant string `json:"tenant,omitempty"`
+
+	// The status of the claimed VLAN in NetBox, one of active, reserved or deprecated.
+	// If not set, the status is active
+	// Field is mutable, not required
+	// Example: "reserved"
+	//+kubebuilder:validation:Enum=active;reserved;deprecated
+	Status string `json:"status,omitempty"`
+
+	// The NetBox Role to be assigned to the claimed VLAN in NetBox, referenced by its name or slug.
+	// If not set, the role of the claimed VLAN in NetBox is not changed
+	// More info on NetBox Roles:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/role.md
+	// Field is mutable, not required
+	// Example: "k8s-storage"
+	Role string `json:"role,omitempty"`
+
+	// The NetBox Custom Fields that should be added to the resource in NetBox.
+	// The values are converted to the type of the custom field in NetBox, e.g. "100" for an
+	// Integer, "true" for a Boolean or a comma separated list for a Multiple selection.
+	// More info on NetBox Custom Fields:
+	// https://github.com/netbox-community/netbox/blob/main/docs/customization/custom-fields.md
+	// Field is mutable, not required
+	// Example:
+	//   customfield1: "Production"
+	//   customfield2: "This is a string"
+	CustomFields map[string]string `json:"customFields,omitempty"`
+
+	// The NetBox Tags that should be assigned to the resource in NetBox, referenced by their
+	// name or slug. Tags which are removed from the list are removed from the resource in NetBox,
+	// tags which were assigned in NetBox are kept.
+	// The tags are passed on to the Vlan of the claim.
+	// More info on NetBox Tags:
+	// https://github.com/netbox-community/netbox/blob/main/docs/models/extras/tag.md
+	// Field is mutable, not required
+	// Example:
+	//   - "production"
+	//   - "team-network"
+	//+listType=set
+	//+kubebuilder:validation:items:MinLength=1
+	Tags []string `json:"tags,omitempty"`
+
+	// Comment that should be added to the resource in NetBox
+	// Field is mutable, not required
+	Comments string `json:"comments,omitempty"`
+
+	// Description that should be added to the resource in NetBox
+	// Field is mutable, not required
+	Description string `json:"description,omitempty"`
+
+	// Defines whether the Resource should be preserved in NetBox when the
+	// Kubernetes Resource is deleted.
+	// - When set to true, the resource will not be deleted but preserved in
+	//   NetBox upon CR deletion
+	// - When set to false, the resource will be cleaned up in NetBox
+	//   upon CR deletion
+	// Setting preserveInNetbox to true is mandatory if the user wants to restore
+	// resources from NetBox (e.g. Sticky VIDs even if resources are deleted and
+	// recreated in Kubernetes)
+	// Field is mutable, not required
+	PreserveInNetbox bool `json:"preserveInNetbox,omitempty"`
+
+	// The name of the NetBoxConnection of the NetBox instance the resource is managed in.
+	// If not set, the NetBox instance of the operator configuration is used
+	// Field is immutable, not required
+	// Example: "netbox-lab"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'connection' is immutable"
+	Connection string `json:"connection,omitempty"`
+}
+
+// VlanClaimStatus defines the observed state of VlanClaim
+type VlanClaimStatus struct {
+	// Due to the fact that the VLAN Group can be specified directly in
+	// `.spec.vlanGroup` or selected from `.spec.vlanGroupSelector`,
+	// we use this field to store exactly which VLAN Group we are using
+	// for all subsequent reconcile loop calls.
+	SelectedVlanGroup string `json:"vlanGroup,omitempty"`
+
+	// The assigned numeric VLAN ID
+	Vid int32 `json:"vid,omitempty"`
+
+	// The name of the Vlan CR created by the VlanClaim Controller
+	VlanName string `json:"vlanName,omitempty"`
+
+	// Conditions represent the latest available observations of an object's state
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="VID",type=integer,JSONPath=`.status.vid`
+//+kubebuilder:printcolumn:name="VlanGroup",type=string,JSONPath=`.status.vlanGroup`
+//+kubebuilder:printcolumn:name="VlanAssigned",type=string,JSONPath=`.status.conditions[?(@.type=="VLANAssigned")].status`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.spec.status`,priority=1
+//+kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.role`,priority=1
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+//+kubebuilder:resource:shortName=vlc
+
+// VlanClaim allows to claim a NetBox VLAN from an existing VLAN Group.
+// The VlanClaim Controller will try to assign the next available VLAN ID
+// of the VLAN Group that is defined in the spec (or selected with the
+// VLAN Group selector) and if successful it will create the Vlan CR. More info
+// about NetBox VLANs:
+// https://github.com/netbox-community/netbox/blob/main/docs/models/ipam/vlan.md
+type VlanClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VlanClaimSpec   `json:"spec,omitempty"`
+	Status VlanClaimStatus `json:"status,omitempty"`
+}
+
+func (v *VlanClaim) Conditions() *[]metav1.Condition {
+	return &v.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+
+// VlanClaimList contains a list of VlanClaim
+type VlanClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VlanClaim `json:"items"`
+}
+
+func init() {
+	register(&VlanClaim{}, &VlanClaimList{})
+}
+
+var ConditionVlanClaimReadyTrue = metav1.Condition{
+	Type:    "Ready",
+	Status:  "True",
+	Reason:  "VlanResourceReady",
+	Message: "VLAN Resource is ready",
+}
+
+var ConditionVlanClaimReadyFalse = metav1.Condition{
+	Type:    "Ready",
+	Status:  "False",
+	Reason:  "VlanResourceNotReady",
+	Message: "VLAN Resource is not ready",
+}
+
+var ConditionVlanAssignedTrue = metav1.Condition{
+	Type:    "VLANAssigned",
+	Status:  "True",
+	Reason:  "VlanCRCreated",
+	Message: "New VLAN fetched from NetBox and Vlan CR was created",
+}
+
+var ConditionVlanAssignedFalse = metav1.Condition{
+	Type:    "VLANAssigned",
+	Status:  "False",
+	Reason:  "VlanCRNotCreated",
+	Message: "Failed to fetch new VLAN from NetBox",
+}
+
+var ConditionVlanGroupSelectedTrue = metav1.Condition{
+	Type:    "VLANGroupSelected",
+	Status:  "True",
+	Reason:  "VlanGroupSelected",
+	Message: "The VLAN Group was selected successfully",
+}
+
+var ConditionVlanGroupSelectedFalse = metav1.Condition{
+	Type:    "VLANGroupSelected",
+	Status:  "False",
+	Reason:  "VlanGroupNotSelected",
+	Message: "The VLAN Group was not able to be selected",
+}
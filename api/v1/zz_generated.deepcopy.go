@@ -79,6 +79,43 @@ func (in *IpAddressClaim) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpAddressClaimDualStackSpec) DeepCopyInto(out *IpAddressClaimDualStackSpec) {
+	*out = *in
+	if in.ParentPrefixSelector != nil {
+		in, out := &in.ParentPrefixSelector, &out.ParentPrefixSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpAddressClaimDualStackSpec.
+func (in *IpAddressClaimDualStackSpec) DeepCopy() *IpAddressClaimDualStackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IpAddressClaimDualStackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpAddressClaimDualStackStatus) DeepCopyInto(out *IpAddressClaimDualStackStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpAddressClaimDualStackStatus.
+func (in *IpAddressClaimDualStackStatus) DeepCopy() *IpAddressClaimDualStackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IpAddressClaimDualStackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpAddressClaimList) DeepCopyInto(out *IpAddressClaimList) {
 	*out = *in
@@ -111,9 +148,46 @@ func (in *IpAddressClaimList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IpAddressClaimParentIpRange) DeepCopyInto(out *IpAddressClaimParentIpRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpAddressClaimParentIpRange.
+func (in *IpAddressClaimParentIpRange) DeepCopy() *IpAddressClaimParentIpRange {
+	if in == nil {
+		return nil
+	}
+	out := new(IpAddressClaimParentIpRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpAddressClaimSpec) DeepCopyInto(out *IpAddressClaimSpec) {
 	*out = *in
+	if in.ParentPrefixes != nil {
+		in, out := &in.ParentPrefixes, &out.ParentPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ParentPrefixSelector != nil {
+		in, out := &in.ParentPrefixSelector, &out.ParentPrefixSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ParentIpRange != nil {
+		in, out := &in.ParentIpRange, &out.ParentIpRange
+		*out = new(IpAddressClaimParentIpRange)
+		**out = **in
+	}
+	if in.DualStack != nil {
+		in, out := &in.DualStack, &out.DualStack
+		*out = new(IpAddressClaimDualStackSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CustomFields != nil {
 		in, out := &in.CustomFields, &out.CustomFields
 		*out = make(map[string]string, len(*in))
@@ -121,6 +195,11 @@ func (in *IpAddressClaimSpec) DeepCopyInto(out *IpAddressClaimSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpAddressClaimSpec.
@@ -136,6 +215,16 @@ func (in *IpAddressClaimSpec) DeepCopy() *IpAddressClaimSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpAddressClaimStatus) DeepCopyInto(out *IpAddressClaimStatus) {
 	*out = *in
+	if in.IpAddresses != nil {
+		in, out := &in.IpAddresses, &out.IpAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DualStack != nil {
+		in, out := &in.DualStack, &out.DualStack
+		*out = new(IpAddressClaimDualStackStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -197,6 +286,11 @@ func (in *IpAddressSpec) DeepCopyInto(out *IpAddressSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpAddressSpec.
@@ -321,6 +415,18 @@ func (in *IpRangeClaimList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IpRangeClaimSpec) DeepCopyInto(out *IpRangeClaimSpec) {
 	*out = *in
+	if in.ParentPrefixes != nil {
+		in, out := &in.ParentPrefixes, &out.ParentPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ParentPrefixSelector != nil {
+		in, out := &in.ParentPrefixSelector, &out.ParentPrefixSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.CustomFields != nil {
 		in, out := &in.CustomFields, &out.CustomFields
 		*out = make(map[string]string, len(*in))
@@ -328,6 +434,11 @@ func (in *IpRangeClaimSpec) DeepCopyInto(out *IpRangeClaimSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeClaimSpec.
@@ -414,6 +525,11 @@ func (in *IpRangeSpec) DeepCopyInto(out *IpRangeSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IpRangeSpec.
@@ -449,6 +565,113 @@ func (in *IpRangeStatus) DeepCopy() *IpRangeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetBoxConnection) DeepCopyInto(out *NetBoxConnection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetBoxConnection.
+func (in *NetBoxConnection) DeepCopy() *NetBoxConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(NetBoxConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetBoxConnection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetBoxConnectionList) DeepCopyInto(out *NetBoxConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NetBoxConnection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetBoxConnectionList.
+func (in *NetBoxConnectionList) DeepCopy() *NetBoxConnectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(NetBoxConnectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetBoxConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetBoxConnectionSpec) DeepCopyInto(out *NetBoxConnectionSpec) {
+	*out = *in
+	if in.HttpsEnable != nil {
+		in, out := &in.HttpsEnable, &out.HttpsEnable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CaCertSecretRef != nil {
+		in, out := &in.CaCertSecretRef, &out.CaCertSecretRef
+		*out = new(SecretKeyReference)
+		**out = **in
+	}
+	out.TokenSecretRef = in.TokenSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetBoxConnectionSpec.
+func (in *NetBoxConnectionSpec) DeepCopy() *NetBoxConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetBoxConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetBoxConnectionStatus) DeepCopyInto(out *NetBoxConnectionStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetBoxConnectionStatus.
+func (in *NetBoxConnectionStatus) DeepCopy() *NetBoxConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetBoxConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Prefix) DeepCopyInto(out *Prefix) {
 	*out = *in
@@ -503,6 +726,43 @@ func (in *PrefixClaim) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefixClaimDualStackSpec) DeepCopyInto(out *PrefixClaimDualStackSpec) {
+	*out = *in
+	if in.ParentPrefixSelector != nil {
+		in, out := &in.ParentPrefixSelector, &out.ParentPrefixSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixClaimDualStackSpec.
+func (in *PrefixClaimDualStackSpec) DeepCopy() *PrefixClaimDualStackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefixClaimDualStackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefixClaimDualStackStatus) DeepCopyInto(out *PrefixClaimDualStackStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixClaimDualStackStatus.
+func (in *PrefixClaimDualStackStatus) DeepCopy() *PrefixClaimDualStackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefixClaimDualStackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrefixClaimList) DeepCopyInto(out *PrefixClaimList) {
 	*out = *in
@@ -538,6 +798,11 @@ func (in *PrefixClaimList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrefixClaimSpec) DeepCopyInto(out *PrefixClaimSpec) {
 	*out = *in
+	if in.ParentPrefixes != nil {
+		in, out := &in.ParentPrefixes, &out.ParentPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ParentPrefixSelector != nil {
 		in, out := &in.ParentPrefixSelector, &out.ParentPrefixSelector
 		*out = make(map[string]string, len(*in))
@@ -545,6 +810,16 @@ func (in *PrefixClaimSpec) DeepCopyInto(out *PrefixClaimSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.DualStack != nil {
+		in, out := &in.DualStack, &out.DualStack
+		*out = new(PrefixClaimDualStackSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vlan != nil {
+		in, out := &in.Vlan, &out.Vlan
+		*out = new(VlanReference)
+		**out = **in
+	}
 	if in.CustomFields != nil {
 		in, out := &in.CustomFields, &out.CustomFields
 		*out = make(map[string]string, len(*in))
@@ -552,6 +827,11 @@ func (in *PrefixClaimSpec) DeepCopyInto(out *PrefixClaimSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixClaimSpec.
@@ -567,6 +847,21 @@ func (in *PrefixClaimSpec) DeepCopy() *PrefixClaimSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrefixClaimStatus) DeepCopyInto(out *PrefixClaimStatus) {
 	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DualStack != nil {
+		in, out := &in.DualStack, &out.DualStack
+		*out = new(PrefixClaimDualStackStatus)
+		**out = **in
+	}
+	if in.Utilization != nil {
+		in, out := &in.Utilization, &out.Utilization
+		*out = new(PrefixUtilization)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -621,6 +916,11 @@ func (in *PrefixList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrefixSpec) DeepCopyInto(out *PrefixSpec) {
 	*out = *in
+	if in.Vlan != nil {
+		in, out := &in.Vlan, &out.Vlan
+		*out = new(VlanReference)
+		**out = **in
+	}
 	if in.CustomFields != nil {
 		in, out := &in.CustomFields, &out.CustomFields
 		*out = make(map[string]string, len(*in))
@@ -628,6 +928,11 @@ func (in *PrefixSpec) DeepCopyInto(out *PrefixSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixSpec.
@@ -644,6 +949,11 @@ func (in *PrefixSpec) DeepCopy() *PrefixSpec {
 func (in *PrefixStatus) DeepCopyInto(out *PrefixStatus) {
 	*out = *in
 	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+	if in.Utilization != nil {
+		in, out := &in.Utilization, &out.Utilization
+		*out = new(PrefixUtilization)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -662,3 +972,272 @@ func (in *PrefixStatus) DeepCopy() *PrefixStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefixUtilization) DeepCopyInto(out *PrefixUtilization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefixUtilization.
+func (in *PrefixUtilization) DeepCopy() *PrefixUtilization {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefixUtilization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyReference) DeepCopyInto(out *SecretKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyReference.
+func (in *SecretKeyReference) DeepCopy() *SecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vlan) DeepCopyInto(out *Vlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vlan.
+func (in *Vlan) DeepCopy() *Vlan {
+	if in == nil {
+		return nil
+	}
+	out := new(Vlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Vlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanClaim) DeepCopyInto(out *VlanClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanClaim.
+func (in *VlanClaim) DeepCopy() *VlanClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VlanClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanClaimList) DeepCopyInto(out *VlanClaimList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VlanClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanClaimList.
+func (in *VlanClaimList) DeepCopy() *VlanClaimList {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanClaimList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VlanClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanClaimSpec) DeepCopyInto(out *VlanClaimSpec) {
+	*out = *in
+	if in.VlanGroupSelector != nil {
+		in, out := &in.VlanGroupSelector, &out.VlanGroupSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CustomFields != nil {
+		in, out := &in.CustomFields, &out.CustomFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanClaimSpec.
+func (in *VlanClaimSpec) DeepCopy() *VlanClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanClaimStatus) DeepCopyInto(out *VlanClaimStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanClaimStatus.
+func (in *VlanClaimStatus) DeepCopy() *VlanClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanList) DeepCopyInto(out *VlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Vlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanList.
+func (in *VlanList) DeepCopy() *VlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanReference) DeepCopyInto(out *VlanReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanReference.
+func (in *VlanReference) DeepCopy() *VlanReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanSpec) DeepCopyInto(out *VlanSpec) {
+	*out = *in
+	if in.CustomFields != nil {
+		in, out := &in.CustomFields, &out.CustomFields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanSpec.
+func (in *VlanSpec) DeepCopy() *VlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VlanStatus) DeepCopyInto(out *VlanStatus) {
+	*out = *in
+	in.LastUpdated.DeepCopyInto(&out.LastUpdated)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VlanStatus.
+func (in *VlanStatus) DeepCopy() *VlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
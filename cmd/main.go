@@ -20,11 +20,13 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"flag"
 	"os"
 
+	"github.com/netbox-community/netbox-operator/pkg/config"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -32,12 +34,17 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -127,8 +134,22 @@ func main() {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	// check existence of ENV POD_NAMESPACE
+	operatorNamespace, envVarExists := os.LookupEnv("POD_NAMESPACE")
+	if !envVarExists {
+		setupLog.Error(errors.New("environment variable 'POD_NAMESPACE' must be defined"), "missing required ENV")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme:                 scheme,
+		Scheme: scheme,
+		// the operator only has access to the Secrets in its namespace, so they are only
+		// watched in it
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.Secret{}: {Namespaces: map[string]cache.Config{operatorNamespace: {}}},
+			},
+		},
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -151,37 +172,76 @@ func main() {
 		os.Exit(1)
 	}
 
-	netboxClientV3, err := api.GetNetboxClient()
+	netboxCompositeClient, err := api.GetNetboxCompositeClient()
 	if err != nil {
 		setupLog.Error(err, "failed to initialize netbox client")
 		os.Exit(1)
 	}
-	netboxClientV4, err := api.GetNetboxClientV4()
-	if err != nil {
-		setupLog.Error(err, "failed to initialize netbox client v4")
-		os.Exit(1)
-	}
 
-	netboxCompositeClient := api.NewNetboxCompositeClient(netboxClientV3, netboxClientV4)
+	operatorConfig := config.GetOperatorConfig()
+	authTokenSecret := types.NamespacedName{Name: operatorConfig.AuthTokenSecretName, Namespace: operatorNamespace}
+	if operatorConfig.AuthTokenSecretName != "" {
+		// the cache of the manager is not started yet, the secret is read from the api server
+		token, err := controller.ReadAuthTokenSecret(context.Background(), mgr.GetAPIReader(), authTokenSecret, operatorConfig.AuthTokenSecretKey)
+		if err != nil {
+			setupLog.Error(err, "failed to read netbox api token")
+			os.Exit(1)
+		}
+		netboxCompositeClient.Tokens().SetToken(token)
+	}
 
-	err = netboxCompositeClient.VerifyNetboxConfiguration()
+	err = netboxCompositeClient.VerifyNetboxConfiguration(context.Background())
 	if err != nil {
 		setupLog.Error(err, "verification of netbox configuration failed")
 		os.Exit(1)
 	}
 
-	// check existence of ENV POD_NAMESPACE
-	operatorNamespace, envVarExists := os.LookupEnv("POD_NAMESPACE")
-	if !envVarExists {
-		setupLog.Error(errors.New("environment variable 'POD_NAMESPACE' must be defined"), "missing required ENV")
+	// the version is cached, so that it is not requested from netbox before every write. If
+	// netbox is unavailable, the version is detected before the first write instead.
+	if _, err = netboxCompositeClient.NetBoxVersion(context.Background()); err != nil {
+		setupLog.Error(err, "unable to detect netbox version, it is detected before the first write")
+	}
+
+	// the NetBoxConnections are read from the cache of the manager, the referenced secrets
+	// directly from the api server so that not all secrets of the namespace are cached
+	netboxClients := api.NewClientRegistry(mgr.GetClient(), mgr.GetAPIReader(), operatorNamespace, netboxCompositeClient)
+
+	// the health monitor keeps the circuit breakers of the netbox clients up to date and
+	// feeds the readiness probe with the health of the netbox instance of the operator configuration
+	netboxHealthMonitor := &api.HealthMonitor{
+		Clients:  netboxClients,
+		Interval: operatorConfig.NetboxHealthCheckInterval,
+	}
+	if err := mgr.Add(netboxHealthMonitor); err != nil {
+		setupLog.Error(err, "unable to set up netbox health monitor")
 		os.Exit(1)
 	}
 
+	switch {
+	case operatorConfig.AuthTokenFile != "":
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			return api.WatchTokenFile(ctx, operatorConfig.AuthTokenFile, api.TokenFilePollInterval, netboxCompositeClient.Tokens())
+		})); err != nil {
+			setupLog.Error(err, "unable to watch netbox api token file")
+			os.Exit(1)
+		}
+	case operatorConfig.AuthTokenSecretName != "":
+		if err = (&controller.AuthTokenSecretReconciler{
+			Reader: mgr.GetAPIReader(),
+			Secret: authTokenSecret,
+			Key:    operatorConfig.AuthTokenSecretKey,
+			Tokens: netboxCompositeClient.Tokens(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "AuthTokenSecret")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controller.IpAddressReconciler{
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		EventStatusRecorder: controller.NewEventStatusRecorder(mgr.GetEventRecorderFor("ip-address-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
-		NetboxClient:        netboxCompositeClient,
+		NetboxClients:       netboxClients,
 		OperatorNamespace:   operatorNamespace,
 		RestConfig:          mgr.GetConfig(),
 	}).SetupWithManager(mgr); err != nil {
@@ -192,7 +252,7 @@ func main() {
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		EventStatusRecorder: controller.NewEventStatusRecorder(mgr.GetEventRecorderFor("ip-address-claim-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
-		NetboxClient:        netboxCompositeClient,
+		NetboxClients:       netboxClients,
 		OperatorNamespace:   operatorNamespace,
 		RestConfig:          mgr.GetConfig(),
 	}).SetupWithManager(mgr); err != nil {
@@ -203,7 +263,7 @@ func main() {
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		EventStatusRecorder: controller.NewEventStatusRecorder(mgr.GetEventRecorderFor("prefix-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
-		NetboxClient:        netboxCompositeClient,
+		NetboxClients:       netboxClients,
 		OperatorNamespace:   operatorNamespace,
 		RestConfig:          mgr.GetConfig(),
 	}).SetupWithManager(mgr); err != nil {
@@ -214,7 +274,7 @@ func main() {
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		EventStatusRecorder: controller.NewEventStatusRecorder(mgr.GetEventRecorderFor("prefix-claim-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
-		NetboxClient:        netboxCompositeClient,
+		NetboxClients:       netboxClients,
 		OperatorNamespace:   operatorNamespace,
 		RestConfig:          mgr.GetConfig(),
 	}).SetupWithManager(mgr); err != nil {
@@ -225,7 +285,7 @@ func main() {
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		EventStatusRecorder: controller.NewEventStatusRecorder(mgr.GetEventRecorderFor("ip-range-claim-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
-		NetboxClient:        netboxCompositeClient,
+		NetboxClients:       netboxClients,
 		OperatorNamespace:   operatorNamespace,
 		RestConfig:          mgr.GetConfig(),
 	}).SetupWithManager(mgr); err != nil {
@@ -236,13 +296,44 @@ func main() {
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
 		EventStatusRecorder: controller.NewEventStatusRecorder(mgr.GetEventRecorderFor("ip-range-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
-		NetboxClient:        netboxCompositeClient,
+		NetboxClients:       netboxClients,
 		OperatorNamespace:   operatorNamespace,
 		RestConfig:          mgr.GetConfig(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IpRange")
 		os.Exit(1)
 	}
+	if err = (&controller.VlanClaimReconciler{
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		EventStatusRecorder: controller.NewEventStatusRecorder(mgr.GetEventRecorderFor("vlan-claim-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
+		NetboxClients:       netboxClients,
+		OperatorNamespace:   operatorNamespace,
+		RestConfig:          mgr.GetConfig(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "VlanClaim")
+		os.Exit(1)
+	}
+	if err = (&controller.VlanReconciler{
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		EventStatusRecorder: controller.NewEventStatusRecorder(mgr.GetEventRecorderFor("vlan-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
+		NetboxClients:       netboxClients,
+		OperatorNamespace:   operatorNamespace,
+		RestConfig:          mgr.GetConfig(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Vlan")
+		os.Exit(1)
+	}
+	if err = (&controller.NetBoxConnectionReconciler{
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+		EventStatusRecorder: controller.NewEventStatusRecorder(mgr.GetEventRecorderFor("netbox-connection-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
+		NetboxClients:       netboxClients,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NetBoxConnection")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -253,6 +344,10 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("netbox", netboxHealthMonitor.ReadyzCheck); err != nil {
+		setupLog.Error(err, "unable to set up netbox ready check")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
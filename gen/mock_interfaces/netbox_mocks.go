@@ -14,470 +14,266 @@ import (
 	http "net/http"
 	reflect "reflect"
 
-	runtime "github.com/go-openapi/runtime"
-	dcim "github.com/netbox-community/go-netbox/v3/netbox/client/dcim"
-	extras "github.com/netbox-community/go-netbox/v3/netbox/client/extras"
-	ipam "github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
-	tenancy "github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
 	netbox "github.com/netbox-community/go-netbox/v4"
 	interfaces "github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockIpamInterface is a mock of IpamInterface interface.
-type MockIpamInterface struct {
+// MockIpamIpAddressesListRequest is a mock of IpamIpAddressesListRequest interface.
+type MockIpamIpAddressesListRequest struct {
 	ctrl     *gomock.Controller
-	recorder *MockIpamInterfaceMockRecorder
+	recorder *MockIpamIpAddressesListRequestMockRecorder
 	isgomock struct{}
 }
 
-// MockIpamInterfaceMockRecorder is the mock recorder for MockIpamInterface.
-type MockIpamInterfaceMockRecorder struct {
-	mock *MockIpamInterface
+// MockIpamIpAddressesListRequestMockRecorder is the mock recorder for MockIpamIpAddressesListRequest.
+type MockIpamIpAddressesListRequestMockRecorder struct {
+	mock *MockIpamIpAddressesListRequest
 }
 
-// NewMockIpamInterface creates a new mock instance.
-func NewMockIpamInterface(ctrl *gomock.Controller) *MockIpamInterface {
-	mock := &MockIpamInterface{ctrl: ctrl}
-	mock.recorder = &MockIpamInterfaceMockRecorder{mock}
+// NewMockIpamIpAddressesListRequest creates a new mock instance.
+func NewMockIpamIpAddressesListRequest(ctrl *gomock.Controller) *MockIpamIpAddressesListRequest {
+	mock := &MockIpamIpAddressesListRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamIpAddressesListRequestMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIpamInterface) EXPECT() *MockIpamInterfaceMockRecorder {
+func (m *MockIpamIpAddressesListRequest) EXPECT() *MockIpamIpAddressesListRequestMockRecorder {
 	return m.recorder
 }
 
-// IpamIPAddressesCreate mocks base method.
-func (m *MockIpamInterface) IpamIPAddressesCreate(params *ipam.IpamIPAddressesCreateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPAddressesCreateCreated, error) {
+// Address mocks base method.
+func (m *MockIpamIpAddressesListRequest) Address(address []string) interfaces.IpamIpAddressesListRequest {
 	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamIPAddressesCreate", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamIPAddressesCreateCreated)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Address", address)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesListRequest)
+	return ret0
 }
 
-// IpamIPAddressesCreate indicates an expected call of IpamIPAddressesCreate.
-func (mr *MockIpamInterfaceMockRecorder) IpamIPAddressesCreate(params, authInfo any, opts ...any) *gomock.Call {
+// Address indicates an expected call of Address.
+func (mr *MockIpamIpAddressesListRequestMockRecorder) Address(address any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIPAddressesCreate", reflect.TypeOf((*MockIpamInterface)(nil).IpamIPAddressesCreate), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Address", reflect.TypeOf((*MockIpamIpAddressesListRequest)(nil).Address), address)
 }
 
-// IpamIPAddressesDelete mocks base method.
-func (m *MockIpamInterface) IpamIPAddressesDelete(params *ipam.IpamIPAddressesDeleteParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPAddressesDeleteNoContent, error) {
+// Execute mocks base method.
+func (m *MockIpamIpAddressesListRequest) Execute() (*netbox.PaginatedIPAddressList, *http.Response, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamIPAddressesDelete", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamIPAddressesDeleteNoContent)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.PaginatedIPAddressList)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// IpamIPAddressesDelete indicates an expected call of IpamIPAddressesDelete.
-func (mr *MockIpamInterfaceMockRecorder) IpamIPAddressesDelete(params, authInfo any, opts ...any) *gomock.Call {
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamIpAddressesListRequestMockRecorder) Execute() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIPAddressesDelete", reflect.TypeOf((*MockIpamInterface)(nil).IpamIPAddressesDelete), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamIpAddressesListRequest)(nil).Execute))
 }
 
-// IpamIPAddressesList mocks base method.
-func (m *MockIpamInterface) IpamIPAddressesList(params *ipam.IpamIPAddressesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPAddressesListOK, error) {
+// Limit mocks base method.
+func (m *MockIpamIpAddressesListRequest) Limit(limit int32) interfaces.IpamIpAddressesListRequest {
 	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamIPAddressesList", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamIPAddressesListOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Limit", limit)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesListRequest)
+	return ret0
 }
 
-// IpamIPAddressesList indicates an expected call of IpamIPAddressesList.
-func (mr *MockIpamInterfaceMockRecorder) IpamIPAddressesList(params, authInfo any, opts ...any) *gomock.Call {
+// Limit indicates an expected call of Limit.
+func (mr *MockIpamIpAddressesListRequestMockRecorder) Limit(limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIPAddressesList", reflect.TypeOf((*MockIpamInterface)(nil).IpamIPAddressesList), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limit", reflect.TypeOf((*MockIpamIpAddressesListRequest)(nil).Limit), limit)
 }
 
-// IpamIPAddressesUpdate mocks base method.
-func (m *MockIpamInterface) IpamIPAddressesUpdate(params *ipam.IpamIPAddressesUpdateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPAddressesUpdateOK, error) {
+// Offset mocks base method.
+func (m *MockIpamIpAddressesListRequest) Offset(offset int32) interfaces.IpamIpAddressesListRequest {
 	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamIPAddressesUpdate", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamIPAddressesUpdateOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Offset", offset)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesListRequest)
+	return ret0
 }
 
-// IpamIPAddressesUpdate indicates an expected call of IpamIPAddressesUpdate.
-func (mr *MockIpamInterfaceMockRecorder) IpamIPAddressesUpdate(params, authInfo any, opts ...any) *gomock.Call {
+// Offset indicates an expected call of Offset.
+func (mr *MockIpamIpAddressesListRequestMockRecorder) Offset(offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIPAddressesUpdate", reflect.TypeOf((*MockIpamInterface)(nil).IpamIPAddressesUpdate), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offset", reflect.TypeOf((*MockIpamIpAddressesListRequest)(nil).Offset), offset)
 }
 
-// IpamIPRangesAvailableIpsList mocks base method.
-func (m *MockIpamInterface) IpamIPRangesAvailableIpsList(params *ipam.IpamIPRangesAvailableIpsListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesAvailableIpsListOK, error) {
+// Parent mocks base method.
+func (m *MockIpamIpAddressesListRequest) Parent(parent []string) interfaces.IpamIpAddressesListRequest {
 	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamIPRangesAvailableIpsList", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamIPRangesAvailableIpsListOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Parent", parent)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesListRequest)
+	return ret0
 }
 
-// IpamIPRangesAvailableIpsList indicates an expected call of IpamIPRangesAvailableIpsList.
-func (mr *MockIpamInterfaceMockRecorder) IpamIPRangesAvailableIpsList(params, authInfo any, opts ...any) *gomock.Call {
+// Parent indicates an expected call of Parent.
+func (mr *MockIpamIpAddressesListRequestMockRecorder) Parent(parent any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIPRangesAvailableIpsList", reflect.TypeOf((*MockIpamInterface)(nil).IpamIPRangesAvailableIpsList), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parent", reflect.TypeOf((*MockIpamIpAddressesListRequest)(nil).Parent), parent)
 }
 
-// IpamIPRangesCreate mocks base method.
-func (m *MockIpamInterface) IpamIPRangesCreate(params *ipam.IpamIPRangesCreateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesCreateCreated, error) {
+// VrfId mocks base method.
+func (m *MockIpamIpAddressesListRequest) VrfId(vrfId []*int32) interfaces.IpamIpAddressesListRequest {
 	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamIPRangesCreate", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamIPRangesCreateCreated)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "VrfId", vrfId)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesListRequest)
+	return ret0
 }
 
-// IpamIPRangesCreate indicates an expected call of IpamIPRangesCreate.
-func (mr *MockIpamInterfaceMockRecorder) IpamIPRangesCreate(params, authInfo any, opts ...any) *gomock.Call {
+// VrfId indicates an expected call of VrfId.
+func (mr *MockIpamIpAddressesListRequestMockRecorder) VrfId(vrfId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIPRangesCreate", reflect.TypeOf((*MockIpamInterface)(nil).IpamIPRangesCreate), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VrfId", reflect.TypeOf((*MockIpamIpAddressesListRequest)(nil).VrfId), vrfId)
 }
 
-// IpamIPRangesDelete mocks base method.
-func (m *MockIpamInterface) IpamIPRangesDelete(params *ipam.IpamIPRangesDeleteParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesDeleteNoContent, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamIPRangesDelete", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamIPRangesDeleteNoContent)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockIpamIpAddressesCreateRequest is a mock of IpamIpAddressesCreateRequest interface.
+type MockIpamIpAddressesCreateRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamIpAddressesCreateRequestMockRecorder
+	isgomock struct{}
 }
 
-// IpamIPRangesDelete indicates an expected call of IpamIPRangesDelete.
-func (mr *MockIpamInterfaceMockRecorder) IpamIPRangesDelete(params, authInfo any, opts ...any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIPRangesDelete", reflect.TypeOf((*MockIpamInterface)(nil).IpamIPRangesDelete), varargs...)
+// MockIpamIpAddressesCreateRequestMockRecorder is the mock recorder for MockIpamIpAddressesCreateRequest.
+type MockIpamIpAddressesCreateRequestMockRecorder struct {
+	mock *MockIpamIpAddressesCreateRequest
 }
 
-// IpamIPRangesList mocks base method.
-func (m *MockIpamInterface) IpamIPRangesList(params *ipam.IpamIPRangesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesListOK, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamIPRangesList", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamIPRangesListOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// NewMockIpamIpAddressesCreateRequest creates a new mock instance.
+func NewMockIpamIpAddressesCreateRequest(ctrl *gomock.Controller) *MockIpamIpAddressesCreateRequest {
+	mock := &MockIpamIpAddressesCreateRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamIpAddressesCreateRequestMockRecorder{mock}
+	return mock
 }
 
-// IpamIPRangesList indicates an expected call of IpamIPRangesList.
-func (mr *MockIpamInterfaceMockRecorder) IpamIPRangesList(params, authInfo any, opts ...any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIPRangesList", reflect.TypeOf((*MockIpamInterface)(nil).IpamIPRangesList), varargs...)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamIpAddressesCreateRequest) EXPECT() *MockIpamIpAddressesCreateRequestMockRecorder {
+	return m.recorder
 }
 
-// IpamIPRangesUpdate mocks base method.
-func (m *MockIpamInterface) IpamIPRangesUpdate(params *ipam.IpamIPRangesUpdateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesUpdateOK, error) {
+// Execute mocks base method.
+func (m *MockIpamIpAddressesCreateRequest) Execute() (*netbox.IPAddress, *http.Response, error) {
 	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamIPRangesUpdate", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamIPRangesUpdateOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// IpamIPRangesUpdate indicates an expected call of IpamIPRangesUpdate.
-func (mr *MockIpamInterfaceMockRecorder) IpamIPRangesUpdate(params, authInfo any, opts ...any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIPRangesUpdate", reflect.TypeOf((*MockIpamInterface)(nil).IpamIPRangesUpdate), varargs...)
-}
-
-// IpamPrefixesAvailableIpsList mocks base method.
-func (m *MockIpamInterface) IpamPrefixesAvailableIpsList(params *ipam.IpamPrefixesAvailableIpsListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesAvailableIpsListOK, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamPrefixesAvailableIpsList", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamPrefixesAvailableIpsListOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// IpamPrefixesAvailableIpsList indicates an expected call of IpamPrefixesAvailableIpsList.
-func (mr *MockIpamInterfaceMockRecorder) IpamPrefixesAvailableIpsList(params, authInfo any, opts ...any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesAvailableIpsList", reflect.TypeOf((*MockIpamInterface)(nil).IpamPrefixesAvailableIpsList), varargs...)
-}
-
-// IpamPrefixesAvailablePrefixesList mocks base method.
-func (m *MockIpamInterface) IpamPrefixesAvailablePrefixesList(params *ipam.IpamPrefixesAvailablePrefixesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesAvailablePrefixesListOK, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamPrefixesAvailablePrefixesList", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamPrefixesAvailablePrefixesListOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// IpamPrefixesAvailablePrefixesList indicates an expected call of IpamPrefixesAvailablePrefixesList.
-func (mr *MockIpamInterfaceMockRecorder) IpamPrefixesAvailablePrefixesList(params, authInfo any, opts ...any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesAvailablePrefixesList", reflect.TypeOf((*MockIpamInterface)(nil).IpamPrefixesAvailablePrefixesList), varargs...)
-}
-
-// IpamPrefixesCreate mocks base method.
-func (m *MockIpamInterface) IpamPrefixesCreate(params *ipam.IpamPrefixesCreateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesCreateCreated, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamPrefixesCreate", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamPrefixesCreateCreated)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.IPAddress)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// IpamPrefixesCreate indicates an expected call of IpamPrefixesCreate.
-func (mr *MockIpamInterfaceMockRecorder) IpamPrefixesCreate(params, authInfo any, opts ...any) *gomock.Call {
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamIpAddressesCreateRequestMockRecorder) Execute() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesCreate", reflect.TypeOf((*MockIpamInterface)(nil).IpamPrefixesCreate), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamIpAddressesCreateRequest)(nil).Execute))
 }
 
-// IpamPrefixesDelete mocks base method.
-func (m *MockIpamInterface) IpamPrefixesDelete(params *ipam.IpamPrefixesDeleteParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesDeleteNoContent, error) {
+// WritableIPAddressRequest mocks base method.
+func (m *MockIpamIpAddressesCreateRequest) WritableIPAddressRequest(writableIPAddressRequest netbox.WritableIPAddressRequest) interfaces.IpamIpAddressesCreateRequest {
 	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamPrefixesDelete", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamPrefixesDeleteNoContent)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// IpamPrefixesDelete indicates an expected call of IpamPrefixesDelete.
-func (mr *MockIpamInterfaceMockRecorder) IpamPrefixesDelete(params, authInfo any, opts ...any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesDelete", reflect.TypeOf((*MockIpamInterface)(nil).IpamPrefixesDelete), varargs...)
-}
-
-// IpamPrefixesList mocks base method.
-func (m *MockIpamInterface) IpamPrefixesList(params *ipam.IpamPrefixesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesListOK, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamPrefixesList", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamPrefixesListOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// IpamPrefixesList indicates an expected call of IpamPrefixesList.
-func (mr *MockIpamInterfaceMockRecorder) IpamPrefixesList(params, authInfo any, opts ...any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesList", reflect.TypeOf((*MockIpamInterface)(nil).IpamPrefixesList), varargs...)
-}
-
-// IpamPrefixesUpdate mocks base method.
-func (m *MockIpamInterface) IpamPrefixesUpdate(params *ipam.IpamPrefixesUpdateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesUpdateOK, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "IpamPrefixesUpdate", varargs...)
-	ret0, _ := ret[0].(*ipam.IpamPrefixesUpdateOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "WritableIPAddressRequest", writableIPAddressRequest)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesCreateRequest)
+	return ret0
 }
 
-// IpamPrefixesUpdate indicates an expected call of IpamPrefixesUpdate.
-func (mr *MockIpamInterfaceMockRecorder) IpamPrefixesUpdate(params, authInfo any, opts ...any) *gomock.Call {
+// WritableIPAddressRequest indicates an expected call of WritableIPAddressRequest.
+func (mr *MockIpamIpAddressesCreateRequestMockRecorder) WritableIPAddressRequest(writableIPAddressRequest any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesUpdate", reflect.TypeOf((*MockIpamInterface)(nil).IpamPrefixesUpdate), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WritableIPAddressRequest", reflect.TypeOf((*MockIpamIpAddressesCreateRequest)(nil).WritableIPAddressRequest), writableIPAddressRequest)
 }
 
-// MockTenancyInterface is a mock of TenancyInterface interface.
-type MockTenancyInterface struct {
+// MockIpamIpAddressesUpdateRequest is a mock of IpamIpAddressesUpdateRequest interface.
+type MockIpamIpAddressesUpdateRequest struct {
 	ctrl     *gomock.Controller
-	recorder *MockTenancyInterfaceMockRecorder
+	recorder *MockIpamIpAddressesUpdateRequestMockRecorder
 	isgomock struct{}
 }
 
-// MockTenancyInterfaceMockRecorder is the mock recorder for MockTenancyInterface.
-type MockTenancyInterfaceMockRecorder struct {
-	mock *MockTenancyInterface
+// MockIpamIpAddressesUpdateRequestMockRecorder is the mock recorder for MockIpamIpAddressesUpdateRequest.
+type MockIpamIpAddressesUpdateRequestMockRecorder struct {
+	mock *MockIpamIpAddressesUpdateRequest
 }
 
-// NewMockTenancyInterface creates a new mock instance.
-func NewMockTenancyInterface(ctrl *gomock.Controller) *MockTenancyInterface {
-	mock := &MockTenancyInterface{ctrl: ctrl}
-	mock.recorder = &MockTenancyInterfaceMockRecorder{mock}
+// NewMockIpamIpAddressesUpdateRequest creates a new mock instance.
+func NewMockIpamIpAddressesUpdateRequest(ctrl *gomock.Controller) *MockIpamIpAddressesUpdateRequest {
+	mock := &MockIpamIpAddressesUpdateRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamIpAddressesUpdateRequestMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTenancyInterface) EXPECT() *MockTenancyInterfaceMockRecorder {
+func (m *MockIpamIpAddressesUpdateRequest) EXPECT() *MockIpamIpAddressesUpdateRequestMockRecorder {
 	return m.recorder
 }
 
-// TenancyTenantsList mocks base method.
-func (m *MockTenancyInterface) TenancyTenantsList(params *tenancy.TenancyTenantsListParams, authInfo runtime.ClientAuthInfoWriter, opts ...tenancy.ClientOption) (*tenancy.TenancyTenantsListOK, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "TenancyTenantsList", varargs...)
-	ret0, _ := ret[0].(*tenancy.TenancyTenantsListOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// Execute mocks base method.
+func (m *MockIpamIpAddressesUpdateRequest) Execute() (*netbox.IPAddress, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.IPAddress)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// TenancyTenantsList indicates an expected call of TenancyTenantsList.
-func (mr *MockTenancyInterfaceMockRecorder) TenancyTenantsList(params, authInfo any, opts ...any) *gomock.Call {
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamIpAddressesUpdateRequestMockRecorder) Execute() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenancyTenantsList", reflect.TypeOf((*MockTenancyInterface)(nil).TenancyTenantsList), varargs...)
-}
-
-// MockExtrasInterface is a mock of ExtrasInterface interface.
-type MockExtrasInterface struct {
-	ctrl     *gomock.Controller
-	recorder *MockExtrasInterfaceMockRecorder
-	isgomock struct{}
-}
-
-// MockExtrasInterfaceMockRecorder is the mock recorder for MockExtrasInterface.
-type MockExtrasInterfaceMockRecorder struct {
-	mock *MockExtrasInterface
-}
-
-// NewMockExtrasInterface creates a new mock instance.
-func NewMockExtrasInterface(ctrl *gomock.Controller) *MockExtrasInterface {
-	mock := &MockExtrasInterface{ctrl: ctrl}
-	mock.recorder = &MockExtrasInterfaceMockRecorder{mock}
-	return mock
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamIpAddressesUpdateRequest)(nil).Execute))
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockExtrasInterface) EXPECT() *MockExtrasInterfaceMockRecorder {
-	return m.recorder
-}
-
-// ExtrasCustomFieldsList mocks base method.
-func (m *MockExtrasInterface) ExtrasCustomFieldsList(params *extras.ExtrasCustomFieldsListParams, authInfo runtime.ClientAuthInfoWriter, opts ...extras.ClientOption) (*extras.ExtrasCustomFieldsListOK, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "ExtrasCustomFieldsList", varargs...)
-	ret0, _ := ret[0].(*extras.ExtrasCustomFieldsListOK)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// WritableIPAddressRequest mocks base method.
+func (m *MockIpamIpAddressesUpdateRequest) WritableIPAddressRequest(writableIPAddressRequest netbox.WritableIPAddressRequest) interfaces.IpamIpAddressesUpdateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WritableIPAddressRequest", writableIPAddressRequest)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesUpdateRequest)
+	return ret0
 }
 
-// ExtrasCustomFieldsList indicates an expected call of ExtrasCustomFieldsList.
-func (mr *MockExtrasInterfaceMockRecorder) ExtrasCustomFieldsList(params, authInfo any, opts ...any) *gomock.Call {
+// WritableIPAddressRequest indicates an expected call of WritableIPAddressRequest.
+func (mr *MockIpamIpAddressesUpdateRequestMockRecorder) WritableIPAddressRequest(writableIPAddressRequest any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtrasCustomFieldsList", reflect.TypeOf((*MockExtrasInterface)(nil).ExtrasCustomFieldsList), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WritableIPAddressRequest", reflect.TypeOf((*MockIpamIpAddressesUpdateRequest)(nil).WritableIPAddressRequest), writableIPAddressRequest)
 }
 
-// MockDcimInterface is a mock of DcimInterface interface.
-type MockDcimInterface struct {
+// MockIpamIpAddressesDestroyRequest is a mock of IpamIpAddressesDestroyRequest interface.
+type MockIpamIpAddressesDestroyRequest struct {
 	ctrl     *gomock.Controller
-	recorder *MockDcimInterfaceMockRecorder
+	recorder *MockIpamIpAddressesDestroyRequestMockRecorder
 	isgomock struct{}
 }
 
-// MockDcimInterfaceMockRecorder is the mock recorder for MockDcimInterface.
-type MockDcimInterfaceMockRecorder struct {
-	mock *MockDcimInterface
+// MockIpamIpAddressesDestroyRequestMockRecorder is the mock recorder for MockIpamIpAddressesDestroyRequest.
+type MockIpamIpAddressesDestroyRequestMockRecorder struct {
+	mock *MockIpamIpAddressesDestroyRequest
 }
 
-// NewMockDcimInterface creates a new mock instance.
-func NewMockDcimInterface(ctrl *gomock.Controller) *MockDcimInterface {
-	mock := &MockDcimInterface{ctrl: ctrl}
-	mock.recorder = &MockDcimInterfaceMockRecorder{mock}
+// NewMockIpamIpAddressesDestroyRequest creates a new mock instance.
+func NewMockIpamIpAddressesDestroyRequest(ctrl *gomock.Controller) *MockIpamIpAddressesDestroyRequest {
+	mock := &MockIpamIpAddressesDestroyRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamIpAddressesDestroyRequestMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockDcimInterface) EXPECT() *MockDcimInterfaceMockRecorder {
+func (m *MockIpamIpAddressesDestroyRequest) EXPECT() *MockIpamIpAddressesDestroyRequestMockRecorder {
 	return m.recorder
 }
 
-// DcimSitesList mocks base method.
-func (m *MockDcimInterface) DcimSitesList(params *dcim.DcimSitesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...dcim.ClientOption) (*dcim.DcimSitesListOK, error) {
-	m.ctrl.T.Helper()
-	varargs := []any{params, authInfo}
-	for _, a := range opts {
-		varargs = append(varargs, a)
-	}
-	ret := m.ctrl.Call(m, "DcimSitesList", varargs...)
-	ret0, _ := ret[0].(*dcim.DcimSitesListOK)
+// Execute mocks base method.
+func (m *MockIpamIpAddressesDestroyRequest) Execute() (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*http.Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DcimSitesList indicates an expected call of DcimSitesList.
-func (mr *MockDcimInterfaceMockRecorder) DcimSitesList(params, authInfo any, opts ...any) *gomock.Call {
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamIpAddressesDestroyRequestMockRecorder) Execute() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]any{params, authInfo}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DcimSitesList", reflect.TypeOf((*MockDcimInterface)(nil).DcimSitesList), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamIpAddressesDestroyRequest)(nil).Execute))
 }
 
 // MockIpamIpRangesListRequest is a mock of IpamIpRangesListRequest interface.
@@ -534,6 +330,34 @@ func (mr *MockIpamIpRangesListRequestMockRecorder) Execute() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamIpRangesListRequest)(nil).Execute))
 }
 
+// Limit mocks base method.
+func (m *MockIpamIpRangesListRequest) Limit(limit int32) interfaces.IpamIpRangesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limit", limit)
+	ret0, _ := ret[0].(interfaces.IpamIpRangesListRequest)
+	return ret0
+}
+
+// Limit indicates an expected call of Limit.
+func (mr *MockIpamIpRangesListRequestMockRecorder) Limit(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limit", reflect.TypeOf((*MockIpamIpRangesListRequest)(nil).Limit), limit)
+}
+
+// Offset mocks base method.
+func (m *MockIpamIpRangesListRequest) Offset(offset int32) interfaces.IpamIpRangesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offset", offset)
+	ret0, _ := ret[0].(interfaces.IpamIpRangesListRequest)
+	return ret0
+}
+
+// Offset indicates an expected call of Offset.
+func (mr *MockIpamIpRangesListRequestMockRecorder) Offset(offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offset", reflect.TypeOf((*MockIpamIpRangesListRequest)(nil).Offset), offset)
+}
+
 // StartAddress mocks base method.
 func (m *MockIpamIpRangesListRequest) StartAddress(startAddress []string) interfaces.IpamIpRangesListRequest {
 	m.ctrl.T.Helper()
@@ -548,6 +372,20 @@ func (mr *MockIpamIpRangesListRequestMockRecorder) StartAddress(startAddress any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAddress", reflect.TypeOf((*MockIpamIpRangesListRequest)(nil).StartAddress), startAddress)
 }
 
+// VrfId mocks base method.
+func (m *MockIpamIpRangesListRequest) VrfId(vrfId []*int32) interfaces.IpamIpRangesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VrfId", vrfId)
+	ret0, _ := ret[0].(interfaces.IpamIpRangesListRequest)
+	return ret0
+}
+
+// VrfId indicates an expected call of VrfId.
+func (mr *MockIpamIpRangesListRequestMockRecorder) VrfId(vrfId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VrfId", reflect.TypeOf((*MockIpamIpRangesListRequest)(nil).VrfId), vrfId)
+}
+
 // MockIpamIpRangesCreateRequest is a mock of IpamIpRangesCreateRequest interface.
 type MockIpamIpRangesCreateRequest struct {
 	ctrl     *gomock.Controller
@@ -695,6 +533,46 @@ func (mr *MockIpamIpRangesDestroyRequestMockRecorder) Execute() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamIpRangesDestroyRequest)(nil).Execute))
 }
 
+// MockIpamIpRangesAvailableIpsListRequest is a mock of IpamIpRangesAvailableIpsListRequest interface.
+type MockIpamIpRangesAvailableIpsListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamIpRangesAvailableIpsListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamIpRangesAvailableIpsListRequestMockRecorder is the mock recorder for MockIpamIpRangesAvailableIpsListRequest.
+type MockIpamIpRangesAvailableIpsListRequestMockRecorder struct {
+	mock *MockIpamIpRangesAvailableIpsListRequest
+}
+
+// NewMockIpamIpRangesAvailableIpsListRequest creates a new mock instance.
+func NewMockIpamIpRangesAvailableIpsListRequest(ctrl *gomock.Controller) *MockIpamIpRangesAvailableIpsListRequest {
+	mock := &MockIpamIpRangesAvailableIpsListRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamIpRangesAvailableIpsListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamIpRangesAvailableIpsListRequest) EXPECT() *MockIpamIpRangesAvailableIpsListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamIpRangesAvailableIpsListRequest) Execute() ([]netbox.AvailableIP, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].([]netbox.AvailableIP)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamIpRangesAvailableIpsListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamIpRangesAvailableIpsListRequest)(nil).Execute))
+}
+
 // MockIpamPrefixesListRequest is a mock of IpamPrefixesListRequest interface.
 type MockIpamPrefixesListRequest struct {
 	ctrl     *gomock.Controller
@@ -719,6 +597,20 @@ func (m *MockIpamPrefixesListRequest) EXPECT() *MockIpamPrefixesListRequestMockR
 	return m.recorder
 }
 
+// Contains mocks base method.
+func (m *MockIpamPrefixesListRequest) Contains(contains string) interfaces.IpamPrefixesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contains", contains)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesListRequest)
+	return ret0
+}
+
+// Contains indicates an expected call of Contains.
+func (mr *MockIpamPrefixesListRequestMockRecorder) Contains(contains any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contains", reflect.TypeOf((*MockIpamPrefixesListRequest)(nil).Contains), contains)
+}
+
 // Execute mocks base method.
 func (m *MockIpamPrefixesListRequest) Execute() (*netbox.PaginatedPrefixList, *http.Response, error) {
 	m.ctrl.T.Helper()
@@ -735,6 +627,34 @@ func (mr *MockIpamPrefixesListRequestMockRecorder) Execute() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamPrefixesListRequest)(nil).Execute))
 }
 
+// Limit mocks base method.
+func (m *MockIpamPrefixesListRequest) Limit(limit int32) interfaces.IpamPrefixesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limit", limit)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesListRequest)
+	return ret0
+}
+
+// Limit indicates an expected call of Limit.
+func (mr *MockIpamPrefixesListRequestMockRecorder) Limit(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limit", reflect.TypeOf((*MockIpamPrefixesListRequest)(nil).Limit), limit)
+}
+
+// Offset mocks base method.
+func (m *MockIpamPrefixesListRequest) Offset(offset int32) interfaces.IpamPrefixesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offset", offset)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesListRequest)
+	return ret0
+}
+
+// Offset indicates an expected call of Offset.
+func (mr *MockIpamPrefixesListRequestMockRecorder) Offset(offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offset", reflect.TypeOf((*MockIpamPrefixesListRequest)(nil).Offset), offset)
+}
+
 // Prefix mocks base method.
 func (m *MockIpamPrefixesListRequest) Prefix(prefix []string) interfaces.IpamPrefixesListRequest {
 	m.ctrl.T.Helper()
@@ -749,6 +669,34 @@ func (mr *MockIpamPrefixesListRequestMockRecorder) Prefix(prefix any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prefix", reflect.TypeOf((*MockIpamPrefixesListRequest)(nil).Prefix), prefix)
 }
 
+// VrfId mocks base method.
+func (m *MockIpamPrefixesListRequest) VrfId(vrfId []*int32) interfaces.IpamPrefixesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VrfId", vrfId)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesListRequest)
+	return ret0
+}
+
+// VrfId indicates an expected call of VrfId.
+func (mr *MockIpamPrefixesListRequestMockRecorder) VrfId(vrfId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VrfId", reflect.TypeOf((*MockIpamPrefixesListRequest)(nil).VrfId), vrfId)
+}
+
+// Within mocks base method.
+func (m *MockIpamPrefixesListRequest) Within(within string) interfaces.IpamPrefixesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Within", within)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesListRequest)
+	return ret0
+}
+
+// Within indicates an expected call of Within.
+func (mr *MockIpamPrefixesListRequestMockRecorder) Within(within any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Within", reflect.TypeOf((*MockIpamPrefixesListRequest)(nil).Within), within)
+}
+
 // MockIpamPrefixesCreateRequest is a mock of IpamPrefixesCreateRequest interface.
 type MockIpamPrefixesCreateRequest struct {
 	ctrl     *gomock.Controller
@@ -896,140 +844,1371 @@ func (mr *MockIpamPrefixesDestroyRequestMockRecorder) Execute() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamPrefixesDestroyRequest)(nil).Execute))
 }
 
-// MockIpamAPI is a mock of IpamAPI interface.
-type MockIpamAPI struct {
+// MockIpamPrefixesAvailableIpsListRequest is a mock of IpamPrefixesAvailableIpsListRequest interface.
+type MockIpamPrefixesAvailableIpsListRequest struct {
 	ctrl     *gomock.Controller
-	recorder *MockIpamAPIMockRecorder
+	recorder *MockIpamPrefixesAvailableIpsListRequestMockRecorder
 	isgomock struct{}
 }
 
-// MockIpamAPIMockRecorder is the mock recorder for MockIpamAPI.
-type MockIpamAPIMockRecorder struct {
-	mock *MockIpamAPI
+// MockIpamPrefixesAvailableIpsListRequestMockRecorder is the mock recorder for MockIpamPrefixesAvailableIpsListRequest.
+type MockIpamPrefixesAvailableIpsListRequestMockRecorder struct {
+	mock *MockIpamPrefixesAvailableIpsListRequest
 }
 
-// NewMockIpamAPI creates a new mock instance.
-func NewMockIpamAPI(ctrl *gomock.Controller) *MockIpamAPI {
-	mock := &MockIpamAPI{ctrl: ctrl}
-	mock.recorder = &MockIpamAPIMockRecorder{mock}
+// NewMockIpamPrefixesAvailableIpsListRequest creates a new mock instance.
+func NewMockIpamPrefixesAvailableIpsListRequest(ctrl *gomock.Controller) *MockIpamPrefixesAvailableIpsListRequest {
+	mock := &MockIpamPrefixesAvailableIpsListRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamPrefixesAvailableIpsListRequestMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIpamAPI) EXPECT() *MockIpamAPIMockRecorder {
+func (m *MockIpamPrefixesAvailableIpsListRequest) EXPECT() *MockIpamPrefixesAvailableIpsListRequestMockRecorder {
 	return m.recorder
 }
 
-// IpamIpRangesCreate mocks base method.
-func (m *MockIpamAPI) IpamIpRangesCreate(ctx context.Context) interfaces.IpamIpRangesCreateRequest {
+// Execute mocks base method.
+func (m *MockIpamPrefixesAvailableIpsListRequest) Execute() ([]netbox.AvailableIP, *http.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IpamIpRangesCreate", ctx)
-	ret0, _ := ret[0].(interfaces.IpamIpRangesCreateRequest)
-	return ret0
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].([]netbox.AvailableIP)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// IpamIpRangesCreate indicates an expected call of IpamIpRangesCreate.
-func (mr *MockIpamAPIMockRecorder) IpamIpRangesCreate(ctx any) *gomock.Call {
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamPrefixesAvailableIpsListRequestMockRecorder) Execute() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpRangesCreate", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpRangesCreate), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamPrefixesAvailableIpsListRequest)(nil).Execute))
 }
 
-// IpamIpRangesDestroy mocks base method.
-func (m *MockIpamAPI) IpamIpRangesDestroy(ctx context.Context, id int32) interfaces.IpamIpRangesDestroyRequest {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IpamIpRangesDestroy", ctx, id)
-	ret0, _ := ret[0].(interfaces.IpamIpRangesDestroyRequest)
-	return ret0
+// MockIpamPrefixesAvailablePrefixesListRequest is a mock of IpamPrefixesAvailablePrefixesListRequest interface.
+type MockIpamPrefixesAvailablePrefixesListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamPrefixesAvailablePrefixesListRequestMockRecorder
+	isgomock struct{}
 }
 
-// IpamIpRangesDestroy indicates an expected call of IpamIpRangesDestroy.
-func (mr *MockIpamAPIMockRecorder) IpamIpRangesDestroy(ctx, id any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpRangesDestroy", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpRangesDestroy), ctx, id)
+// MockIpamPrefixesAvailablePrefixesListRequestMockRecorder is the mock recorder for MockIpamPrefixesAvailablePrefixesListRequest.
+type MockIpamPrefixesAvailablePrefixesListRequestMockRecorder struct {
+	mock *MockIpamPrefixesAvailablePrefixesListRequest
 }
 
-// IpamIpRangesList mocks base method.
+// NewMockIpamPrefixesAvailablePrefixesListRequest creates a new mock instance.
+func NewMockIpamPrefixesAvailablePrefixesListRequest(ctrl *gomock.Controller) *MockIpamPrefixesAvailablePrefixesListRequest {
+	mock := &MockIpamPrefixesAvailablePrefixesListRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamPrefixesAvailablePrefixesListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamPrefixesAvailablePrefixesListRequest) EXPECT() *MockIpamPrefixesAvailablePrefixesListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamPrefixesAvailablePrefixesListRequest) Execute() ([]netbox.AvailablePrefix, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].([]netbox.AvailablePrefix)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamPrefixesAvailablePrefixesListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamPrefixesAvailablePrefixesListRequest)(nil).Execute))
+}
+
+// MockIpamVrfsListRequest is a mock of IpamVrfsListRequest interface.
+type MockIpamVrfsListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamVrfsListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamVrfsListRequestMockRecorder is the mock recorder for MockIpamVrfsListRequest.
+type MockIpamVrfsListRequestMockRecorder struct {
+	mock *MockIpamVrfsListRequest
+}
+
+// NewMockIpamVrfsListRequest creates a new mock instance.
+func NewMockIpamVrfsListRequest(ctrl *gomock.Controller) *MockIpamVrfsListRequest {
+	mock := &MockIpamVrfsListRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamVrfsListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamVrfsListRequest) EXPECT() *MockIpamVrfsListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamVrfsListRequest) Execute() (*netbox.PaginatedVRFList, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.PaginatedVRFList)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamVrfsListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamVrfsListRequest)(nil).Execute))
+}
+
+// Name mocks base method.
+func (m *MockIpamVrfsListRequest) Name(name []string) interfaces.IpamVrfsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name", name)
+	ret0, _ := ret[0].(interfaces.IpamVrfsListRequest)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockIpamVrfsListRequestMockRecorder) Name(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockIpamVrfsListRequest)(nil).Name), name)
+}
+
+// MockIpamRolesListRequest is a mock of IpamRolesListRequest interface.
+type MockIpamRolesListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamRolesListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamRolesListRequestMockRecorder is the mock recorder for MockIpamRolesListRequest.
+type MockIpamRolesListRequestMockRecorder struct {
+	mock *MockIpamRolesListRequest
+}
+
+// NewMockIpamRolesListRequest creates a new mock instance.
+func NewMockIpamRolesListRequest(ctrl *gomock.Controller) *MockIpamRolesListRequest {
+	mock := &MockIpamRolesListRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamRolesListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamRolesListRequest) EXPECT() *MockIpamRolesListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamRolesListRequest) Execute() (*netbox.PaginatedRoleList, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.PaginatedRoleList)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamRolesListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamRolesListRequest)(nil).Execute))
+}
+
+// Name mocks base method.
+func (m *MockIpamRolesListRequest) Name(name []string) interfaces.IpamRolesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name", name)
+	ret0, _ := ret[0].(interfaces.IpamRolesListRequest)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockIpamRolesListRequestMockRecorder) Name(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockIpamRolesListRequest)(nil).Name), name)
+}
+
+// Slug mocks base method.
+func (m *MockIpamRolesListRequest) Slug(slug []string) interfaces.IpamRolesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Slug", slug)
+	ret0, _ := ret[0].(interfaces.IpamRolesListRequest)
+	return ret0
+}
+
+// Slug indicates an expected call of Slug.
+func (mr *MockIpamRolesListRequestMockRecorder) Slug(slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Slug", reflect.TypeOf((*MockIpamRolesListRequest)(nil).Slug), slug)
+}
+
+// MockIpamVlansListRequest is a mock of IpamVlansListRequest interface.
+type MockIpamVlansListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamVlansListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamVlansListRequestMockRecorder is the mock recorder for MockIpamVlansListRequest.
+type MockIpamVlansListRequestMockRecorder struct {
+	mock *MockIpamVlansListRequest
+}
+
+// NewMockIpamVlansListRequest creates a new mock instance.
+func NewMockIpamVlansListRequest(ctrl *gomock.Controller) *MockIpamVlansListRequest {
+	mock := &MockIpamVlansListRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamVlansListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamVlansListRequest) EXPECT() *MockIpamVlansListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamVlansListRequest) Execute() (*netbox.PaginatedVLANList, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.PaginatedVLANList)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamVlansListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamVlansListRequest)(nil).Execute))
+}
+
+// GroupId mocks base method.
+func (m *MockIpamVlansListRequest) GroupId(groupId []*int32) interfaces.IpamVlansListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupId", groupId)
+	ret0, _ := ret[0].(interfaces.IpamVlansListRequest)
+	return ret0
+}
+
+// GroupId indicates an expected call of GroupId.
+func (mr *MockIpamVlansListRequestMockRecorder) GroupId(groupId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupId", reflect.TypeOf((*MockIpamVlansListRequest)(nil).GroupId), groupId)
+}
+
+// Limit mocks base method.
+func (m *MockIpamVlansListRequest) Limit(limit int32) interfaces.IpamVlansListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limit", limit)
+	ret0, _ := ret[0].(interfaces.IpamVlansListRequest)
+	return ret0
+}
+
+// Limit indicates an expected call of Limit.
+func (mr *MockIpamVlansListRequestMockRecorder) Limit(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limit", reflect.TypeOf((*MockIpamVlansListRequest)(nil).Limit), limit)
+}
+
+// Name mocks base method.
+func (m *MockIpamVlansListRequest) Name(name []string) interfaces.IpamVlansListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name", name)
+	ret0, _ := ret[0].(interfaces.IpamVlansListRequest)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockIpamVlansListRequestMockRecorder) Name(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockIpamVlansListRequest)(nil).Name), name)
+}
+
+// Offset mocks base method.
+func (m *MockIpamVlansListRequest) Offset(offset int32) interfaces.IpamVlansListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offset", offset)
+	ret0, _ := ret[0].(interfaces.IpamVlansListRequest)
+	return ret0
+}
+
+// Offset indicates an expected call of Offset.
+func (mr *MockIpamVlansListRequestMockRecorder) Offset(offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offset", reflect.TypeOf((*MockIpamVlansListRequest)(nil).Offset), offset)
+}
+
+// Vid mocks base method.
+func (m *MockIpamVlansListRequest) Vid(vid []int32) interfaces.IpamVlansListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vid", vid)
+	ret0, _ := ret[0].(interfaces.IpamVlansListRequest)
+	return ret0
+}
+
+// Vid indicates an expected call of Vid.
+func (mr *MockIpamVlansListRequestMockRecorder) Vid(vid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vid", reflect.TypeOf((*MockIpamVlansListRequest)(nil).Vid), vid)
+}
+
+// MockIpamVlansCreateRequest is a mock of IpamVlansCreateRequest interface.
+type MockIpamVlansCreateRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamVlansCreateRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamVlansCreateRequestMockRecorder is the mock recorder for MockIpamVlansCreateRequest.
+type MockIpamVlansCreateRequestMockRecorder struct {
+	mock *MockIpamVlansCreateRequest
+}
+
+// NewMockIpamVlansCreateRequest creates a new mock instance.
+func NewMockIpamVlansCreateRequest(ctrl *gomock.Controller) *MockIpamVlansCreateRequest {
+	mock := &MockIpamVlansCreateRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamVlansCreateRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamVlansCreateRequest) EXPECT() *MockIpamVlansCreateRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamVlansCreateRequest) Execute() (*netbox.VLAN, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.VLAN)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamVlansCreateRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamVlansCreateRequest)(nil).Execute))
+}
+
+// WritableVLANRequest mocks base method.
+func (m *MockIpamVlansCreateRequest) WritableVLANRequest(writableVLANRequest netbox.WritableVLANRequest) interfaces.IpamVlansCreateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WritableVLANRequest", writableVLANRequest)
+	ret0, _ := ret[0].(interfaces.IpamVlansCreateRequest)
+	return ret0
+}
+
+// WritableVLANRequest indicates an expected call of WritableVLANRequest.
+func (mr *MockIpamVlansCreateRequestMockRecorder) WritableVLANRequest(writableVLANRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WritableVLANRequest", reflect.TypeOf((*MockIpamVlansCreateRequest)(nil).WritableVLANRequest), writableVLANRequest)
+}
+
+// MockIpamVlansUpdateRequest is a mock of IpamVlansUpdateRequest interface.
+type MockIpamVlansUpdateRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamVlansUpdateRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamVlansUpdateRequestMockRecorder is the mock recorder for MockIpamVlansUpdateRequest.
+type MockIpamVlansUpdateRequestMockRecorder struct {
+	mock *MockIpamVlansUpdateRequest
+}
+
+// NewMockIpamVlansUpdateRequest creates a new mock instance.
+func NewMockIpamVlansUpdateRequest(ctrl *gomock.Controller) *MockIpamVlansUpdateRequest {
+	mock := &MockIpamVlansUpdateRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamVlansUpdateRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamVlansUpdateRequest) EXPECT() *MockIpamVlansUpdateRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamVlansUpdateRequest) Execute() (*netbox.VLAN, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.VLAN)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamVlansUpdateRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamVlansUpdateRequest)(nil).Execute))
+}
+
+// WritableVLANRequest mocks base method.
+func (m *MockIpamVlansUpdateRequest) WritableVLANRequest(writableVLANRequest netbox.WritableVLANRequest) interfaces.IpamVlansUpdateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WritableVLANRequest", writableVLANRequest)
+	ret0, _ := ret[0].(interfaces.IpamVlansUpdateRequest)
+	return ret0
+}
+
+// WritableVLANRequest indicates an expected call of WritableVLANRequest.
+func (mr *MockIpamVlansUpdateRequestMockRecorder) WritableVLANRequest(writableVLANRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WritableVLANRequest", reflect.TypeOf((*MockIpamVlansUpdateRequest)(nil).WritableVLANRequest), writableVLANRequest)
+}
+
+// MockIpamVlansDestroyRequest is a mock of IpamVlansDestroyRequest interface.
+type MockIpamVlansDestroyRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamVlansDestroyRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamVlansDestroyRequestMockRecorder is the mock recorder for MockIpamVlansDestroyRequest.
+type MockIpamVlansDestroyRequestMockRecorder struct {
+	mock *MockIpamVlansDestroyRequest
+}
+
+// NewMockIpamVlansDestroyRequest creates a new mock instance.
+func NewMockIpamVlansDestroyRequest(ctrl *gomock.Controller) *MockIpamVlansDestroyRequest {
+	mock := &MockIpamVlansDestroyRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamVlansDestroyRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamVlansDestroyRequest) EXPECT() *MockIpamVlansDestroyRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamVlansDestroyRequest) Execute() (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamVlansDestroyRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamVlansDestroyRequest)(nil).Execute))
+}
+
+// MockIpamVlanGroupsListRequest is a mock of IpamVlanGroupsListRequest interface.
+type MockIpamVlanGroupsListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamVlanGroupsListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamVlanGroupsListRequestMockRecorder is the mock recorder for MockIpamVlanGroupsListRequest.
+type MockIpamVlanGroupsListRequestMockRecorder struct {
+	mock *MockIpamVlanGroupsListRequest
+}
+
+// NewMockIpamVlanGroupsListRequest creates a new mock instance.
+func NewMockIpamVlanGroupsListRequest(ctrl *gomock.Controller) *MockIpamVlanGroupsListRequest {
+	mock := &MockIpamVlanGroupsListRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamVlanGroupsListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamVlanGroupsListRequest) EXPECT() *MockIpamVlanGroupsListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamVlanGroupsListRequest) Execute() (*netbox.PaginatedVLANGroupList, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.PaginatedVLANGroupList)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamVlanGroupsListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamVlanGroupsListRequest)(nil).Execute))
+}
+
+// Limit mocks base method.
+func (m *MockIpamVlanGroupsListRequest) Limit(limit int32) interfaces.IpamVlanGroupsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limit", limit)
+	ret0, _ := ret[0].(interfaces.IpamVlanGroupsListRequest)
+	return ret0
+}
+
+// Limit indicates an expected call of Limit.
+func (mr *MockIpamVlanGroupsListRequestMockRecorder) Limit(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limit", reflect.TypeOf((*MockIpamVlanGroupsListRequest)(nil).Limit), limit)
+}
+
+// Name mocks base method.
+func (m *MockIpamVlanGroupsListRequest) Name(name []string) interfaces.IpamVlanGroupsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name", name)
+	ret0, _ := ret[0].(interfaces.IpamVlanGroupsListRequest)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockIpamVlanGroupsListRequestMockRecorder) Name(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockIpamVlanGroupsListRequest)(nil).Name), name)
+}
+
+// Offset mocks base method.
+func (m *MockIpamVlanGroupsListRequest) Offset(offset int32) interfaces.IpamVlanGroupsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offset", offset)
+	ret0, _ := ret[0].(interfaces.IpamVlanGroupsListRequest)
+	return ret0
+}
+
+// Offset indicates an expected call of Offset.
+func (mr *MockIpamVlanGroupsListRequestMockRecorder) Offset(offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offset", reflect.TypeOf((*MockIpamVlanGroupsListRequest)(nil).Offset), offset)
+}
+
+// Slug mocks base method.
+func (m *MockIpamVlanGroupsListRequest) Slug(slug []string) interfaces.IpamVlanGroupsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Slug", slug)
+	ret0, _ := ret[0].(interfaces.IpamVlanGroupsListRequest)
+	return ret0
+}
+
+// Slug indicates an expected call of Slug.
+func (mr *MockIpamVlanGroupsListRequestMockRecorder) Slug(slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Slug", reflect.TypeOf((*MockIpamVlanGroupsListRequest)(nil).Slug), slug)
+}
+
+// MockIpamVlanGroupsAvailableVlansListRequest is a mock of IpamVlanGroupsAvailableVlansListRequest interface.
+type MockIpamVlanGroupsAvailableVlansListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamVlanGroupsAvailableVlansListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamVlanGroupsAvailableVlansListRequestMockRecorder is the mock recorder for MockIpamVlanGroupsAvailableVlansListRequest.
+type MockIpamVlanGroupsAvailableVlansListRequestMockRecorder struct {
+	mock *MockIpamVlanGroupsAvailableVlansListRequest
+}
+
+// NewMockIpamVlanGroupsAvailableVlansListRequest creates a new mock instance.
+func NewMockIpamVlanGroupsAvailableVlansListRequest(ctrl *gomock.Controller) *MockIpamVlanGroupsAvailableVlansListRequest {
+	mock := &MockIpamVlanGroupsAvailableVlansListRequest{ctrl: ctrl}
+	mock.recorder = &MockIpamVlanGroupsAvailableVlansListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamVlanGroupsAvailableVlansListRequest) EXPECT() *MockIpamVlanGroupsAvailableVlansListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockIpamVlanGroupsAvailableVlansListRequest) Execute() ([]netbox.AvailableVLAN, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].([]netbox.AvailableVLAN)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockIpamVlanGroupsAvailableVlansListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockIpamVlanGroupsAvailableVlansListRequest)(nil).Execute))
+}
+
+// MockIpamAPI is a mock of IpamAPI interface.
+type MockIpamAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockIpamAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockIpamAPIMockRecorder is the mock recorder for MockIpamAPI.
+type MockIpamAPIMockRecorder struct {
+	mock *MockIpamAPI
+}
+
+// NewMockIpamAPI creates a new mock instance.
+func NewMockIpamAPI(ctrl *gomock.Controller) *MockIpamAPI {
+	mock := &MockIpamAPI{ctrl: ctrl}
+	mock.recorder = &MockIpamAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIpamAPI) EXPECT() *MockIpamAPIMockRecorder {
+	return m.recorder
+}
+
+// IpamIpAddressesCreate mocks base method.
+func (m *MockIpamAPI) IpamIpAddressesCreate(ctx context.Context) interfaces.IpamIpAddressesCreateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamIpAddressesCreate", ctx)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesCreateRequest)
+	return ret0
+}
+
+// IpamIpAddressesCreate indicates an expected call of IpamIpAddressesCreate.
+func (mr *MockIpamAPIMockRecorder) IpamIpAddressesCreate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpAddressesCreate", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpAddressesCreate), ctx)
+}
+
+// IpamIpAddressesDestroy mocks base method.
+func (m *MockIpamAPI) IpamIpAddressesDestroy(ctx context.Context, id int32) interfaces.IpamIpAddressesDestroyRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamIpAddressesDestroy", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesDestroyRequest)
+	return ret0
+}
+
+// IpamIpAddressesDestroy indicates an expected call of IpamIpAddressesDestroy.
+func (mr *MockIpamAPIMockRecorder) IpamIpAddressesDestroy(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpAddressesDestroy", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpAddressesDestroy), ctx, id)
+}
+
+// IpamIpAddressesList mocks base method.
+func (m *MockIpamAPI) IpamIpAddressesList(ctx context.Context) interfaces.IpamIpAddressesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamIpAddressesList", ctx)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesListRequest)
+	return ret0
+}
+
+// IpamIpAddressesList indicates an expected call of IpamIpAddressesList.
+func (mr *MockIpamAPIMockRecorder) IpamIpAddressesList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpAddressesList", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpAddressesList), ctx)
+}
+
+// IpamIpAddressesUpdate mocks base method.
+func (m *MockIpamAPI) IpamIpAddressesUpdate(ctx context.Context, id int32) interfaces.IpamIpAddressesUpdateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamIpAddressesUpdate", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamIpAddressesUpdateRequest)
+	return ret0
+}
+
+// IpamIpAddressesUpdate indicates an expected call of IpamIpAddressesUpdate.
+func (mr *MockIpamAPIMockRecorder) IpamIpAddressesUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpAddressesUpdate", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpAddressesUpdate), ctx, id)
+}
+
+// IpamIpRangesAvailableIpsList mocks base method.
+func (m *MockIpamAPI) IpamIpRangesAvailableIpsList(ctx context.Context, id int32) interfaces.IpamIpRangesAvailableIpsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamIpRangesAvailableIpsList", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamIpRangesAvailableIpsListRequest)
+	return ret0
+}
+
+// IpamIpRangesAvailableIpsList indicates an expected call of IpamIpRangesAvailableIpsList.
+func (mr *MockIpamAPIMockRecorder) IpamIpRangesAvailableIpsList(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpRangesAvailableIpsList", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpRangesAvailableIpsList), ctx, id)
+}
+
+// IpamIpRangesCreate mocks base method.
+func (m *MockIpamAPI) IpamIpRangesCreate(ctx context.Context) interfaces.IpamIpRangesCreateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamIpRangesCreate", ctx)
+	ret0, _ := ret[0].(interfaces.IpamIpRangesCreateRequest)
+	return ret0
+}
+
+// IpamIpRangesCreate indicates an expected call of IpamIpRangesCreate.
+func (mr *MockIpamAPIMockRecorder) IpamIpRangesCreate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpRangesCreate", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpRangesCreate), ctx)
+}
+
+// IpamIpRangesDestroy mocks base method.
+func (m *MockIpamAPI) IpamIpRangesDestroy(ctx context.Context, id int32) interfaces.IpamIpRangesDestroyRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamIpRangesDestroy", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamIpRangesDestroyRequest)
+	return ret0
+}
+
+// IpamIpRangesDestroy indicates an expected call of IpamIpRangesDestroy.
+func (mr *MockIpamAPIMockRecorder) IpamIpRangesDestroy(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpRangesDestroy", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpRangesDestroy), ctx, id)
+}
+
+// IpamIpRangesList mocks base method.
 func (m *MockIpamAPI) IpamIpRangesList(ctx context.Context) interfaces.IpamIpRangesListRequest {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IpamIpRangesList", ctx)
-	ret0, _ := ret[0].(interfaces.IpamIpRangesListRequest)
+	ret := m.ctrl.Call(m, "IpamIpRangesList", ctx)
+	ret0, _ := ret[0].(interfaces.IpamIpRangesListRequest)
+	return ret0
+}
+
+// IpamIpRangesList indicates an expected call of IpamIpRangesList.
+func (mr *MockIpamAPIMockRecorder) IpamIpRangesList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpRangesList", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpRangesList), ctx)
+}
+
+// IpamIpRangesUpdate mocks base method.
+func (m *MockIpamAPI) IpamIpRangesUpdate(ctx context.Context, id int32) interfaces.IpamIpRangesUpdateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamIpRangesUpdate", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamIpRangesUpdateRequest)
+	return ret0
+}
+
+// IpamIpRangesUpdate indicates an expected call of IpamIpRangesUpdate.
+func (mr *MockIpamAPIMockRecorder) IpamIpRangesUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpRangesUpdate", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpRangesUpdate), ctx, id)
+}
+
+// IpamPrefixesAvailableIpsList mocks base method.
+func (m *MockIpamAPI) IpamPrefixesAvailableIpsList(ctx context.Context, id int32) interfaces.IpamPrefixesAvailableIpsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamPrefixesAvailableIpsList", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesAvailableIpsListRequest)
+	return ret0
+}
+
+// IpamPrefixesAvailableIpsList indicates an expected call of IpamPrefixesAvailableIpsList.
+func (mr *MockIpamAPIMockRecorder) IpamPrefixesAvailableIpsList(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesAvailableIpsList", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesAvailableIpsList), ctx, id)
+}
+
+// IpamPrefixesAvailablePrefixesList mocks base method.
+func (m *MockIpamAPI) IpamPrefixesAvailablePrefixesList(ctx context.Context, id int32) interfaces.IpamPrefixesAvailablePrefixesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamPrefixesAvailablePrefixesList", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesAvailablePrefixesListRequest)
+	return ret0
+}
+
+// IpamPrefixesAvailablePrefixesList indicates an expected call of IpamPrefixesAvailablePrefixesList.
+func (mr *MockIpamAPIMockRecorder) IpamPrefixesAvailablePrefixesList(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesAvailablePrefixesList", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesAvailablePrefixesList), ctx, id)
+}
+
+// IpamPrefixesCreate mocks base method.
+func (m *MockIpamAPI) IpamPrefixesCreate(ctx context.Context) interfaces.IpamPrefixesCreateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamPrefixesCreate", ctx)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesCreateRequest)
+	return ret0
+}
+
+// IpamPrefixesCreate indicates an expected call of IpamPrefixesCreate.
+func (mr *MockIpamAPIMockRecorder) IpamPrefixesCreate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesCreate", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesCreate), ctx)
+}
+
+// IpamPrefixesDestroy mocks base method.
+func (m *MockIpamAPI) IpamPrefixesDestroy(ctx context.Context, id int32) interfaces.IpamPrefixesDestroyRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamPrefixesDestroy", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesDestroyRequest)
+	return ret0
+}
+
+// IpamPrefixesDestroy indicates an expected call of IpamPrefixesDestroy.
+func (mr *MockIpamAPIMockRecorder) IpamPrefixesDestroy(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesDestroy", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesDestroy), ctx, id)
+}
+
+// IpamPrefixesList mocks base method.
+func (m *MockIpamAPI) IpamPrefixesList(ctx context.Context) interfaces.IpamPrefixesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamPrefixesList", ctx)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesListRequest)
+	return ret0
+}
+
+// IpamPrefixesList indicates an expected call of IpamPrefixesList.
+func (mr *MockIpamAPIMockRecorder) IpamPrefixesList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesList", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesList), ctx)
+}
+
+// IpamPrefixesUpdate mocks base method.
+func (m *MockIpamAPI) IpamPrefixesUpdate(ctx context.Context, id int32) interfaces.IpamPrefixesUpdateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamPrefixesUpdate", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamPrefixesUpdateRequest)
+	return ret0
+}
+
+// IpamPrefixesUpdate indicates an expected call of IpamPrefixesUpdate.
+func (mr *MockIpamAPIMockRecorder) IpamPrefixesUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesUpdate", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesUpdate), ctx, id)
+}
+
+// IpamRolesList mocks base method.
+func (m *MockIpamAPI) IpamRolesList(ctx context.Context) interfaces.IpamRolesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamRolesList", ctx)
+	ret0, _ := ret[0].(interfaces.IpamRolesListRequest)
+	return ret0
+}
+
+// IpamRolesList indicates an expected call of IpamRolesList.
+func (mr *MockIpamAPIMockRecorder) IpamRolesList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamRolesList", reflect.TypeOf((*MockIpamAPI)(nil).IpamRolesList), ctx)
+}
+
+// IpamVlanGroupsAvailableVlansList mocks base method.
+func (m *MockIpamAPI) IpamVlanGroupsAvailableVlansList(ctx context.Context, id int32) interfaces.IpamVlanGroupsAvailableVlansListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamVlanGroupsAvailableVlansList", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamVlanGroupsAvailableVlansListRequest)
+	return ret0
+}
+
+// IpamVlanGroupsAvailableVlansList indicates an expected call of IpamVlanGroupsAvailableVlansList.
+func (mr *MockIpamAPIMockRecorder) IpamVlanGroupsAvailableVlansList(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamVlanGroupsAvailableVlansList", reflect.TypeOf((*MockIpamAPI)(nil).IpamVlanGroupsAvailableVlansList), ctx, id)
+}
+
+// IpamVlanGroupsList mocks base method.
+func (m *MockIpamAPI) IpamVlanGroupsList(ctx context.Context) interfaces.IpamVlanGroupsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamVlanGroupsList", ctx)
+	ret0, _ := ret[0].(interfaces.IpamVlanGroupsListRequest)
+	return ret0
+}
+
+// IpamVlanGroupsList indicates an expected call of IpamVlanGroupsList.
+func (mr *MockIpamAPIMockRecorder) IpamVlanGroupsList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamVlanGroupsList", reflect.TypeOf((*MockIpamAPI)(nil).IpamVlanGroupsList), ctx)
+}
+
+// IpamVlansCreate mocks base method.
+func (m *MockIpamAPI) IpamVlansCreate(ctx context.Context) interfaces.IpamVlansCreateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamVlansCreate", ctx)
+	ret0, _ := ret[0].(interfaces.IpamVlansCreateRequest)
+	return ret0
+}
+
+// IpamVlansCreate indicates an expected call of IpamVlansCreate.
+func (mr *MockIpamAPIMockRecorder) IpamVlansCreate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamVlansCreate", reflect.TypeOf((*MockIpamAPI)(nil).IpamVlansCreate), ctx)
+}
+
+// IpamVlansDestroy mocks base method.
+func (m *MockIpamAPI) IpamVlansDestroy(ctx context.Context, id int32) interfaces.IpamVlansDestroyRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamVlansDestroy", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamVlansDestroyRequest)
+	return ret0
+}
+
+// IpamVlansDestroy indicates an expected call of IpamVlansDestroy.
+func (mr *MockIpamAPIMockRecorder) IpamVlansDestroy(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamVlansDestroy", reflect.TypeOf((*MockIpamAPI)(nil).IpamVlansDestroy), ctx, id)
+}
+
+// IpamVlansList mocks base method.
+func (m *MockIpamAPI) IpamVlansList(ctx context.Context) interfaces.IpamVlansListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamVlansList", ctx)
+	ret0, _ := ret[0].(interfaces.IpamVlansListRequest)
+	return ret0
+}
+
+// IpamVlansList indicates an expected call of IpamVlansList.
+func (mr *MockIpamAPIMockRecorder) IpamVlansList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamVlansList", reflect.TypeOf((*MockIpamAPI)(nil).IpamVlansList), ctx)
+}
+
+// IpamVlansUpdate mocks base method.
+func (m *MockIpamAPI) IpamVlansUpdate(ctx context.Context, id int32) interfaces.IpamVlansUpdateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamVlansUpdate", ctx, id)
+	ret0, _ := ret[0].(interfaces.IpamVlansUpdateRequest)
+	return ret0
+}
+
+// IpamVlansUpdate indicates an expected call of IpamVlansUpdate.
+func (mr *MockIpamAPIMockRecorder) IpamVlansUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamVlansUpdate", reflect.TypeOf((*MockIpamAPI)(nil).IpamVlansUpdate), ctx, id)
+}
+
+// IpamVrfsList mocks base method.
+func (m *MockIpamAPI) IpamVrfsList(ctx context.Context) interfaces.IpamVrfsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IpamVrfsList", ctx)
+	ret0, _ := ret[0].(interfaces.IpamVrfsListRequest)
 	return ret0
 }
 
-// IpamIpRangesList indicates an expected call of IpamIpRangesList.
-func (mr *MockIpamAPIMockRecorder) IpamIpRangesList(ctx any) *gomock.Call {
+// IpamVrfsList indicates an expected call of IpamVrfsList.
+func (mr *MockIpamAPIMockRecorder) IpamVrfsList(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpRangesList", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpRangesList), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamVrfsList", reflect.TypeOf((*MockIpamAPI)(nil).IpamVrfsList), ctx)
 }
 
-// IpamIpRangesUpdate mocks base method.
-func (m *MockIpamAPI) IpamIpRangesUpdate(ctx context.Context, id int32) interfaces.IpamIpRangesUpdateRequest {
+// MockTenancyTenantsListRequest is a mock of TenancyTenantsListRequest interface.
+type MockTenancyTenantsListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenancyTenantsListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockTenancyTenantsListRequestMockRecorder is the mock recorder for MockTenancyTenantsListRequest.
+type MockTenancyTenantsListRequestMockRecorder struct {
+	mock *MockTenancyTenantsListRequest
+}
+
+// NewMockTenancyTenantsListRequest creates a new mock instance.
+func NewMockTenancyTenantsListRequest(ctrl *gomock.Controller) *MockTenancyTenantsListRequest {
+	mock := &MockTenancyTenantsListRequest{ctrl: ctrl}
+	mock.recorder = &MockTenancyTenantsListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenancyTenantsListRequest) EXPECT() *MockTenancyTenantsListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockTenancyTenantsListRequest) Execute() (*netbox.PaginatedTenantList, *http.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IpamIpRangesUpdate", ctx, id)
-	ret0, _ := ret[0].(interfaces.IpamIpRangesUpdateRequest)
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.PaginatedTenantList)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockTenancyTenantsListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockTenancyTenantsListRequest)(nil).Execute))
+}
+
+// Name mocks base method.
+func (m *MockTenancyTenantsListRequest) Name(name []string) interfaces.TenancyTenantsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name", name)
+	ret0, _ := ret[0].(interfaces.TenancyTenantsListRequest)
 	return ret0
 }
 
-// IpamIpRangesUpdate indicates an expected call of IpamIpRangesUpdate.
-func (mr *MockIpamAPIMockRecorder) IpamIpRangesUpdate(ctx, id any) *gomock.Call {
+// Name indicates an expected call of Name.
+func (mr *MockTenancyTenantsListRequestMockRecorder) Name(name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamIpRangesUpdate", reflect.TypeOf((*MockIpamAPI)(nil).IpamIpRangesUpdate), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockTenancyTenantsListRequest)(nil).Name), name)
 }
 
-// IpamPrefixesCreate mocks base method.
-func (m *MockIpamAPI) IpamPrefixesCreate(ctx context.Context) interfaces.IpamPrefixesCreateRequest {
+// MockTenancyAPI is a mock of TenancyAPI interface.
+type MockTenancyAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockTenancyAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockTenancyAPIMockRecorder is the mock recorder for MockTenancyAPI.
+type MockTenancyAPIMockRecorder struct {
+	mock *MockTenancyAPI
+}
+
+// NewMockTenancyAPI creates a new mock instance.
+func NewMockTenancyAPI(ctrl *gomock.Controller) *MockTenancyAPI {
+	mock := &MockTenancyAPI{ctrl: ctrl}
+	mock.recorder = &MockTenancyAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTenancyAPI) EXPECT() *MockTenancyAPIMockRecorder {
+	return m.recorder
+}
+
+// TenancyTenantsList mocks base method.
+func (m *MockTenancyAPI) TenancyTenantsList(ctx context.Context) interfaces.TenancyTenantsListRequest {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IpamPrefixesCreate", ctx)
-	ret0, _ := ret[0].(interfaces.IpamPrefixesCreateRequest)
+	ret := m.ctrl.Call(m, "TenancyTenantsList", ctx)
+	ret0, _ := ret[0].(interfaces.TenancyTenantsListRequest)
 	return ret0
 }
 
-// IpamPrefixesCreate indicates an expected call of IpamPrefixesCreate.
-func (mr *MockIpamAPIMockRecorder) IpamPrefixesCreate(ctx any) *gomock.Call {
+// TenancyTenantsList indicates an expected call of TenancyTenantsList.
+func (mr *MockTenancyAPIMockRecorder) TenancyTenantsList(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesCreate", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesCreate), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenancyTenantsList", reflect.TypeOf((*MockTenancyAPI)(nil).TenancyTenantsList), ctx)
 }
 
-// IpamPrefixesDestroy mocks base method.
-func (m *MockIpamAPI) IpamPrefixesDestroy(ctx context.Context, id int32) interfaces.IpamPrefixesDestroyRequest {
+// MockDcimSitesListRequest is a mock of DcimSitesListRequest interface.
+type MockDcimSitesListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockDcimSitesListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockDcimSitesListRequestMockRecorder is the mock recorder for MockDcimSitesListRequest.
+type MockDcimSitesListRequestMockRecorder struct {
+	mock *MockDcimSitesListRequest
+}
+
+// NewMockDcimSitesListRequest creates a new mock instance.
+func NewMockDcimSitesListRequest(ctrl *gomock.Controller) *MockDcimSitesListRequest {
+	mock := &MockDcimSitesListRequest{ctrl: ctrl}
+	mock.recorder = &MockDcimSitesListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDcimSitesListRequest) EXPECT() *MockDcimSitesListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockDcimSitesListRequest) Execute() (*netbox.PaginatedSiteList, *http.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IpamPrefixesDestroy", ctx, id)
-	ret0, _ := ret[0].(interfaces.IpamPrefixesDestroyRequest)
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.PaginatedSiteList)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockDcimSitesListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockDcimSitesListRequest)(nil).Execute))
+}
+
+// Name mocks base method.
+func (m *MockDcimSitesListRequest) Name(name []string) interfaces.DcimSitesListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name", name)
+	ret0, _ := ret[0].(interfaces.DcimSitesListRequest)
 	return ret0
 }
 
-// IpamPrefixesDestroy indicates an expected call of IpamPrefixesDestroy.
-func (mr *MockIpamAPIMockRecorder) IpamPrefixesDestroy(ctx, id any) *gomock.Call {
+// Name indicates an expected call of Name.
+func (mr *MockDcimSitesListRequestMockRecorder) Name(name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesDestroy", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesDestroy), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockDcimSitesListRequest)(nil).Name), name)
 }
 
-// IpamPrefixesList mocks base method.
-func (m *MockIpamAPI) IpamPrefixesList(ctx context.Context) interfaces.IpamPrefixesListRequest {
+// MockDcimAPI is a mock of DcimAPI interface.
+type MockDcimAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockDcimAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockDcimAPIMockRecorder is the mock recorder for MockDcimAPI.
+type MockDcimAPIMockRecorder struct {
+	mock *MockDcimAPI
+}
+
+// NewMockDcimAPI creates a new mock instance.
+func NewMockDcimAPI(ctrl *gomock.Controller) *MockDcimAPI {
+	mock := &MockDcimAPI{ctrl: ctrl}
+	mock.recorder = &MockDcimAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDcimAPI) EXPECT() *MockDcimAPIMockRecorder {
+	return m.recorder
+}
+
+// DcimSitesList mocks base method.
+func (m *MockDcimAPI) DcimSitesList(ctx context.Context) interfaces.DcimSitesListRequest {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IpamPrefixesList", ctx)
-	ret0, _ := ret[0].(interfaces.IpamPrefixesListRequest)
+	ret := m.ctrl.Call(m, "DcimSitesList", ctx)
+	ret0, _ := ret[0].(interfaces.DcimSitesListRequest)
 	return ret0
 }
 
-// IpamPrefixesList indicates an expected call of IpamPrefixesList.
-func (mr *MockIpamAPIMockRecorder) IpamPrefixesList(ctx any) *gomock.Call {
+// DcimSitesList indicates an expected call of DcimSitesList.
+func (mr *MockDcimAPIMockRecorder) DcimSitesList(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesList", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesList), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DcimSitesList", reflect.TypeOf((*MockDcimAPI)(nil).DcimSitesList), ctx)
 }
 
-// IpamPrefixesUpdate mocks base method.
-func (m *MockIpamAPI) IpamPrefixesUpdate(ctx context.Context, id int32) interfaces.IpamPrefixesUpdateRequest {
+// MockExtrasCustomFieldsListRequest is a mock of ExtrasCustomFieldsListRequest interface.
+type MockExtrasCustomFieldsListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockExtrasCustomFieldsListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockExtrasCustomFieldsListRequestMockRecorder is the mock recorder for MockExtrasCustomFieldsListRequest.
+type MockExtrasCustomFieldsListRequestMockRecorder struct {
+	mock *MockExtrasCustomFieldsListRequest
+}
+
+// NewMockExtrasCustomFieldsListRequest creates a new mock instance.
+func NewMockExtrasCustomFieldsListRequest(ctrl *gomock.Controller) *MockExtrasCustomFieldsListRequest {
+	mock := &MockExtrasCustomFieldsListRequest{ctrl: ctrl}
+	mock.recorder = &MockExtrasCustomFieldsListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExtrasCustomFieldsListRequest) EXPECT() *MockExtrasCustomFieldsListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockExtrasCustomFieldsListRequest) Execute() (*netbox.PaginatedCustomFieldList, *http.Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IpamPrefixesUpdate", ctx, id)
-	ret0, _ := ret[0].(interfaces.IpamPrefixesUpdateRequest)
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.PaginatedCustomFieldList)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockExtrasCustomFieldsListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockExtrasCustomFieldsListRequest)(nil).Execute))
+}
+
+// Name mocks base method.
+func (m *MockExtrasCustomFieldsListRequest) Name(name []string) interfaces.ExtrasCustomFieldsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name", name)
+	ret0, _ := ret[0].(interfaces.ExtrasCustomFieldsListRequest)
 	return ret0
 }
 
-// IpamPrefixesUpdate indicates an expected call of IpamPrefixesUpdate.
-func (mr *MockIpamAPIMockRecorder) IpamPrefixesUpdate(ctx, id any) *gomock.Call {
+// Name indicates an expected call of Name.
+func (mr *MockExtrasCustomFieldsListRequestMockRecorder) Name(name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IpamPrefixesUpdate", reflect.TypeOf((*MockIpamAPI)(nil).IpamPrefixesUpdate), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockExtrasCustomFieldsListRequest)(nil).Name), name)
+}
+
+// MockExtrasTagsListRequest is a mock of ExtrasTagsListRequest interface.
+type MockExtrasTagsListRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockExtrasTagsListRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockExtrasTagsListRequestMockRecorder is the mock recorder for MockExtrasTagsListRequest.
+type MockExtrasTagsListRequestMockRecorder struct {
+	mock *MockExtrasTagsListRequest
+}
+
+// NewMockExtrasTagsListRequest creates a new mock instance.
+func NewMockExtrasTagsListRequest(ctrl *gomock.Controller) *MockExtrasTagsListRequest {
+	mock := &MockExtrasTagsListRequest{ctrl: ctrl}
+	mock.recorder = &MockExtrasTagsListRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExtrasTagsListRequest) EXPECT() *MockExtrasTagsListRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockExtrasTagsListRequest) Execute() (*netbox.PaginatedTagList, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.PaginatedTagList)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockExtrasTagsListRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockExtrasTagsListRequest)(nil).Execute))
+}
+
+// Name mocks base method.
+func (m *MockExtrasTagsListRequest) Name(name []string) interfaces.ExtrasTagsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name", name)
+	ret0, _ := ret[0].(interfaces.ExtrasTagsListRequest)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockExtrasTagsListRequestMockRecorder) Name(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockExtrasTagsListRequest)(nil).Name), name)
+}
+
+// Slug mocks base method.
+func (m *MockExtrasTagsListRequest) Slug(slug []string) interfaces.ExtrasTagsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Slug", slug)
+	ret0, _ := ret[0].(interfaces.ExtrasTagsListRequest)
+	return ret0
+}
+
+// Slug indicates an expected call of Slug.
+func (mr *MockExtrasTagsListRequestMockRecorder) Slug(slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Slug", reflect.TypeOf((*MockExtrasTagsListRequest)(nil).Slug), slug)
+}
+
+// MockExtrasTagsCreateRequest is a mock of ExtrasTagsCreateRequest interface.
+type MockExtrasTagsCreateRequest struct {
+	ctrl     *gomock.Controller
+	recorder *MockExtrasTagsCreateRequestMockRecorder
+	isgomock struct{}
+}
+
+// MockExtrasTagsCreateRequestMockRecorder is the mock recorder for MockExtrasTagsCreateRequest.
+type MockExtrasTagsCreateRequestMockRecorder struct {
+	mock *MockExtrasTagsCreateRequest
+}
+
+// NewMockExtrasTagsCreateRequest creates a new mock instance.
+func NewMockExtrasTagsCreateRequest(ctrl *gomock.Controller) *MockExtrasTagsCreateRequest {
+	mock := &MockExtrasTagsCreateRequest{ctrl: ctrl}
+	mock.recorder = &MockExtrasTagsCreateRequestMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExtrasTagsCreateRequest) EXPECT() *MockExtrasTagsCreateRequestMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockExtrasTagsCreateRequest) Execute() (*netbox.Tag, *http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute")
+	ret0, _ := ret[0].(*netbox.Tag)
+	ret1, _ := ret[1].(*http.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockExtrasTagsCreateRequestMockRecorder) Execute() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockExtrasTagsCreateRequest)(nil).Execute))
+}
+
+// TagRequest mocks base method.
+func (m *MockExtrasTagsCreateRequest) TagRequest(tagRequest netbox.TagRequest) interfaces.ExtrasTagsCreateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TagRequest", tagRequest)
+	ret0, _ := ret[0].(interfaces.ExtrasTagsCreateRequest)
+	return ret0
+}
+
+// TagRequest indicates an expected call of TagRequest.
+func (mr *MockExtrasTagsCreateRequestMockRecorder) TagRequest(tagRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TagRequest", reflect.TypeOf((*MockExtrasTagsCreateRequest)(nil).TagRequest), tagRequest)
+}
+
+// MockExtrasAPI is a mock of ExtrasAPI interface.
+type MockExtrasAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockExtrasAPIMockRecorder
+	isgomock struct{}
+}
+
+// MockExtrasAPIMockRecorder is the mock recorder for MockExtrasAPI.
+type MockExtrasAPIMockRecorder struct {
+	mock *MockExtrasAPI
+}
+
+// NewMockExtrasAPI creates a new mock instance.
+func NewMockExtrasAPI(ctrl *gomock.Controller) *MockExtrasAPI {
+	mock := &MockExtrasAPI{ctrl: ctrl}
+	mock.recorder = &MockExtrasAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExtrasAPI) EXPECT() *MockExtrasAPIMockRecorder {
+	return m.recorder
+}
+
+// ExtrasCustomFieldsList mocks base method.
+func (m *MockExtrasAPI) ExtrasCustomFieldsList(ctx context.Context) interfaces.ExtrasCustomFieldsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtrasCustomFieldsList", ctx)
+	ret0, _ := ret[0].(interfaces.ExtrasCustomFieldsListRequest)
+	return ret0
+}
+
+// ExtrasCustomFieldsList indicates an expected call of ExtrasCustomFieldsList.
+func (mr *MockExtrasAPIMockRecorder) ExtrasCustomFieldsList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtrasCustomFieldsList", reflect.TypeOf((*MockExtrasAPI)(nil).ExtrasCustomFieldsList), ctx)
+}
+
+// ExtrasTagsCreate mocks base method.
+func (m *MockExtrasAPI) ExtrasTagsCreate(ctx context.Context) interfaces.ExtrasTagsCreateRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtrasTagsCreate", ctx)
+	ret0, _ := ret[0].(interfaces.ExtrasTagsCreateRequest)
+	return ret0
+}
+
+// ExtrasTagsCreate indicates an expected call of ExtrasTagsCreate.
+func (mr *MockExtrasAPIMockRecorder) ExtrasTagsCreate(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtrasTagsCreate", reflect.TypeOf((*MockExtrasAPI)(nil).ExtrasTagsCreate), ctx)
+}
+
+// ExtrasTagsList mocks base method.
+func (m *MockExtrasAPI) ExtrasTagsList(ctx context.Context) interfaces.ExtrasTagsListRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtrasTagsList", ctx)
+	ret0, _ := ret[0].(interfaces.ExtrasTagsListRequest)
+	return ret0
+}
+
+// ExtrasTagsList indicates an expected call of ExtrasTagsList.
+func (mr *MockExtrasAPIMockRecorder) ExtrasTagsList(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtrasTagsList", reflect.TypeOf((*MockExtrasAPI)(nil).ExtrasTagsList), ctx)
 }
 
 // MockAPIStatusRetrieveRequest is a mock of APIStatusRetrieveRequest interface.
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// AuthTokenSecretReconciler swaps the NetBox API token of the operator configuration
+// when the Secret holding it changes, so that a rotated token is used without a restart.
+type AuthTokenSecretReconciler struct {
+	// Reader reads the data of the Secret, the Secrets are only watched by their metadata
+	Reader client.Reader
+	Secret types.NamespacedName
+	Key    string
+	Tokens *api.TokenProvider
+}
+
+// Reconcile reads the token from the Secret and swaps it into the token provider.
+func (r *AuthTokenSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	token, err := ReadAuthTokenSecret(ctx, r.Reader, r.Secret, r.Key)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if r.Tokens.SetToken(token) {
+		logger.Info("swapped rotated netbox api token")
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AuthTokenSecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("authtokensecret").
+		For(&corev1.Secret{}, builder.OnlyMetadata, builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+			return o.GetNamespace() == r.Secret.Namespace && o.GetName() == r.Secret.Name
+		}))).
+		Complete(r)
+}
+
+// ReadAuthTokenSecret returns the NetBox API token in the key of the Secret, surrounding whitespace
+// is trimmed, e.g. the newline of a value created with `echo token | base64`
+func ReadAuthTokenSecret(ctx context.Context, reader client.Reader, secretName types.NamespacedName, key string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, secretName, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", secretName, err)
+	}
+
+	token := strings.TrimSpace(string(secret.Data[key]))
+	if token == "" {
+		return "", fmt.Errorf("secret %s has no value for key %s", secretName, key)
+	}
+	return token, nil
+}
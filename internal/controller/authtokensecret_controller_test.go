@@ -0,0 +1,64 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReadAuthTokenSecret(t *testing.T) {
+	secretName := types.NamespacedName{Name: "netbox-token", Namespace: "netbox-operator-system"}
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "token", value: "0123456789abcdef", expected: "0123456789abcdef"},
+		{name: "token with trailing newline", value: "0123456789abcdef\n", expected: "0123456789abcdef"},
+		{name: "empty token", value: "", wantErr: true},
+		{name: "whitespace only", value: " \n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: secretName.Name, Namespace: secretName.Namespace},
+				Data:       map[string][]byte{"token": []byte(tt.value)},
+			}).Build()
+
+			token, err := ReadAuthTokenSecret(context.TODO(), reader, secretName, "token")
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got token %#v", token)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if token != tt.expected {
+				t.Errorf("expected token %#v, got %#v", tt.expected, token)
+			}
+		})
+	}
+}
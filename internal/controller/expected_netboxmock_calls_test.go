@@ -17,101 +17,107 @@ limitations under the License.
 package controller
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/go-test/deep"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
 	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
 	"go.uber.org/mock/gomock"
 )
 
+// diffRequests compares the JSON bodies of two NetBox requests, as the nullable fields of
+// the v4 models can't be compared field by field
+func diffRequests(got interface{}, expected interface{}) []string {
+	toMap := func(request interface{}) map[string]interface{} {
+		body, err := json.Marshal(request)
+		if err != nil {
+			return map[string]interface{}{"error": err.Error()}
+		}
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return map[string]interface{}{"error": err.Error()}
+		}
+		return fields
+	}
+	return deep.Equal(toMap(got), toMap(expected))
+}
+
+func httpResponse(statusCode int) *http.Response {
+	return &http.Response{StatusCode: statusCode, Body: http.NoBody}
+}
+
 // -----------------------------
 // IPAM Mock Functions
 // -----------------------------
 
-func mockIpAddressListWithIpAddressFilter(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesList(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesListOK, error) {
-			got := params.(*ipam.IpamIPAddressesListParams)
-			diff := deep.Equal(got, ExpectedIpAddressListParamsWithIpAddressData)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesList, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesListOK{Payload: nil}, err
-			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesList was called with expected input\n")
-			return &ipam.IpamIPAddressesListOK{Payload: mockedResponseIPAddressListWithHash(customFieldsWithHash)}, nil
+// mockIpAddressListWithAddress mocks the list of the ip addresses filtered by their address
+func mockIpAddressListWithAddress(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error, response func() *v4client.PaginatedIPAddressList, description string) {
+	ipamMock.EXPECT().IpamIpAddressesList(gomock.Any()).
+		DoAndReturn(func(_ context.Context) interfaces.IpamIpAddressesListRequest {
+			request := mock_interfaces.NewMockIpamIpAddressesListRequest(mockCtrl)
+			request.EXPECT().Address(gomock.Any()).
+				DoAndReturn(func(address []string) interfaces.IpamIpAddressesListRequest {
+					diff := deep.Equal(address, ExpectedIpAddressListParamsWithIpAddressData)
+					if len(diff) > 0 {
+						catchUnexpectedParams <- fmt.Errorf("netboxmock: unexpected call to ipam.IpamIpAddressesList, diff to expected params diff: %+v", diff)
+					}
+					return request
+				})
+			request.EXPECT().Execute().
+				DoAndReturn(func() (*v4client.PaginatedIPAddressList, *http.Response, error) {
+					fmt.Printf("NETBOXMOCK\t ipam.IpamIpAddressesList%s was called with expected input\n", description)
+					return response(), httpResponse(http.StatusOK), nil
+				})
+			return request
 		}).MinTimes(1)
 }
 
-func mockIpAddressListWithIpAddressFilterEmptyResult(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesList(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesListOK, error) {
-			got := params.(*ipam.IpamIPAddressesListParams)
-			diff := deep.Equal(got, ExpectedIpAddressListParamsWithIpAddressData)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesList, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesListOK{Payload: nil}, err
-			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesList (empty result) was called with expected input,\n")
-			return &ipam.IpamIPAddressesListOK{Payload: mockedResponseEmptyIPAddressList()}, nil
+// mockIpAddressListWithHash mocks the paginated list of the ip addresses filtered by the restoration hash
+func mockIpAddressListWithHash(ipamMock *mock_interfaces.MockIpamAPI, response func() *v4client.PaginatedIPAddressList, description string) {
+	ipamMock.EXPECT().IpamIpAddressesList(gomock.Any()).
+		DoAndReturn(func(_ context.Context) interfaces.IpamIpAddressesListRequest {
+			request := mock_interfaces.NewMockIpamIpAddressesListRequest(mockCtrl)
+			request.EXPECT().Limit(gomock.Any()).Return(request)
+			request.EXPECT().Offset(gomock.Any()).Return(request)
+			request.EXPECT().Execute().
+				DoAndReturn(func() (*v4client.PaginatedIPAddressList, *http.Response, error) {
+					fmt.Printf("NETBOXMOCK\t ipam.IpamIpAddressesList%s was called with expected input\n", description)
+					return response(), httpResponse(http.StatusOK), nil
+				})
+			return request
 		}).MinTimes(1)
 }
 
-func mockIpAddressListWithHashFilterEmptyResult(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesList(gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesListOK, error) {
-			got := params.(*ipam.IpamIPAddressesListParams)
-			diff := deep.Equal(got, ExpectedIpAddressListParams)
-			// skip check for the 3rd input parameter as it is a method, method is a non comparable type
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesList, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesListOK{Payload: nil}, err
-			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesList (empty result) was called with expected input,\n")
-			return &ipam.IpamIPAddressesListOK{Payload: mockedResponseEmptyIPAddressList()}, nil
-		}).MinTimes(1)
+func mockIpAddressListWithIpAddressFilter(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpAddressListWithAddress(ipamMock, catchUnexpectedParams, func() *v4client.PaginatedIPAddressList {
+		return mockedResponseIPAddressListWithHash(customFieldsWithHash)
+	}, "")
 }
 
-func mockIpAddressListWithHashFilter(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesList(gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesListOK, error) {
-			got := params.(*ipam.IpamIPAddressesListParams)
-			diff := deep.Equal(got, ExpectedIpAddressListParams)
-			// skip check for the 3rd input parameter as it is a method, method is a non comparable type
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesList, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesListOK{Payload: nil}, err
-			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesList (empty result) was called with expected input,\n")
-			return &ipam.IpamIPAddressesListOK{Payload: mockedResponseIPAddressList()}, nil
-		}).MinTimes(1)
+func mockIpAddressListWithIpAddressFilterEmptyResult(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpAddressListWithAddress(ipamMock, catchUnexpectedParams, mockedResponseEmptyIPAddressList, " (empty result)")
 }
 
-func mockIpAddressListWithHashFilterMismatch(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesList(gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesListOK, error) {
-			got := params.(*ipam.IpamIPAddressesListParams)
-			diff := deep.Equal(got, ExpectedIpAddressListParamsWithIpAddressData)
-			// skip check for the 3rd input parameter as it is a method, method is a non comparable type
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesList, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesListOK{Payload: nil}, err
-			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesList (empty result) was called with expected input,\n")
-			return &ipam.IpamIPAddressesListOK{Payload: mockedResponseIPAddressListWithHash(customFieldsWithHashMismatch)}, nil
-		}).MinTimes(1)
+func mockIpAddressListWithHashFilterEmptyResult(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpAddressListWithHash(ipamMock, mockedResponseEmptyIPAddressList, " (empty result)")
 }
 
-func mockPrefixesList(ipamAPIMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
-	ipamAPIMock.EXPECT().IpamPrefixesList(gomock.Any()).
+func mockIpAddressListWithHashFilter(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpAddressListWithHash(ipamMock, mockedResponseIPAddressList, "")
+}
+
+func mockIpAddressListWithHashFilterMismatch(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpAddressListWithAddress(ipamMock, catchUnexpectedParams, func() *v4client.PaginatedIPAddressList {
+		return mockedResponseIPAddressListWithHash(customFieldsWithHashMismatch)
+	}, " (hash mismatch)")
+}
+
+func mockPrefixesList(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	ipamMock.EXPECT().IpamPrefixesList(gomock.Any()).
 		DoAndReturn(func(ctx interface{}) *mock_interfaces.MockIpamPrefixesListRequest {
 			fmt.Printf("NETBOXMOCK\t ipam.IpamPrefixesListRequest was called with expected input,\n")
 			return mockIpamPrefixesListRequest
@@ -137,132 +143,236 @@ func mockPrefixesListRequestExecute(mockprefixesListRequest *mock_interfaces.Moc
 	mockprefixesListRequest.EXPECT().Execute().
 		DoAndReturn(func() (*v4client.PaginatedPrefixList, *http.Response, error) {
 			fmt.Printf("NETBOXMOCK\t PrefixesListRequest.Execute was called with expected input,\n")
-			return mockedResponsePrefixList(), &http.Response{StatusCode: 200, Body: http.NoBody}, nil
+			return mockedResponsePrefixList(), httpResponse(http.StatusOK), nil
 		}).MinTimes(1)
 }
 
-func mockPrefixesAvailableIpsList(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
+func mockPrefixesAvailableIpsList(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
 	ipamMock.EXPECT().IpamPrefixesAvailableIpsList(gomock.Any(), gomock.Any()).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamPrefixesAvailableIpsListOK, error) {
-			got := params.(*ipam.IpamPrefixesAvailableIpsListParams)
-			diff := deep.Equal(got, ExpectedPrefixesAvailableIpsListParams)
-			// skip check for the 3rd input parameter as it is a method, method is a non comparable type
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamPrefixesAvailableIpsList, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamPrefixesAvailableIpsListOK{Payload: nil}, err
+		DoAndReturn(func(_ context.Context, id int32) interfaces.IpamPrefixesAvailableIpsListRequest {
+			request := mock_interfaces.NewMockIpamPrefixesAvailableIpsListRequest(mockCtrl)
+			if id != ExpectedPrefixesAvailableIpsListParams {
+				catchUnexpectedParams <- fmt.Errorf("netboxmock: unexpected call to ipam.IpamPrefixesAvailableIpsList, got prefix id %d, expected %d", id, ExpectedPrefixesAvailableIpsListParams)
+				request.EXPECT().Execute().Return(nil, nil, fmt.Errorf("unexpected prefix id %d", id))
+				return request
 			}
 			fmt.Printf("NETBOXMOCK\t ipam.IpamPrefixesAvailableIpsList was called with expected input,\n")
-			return &ipam.IpamPrefixesAvailableIpsListOK{Payload: mockedResponseExpectedAvailableIpAddress()}, nil
+			request.EXPECT().Execute().Return(mockedResponseExpectedAvailableIpAddress(), httpResponse(http.StatusOK), nil)
+			return request
 		}).MinTimes(1)
 }
 
-func mockIpAddressesDelete(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesDelete(gomock.Any(), nil).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesDeleteNoContent, error) {
-			got := params.(*ipam.IpamIPAddressesDeleteParams)
-			diff := deep.Equal(got, ExpectedDeleteParams)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesDelete, diff to expected params diff: %+v", diff)
+// mockIpAddressesDestroy mocks the deletion of the ip address with the expected id, NetBox responds with statusCode
+func mockIpAddressesDestroy(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error, expectedId int32, statusCode int) {
+	ipamMock.EXPECT().IpamIpAddressesDestroy(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id int32) interfaces.IpamIpAddressesDestroyRequest {
+			request := mock_interfaces.NewMockIpamIpAddressesDestroyRequest(mockCtrl)
+			if id != expectedId {
+				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIpAddressesDestroy, got id %d, expected %d", id, expectedId)
 				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesDeleteNoContent{}, err
+				request.EXPECT().Execute().Return(nil, err)
+				return request
 			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesDelete was called with mock input\n")
-			return &ipam.IpamIPAddressesDeleteNoContent{}, nil
+			fmt.Printf("NETBOXMOCK\t ipam.IpamIpAddressesDestroy was called with mock input\n")
+			request.EXPECT().Execute().Return(httpResponse(statusCode), nil)
+			return request
 		}).MinTimes(1)
 }
 
-func mockIpAddressesDeleteFail(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesDelete(gomock.Any(), nil).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesDeleteNoContent, error) {
-			got := params.(*ipam.IpamIPAddressesDeleteParams)
-			diff := deep.Equal(got, ExpectedDeleteFailParams)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesDelete, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesDeleteNoContent{}, err
-			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesDelete was called with mock input\n")
-			return nil, ipam.NewIpamIPAddressesDeleteDefault(404)
-		}).MinTimes(1)
+func mockIpAddressesDelete(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpAddressesDestroy(ipamMock, catchUnexpectedParams, ExpectedDeleteParams, http.StatusNoContent)
 }
 
-func mockIpamIPAddressesUpdateTwice(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
+func mockIpAddressesDeleteFail(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpAddressesDestroy(ipamMock, catchUnexpectedParams, ExpectedDeleteFailParams, http.StatusNotFound)
+}
+
+// mockIpamIPAddressesUpdateRequest mocks the update of the ip address, the request is compared to expected
+func mockIpamIPAddressesUpdateRequest(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error, expected *v4client.WritableIPAddressRequest, fail bool) *gomock.Call {
+	return ipamMock.EXPECT().IpamIpAddressesUpdate(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id int32) interfaces.IpamIpAddressesUpdateRequest {
+			request := mock_interfaces.NewMockIpamIpAddressesUpdateRequest(mockCtrl)
+			request.EXPECT().WritableIPAddressRequest(gomock.Any()).
+				DoAndReturn(func(got v4client.WritableIPAddressRequest) interfaces.IpamIpAddressesUpdateRequest {
+					diff := diffRequests(&got, expected)
+					if id != expectedIpAddressID {
+						diff = append(diff, fmt.Sprintf("id: %d != %d", id, expectedIpAddressID))
+					}
+					if len(diff) > 0 {
+						catchUnexpectedParams <- fmt.Errorf("netboxmock: unexpected call to ipam.IpamIpAddressesUpdate, diff to expected params diff: %+v", diff)
+					}
+					return request
+				})
+			request.EXPECT().Execute().
+				DoAndReturn(func() (*v4client.IPAddress, *http.Response, error) {
+					fmt.Printf("NETBOXMOCK\t ipam.IpamIpAddressesUpdate was called with expected input\n")
+					if fail {
+						return nil, httpResponse(http.StatusInternalServerError), fmt.Errorf("ipam.IpamIpAddressesUpdate: mock error in netbox")
+					}
+					return mockedResponseIPAddress(), httpResponse(http.StatusOK), nil
+				})
+			return request
+		})
+}
+
+func mockIpamIPAddressesUpdateTwice(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
 	mockIpamIPAddressesUpdateWithCount(ipamMock, catchUnexpectedParams, 2)
 }
 
-func mockIpamIPAddressesUpdateWithCount(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error, times int) {
-	ipamMock.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesUpdateOK, error) {
-			got := params.(*ipam.IpamIPAddressesUpdateParams)
-			diff := deep.Equal(got, ExpectedIpAddressUpdateParams)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesUpdate, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesUpdateOK{}, err
-			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesUpdate was called with expected input\n")
-			return &ipam.IpamIPAddressesUpdateOK{Payload: mockedResponseIPAddress()}, nil
-		}).Times(times)
+func mockIpamIPAddressesUpdateWithCount(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error, times int) {
+	mockIpamIPAddressesUpdateRequest(ipamMock, catchUnexpectedParams, ExpectedIpAddressUpdateParams, false).Times(times)
 }
 
-func mockIpamIPAddressesUpdateWithHash(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesUpdateOK, error) {
-			got := params.(*ipam.IpamIPAddressesUpdateParams)
-			diff := deep.Equal(got, ExpectedIpAddressUpdateWithHashParams)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesUpdate (with hash), diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesUpdateOK{}, err
-			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesUpdate (with hash) was called with mock input\n")
-			return &ipam.IpamIPAddressesUpdateOK{Payload: mockedResponseIPAddress()}, nil
+func mockIpamIPAddressesUpdateWithHash(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpamIPAddressesUpdateRequest(ipamMock, catchUnexpectedParams, ExpectedIpAddressUpdateWithHashParams, false).MinTimes(1)
+}
+
+func mockIpamIPAddressesUpdateFail(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpamIPAddressesUpdateRequest(ipamMock, catchUnexpectedParams, ExpectedIpAddressUpdateParams, true).MinTimes(1)
+}
+
+// mockIpamIPAddressesCreateRequest mocks the creation of the ip address, the request is compared to expected
+func mockIpamIPAddressesCreateRequest(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error, expected *v4client.WritableIPAddressRequest) {
+	ipamMock.EXPECT().IpamIpAddressesCreate(gomock.Any()).
+		DoAndReturn(func(_ context.Context) interfaces.IpamIpAddressesCreateRequest {
+			request := mock_interfaces.NewMockIpamIpAddressesCreateRequest(mockCtrl)
+			request.EXPECT().WritableIPAddressRequest(gomock.Any()).
+				DoAndReturn(func(got v4client.WritableIPAddressRequest) interfaces.IpamIpAddressesCreateRequest {
+					diff := diffRequests(&got, expected)
+					if len(diff) > 0 {
+						catchUnexpectedParams <- fmt.Errorf("netboxmock: unexpected call to ipam.IpamIpAddressesCreate, diff to expected params diff: %+v", diff)
+					}
+					return request
+				})
+			request.EXPECT().Execute().
+				DoAndReturn(func() (*v4client.IPAddress, *http.Response, error) {
+					fmt.Printf("NETBOXMOCK\t ipam.IpamIpAddressesCreate was called with expected input\n")
+					return mockedResponseIPAddress(), httpResponse(http.StatusCreated), nil
+				})
+			return request
 		}).MinTimes(1)
 }
 
-func mockIpamIPAddressesUpdateFail(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesUpdateOK, error) {
-			got := params.(*ipam.IpamIPAddressesUpdateParams)
-			diff := deep.Equal(got, ExpectedIpAddressUpdateParams)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesUpdate, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesUpdateOK{}, err
-			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesUpdate was called with expected input\n")
-			return &ipam.IpamIPAddressesUpdateOK{Payload: nil}, fmt.Errorf("ipam.IpamIpAddressesUpdate: mock error in netbox")
+func mockIpamIPAddressesCreate(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpamIPAddressesCreateRequest(ipamMock, catchUnexpectedParams, ExpectedIpAddressesCreateParams)
+}
+
+func mockIpamIPAddressesCreateWithHash(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockIpamIPAddressesCreateRequest(ipamMock, catchUnexpectedParams, ExpectedIpAddressesCreateWithHashParams)
+}
+
+// mockVlanGroupsList mocks the list of the vlan groups filtered by their name
+func mockVlanGroupsList(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	ipamMock.EXPECT().IpamVlanGroupsList(gomock.Any()).
+		DoAndReturn(func(_ context.Context) interfaces.IpamVlanGroupsListRequest {
+			request := mock_interfaces.NewMockIpamVlanGroupsListRequest(mockCtrl)
+			request.EXPECT().Name(gomock.Any()).
+				DoAndReturn(func(name []string) interfaces.IpamVlanGroupsListRequest {
+					diff := deep.Equal(name, []string{vlanGroupName})
+					if len(diff) > 0 {
+						catchUnexpectedParams <- fmt.Errorf("netboxmock: unexpected call to ipam.IpamVlanGroupsList, diff to expected params diff: %+v", diff)
+					}
+					return request
+				})
+			request.EXPECT().Limit(gomock.Any()).Return(request)
+			request.EXPECT().Offset(gomock.Any()).Return(request)
+			request.EXPECT().Execute().
+				DoAndReturn(func() (*v4client.PaginatedVLANGroupList, *http.Response, error) {
+					fmt.Printf("NETBOXMOCK\t ipam.IpamVlanGroupsList was called with expected input\n")
+					return mockedResponseVlanGroupList(), httpResponse(http.StatusOK), nil
+				})
+			return request
 		}).MinTimes(1)
 }
 
-func mockIpamIPAddressesCreate(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesCreate(gomock.Any(), nil).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesCreateCreated, error) {
-			got := params.(*ipam.IpamIPAddressesCreateParams)
-			diff := deep.Equal(got, ExpectedIpAddressesCreateParams)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesCreate, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesCreateCreated{}, err
+// mockVlanGroupsAvailableVlansList mocks the list of the available vlans of the vlan group
+func mockVlanGroupsAvailableVlansList(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	ipamMock.EXPECT().IpamVlanGroupsAvailableVlansList(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id int32) interfaces.IpamVlanGroupsAvailableVlansListRequest {
+			request := mock_interfaces.NewMockIpamVlanGroupsAvailableVlansListRequest(mockCtrl)
+			if id != vlanGroupId {
+				catchUnexpectedParams <- fmt.Errorf("netboxmock: unexpected call to ipam.IpamVlanGroupsAvailableVlansList, got vlan group id %d, expected %d", id, vlanGroupId)
+				request.EXPECT().Execute().Return(nil, nil, fmt.Errorf("unexpected vlan group id %d", id))
+				return request
 			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesCreate was called with expected input\n")
-			return &ipam.IpamIPAddressesCreateCreated{Payload: mockedResponseIPAddress()}, nil
+			fmt.Printf("NETBOXMOCK\t ipam.IpamVlanGroupsAvailableVlansList was called with expected input\n")
+			request.EXPECT().Execute().Return(mockedResponseAvailableVlans(), httpResponse(http.StatusOK), nil)
+			return request
 		}).MinTimes(1)
 }
 
-func mockIpamIPAddressesCreateWithHash(ipamMock *mock_interfaces.MockIpamInterface, catchUnexpectedParams chan error) {
-	ipamMock.EXPECT().IpamIPAddressesCreate(gomock.Any(), nil).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*ipam.IpamIPAddressesCreateCreated, error) {
-			got := params.(*ipam.IpamIPAddressesCreateParams)
-			diff := deep.Equal(got, ExpectedIpAddressesCreateWithHashParams)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamIPAddressesCreate (with hash), diff to expected params diff: %+v", diff)
+// mockVlansListRequest mocks the paginated list of the vlans, filtered by the vid or by the restoration hash
+func mockVlansListRequest(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error, response func() *v4client.PaginatedVLANList, description string) {
+	ipamMock.EXPECT().IpamVlansList(gomock.Any()).
+		DoAndReturn(func(_ context.Context) interfaces.IpamVlansListRequest {
+			request := mock_interfaces.NewMockIpamVlansListRequest(mockCtrl)
+			// the vlans restored by the restoration hash are not filtered by vid
+			request.EXPECT().Vid(gomock.Any()).
+				DoAndReturn(func(got []int32) interfaces.IpamVlansListRequest {
+					diff := deep.Equal(got, []int32{vid})
+					if len(diff) > 0 {
+						catchUnexpectedParams <- fmt.Errorf("netboxmock: unexpected call to ipam.IpamVlansList, diff to expected params diff: %+v", diff)
+					}
+					return request
+				}).AnyTimes()
+			request.EXPECT().GroupId(gomock.Any()).Return(request).AnyTimes()
+			request.EXPECT().Limit(gomock.Any()).Return(request)
+			request.EXPECT().Offset(gomock.Any()).Return(request)
+			request.EXPECT().Execute().
+				DoAndReturn(func() (*v4client.PaginatedVLANList, *http.Response, error) {
+					fmt.Printf("NETBOXMOCK\t ipam.IpamVlansList%s was called with expected input\n", description)
+					return response(), httpResponse(http.StatusOK), nil
+				})
+			return request
+		}).MinTimes(1)
+}
+
+func mockVlansListEmptyResult(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockVlansListRequest(ipamMock, catchUnexpectedParams, mockedResponseEmptyVlanList, " (empty result)")
+}
+
+func mockVlansListWithHashMismatch(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	mockVlansListRequest(ipamMock, catchUnexpectedParams, func() *v4client.PaginatedVLANList {
+		return mockedResponseVlanList(customFieldsWithHashMismatch)
+	}, " (hash mismatch)")
+}
+
+// mockVlansCreate mocks the creation of the vlan, the request is compared to expected
+func mockVlansCreate(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error, expected *v4client.WritableVLANRequest) {
+	ipamMock.EXPECT().IpamVlansCreate(gomock.Any()).
+		DoAndReturn(func(_ context.Context) interfaces.IpamVlansCreateRequest {
+			request := mock_interfaces.NewMockIpamVlansCreateRequest(mockCtrl)
+			request.EXPECT().WritableVLANRequest(gomock.Any()).
+				DoAndReturn(func(got v4client.WritableVLANRequest) interfaces.IpamVlansCreateRequest {
+					diff := diffRequests(&got, expected)
+					if len(diff) > 0 {
+						catchUnexpectedParams <- fmt.Errorf("netboxmock: unexpected call to ipam.IpamVlansCreate, diff to expected params diff: %+v", diff)
+					}
+					return request
+				})
+			request.EXPECT().Execute().
+				DoAndReturn(func() (*v4client.VLAN, *http.Response, error) {
+					fmt.Printf("NETBOXMOCK\t ipam.IpamVlansCreate was called with expected input\n")
+					return mockedResponseVlan(), httpResponse(http.StatusCreated), nil
+				})
+			return request
+		}).MinTimes(1)
+}
+
+// mockVlansDestroy mocks the deletion of the vlan with the expected id
+func mockVlansDestroy(ipamMock *mock_interfaces.MockIpamAPI, catchUnexpectedParams chan error) {
+	ipamMock.EXPECT().IpamVlansDestroy(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id int32) interfaces.IpamVlansDestroyRequest {
+			request := mock_interfaces.NewMockIpamVlansDestroyRequest(mockCtrl)
+			if id != vlanId {
+				err := fmt.Errorf("netboxmock: unexpected call to ipam.IpamVlansDestroy, got id %d, expected %d", id, vlanId)
 				catchUnexpectedParams <- err
-				return &ipam.IpamIPAddressesCreateCreated{}, err
+				request.EXPECT().Execute().Return(nil, err)
+				return request
 			}
-			fmt.Printf("NETBOXMOCK\t ipam.IpamIPAddressesCreate (with hash) was called with expected input\n")
-			return &ipam.IpamIPAddressesCreateCreated{Payload: mockedResponseIPAddress()}, nil
+			fmt.Printf("NETBOXMOCK\t ipam.IpamVlansDestroy was called with expected input\n")
+			request.EXPECT().Execute().Return(httpResponse(http.StatusNoContent), nil)
+			return request
 		}).MinTimes(1)
 }
 
@@ -270,39 +380,66 @@ func mockIpamIPAddressesCreateWithHash(ipamMock *mock_interfaces.MockIpamInterfa
 // Tenancy Mock Functions
 // -----------------------------
 
-func mockTenancyTenancyTenantsList(tenancyMock *mock_interfaces.MockTenancyInterface, catchUnexpectedParams chan error) {
-	tenancyMock.EXPECT().TenancyTenantsList(gomock.Any(), nil).
-		DoAndReturn(func(params interface{}, authInfo interface{}, opts ...interface{}) (*tenancy.TenancyTenantsListOK, error) {
-			got := params.(*tenancy.TenancyTenantsListParams)
-			diff := deep.Equal(got, ExpectedTenantsListParams)
-			if len(diff) > 0 {
-				err := fmt.Errorf("netboxmock: unexpected call to tenancy.TenancyTenantsList, diff to expected params diff: %+v", diff)
-				catchUnexpectedParams <- err
-				return &tenancy.TenancyTenantsListOK{}, err
-			}
-			fmt.Printf("NETBOXMOCK\t tenancy.TenancyTenantsList was called with expected input\n")
-			return &tenancy.TenancyTenantsListOK{Payload: mockedResponseTenancyTenantsList()}, nil
+func mockTenancyTenancyTenantsList(tenancyMock *mock_interfaces.MockTenancyAPI, catchUnexpectedParams chan error) {
+	tenancyMock.EXPECT().TenancyTenantsList(gomock.Any()).
+		DoAndReturn(func(_ context.Context) interfaces.TenancyTenantsListRequest {
+			request := mock_interfaces.NewMockTenancyTenantsListRequest(mockCtrl)
+			request.EXPECT().Name(gomock.Any()).
+				DoAndReturn(func(name []string) interfaces.TenancyTenantsListRequest {
+					diff := deep.Equal(name, ExpectedTenantsListParams)
+					if len(diff) > 0 {
+						catchUnexpectedParams <- fmt.Errorf("netboxmock: unexpected call to tenancy.TenancyTenantsList, diff to expected params diff: %+v", diff)
+					}
+					return request
+				})
+			request.EXPECT().Execute().
+				DoAndReturn(func() (*v4client.PaginatedTenantList, *http.Response, error) {
+					fmt.Printf("NETBOXMOCK\t tenancy.TenancyTenantsList was called with expected input\n")
+					return mockedResponseTenancyTenantsList(), httpResponse(http.StatusOK), nil
+				})
+			return request
 		}).MinTimes(1)
 }
 
+// -----------------------------
+// Extras Mock Functions
+// -----------------------------
+
+// mockExtrasCustomFieldsList returns the custom fields of the test data as text custom fields
+func mockExtrasCustomFieldsList(extrasMock *mock_interfaces.MockExtrasAPI) {
+	extrasMock.EXPECT().ExtrasCustomFieldsList(gomock.Any()).
+		DoAndReturn(func(_ context.Context) interfaces.ExtrasCustomFieldsListRequest {
+			list := &v4client.PaginatedCustomFieldList{}
+			request := mock_interfaces.NewMockExtrasCustomFieldsListRequest(mockCtrl)
+			request.EXPECT().Name(gomock.Any()).
+				DoAndReturn(func(name []string) interfaces.ExtrasCustomFieldsListRequest {
+					if _, ok := customFieldsCR[name[0]]; ok {
+						fieldType := v4client.CUSTOMFIELDTYPEVALUE_TEXT
+						list.Count = 1
+						list.Results = []v4client.CustomField{{Name: name[0], Type: v4client.CustomFieldType{Value: &fieldType}}}
+					}
+					return request
+				})
+			request.EXPECT().Execute().
+				DoAndReturn(func() (*v4client.PaginatedCustomFieldList, *http.Response, error) {
+					return list, httpResponse(http.StatusOK), nil
+				})
+			return request
+		}).AnyTimes()
+}
+
 // -----------------------------
 // Reset Mock Functions
 // -----------------------------
 
-func resetMockFunctions(ipamMockA *mock_interfaces.MockIpamInterface, ipamMockB *mock_interfaces.MockIpamInterface, tenancyMock *mock_interfaces.MockTenancyInterface) {
-	ipamMockA.EXPECT().IpamIPAddressesList(gomock.Any(), gomock.Any()).Times(0)
-	ipamMockA.EXPECT().IpamIPAddressesUpdate(gomock.Any(), gomock.Any(), nil).Times(0)
-	ipamMockA.EXPECT().IpamPrefixesList(gomock.Any(), gomock.Any()).Times(0)
-	ipamMockA.EXPECT().IpamPrefixesAvailableIpsList(gomock.Any(), gomock.Any()).Times(0)
-	ipamMockA.EXPECT().IpamIPAddressesDelete(gomock.Any(), nil).Times(0)
-	ipamMockA.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Times(0)
-	ipamMockA.EXPECT().IpamIPAddressesCreate(gomock.Any(), nil).Times(0)
-	ipamMockB.EXPECT().IpamIPAddressesList(gomock.Any(), gomock.Any()).Times(0)
-	ipamMockB.EXPECT().IpamIPAddressesUpdate(gomock.Any(), gomock.Any(), nil).Times(0)
-	ipamMockB.EXPECT().IpamPrefixesList(gomock.Any(), gomock.Any()).Times(0)
-	ipamMockB.EXPECT().IpamPrefixesAvailableIpsList(gomock.Any(), gomock.Any()).Times(0)
-	ipamMockB.EXPECT().IpamIPAddressesDelete(gomock.Any(), nil).Times(0)
-	ipamMockB.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Times(0)
-	ipamMockB.EXPECT().IpamIPAddressesCreate(gomock.Any(), nil).Times(0)
-	tenancyMock.EXPECT().TenancyTenantsList(gomock.Any(), nil).Times(0)
+func resetMockFunctions(ipamMockA *mock_interfaces.MockIpamAPI, ipamMockB *mock_interfaces.MockIpamAPI, tenancyMock *mock_interfaces.MockTenancyAPI) {
+	for _, ipamMock := range []*mock_interfaces.MockIpamAPI{ipamMockA, ipamMockB} {
+		ipamMock.EXPECT().IpamIpAddressesList(gomock.Any()).Times(0)
+		ipamMock.EXPECT().IpamIpAddressesCreate(gomock.Any()).Times(0)
+		ipamMock.EXPECT().IpamIpAddressesUpdate(gomock.Any(), gomock.Any()).Times(0)
+		ipamMock.EXPECT().IpamIpAddressesDestroy(gomock.Any(), gomock.Any()).Times(0)
+		ipamMock.EXPECT().IpamPrefixesList(gomock.Any()).Times(0)
+		ipamMock.EXPECT().IpamPrefixesAvailableIpsList(gomock.Any(), gomock.Any()).Times(0)
+	}
+	tenancyMock.EXPECT().TenancyTenantsList(gomock.Any()).Times(0)
 }
@@ -27,7 +27,7 @@ import (
 	"time"
 
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
-	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/netbox-community/netbox-operator/pkg/scheduler"
@@ -48,12 +48,14 @@ import (
 
 const IpAddressFinalizerName = "ipaddress.netbox.dev/finalizer"
 const IPManagedCustomFieldsAnnotationName = "ipaddress.netbox.dev/managed-custom-fields"
+const IPManagedTagsAnnotationName = "ipaddress.netbox.dev/managed-tags"
+const IPManagedDnsNameAnnotationName = "ipaddress.netbox.dev/managed-dns-name"
 
 // IpAddressReconciler reconciles a IpAddress object
 type IpAddressReconciler struct {
 	client.Client
 	Scheme              *runtime.Scheme
-	NetboxClient        *api.NetboxCompositeClient
+	NetboxClients       *api.ClientRegistry
 	EventStatusRecorder *EventStatusRecorder
 	OperatorNamespace   string
 	RestConfig          *rest.Config
@@ -90,6 +92,18 @@ func (r *IpAddressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		logger.Info("reconcile loop finished")
 	}()
 
+	// resolve the client of the NetBox instance the resource is managed in
+	netboxClient, err := r.NetboxClients.ClientFor(ctx, o.Spec.Connection)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer r.EventStatusRecorder.ReportTokenRejected(o, netboxClient)
+
+	// short-circuit while the circuit breaker of NetBox is open instead of piling up failing requests
+	if err := netboxClient.Available(); err != nil {
+		return ctrl.Result{RequeueAfter: netboxClient.RetryAfter()}, NewDomainError("%w", err)
+	}
+
 	// cancelLock stops the lease renewal goroutine on early returns (lease expires naturally).
 	// Explicit cancelLock()+UnlockWithRetry() runs inline after the critical section.
 	var cancelLock context.CancelFunc
@@ -101,7 +115,7 @@ func (r *IpAddressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 
 		if !o.Spec.PreserveInNetbox && o.Status.IpAddressId != 0 {
-			if err = r.NetboxClient.DeleteIpAddress(o.Status.IpAddressId); err != nil {
+			if err = netboxClient.DeleteIpAddress(ctx, int32(o.Status.IpAddressId)); err != nil {
 				return ctrl.Result{}, NewDomainError("failed to delete ip address from netbox: %w", err)
 			}
 		}
@@ -132,6 +146,7 @@ func (r *IpAddressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// and IpAddress is owned by an IpAddressClaim
 	or := o.OwnerReferences
 	var ll *leaselocker.LeaseLocker
+	var releaseLeaseLock bool
 	if len(or) > 0 /* len(nil array) = 0 */ && !apismeta.IsStatusConditionTrue(o.Status.Conditions, "Ready") {
 		// get ip address claim
 		orLookupKey := types.NamespacedName{
@@ -144,32 +159,45 @@ func (r *IpAddressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 			return ctrl.Result{}, err
 		}
 
-		// get name of parent prefix
-		leaseLockerNSN := types.NamespacedName{
-			Name:      convertCIDRToLeaseLockName(ipAddressClaim.Spec.ParentPrefix),
-			Namespace: r.OperatorNamespace,
-		}
-		ll, err = leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.String())
-		if err != nil {
-			return ctrl.Result{}, err
+		parentPrefix := selectedParentOfIpAddress(ipAddressClaim, o.Name)
+		leaseLockOwner := leaseLockOwnerOfIpAddress(ipAddressClaim, o.Name)
+		releaseLeaseLock = !sharesLeaseLock(ipAddressClaim.Spec.Count)
+		if parentPrefix == "" {
+			// the parent prefix is not selected
+			return ctrl.Result{}, NewDomainError("the parent prefix is not selected")
 		}
 
-		var lockCtx context.Context
-		lockCtx, cancelLock = context.WithTimeout(ctx, lockAcquireTimeout)
-		defer func() {
-			if cancelLock != nil {
-				cancelLock() // ensure renewal goroutine stops on any return path
+		if parentPrefix != msgCanNotInferIpAddressParentPrefix {
+			// we can't restore from the restoration hash
+
+			// get name of parent prefix
+			leaseLockerNSN := types.NamespacedName{
+				Name:      convertCIDRToLeaseLockName(leaseLockParentOfIpAddress(ipAddressClaim, o.Name)),
+				Namespace: r.OperatorNamespace,
+			}
+			ll, err = leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.Namespace+"/"+leaseLockOwner)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			var lockCtx context.Context
+			lockCtx, cancelLock = context.WithTimeout(ctx, lockAcquireTimeout)
+			defer func() {
+				if cancelLock != nil {
+					cancelLock() // ensure renewal goroutine stops on any return path
+				}
+			}()
+			locked := ll.TryLock(lockCtx)
+			if !locked {
+				metrics.LeaseLockContentionTotal.WithLabelValues(ipAddressKind, parentPrefix).Inc()
+				errorMsg := fmt.Sprintf("failed to lock parent prefix %s", parentPrefix)
+				r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "FailedToLockParentPrefix", errorMsg)
+				return ctrl.Result{
+					RequeueAfter: 2 * time.Second,
+				}, NewDomainError("%s", errorMsg)
 			}
-		}()
-		locked := ll.TryLock(lockCtx)
-		if !locked {
-			errorMsg := fmt.Sprintf("failed to lock parent prefix %s", ipAddressClaim.Spec.ParentPrefix)
-			r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "FailedToLockParentPrefix", errorMsg)
-			return ctrl.Result{
-				RequeueAfter: 2 * time.Second,
-			}, NewDomainError("%s", errorMsg)
+			logger.V(4).Info("successfully locked parent prefix", "prefix", parentPrefix)
 		}
-		logger.V(4).Info("successfully locked parent prefix", "prefix", ipAddressClaim.Spec.ParentPrefix)
 	}
 
 	// 2. reserve or update ip address in netbox
@@ -179,12 +207,12 @@ func (r *IpAddressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, err
 	}
 
-	ipAddressModel, err := generateNetboxIpAddressModelFromIpAddressSpec(&o.Spec, req, annotations[IPManagedCustomFieldsAnnotationName])
+	ipAddressModel, err := generateNetboxIpAddressModelFromIpAddressSpec(&o.Spec, req, annotations[IPManagedCustomFieldsAnnotationName], annotations[IPManagedTagsAnnotationName], annotations[IPManagedDnsNameAnnotationName])
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	netboxIpAddressModel, statusUpToDate, err := r.NetboxClient.ReserveOrUpdateIpAddress(ctx, ipAddressModel, o)
+	netboxIpAddressModel, statusUpToDate, err := netboxClient.ReserveOrUpdateIpAddress(ctx, ipAddressModel, o)
 	if err != nil {
 		if errors.Is(err, api.ErrRestorationHashMismatch) && o.Status.IpAddressId == 0 {
 			// if there is a restoration hash mismatch and the IpAddressId status field is not set,
@@ -203,7 +231,11 @@ func (r *IpAddressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// 3. unlock lease of parent prefix — allocation is done, lock no longer needed
 	if ll != nil {
 		cancelLock()
-		ll.UnlockWithRetry(ctx)
+		// a lease shared with the siblings of a claim with a count greater than 1 expires instead,
+		// so that no other claim allocates from the parent prefix before all siblings are reserved
+		if releaseLeaseLock {
+			ll.UnlockWithRetry(ctx)
+		}
 	}
 
 	// 4. if no change in spec generation and NetBox object, skip K8s status update
@@ -221,6 +253,18 @@ func (r *IpAddressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, NewDomainError("failed to generate managed custom fields annotation: %w", err)
 	}
 
+	annotations[IPManagedTagsAnnotationName], err = generateManagedTagsAnnotation(o.Spec.Tags)
+	if err != nil {
+		return ctrl.Result{}, NewDomainError("failed to generate managed tags annotation: %w", err)
+	}
+
+	// the dns name set by the operator is recorded, so that it is cleared in NetBox when it is removed from the spec
+	if o.Spec.DnsName != "" {
+		annotations[IPManagedDnsNameAnnotationName] = o.Spec.DnsName
+	} else {
+		delete(annotations, IPManagedDnsNameAnnotationName)
+	}
+
 	// snapshot before annotation mutation for merge-patch
 	patch := client.MergeFrom(o.DeepCopy())
 
@@ -233,14 +277,14 @@ func (r *IpAddressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	// 4. update status fields (set after r.Patch to avoid being overwritten by API response)
-	o.Status.IpAddressId = netboxIpAddressModel.ID
-	o.Status.IpAddressUrl = config.GetBaseUrl() + "/ipam/ip-addresses/" + strconv.FormatInt(netboxIpAddressModel.ID, 10)
-	if netboxIpAddressModel.LastUpdated != nil {
-		o.Status.LastUpdated = metav1.NewTime(time.Time(*netboxIpAddressModel.LastUpdated))
+	o.Status.IpAddressId = int64(netboxIpAddressModel.GetId())
+	o.Status.IpAddressUrl = netboxClient.BaseUrl() + "/ipam/ip-addresses/" + strconv.FormatInt(int64(netboxIpAddressModel.GetId()), 10)
+	if lastUpdated := netboxIpAddressModel.LastUpdated.Get(); lastUpdated != nil {
+		o.Status.LastUpdated = metav1.NewTime(*lastUpdated)
 	}
 
 	// check if created ip address contains entire description from spec
-	_, found := strings.CutPrefix(netboxIpAddressModel.Description, req.String()+" // "+o.Spec.Description)
+	_, found := strings.CutPrefix(netboxIpAddressModel.GetDescription(), req.String()+" // "+o.Spec.Description)
 	if !found {
 		r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "IpDescriptionTruncated", "ip address was created with truncated description")
 	}
@@ -273,6 +317,12 @@ func (r *IpAddressReconciler) updateStatus(ctx context.Context, o *netboxv1.IpAd
 	logger.V(4).Info("updating ipaddress status")
 
 	switch {
+	case errors.Is(reconcileErr, api.ErrNetboxUnavailable):
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionReadyFalseNetBoxUnavailable, corev1.EventTypeWarning, reconcileErr)
+	case o.DeletionTimestamp.IsZero() && errors.Is(reconcileErr, api.ErrInvalidCustomFieldValue):
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionReadyFalseInvalidCustomField, corev1.EventTypeWarning, reconcileErr)
 	case !o.DeletionTimestamp.IsZero() && reconcileErr != nil:
 		r.EventStatusRecorder.Report(ctx, o,
 			netboxv1.ConditionIpaddressReadyFalseDeletionFailed, corev1.EventTypeWarning, reconcileErr)
@@ -304,7 +354,12 @@ func (r *IpAddressReconciler) updateStatus(ctx context.Context, o *netboxv1.IpAd
 	return IgnoreDomainError(result, err)
 }
 
-func generateNetboxIpAddressModelFromIpAddressSpec(spec *netboxv1.IpAddressSpec, req ctrl.Request, lastIpAddressMetadata string) (*models.IPAddress, error) {
+func generateNetboxIpAddressModelFromIpAddressSpec(spec *netboxv1.IpAddressSpec, req ctrl.Request, lastIpAddressMetadata string, lastManagedTags string, lastManagedDnsName string) (*models.IPAddress, error) {
+	managedTags, err := parseManagedTagsAnnotation(lastManagedTags)
+	if err != nil {
+		return nil, err
+	}
+
 	// unmarshal lastIpAddressMetadata json string to map[string]string
 	lastAppliedCustomFields := make(map[string]string)
 	if lastIpAddressMetadata != "" {
@@ -327,12 +382,19 @@ func generateNetboxIpAddressModelFromIpAddressSpec(spec *netboxv1.IpAddressSpec,
 	}
 
 	return &models.IPAddress{
-		IpAddress: spec.IpAddress,
+		IpAddress:    spec.IpAddress,
+		DnsName:      spec.DnsName,
+		ClearDnsName: spec.DnsName == "" && lastManagedDnsName != "",
 		Metadata: &models.NetboxMetadata{
 			Comments:    spec.Comments,
 			Custom:      netboxCustomFields,
 			Description: req.String() + " // " + spec.Description,
 			Tenant:      spec.Tenant,
+			Vrf:         spec.Vrf,
+			Status:      spec.Status,
+			Role:        spec.Role,
+			Tags:        spec.Tags,
+			ManagedTags: managedTags,
 		},
 	}, nil
 }
@@ -90,8 +90,8 @@ var _ = Describe("IpAddress Controller", Ordered, func() {
 
 	DescribeTable("Reconciler (ip address CR without owner reference)", func(
 		cr *netboxv1.IpAddress, // our CR as typed object
-		IpamMocksIpAddress []func(*mock_interfaces.MockIpamInterface, chan error),
-		TenancyMocks []func(*mock_interfaces.MockTenancyInterface, chan error),
+		IpamMocksIpAddress []func(*mock_interfaces.MockIpamAPI, chan error),
+		TenancyMocks []func(*mock_interfaces.MockTenancyAPI, chan error),
 		restorationHashMismatch bool, // To check for deletion if restoration hash does not match
 		expectedConditionReady metav1.Condition, // Expected state of the ConditionReady condition
 		expectedCRStatus netboxv1.IpAddressStatus, // Expected status of the CR
@@ -164,31 +164,31 @@ var _ = Describe("IpAddress Controller", Ordered, func() {
 	},
 		Entry("Create IpAddress CR, reserve new ip address in NetBox",
 			defaultIpAddressCR(false),
-			[]func(*mock_interfaces.MockIpamInterface, chan error){
+			[]func(*mock_interfaces.MockIpamAPI, chan error){
 				mockIpAddressListWithIpAddressFilterEmptyResult,
 				mockIpamIPAddressesCreate,
 				mockIpAddressesDelete,
 			},
-			[]func(*mock_interfaces.MockTenancyInterface, chan error){
+			[]func(*mock_interfaces.MockTenancyAPI, chan error){
 				mockTenancyTenancyTenantsList,
 			},
 			false, netboxv1.ConditionIpaddressReadyTrue, ExpectedIpAddressStatus),
 		Entry("Create IpAddress CR, ip address already reserved in NetBox, preserved in netbox",
 			defaultIpAddressCR(true),
-			[]func(*mock_interfaces.MockIpamInterface, chan error){
+			[]func(*mock_interfaces.MockIpamAPI, chan error){
 				mockIpAddressListWithIpAddressFilter,
 				// allow the update mock to be called twice
 				// there are race conditions where the cr is reconciled again
 				// before the ready condition is set to true
 				mockIpamIPAddressesUpdateTwice,
 			},
-			[]func(*mock_interfaces.MockTenancyInterface, chan error){
+			[]func(*mock_interfaces.MockTenancyAPI, chan error){
 				mockTenancyTenancyTenantsList,
 			},
 			false, netboxv1.ConditionIpaddressReadyTrue, ExpectedIpAddressStatus),
 		Entry("Create IpAddress CR, ip address already reserved in NetBox",
 			defaultIpAddressCR(false),
-			[]func(*mock_interfaces.MockIpamInterface, chan error){
+			[]func(*mock_interfaces.MockIpamAPI, chan error){
 				mockIpAddressListWithIpAddressFilter,
 				// allow the update mock to be called twice
 				// there are race conditions where the cr is reconciled again
@@ -196,27 +196,27 @@ var _ = Describe("IpAddress Controller", Ordered, func() {
 				mockIpamIPAddressesUpdateTwice,
 				mockIpAddressesDelete,
 			},
-			[]func(*mock_interfaces.MockTenancyInterface, chan error){
+			[]func(*mock_interfaces.MockTenancyAPI, chan error){
 				mockTenancyTenancyTenantsList,
 			},
 			false, netboxv1.ConditionIpaddressReadyTrue, ExpectedIpAddressStatus),
 		Entry("Create IpAddress CR, reserve or update failure",
 			defaultIpAddressCR(false),
-			[]func(*mock_interfaces.MockIpamInterface, chan error){
+			[]func(*mock_interfaces.MockIpamAPI, chan error){
 				mockIpAddressListWithIpAddressFilter,
 				mockIpamIPAddressesUpdateFail,
 				mockIpAddressesDeleteFail,
 			},
-			[]func(*mock_interfaces.MockTenancyInterface, chan error){
+			[]func(*mock_interfaces.MockTenancyAPI, chan error){
 				mockTenancyTenancyTenantsList,
 			},
 			false, netboxv1.ConditionIpaddressReadyFalse, ExpectedIpAddressFailedStatus),
 		Entry("Create IpAddress CR, restoration hash mismatch",
 			defaultIpAddressCreatedByClaim(true),
-			[]func(*mock_interfaces.MockIpamInterface, chan error){
+			[]func(*mock_interfaces.MockIpamAPI, chan error){
 				mockIpAddressListWithHashFilterMismatch,
 			},
-			[]func(*mock_interfaces.MockTenancyInterface, chan error){
+			[]func(*mock_interfaces.MockTenancyAPI, chan error){
 				mockTenancyTenancyTenantsList,
 			},
 			true, metav1.Condition{}, netboxv1.IpAddressStatus{}),
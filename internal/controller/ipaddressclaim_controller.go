@@ -24,6 +24,7 @@ import (
 	"time"
 
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/netbox-community/netbox-operator/pkg/scheduler"
@@ -41,11 +42,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+const (
+	msgCanNotInferIpAddressParentPrefix = "IP address restored from hash, cannot infer the parent prefix"
+)
+
 // IpAddressClaimReconciler reconciles a IpAddressClaim object
 type IpAddressClaimReconciler struct {
 	client.Client
 	Scheme              *runtime.Scheme
-	NetboxClient        *api.NetboxCompositeClient
+	NetboxClients       *api.ClientRegistry
 	EventStatusRecorder *EventStatusRecorder
 	OperatorNamespace   string
 	RestConfig          *rest.Config
@@ -54,6 +59,7 @@ type IpAddressClaimReconciler struct {
 //+kubebuilder:rbac:groups=netbox.dev,resources=ipaddressclaims,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=netbox.dev,resources=ipaddressclaims/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=netbox.dev,resources=ipaddressclaims/finalizers,verbs=update
+//+kubebuilder:rbac:groups=netbox.dev,resources=ipranges,verbs=get
 //+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -89,7 +95,125 @@ func (r *IpAddressClaimReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		logger.Info("reconcile loop finished")
 	}()
 
-	// 1. check if matching IpAddress object already exists
+	// fail early if the dns name of the claim is invalid, the IpAddresses are generated with it
+	if _, err := generateDnsName(o); err != nil {
+		return ctrl.Result{}, NewDomainError("%w", err)
+	}
+
+	// resolve the client of the NetBox instance the resource is managed in
+	netboxClient, err := r.NetboxClients.ClientFor(ctx, o.Spec.Connection)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer r.EventStatusRecorder.ReportTokenRejected(o, netboxClient)
+
+	// short-circuit while the circuit breaker of NetBox is open instead of piling up failing requests
+	if err := netboxClient.Available(); err != nil {
+		return ctrl.Result{RequeueAfter: netboxClient.RetryAfter()}, NewDomainError("%w", err)
+	}
+
+	// 1. compute and assign the parent prefix if required
+	// Status.SelectedParentPrefix stores the selected parent prefix and is the
+	// source of truth for future parent prefix references
+	if o.Status.SelectedParentPrefix == "" && o.Status.SelectedParentIpRange == "" /* parent prefix not yet selected/assigned */ {
+		if o.Spec.ParentPrefix != "" {
+			o.Status.SelectedParentPrefix = o.Spec.ParentPrefix
+
+			// set status, and condition field
+			msg := fmt.Sprintf("parentPrefix is provided in CR: %v", o.Status.SelectedParentPrefix)
+			r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+		} else if len(o.Spec.ParentPrefixSelector) > 0 || len(o.Spec.ParentPrefixes) > 0 {
+			// since the parent prefix is not part of the restoration hash computation
+			// we can quickly check to see if the ip address with the restoration hash is matched in NetBox
+			h := generateIpAddressRestorationHash(o)
+			canBeRestored, err := netboxClient.RestoreExistingIpByHash(ctx, h, o.Spec.Vrf)
+			if err != nil {
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+
+			if canBeRestored != nil {
+				// the ip address will be restored directly, as for prefix claims the
+				// original parent prefix can't be inferred in this case
+				o.Status.SelectedParentPrefix = msgCanNotInferIpAddressParentPrefix
+
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msgCanNotInferIpAddressParentPrefix)
+			} else if len(o.Spec.ParentPrefixes) > 0 {
+				// use the first entry of the ordered parent prefixes the ip address can be claimed from
+				i, err := selectParentPrefixFromList(o.Spec.ParentPrefixes, func(parentPrefix string) error {
+					_, err := netboxClient.GetAvailableIpAddressByClaim(
+						ctx,
+						&models.IPAddressClaim{
+							ParentPrefix: parentPrefix,
+							Metadata: &models.NetboxMetadata{
+								Tenant: o.Spec.Tenant,
+								Vrf:    o.Spec.Vrf,
+							},
+						})
+					return err
+				})
+				if err != nil {
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+				o.Status.SelectedParentPrefix = o.Spec.ParentPrefixes[i]
+
+				// set status, and condition field
+				msg := fmt.Sprintf("parentPrefix is selected from parentPrefixes[%d]: %v", i, o.Status.SelectedParentPrefix)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+			} else {
+				// fetch the prefixes matching the selector which still have an available ip address
+				parentPrefixCandidates, err := netboxClient.GetAvailableIpAddressParentPrefixesBySelector(ctx, &o.Spec)
+				if err != nil {
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+				if len(parentPrefixCandidates) == 0 {
+					err := errors.New("no parent prefix found matching the parentPrefixSelector")
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+
+				// the candidates are in the order returned by NetBox, the first one is used
+				o.Status.SelectedParentPrefix = parentPrefixCandidates[0].Prefix
+
+				// set status, and condition field
+				msg := fmt.Sprintf("parentPrefix is selected: %v", o.Status.SelectedParentPrefix)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+			}
+		} else if o.Spec.ParentIpRange != nil {
+			parentIpRange, err := r.resolveParentIpRange(ctx, o)
+			if err != nil {
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+				return ctrl.Result{}, err
+			}
+			o.Status.SelectedParentIpRange = parentIpRange
+
+			// the parent prefix of the ip range is locked when ip addresses are claimed from it
+			ipAddressClaimModel, err := generateIpAddressClaimModel(o)
+			if err != nil {
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+			o.Status.SelectedParentPrefix, err = netboxClient.GetParentIpRangePrefix(ctx, ipAddressClaimModel)
+			if err != nil {
+				o.Status.SelectedParentIpRange = ""
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+
+			// set status, and condition field
+			msg := fmt.Sprintf("parentIpRange is provided in CR: %v", o.Status.SelectedParentIpRange)
+			r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+		} else {
+			// this case should not be triggered anymore, as we have validation rules put in place on the CR
+			return ctrl.Result{}, NewDomainError("either ParentPrefixSelector, ParentPrefixes, ParentPrefix or ParentIpRange needs to be set")
+		}
+
+		// Persist SelectedParentPrefix to the API server before creating the
+		// IpAddress CR, the IpAddress controller reads it to lock the parent prefix.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// 2. check if matching IpAddress object already exists
 	ipAddress := &netboxv1.IpAddress{}
 	ipAddressName := o.Name
 	ipAddressLookupKey := types.NamespacedName{
@@ -97,7 +221,7 @@ func (r *IpAddressClaimReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		Namespace: o.Namespace,
 	}
 
-	err := r.Get(ctx, ipAddressLookupKey, ipAddress)
+	err = r.Get(ctx, ipAddressLookupKey, ipAddress)
 	if err != nil {
 		// return error if not a notfound error
 		if !apierrors.IsNotFound(err) {
@@ -106,59 +230,80 @@ func (r *IpAddressClaimReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 		logger.V(4).Info("ipaddress object matching ipaddress claim was not found, creating new ipaddress object")
 
-		// 2. check if lease for parent prefix is available
-		leaseLockerNSN := types.NamespacedName{
-			Name:      convertCIDRToLeaseLockName(o.Spec.ParentPrefix),
-			Namespace: r.OperatorNamespace,
-		}
-		ll, err := leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.Namespace+"/"+ipAddressName)
-		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to create lease locker: %w", err)
-		}
+		parentPrefix := selectedParentOfIpAddress(o, ipAddressName)
+		if parentPrefix != msgCanNotInferIpAddressParentPrefix {
+			// we can't restore from the restoration hash
 
-		// 3. try to lock lease for parent prefix
-		lockCtx, cancelLock := context.WithTimeout(ctx, lockAcquireTimeout)
-		defer cancelLock() // ensure renewal goroutine stops on any return path
-		locked := ll.TryLock(lockCtx)
-		if !locked {
-			// lock for parent prefix was not available, rescheduling
-			errorMsg := fmt.Sprintf("failed to lock parent prefix %s", o.Spec.ParentPrefix)
-			return ctrl.Result{
-				RequeueAfter: 2 * time.Second,
-			}, NewDomainError("%s", errorMsg)
+			// 3. check if lease for parent prefix is available
+			leaseLockerNSN := types.NamespacedName{
+				Name:      convertCIDRToLeaseLockName(leaseLockParentOfIpAddress(o, ipAddressName)),
+				Namespace: r.OperatorNamespace,
+			}
+			ll, err := leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.Namespace+"/"+ipAddressName)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to create lease locker: %w", err)
+			}
+
+			// 4. try to lock lease for parent prefix
+			lockCtx, cancelLock := context.WithTimeout(ctx, lockAcquireTimeout)
+			defer cancelLock() // ensure renewal goroutine stops on any return path
+			locked := ll.TryLock(lockCtx)
+			if !locked {
+				// lock for parent prefix was not available, rescheduling
+				metrics.LeaseLockContentionTotal.WithLabelValues(ipAddressClaimKind, parentPrefix).Inc()
+				errorMsg := fmt.Sprintf("failed to lock parent prefix %s", parentPrefix)
+				return ctrl.Result{
+					RequeueAfter: 2 * time.Second,
+				}, NewDomainError("%s", errorMsg)
+			}
+			logger.V(4).Info("successfully locked parent prefix", "prefix", parentPrefix)
 		}
-		logger.V(4).Info("successfully locked parent prefix", "prefix", o.Spec.ParentPrefix)
 
-		// 4. try to reclaim ip address
+		// 5. try to reclaim ip address
 		h := generateIpAddressRestorationHash(o)
-		ipAddressModel, err := r.NetboxClient.RestoreExistingIpByHash(h)
+		ipAddressModel, err := netboxClient.RestoreExistingIpByHash(ctx, h, o.Spec.Vrf)
 		if err != nil {
 			return ctrl.Result{}, NewDomainError("%w", err)
 		}
+		metrics.ObserveRestoration(ipAddressClaimKind, ipAddressModel != nil)
 
 		if ipAddressModel == nil {
 			// ip address cannot be restored from netbox
-			// 5.a assign new available ip address
-			ipAddressModel, err = r.NetboxClient.GetAvailableIpAddressByClaim(
-				ctx,
-				&models.IPAddressClaim{
-					ParentPrefix: o.Spec.ParentPrefix,
-					Metadata: &models.NetboxMetadata{
-						Tenant: o.Spec.Tenant,
-					},
-				})
+			// 6.a assign the preferred or a new available ip address
+			ipAddressClaimModel, err := generateIpAddressClaimModel(o)
 			if err != nil {
 				return ctrl.Result{}, NewDomainError("%w", err)
 			}
+			if o.Spec.PreferredAddress != "" {
+				ipAddressModel, err = netboxClient.GetPreferredIpAddressByClaim(ctx, ipAddressClaimModel, o.Spec.PreferredAddress)
+				if errors.Is(err, api.ErrPreferredAddressNotAvailable) && o.Spec.PreferredAllocationPolicy == netboxv1.PreferredAllocationPolicyFallbackToDynamic {
+					logger.V(4).Info("preferred ip address is not available, falling back to dynamic allocation", "ip", o.Spec.PreferredAddress)
+					ipAddressModel, err = netboxClient.GetAvailableIpAddressByClaim(ctx, ipAddressClaimModel)
+				}
+			} else {
+				ipAddressModel, err = netboxClient.GetAvailableIpAddressByClaim(ctx, ipAddressClaimModel)
+			}
+			if err != nil {
+				observeParentExhausted(ipAddressClaimKind, parentPrefix, err)
+				if (errors.Is(err, api.ErrParentPrefixExhausted) && len(o.Spec.ParentPrefixSelector) > 0) ||
+					(isParentPrefixUnusableErr(err) && len(o.Spec.ParentPrefixes) > 0) {
+					// we reset the selected parent prefix, since no ip address can be claimed from it anymore,
+					// the next reconcile loop selects the next candidate
+					o.Status.SelectedParentPrefix = ""
+					return ctrl.Result{}, NewDomainError("will restart the parent prefix selection process: %w", err)
+				}
+
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
 			logger.V(4).Info("ip address is not reserved in netbox, assigned new ip address", "ip", ipAddressModel.IpAddress)
 		} else {
-			// 5.b reassign reserved ip address from netbox
+			// 6.b reassign reserved ip address from netbox
 			// do nothing, ip address restored
 			logger.V(4).Info("reassign reserved ip address from netbox", "ip", ipAddressModel.IpAddress)
 		}
 
-		// 6.a create the IPAddress object
-		ipAddressResource := generateIpAddressFromIpAddressClaim(o, ipAddressModel.IpAddress, logger)
+		// 7.a create the IPAddress object
+		ipAddressResource := generateIpAddressFromIpAddressClaim(o, ipAddressModel.IpAddress, inheritedVrf(o.Spec.Vrf, ipAddressModel.Metadata), logger)
 		if err := controllerutil.SetControllerReference(o, ipAddressResource, r.Scheme); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to set controller reference: %w", err)
 		}
@@ -168,14 +313,21 @@ func (r *IpAddressClaimReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 
 		logger.V(4).Info("successfully created IpAddress resource")
-
+		ipAddress = ipAddressResource
 	} else {
-		// 6.b update fields of IPAddress object
+		// 7.b update fields of IPAddress object
+		if err := checkNameConflict(ipAddressKind, ipAddress, o, true); err != nil {
+			return ctrl.Result{}, err
+		}
 		logger.V(4).Info("update ipaddress resource")
-		updatedIpAddressSpec := generateIpAddressSpec(o, ipAddress.Spec.IpAddress, logger)
+		updatedIpAddressSpec := generateIpAddressSpec(o, ipAddress.Spec.IpAddress, ipAddress.Spec.Vrf, logger)
 		_, err := ctrl.CreateOrUpdate(ctx, r.Client, ipAddress, func() error {
 			// only add the mutable fields here
 			ipAddress.Spec.CustomFields = updatedIpAddressSpec.CustomFields
+			ipAddress.Spec.Tags = updatedIpAddressSpec.Tags
+			ipAddress.Spec.Status = updatedIpAddressSpec.Status
+			ipAddress.Spec.Role = updatedIpAddressSpec.Role
+			ipAddress.Spec.DnsName = updatedIpAddressSpec.DnsName
 			ipAddress.Spec.Comments = updatedIpAddressSpec.Comments
 			ipAddress.Spec.Description = updatedIpAddressSpec.Description
 			ipAddress.Spec.PreserveInNetbox = updatedIpAddressSpec.PreserveInNetbox
@@ -189,7 +341,13 @@ func (r *IpAddressClaimReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 	}
 
-	return ctrl.Result{}, nil
+	// 8. claim the IP Address of the other IP family in dual-stack mode
+	if o.Spec.DualStack != nil {
+		return r.reconcileDualStackIpAddress(ctx, req, netboxClient, o, ipAddress.Spec.IpAddress)
+	}
+
+	// 9. claim the remaining IP Addresses if the count is greater than 1, or delete them after scale down
+	return r.reconcileIpAddressCount(ctx, req, netboxClient, o, ipAddress)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -232,6 +390,17 @@ func (r *IpAddressClaimReconciler) updateStatus(ctx context.Context, claim *netb
 
 	logger.V(4).Info("updating ipaddressclaim status")
 
+	if errors.Is(reconcileErr, api.ErrNetboxUnavailable) {
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionReadyFalseNetBoxUnavailable, corev1.EventTypeWarning, reconcileErr)
+		return result, err
+	}
+
+	if errors.Is(reconcileErr, errNameConflict) {
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionIpAssignedFalse, corev1.EventTypeWarning, reconcileErr)
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionReadyFalseNameConflict, corev1.EventTypeWarning, reconcileErr)
+		return result, err
+	}
+
 	// Fetch the latest IpAddress object
 	ipAddress := &netboxv1.IpAddress{}
 	err = r.Client.Get(ctx, lookupKey, ipAddress)
@@ -251,16 +420,88 @@ func (r *IpAddressClaimReconciler) updateStatus(ctx context.Context, claim *netb
 		return result, err
 	}
 
+	ready := apismeta.IsStatusConditionTrue(ipAddress.Status.Conditions, netboxv1.ConditionIpaddressReadyTrue.Type)
+	if ready {
+		claim.Status.IpAddress = ipAddress.Spec.IpAddress
+		claim.Status.IpAddressDotDecimal = strings.Split(ipAddress.Spec.IpAddress, "/")[0]
+		claim.Status.IpAddressName = ipAddress.Name
+		if statusBase.Status.IpAddress == "" {
+			metrics.ObserveClaimAllocation(ipAddressClaimKind, claim.CreationTimestamp.Time)
+		}
+	}
+
+	// In dual-stack mode, the IpAddress of the other IP family is required as well
+	if claim.Spec.DualStack != nil {
+		dualStackIpAddress := &netboxv1.IpAddress{}
+		err = r.Client.Get(ctx, types.NamespacedName{Name: dualStackName(claim.Name), Namespace: claim.Namespace}, dualStackIpAddress)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// dual-stack IpAddress doesn't exist yet
+				r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionIpAssignedFalse, corev1.EventTypeWarning, reconcileErr)
+				r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionIpClaimReadyFalse, corev1.EventTypeWarning, reconcileErr)
+				if result.IsZero() {
+					result = ctrl.Result{RequeueAfter: 1 * time.Second}
+				}
+				err = nil
+				return result, err
+			}
+			err = fmt.Errorf("failed to get dual-stack IpAddress for status update: %w", err)
+			return result, err
+		}
+
+		if apismeta.IsStatusConditionTrue(dualStackIpAddress.Status.Conditions, netboxv1.ConditionIpaddressReadyTrue.Type) {
+			if claim.Status.DualStack == nil {
+				claim.Status.DualStack = &netboxv1.IpAddressClaimDualStackStatus{}
+			}
+			claim.Status.DualStack.IpAddress = dualStackIpAddress.Spec.IpAddress
+			claim.Status.DualStack.IpAddressDotDecimal = strings.Split(dualStackIpAddress.Spec.IpAddress, "/")[0]
+			claim.Status.DualStack.IpAddressName = dualStackIpAddress.Name
+		} else {
+			ready = false
+		}
+	}
+
+	// With a count greater than 1, all IpAddresses of the claim are required
+	count := claimCount(claim.Spec.Count)
+	if count > 1 {
+		var ipAddresses map[int]*netboxv1.IpAddress
+		ipAddresses, err = r.listIndexedIpAddresses(ctx, claim)
+		if err != nil {
+			return result, err
+		}
+
+		allocated := make([]string, 0, count)
+		allocated = append(allocated, ipAddress.Spec.IpAddress)
+		for index := 1; index < count; index++ {
+			indexedIpAddress, ok := ipAddresses[index]
+			if !ok {
+				// not all IpAddresses exist yet
+				r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionIpAssignedFalse, corev1.EventTypeWarning, reconcileErr)
+				r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionIpClaimReadyFalse, corev1.EventTypeWarning, reconcileErr)
+				if result.IsZero() {
+					result = ctrl.Result{RequeueAfter: 1 * time.Second}
+				}
+				return result, nil
+			}
+			if !apismeta.IsStatusConditionTrue(indexedIpAddress.Status.Conditions, netboxv1.ConditionIpaddressReadyTrue.Type) {
+				ready = false
+			}
+			allocated = append(allocated, indexedIpAddress.Spec.IpAddress)
+		}
+		if ready {
+			claim.Status.IpAddresses = allocated
+		}
+	} else {
+		claim.Status.IpAddresses = nil
+	}
+
 	// IpAddress exists - report successful IP assignment if not already reported
 	if apismeta.FindStatusCondition(claim.Status.Conditions, netboxv1.ConditionIpAssignedTrue.Type) == nil || apismeta.IsStatusConditionFalse(claim.Status.Conditions, netboxv1.ConditionIpAssignedTrue.Type) {
 		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionIpAssignedTrue, corev1.EventTypeNormal, nil)
 	}
 	// Update status based on IpAddress readiness
-	if apismeta.IsStatusConditionTrue(ipAddress.Status.Conditions, netboxv1.ConditionIpaddressReadyTrue.Type) {
+	if ready {
 		logger.V(4).Info("ipaddress status ready true")
-		claim.Status.IpAddress = ipAddress.Spec.IpAddress
-		claim.Status.IpAddressDotDecimal = strings.Split(ipAddress.Spec.IpAddress, "/")[0]
-		claim.Status.IpAddressName = ipAddress.Name
 		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionIpClaimReadyTrue, corev1.EventTypeNormal, nil)
 	} else {
 		logger.V(4).Info("ipaddress status ready false")
@@ -271,3 +512,24 @@ func (r *IpAddressClaimReconciler) updateStatus(ctx context.Context, claim *netb
 
 	return result, err
 }
+
+// resolveParentIpRange returns the NetBox IP Range referenced in `.spec.parentIpRange` in the
+// format <startAddress>-<endAddress>, an IpRange CR referenced by name is read from the namespace
+// of the IpAddressClaim
+func (r *IpAddressClaimReconciler) resolveParentIpRange(ctx context.Context, o *netboxv1.IpAddressClaim) (string, error) {
+	parentIpRange := o.Spec.ParentIpRange
+	if parentIpRange.Name == "" {
+		return parentIpRange.StartAddress + "-" + parentIpRange.EndAddress, nil
+	}
+
+	ipRange := &netboxv1.IpRange{}
+	err := r.Get(ctx, types.NamespacedName{Name: parentIpRange.Name, Namespace: o.Namespace}, ipRange)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", NewDomainError("parent IpRange %s not found", parentIpRange.Name)
+		}
+		return "", fmt.Errorf("failed to get parent IpRange %s: %w", parentIpRange.Name, err)
+	}
+
+	return ipRange.Spec.StartAddress + "-" + ipRange.Spec.EndAddress, nil
+}
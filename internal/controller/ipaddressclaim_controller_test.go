@@ -57,20 +57,16 @@ var _ = Describe("IpAddressClaim Controller", Ordered, func() {
 		cr *netboxv1.IpAddressClaim, // our CR as typed object
 		ipcr *netboxv1.IpAddress, // ip address CR expected to be created by ip address claim controller
 		ipcrMockStatus netboxv1.IpAddressStatus, // the that will be added to mock the ip address controller
-		MockIpamAPI []func(*mock_interfaces.MockIpamAPI, chan error),
 		MockIpamPrefixesListRequest []func(*mock_interfaces.MockIpamPrefixesListRequest, chan error),
-		IpamMocksIpAddressClaim []func(*mock_interfaces.MockIpamInterface, chan error),
-		IpamMocksIpAddress []func(*mock_interfaces.MockIpamInterface, chan error),
-		TenancyMocks []func(*mock_interfaces.MockTenancyInterface, chan error),
+		IpamMocksIpAddressClaim []func(*mock_interfaces.MockIpamAPI, chan error),
+		IpamMocksIpAddress []func(*mock_interfaces.MockIpamAPI, chan error),
+		TenancyMocks []func(*mock_interfaces.MockTenancyAPI, chan error),
 		expectedConditionReady bool, // Expected state of the ConditionReady condition
 		expectedConditionIpAssigned bool, // Expected state of the ConditionReady condition
 		expectedCRStatus netboxv1.IpAddressClaimStatus, // Expected status of the CR
 		prefixLockedByOtherOwner bool, // If prefix is locked by other owner when ipaddress claim CR is created
 	) {
 		By("Setting up mocks")
-		for _, mock := range MockIpamAPI {
-			mock(mockIpamAPI, unexpectedCallCh)
-		}
 		for _, mock := range MockIpamPrefixesListRequest {
 			mock(mockIpamPrefixesListRequest, unexpectedCallCh)
 		}
@@ -177,45 +173,41 @@ var _ = Describe("IpAddressClaim Controller", Ordered, func() {
 	},
 		Entry("Create IpAddressClaim CR, reserve new ip address in NetBox",
 			defaultIpAddressClaimCR(), defaultIpAddressCreatedByClaim(false), ExpectedIpAddressStatus,
-			[]func(*mock_interfaces.MockIpamAPI, chan error){
-				mockPrefixesList,
-			},
 			[]func(*mock_interfaces.MockIpamPrefixesListRequest, chan error){
 				mockPrefixesListRequestSetPrefix,
 				mockPrefixesListRequestExecute,
 			},
-			[]func(*mock_interfaces.MockIpamInterface, chan error){
+			[]func(*mock_interfaces.MockIpamAPI, chan error){
+				mockPrefixesList,
 				mockIpAddressListWithHashFilterEmptyResult,
 				mockPrefixesAvailableIpsList,
 			},
-			[]func(*mock_interfaces.MockIpamInterface, chan error){
+			[]func(*mock_interfaces.MockIpamAPI, chan error){
 				mockIpAddressListWithIpAddressFilterEmptyResult,
 				mockIpamIPAddressesCreateWithHash,
 				mockIpAddressesDelete,
 			},
-			[]func(*mock_interfaces.MockTenancyInterface, chan error){
+			[]func(*mock_interfaces.MockTenancyAPI, chan error){
 				mockTenancyTenancyTenantsList,
 			},
 			true, true, ExpectedIpAddressClaimStatus, false),
 		Entry("Create IpAddressClaim CR, reassign ip from NetBox",
 			defaultIpAddressClaimCR(), defaultIpAddressCreatedByClaim(false), ExpectedIpAddressStatus,
-			[]func(*mock_interfaces.MockIpamAPI, chan error){},
 			[]func(*mock_interfaces.MockIpamPrefixesListRequest, chan error){},
-			[]func(*mock_interfaces.MockIpamInterface, chan error){
+			[]func(*mock_interfaces.MockIpamAPI, chan error){
 				mockIpAddressListWithHashFilter,
 			},
-			[]func(*mock_interfaces.MockIpamInterface, chan error){
+			[]func(*mock_interfaces.MockIpamAPI, chan error){
 				mockIpAddressListWithIpAddressFilter,
 				mockIpamIPAddressesUpdateWithHash,
 				mockIpAddressesDelete,
 			},
-			[]func(*mock_interfaces.MockTenancyInterface, chan error){
+			[]func(*mock_interfaces.MockTenancyAPI, chan error){
 				mockTenancyTenancyTenantsList,
 			},
 			true, true, ExpectedIpAddressClaimStatus, false),
 		Entry("Create IpAddressClaim CR, prefix locked by other resource",
 			defaultIpAddressClaimCR(), defaultIpAddressCreatedByClaim(false), nil,
-			[]func(*mock_interfaces.MockIpamAPI, chan error){},
 			[]func(*mock_interfaces.MockIpamPrefixesListRequest, chan error){},
 			nil,
 			nil,
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+
+	"github.com/swisscom/leaselocker"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// listIndexedIpAddresses returns the IpAddresses of the IpAddressClaim with an index greater than 0
+// by index, the IpAddress with index 0 is the one named after the claim.
+func (r *IpAddressClaimReconciler) listIndexedIpAddresses(ctx context.Context, o *netboxv1.IpAddressClaim) (map[int]*netboxv1.IpAddress, error) {
+	ipAddressList := &netboxv1.IpAddressList{}
+	if err := r.List(ctx, ipAddressList, client.InNamespace(o.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list IpAddresses: %w", err)
+	}
+
+	ipAddresses := make(map[int]*netboxv1.IpAddress)
+	for i := range ipAddressList.Items {
+		ipAddress := &ipAddressList.Items[i]
+		if !metav1.IsControlledBy(ipAddress, o) {
+			continue
+		}
+		if index := indexOfIndexedName(o.Name, ipAddress.Name); index > 0 {
+			ipAddresses[index] = ipAddress
+		}
+	}
+	return ipAddresses, nil
+}
+
+// reconcileIpAddressCount scales the IpAddresses of an IpAddressClaim to its count. The IpAddress
+// with index 0 is reconciled like the one of a claim without count, ipAddress is that IpAddress.
+// The missing IpAddresses are claimed from the selected parent prefix while holding its lease,
+// the lease is shared with the IpAddress controller which doesn't release it, so that no other
+// claim allocates from the parent prefix before all IP Addresses are reserved in NetBox.
+func (r *IpAddressClaimReconciler) reconcileIpAddressCount(ctx context.Context, req ctrl.Request, netboxClient *api.NetboxCompositeClient, o *netboxv1.IpAddressClaim, ipAddress *netboxv1.IpAddress) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	count := claimCount(o.Spec.Count)
+
+	ipAddresses, err := r.listIndexedIpAddresses(ctx, o)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// 9.1 delete the IpAddresses with an index beyond the count
+	for index, existing := range ipAddresses {
+		if index < count {
+			continue
+		}
+		logger.V(4).Info("deleting ipaddress resource after scale down", "ipaddress", existing.Name)
+		if err := r.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete IpAddress %s: %w", existing.Name, err)
+		}
+		delete(ipAddresses, index)
+	}
+
+	// 9.2 update fields of the existing IpAddress objects
+	for index, existing := range ipAddresses {
+		updatedIpAddressSpec := generateIndexedIpAddressSpec(o, index, existing.Spec.IpAddress, existing.Spec.Vrf, logger)
+		_, err := ctrl.CreateOrUpdate(ctx, r.Client, existing, func() error {
+			// only add the mutable fields here
+			existing.Spec.CustomFields = updatedIpAddressSpec.CustomFields
+			existing.Spec.Tags = updatedIpAddressSpec.Tags
+			existing.Spec.Status = updatedIpAddressSpec.Status
+			existing.Spec.Role = updatedIpAddressSpec.Role
+			existing.Spec.DnsName = updatedIpAddressSpec.DnsName
+			existing.Spec.Comments = updatedIpAddressSpec.Comments
+			existing.Spec.Description = updatedIpAddressSpec.Description
+			existing.Spec.PreserveInNetbox = updatedIpAddressSpec.PreserveInNetbox
+			if err := controllerutil.SetControllerReference(o, existing, r.Scheme); err != nil {
+				return fmt.Errorf("failed to set controller reference: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update IpAddress %s: %w", existing.Name, err)
+		}
+	}
+
+	missingIndices := make([]int, 0, count)
+	for index := 1; index < count; index++ {
+		if _, ok := ipAddresses[index]; !ok {
+			missingIndices = append(missingIndices, index)
+		}
+	}
+	if len(missingIndices) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	// the names of the missing IpAddresss might be used by resources of another claim, e.g. of a claim
+	// named <claim>-<index>, check them before anything is allocated in NetBox
+	for _, index := range missingIndices {
+		existing := &netboxv1.IpAddress{}
+		err := r.Get(ctx, types.NamespacedName{Name: indexedName(o.Name, index), Namespace: o.Namespace}, existing)
+		if err == nil {
+			return ctrl.Result{}, checkNameConflict(ipAddressKind, existing, o, false)
+		}
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get IpAddress %s: %w", indexedName(o.Name, index), err)
+		}
+	}
+
+	parentPrefix := selectedParentOfIpAddress(o, o.Name)
+	if parentPrefix != msgCanNotInferIpAddressParentPrefix {
+		// 9.3 lock the lease of the parent prefix, with the owner used by all IpAddresses of the claim
+		leaseLockerNSN := types.NamespacedName{
+			Name:      convertCIDRToLeaseLockName(leaseLockParentOfIpAddress(o, o.Name)),
+			Namespace: r.OperatorNamespace,
+		}
+		ll, err := leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.Namespace+"/"+leaseLockOwnerOfIpAddress(o, o.Name))
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create lease locker: %w", err)
+		}
+
+		lockCtx, cancelLock := context.WithTimeout(ctx, lockAcquireTimeout)
+		defer cancelLock()
+		if !ll.TryLock(lockCtx) {
+			metrics.LeaseLockContentionTotal.WithLabelValues(ipAddressClaimKind, parentPrefix).Inc()
+			errorMsg := fmt.Sprintf("failed to lock parent prefix %s", parentPrefix)
+			return ctrl.Result{
+				RequeueAfter: 2 * time.Second,
+			}, NewDomainError("%s", errorMsg)
+		}
+		logger.V(4).Info("successfully locked parent prefix", "prefix", parentPrefix)
+	}
+
+	// 9.4 try to reclaim the missing ip addresses, they are in the VRF of the IpAddress with index 0
+	ipAddressesByIndex := make(map[int]string, len(missingIndices))
+	unrestoredIndices := make([]int, 0, len(missingIndices))
+	for _, index := range missingIndices {
+		ipAddressModel, err := netboxClient.RestoreExistingIpByHash(ctx, generateIndexedIpAddressRestorationHash(o, index), ipAddress.Spec.Vrf)
+		if err != nil {
+			return ctrl.Result{}, NewDomainError("%w", err)
+		}
+		metrics.ObserveRestoration(ipAddressClaimKind, ipAddressModel != nil)
+		if ipAddressModel == nil {
+			unrestoredIndices = append(unrestoredIndices, index)
+			continue
+		}
+		logger.V(4).Info("reassign reserved ip address from netbox", "ip", ipAddressModel.IpAddress, "index", index)
+		ipAddressesByIndex[index] = ipAddressModel.IpAddress
+	}
+
+	if len(unrestoredIndices) > 0 {
+		if parentPrefix == msgCanNotInferIpAddressParentPrefix {
+			return ctrl.Result{}, NewDomainError("%d of %d ip addresses can't be restored and the parent prefix can't be inferred", len(unrestoredIndices), count)
+		}
+
+		// 9.5 assign new available ip addresses, skipping the ones which are not yet reserved in netbox
+		excludedIpAddresses := make([]string, 0, len(ipAddresses)+len(ipAddressesByIndex)+1)
+		excludedIpAddresses = append(excludedIpAddresses, ipAddress.Spec.IpAddress)
+		for _, existing := range ipAddresses {
+			excludedIpAddresses = append(excludedIpAddresses, existing.Spec.IpAddress)
+		}
+		for _, restored := range ipAddressesByIndex {
+			excludedIpAddresses = append(excludedIpAddresses, restored)
+		}
+
+		ipAddressClaimModel, err := generateIpAddressClaimModel(o)
+		if err != nil {
+			return ctrl.Result{}, NewDomainError("%w", err)
+		}
+		// the VRF might be inherited from the parent prefix, it is the same for all IpAddresses
+		ipAddressClaimModel.Metadata.Vrf = ipAddress.Spec.Vrf
+		ipAddressModels, err := netboxClient.GetAvailableIpAddressesByClaim(ctx, ipAddressClaimModel, len(unrestoredIndices), excludedIpAddresses)
+		if err != nil {
+			observeParentExhausted(ipAddressClaimKind, parentPrefix, err)
+			return ctrl.Result{}, NewDomainError("%w", err)
+		}
+		for i, index := range unrestoredIndices {
+			logger.V(4).Info("ip address is not reserved in netbox, assigned new ip address", "ip", ipAddressModels[i].IpAddress, "index", index)
+			ipAddressesByIndex[index] = ipAddressModels[i].IpAddress
+		}
+	}
+
+	// 9.6 create the missing IpAddress objects
+	for _, index := range missingIndices {
+		ipAddressResource := generateIndexedIpAddressFromIpAddressClaim(o, index, ipAddressesByIndex[index], ipAddress.Spec.Vrf, logger)
+		if err := controllerutil.SetControllerReference(o, ipAddressResource, r.Scheme); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		if err := r.Create(ctx, ipAddressResource); err != nil {
+			return ctrl.Result{}, NewDomainError("failed to create IpAddress %s: %w", ipAddressResource.Name, err)
+		}
+	}
+
+	logger.V(4).Info("successfully created IpAddress resources", "count", len(missingIndices))
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+
+	"github.com/swisscom/leaselocker"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileDualStackIpAddress claims the IP Address of the other IP family of an IpAddressClaim
+// in dual-stack mode. It follows the same steps as the reconciliation of the first IP Address,
+// ipAddress is the first IP Address in CIDR notation and is used to verify the IP family.
+func (r *IpAddressClaimReconciler) reconcileDualStackIpAddress(ctx context.Context, req ctrl.Request, netboxClient *api.NetboxCompositeClient, o *netboxv1.IpAddressClaim, ipAddress string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if o.Status.DualStack == nil {
+		o.Status.DualStack = &netboxv1.IpAddressClaimDualStackStatus{}
+	}
+
+	// 8.1 compute and assign the dual-stack parent prefix if required
+	if o.Status.DualStack.SelectedParentPrefix == "" {
+		if o.Spec.DualStack.ParentPrefix != "" {
+			if err := verifyOtherIpFamily(ipAddress, o.Spec.DualStack.ParentPrefix); err != nil {
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+			o.Status.DualStack.SelectedParentPrefix = o.Spec.DualStack.ParentPrefix
+
+			msg := fmt.Sprintf("dual-stack parentPrefix is provided in CR: %v", o.Status.DualStack.SelectedParentPrefix)
+			r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+		} else {
+			h := generateDualStackIpAddressRestorationHash(o)
+			canBeRestored, err := netboxClient.RestoreExistingIpByHash(ctx, h, o.Spec.Vrf)
+			if err != nil {
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+
+			if canBeRestored != nil {
+				o.Status.DualStack.SelectedParentPrefix = msgCanNotInferIpAddressParentPrefix
+
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, "dual-stack "+msgCanNotInferIpAddressParentPrefix)
+			} else {
+				parentPrefixCandidates, err := netboxClient.GetAvailableIpAddressParentPrefixesBySelector(ctx, &netboxv1.IpAddressClaimSpec{
+					ParentPrefixSelector: o.Spec.DualStack.ParentPrefixSelector,
+					Tenant:               o.Spec.Tenant,
+					Vrf:                  o.Spec.Vrf,
+				})
+				if err != nil {
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+
+				// the candidates are in the order returned by NetBox, the first one of the other IP family is used
+				for _, candidate := range parentPrefixCandidates {
+					if verifyOtherIpFamily(ipAddress, candidate.Prefix) == nil {
+						o.Status.DualStack.SelectedParentPrefix = candidate.Prefix
+						break
+					}
+				}
+				if o.Status.DualStack.SelectedParentPrefix == "" {
+					err := errors.New("no parent prefix of the other IP family found matching the dual-stack parentPrefixSelector")
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+
+				msg := fmt.Sprintf("dual-stack parentPrefix is selected: %v", o.Status.DualStack.SelectedParentPrefix)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+			}
+		}
+
+		// Persist the dual-stack SelectedParentPrefix to the API server before creating
+		// the second IpAddress CR, the IpAddress controller reads it to lock the parent prefix.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// 8.2 check if the dual-stack IpAddress object already exists
+	dualStackIpAddress := &netboxv1.IpAddress{}
+	dualStackIpAddressName := dualStackName(o.Name)
+	err := r.Get(ctx, types.NamespacedName{Name: dualStackIpAddressName, Namespace: o.Namespace}, dualStackIpAddress)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get dual-stack IpAddress: %w", err)
+		}
+
+		logger.V(4).Info("dual-stack ipaddress object matching ipaddress claim was not found, creating new ipaddress object")
+
+		parentPrefix := o.Status.DualStack.SelectedParentPrefix
+		if parentPrefix != msgCanNotInferIpAddressParentPrefix {
+			// 8.3 lock the lease of the dual-stack parent prefix
+			leaseLockerNSN := types.NamespacedName{
+				Name:      convertCIDRToLeaseLockName(parentPrefix),
+				Namespace: r.OperatorNamespace,
+			}
+			ll, err := leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.Namespace+"/"+dualStackIpAddressName)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to create lease locker: %w", err)
+			}
+
+			lockCtx, cancelLock := context.WithTimeout(ctx, lockAcquireTimeout)
+			defer cancelLock()
+			if !ll.TryLock(lockCtx) {
+				metrics.LeaseLockContentionTotal.WithLabelValues(ipAddressClaimKind, parentPrefix).Inc()
+				errorMsg := fmt.Sprintf("failed to lock parent prefix %s", parentPrefix)
+				return ctrl.Result{
+					RequeueAfter: 2 * time.Second,
+				}, NewDomainError("%s", errorMsg)
+			}
+			logger.V(4).Info("successfully locked dual-stack parent prefix", "prefix", parentPrefix)
+		}
+
+		// 8.4 try to reclaim the dual-stack ip address
+		ipAddressModel, err := netboxClient.RestoreExistingIpByHash(ctx, generateDualStackIpAddressRestorationHash(o), o.Spec.Vrf)
+		if err != nil {
+			return ctrl.Result{}, NewDomainError("%w", err)
+		}
+		metrics.ObserveRestoration(ipAddressClaimKind, ipAddressModel != nil)
+
+		if ipAddressModel == nil {
+			// 8.5 assign new available ip address of the other IP family
+			ipAddressModel, err = netboxClient.GetAvailableIpAddressByClaim(
+				ctx,
+				&models.IPAddressClaim{
+					ParentPrefix: parentPrefix,
+					Metadata: &models.NetboxMetadata{
+						Tenant: o.Spec.Tenant,
+						Vrf:    o.Spec.Vrf,
+					},
+				})
+			if err != nil {
+				observeParentExhausted(ipAddressClaimKind, parentPrefix, err)
+				if errors.Is(err, api.ErrParentPrefixExhausted) && len(o.Spec.DualStack.ParentPrefixSelector) > 0 {
+					// the next reconcile loop selects the next candidate
+					o.Status.DualStack.SelectedParentPrefix = ""
+					return ctrl.Result{}, NewDomainError("will restart the dual-stack parent prefix selection process: %w", err)
+				}
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+			logger.V(4).Info("dual-stack ip address is not reserved in netbox, assigned new ip address", "ip", ipAddressModel.IpAddress)
+		} else {
+			logger.V(4).Info("reassign reserved dual-stack ip address from netbox", "ip", ipAddressModel.IpAddress)
+		}
+
+		// 8.6 create the dual-stack IpAddress object
+		ipAddressResource := generateDualStackIpAddressFromIpAddressClaim(o, ipAddressModel.IpAddress, inheritedVrf(o.Spec.Vrf, ipAddressModel.Metadata), logger)
+		if err := controllerutil.SetControllerReference(o, ipAddressResource, r.Scheme); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		if err := r.Create(ctx, ipAddressResource); err != nil {
+			return ctrl.Result{}, NewDomainError("failed to create dual-stack IpAddress: %w", err)
+		}
+
+		logger.V(4).Info("successfully created dual-stack IpAddress resource")
+		return ctrl.Result{}, nil
+	}
+
+	// 8.7 update fields of the dual-stack IpAddress object
+	updatedIpAddressSpec := generateDualStackIpAddressSpec(o, dualStackIpAddress.Spec.IpAddress, dualStackIpAddress.Spec.Vrf, logger)
+	_, err = ctrl.CreateOrUpdate(ctx, r.Client, dualStackIpAddress, func() error {
+		// only add the mutable fields here
+		dualStackIpAddress.Spec.CustomFields = updatedIpAddressSpec.CustomFields
+		dualStackIpAddress.Spec.Tags = updatedIpAddressSpec.Tags
+		dualStackIpAddress.Spec.Status = updatedIpAddressSpec.Status
+		dualStackIpAddress.Spec.Role = updatedIpAddressSpec.Role
+		dualStackIpAddress.Spec.DnsName = updatedIpAddressSpec.DnsName
+		dualStackIpAddress.Spec.Comments = updatedIpAddressSpec.Comments
+		dualStackIpAddress.Spec.Description = updatedIpAddressSpec.Description
+		dualStackIpAddress.Spec.PreserveInNetbox = updatedIpAddressSpec.PreserveInNetbox
+		if err := controllerutil.SetControllerReference(o, dualStackIpAddress, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update dual-stack IpAddress: %w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
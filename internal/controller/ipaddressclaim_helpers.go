@@ -19,25 +19,64 @@ package controller
 import (
 	"crypto/sha1"
 	"fmt"
+	"slices"
+	"strings"
+	"text/template"
 
 	"github.com/go-logr/logr"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
-func generateIpAddressFromIpAddressClaim(claim *netboxv1.IpAddressClaim, ip string, logger logr.Logger) *netboxv1.IpAddress {
+func generateIpAddressFromIpAddressClaim(claim *netboxv1.IpAddressClaim, ip string, vrf string, logger logr.Logger) *netboxv1.IpAddress {
 	ipAddressResource := &netboxv1.IpAddress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      claim.Name,
 			Namespace: claim.Namespace,
 		},
-		Spec: generateIpAddressSpec(claim, ip, logger),
+		Spec: generateIpAddressSpec(claim, ip, vrf, logger),
 	}
 	return ipAddressResource
 }
 
-func generateIpAddressSpec(claim *netboxv1.IpAddressClaim, ip string, logger logr.Logger) netboxv1.IpAddressSpec {
+func generateDualStackIpAddressFromIpAddressClaim(claim *netboxv1.IpAddressClaim, ip string, vrf string, logger logr.Logger) *netboxv1.IpAddress {
+	return &netboxv1.IpAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dualStackName(claim.Name),
+			Namespace: claim.Namespace,
+		},
+		Spec: generateDualStackIpAddressSpec(claim, ip, vrf, logger),
+	}
+}
+
+func generateIndexedIpAddressFromIpAddressClaim(claim *netboxv1.IpAddressClaim, index int, ip string, vrf string, logger logr.Logger) *netboxv1.IpAddress {
+	return &netboxv1.IpAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      indexedName(claim.Name, index),
+			Namespace: claim.Namespace,
+		},
+		Spec: generateIndexedIpAddressSpec(claim, index, ip, vrf, logger),
+	}
+}
+
+func generateIpAddressSpec(claim *netboxv1.IpAddressClaim, ip string, vrf string, logger logr.Logger) netboxv1.IpAddressSpec {
+	return generateIpAddressSpecWithRestorationHash(claim, ip, vrf, generateIpAddressRestorationHash(claim), logger)
+}
+
+func generateDualStackIpAddressSpec(claim *netboxv1.IpAddressClaim, ip string, vrf string, logger logr.Logger) netboxv1.IpAddressSpec {
+	return generateIpAddressSpecWithRestorationHash(claim, ip, vrf, generateDualStackIpAddressRestorationHash(claim), logger)
+}
+
+func generateIndexedIpAddressSpec(claim *netboxv1.IpAddressClaim, index int, ip string, vrf string, logger logr.Logger) netboxv1.IpAddressSpec {
+	return generateIpAddressSpecWithRestorationHash(claim, ip, vrf, generateIndexedIpAddressRestorationHash(claim, index), logger)
+}
+
+// generateIpAddressSpecWithRestorationHash returns the spec of an IpAddress of the claim, the vrf is the
+// VRF of the claim or the one inherited from the parent prefix or parent ip range
+func generateIpAddressSpecWithRestorationHash(claim *netboxv1.IpAddressClaim, ip string, vrf string, restorationHash string, logger logr.Logger) netboxv1.IpAddressSpec {
 	// log a warning if the netboxOperatorRestorationHash name is a key in the customFields map of the IpAddressClaim
 	_, ok := claim.Spec.CustomFields[config.GetOperatorConfig().NetboxRestorationHashFieldName]
 	if ok {
@@ -50,32 +89,182 @@ func generateIpAddressSpec(claim *netboxv1.IpAddressClaim, ip string, logger log
 		customFields[k] = v
 	}
 
-	customFields[config.GetOperatorConfig().NetboxRestorationHashFieldName] = generateIpAddressRestorationHash(claim)
+	customFields[config.GetOperatorConfig().NetboxRestorationHashFieldName] = restorationHash
+
+	// the dns name is validated before the IpAddresses are generated, see generateDnsName
+	dnsName, err := generateDnsName(claim)
+	if err != nil {
+		logger.Info(fmt.Sprintf("Warning: %s, the dns name is not set", err))
+	}
 
 	return netboxv1.IpAddressSpec{
 		IpAddress:        ip,
 		Tenant:           claim.Spec.Tenant,
+		Vrf:              vrf,
+		Status:           claim.Spec.Status,
+		Role:             claim.Spec.Role,
+		DnsName:          dnsName,
 		CustomFields:     customFields,
+		Tags:             slices.Clone(claim.Spec.Tags),
 		Description:      claim.Spec.Description,
 		Comments:         claim.Spec.Comments,
 		PreserveInNetbox: claim.Spec.PreserveInNetbox,
+		Connection:       claim.Spec.Connection,
 	}
 }
 
+// generateDnsName returns the DNS name of the IpAddresses of the claim. The dnsName of the claim is
+// a template which is executed with the metadata of the claim, e.g. "{{.Name}}.{{.Namespace}}.k8s.example.com",
+// it returns an error if the template is invalid or the result is not an RFC 1123 subdomain.
+func generateDnsName(claim *netboxv1.IpAddressClaim) (string, error) {
+	if claim.Spec.DnsName == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New("dnsName").Option("missingkey=error").Parse(claim.Spec.DnsName)
+	if err != nil {
+		return "", fmt.Errorf("invalid dns name template %q: %w", claim.Spec.DnsName, err)
+	}
+	var dnsName strings.Builder
+	if err := tmpl.Execute(&dnsName, claim.ObjectMeta); err != nil {
+		return "", fmt.Errorf("failed to generate dns name from template %q: %w", claim.Spec.DnsName, err)
+	}
+
+	if errs := validation.IsDNS1123Subdomain(dnsName.String()); len(errs) > 0 {
+		return "", fmt.Errorf("invalid dns name %q: %s", dnsName.String(), strings.Join(errs, ", "))
+	}
+	return dnsName.String(), nil
+}
+
 func generateIpAddressRestorationHash(claim *netboxv1.IpAddressClaim) string {
 	rd := IpAddressClaimRestorationData{
-		Namespace:    claim.Namespace,
-		Name:         claim.Name,
-		ParentPrefix: claim.Spec.ParentPrefix,
-		Tenant:       claim.Spec.Tenant,
+		Namespace:            claim.Namespace,
+		Name:                 claim.Name,
+		ParentPrefix:         claim.Spec.ParentPrefix,
+		Tenant:               claim.Spec.Tenant,
+		ParentPrefixSelector: parentPrefixSelectorToString(claim.Spec.ParentPrefixSelector),
+		ParentPrefixes:       parentPrefixesToString(claim.Spec.ParentPrefixes),
+		ParentIpRange:        parentIpRangeToString(claim.Spec.ParentIpRange),
 	}
-	return fmt.Sprintf("%x", sha1.Sum([]byte(rd.Namespace+rd.Name+rd.ParentPrefix+rd.Tenant)))
+	return rd.ComputeHash()
+}
+
+// generateIndexedIpAddressRestorationHash computes the restoration hash of the IP Address with the
+// given index of an IpAddressClaim with a count greater than 1, using the name of its IpAddress CR.
+// For index 0 it is the restoration hash of the IpAddressClaim.
+func generateIndexedIpAddressRestorationHash(claim *netboxv1.IpAddressClaim, index int) string {
+	rd := IpAddressClaimRestorationData{
+		Namespace:            claim.Namespace,
+		Name:                 indexedName(claim.Name, index),
+		ParentPrefix:         claim.Spec.ParentPrefix,
+		Tenant:               claim.Spec.Tenant,
+		ParentPrefixSelector: parentPrefixSelectorToString(claim.Spec.ParentPrefixSelector),
+		ParentPrefixes:       parentPrefixesToString(claim.Spec.ParentPrefixes),
+		ParentIpRange:        parentIpRangeToString(claim.Spec.ParentIpRange),
+	}
+	return rd.ComputeHash()
+}
+
+// generateDualStackIpAddressRestorationHash computes the restoration hash of the IP Address
+// of the other IP family in dual-stack mode, using the name of the second IpAddress CR
+func generateDualStackIpAddressRestorationHash(claim *netboxv1.IpAddressClaim) string {
+	rd := IpAddressClaimRestorationData{
+		Namespace: claim.Namespace,
+		Name:      dualStackName(claim.Name),
+		Tenant:    claim.Spec.Tenant,
+	}
+	if claim.Spec.DualStack != nil {
+		rd.ParentPrefix = claim.Spec.DualStack.ParentPrefix
+		rd.ParentPrefixSelector = parentPrefixSelectorToString(claim.Spec.DualStack.ParentPrefixSelector)
+	}
+	return rd.ComputeHash()
+}
+
+// selectedParentOfIpAddress returns the parent prefix or parent ip range the IpAddress with the given name
+// is claimed from, for the IpAddress of the other IP family in dual-stack mode it is the dual-stack one
+func selectedParentOfIpAddress(claim *netboxv1.IpAddressClaim, ipAddressName string) string {
+	if claim.Spec.DualStack != nil && ipAddressName == dualStackName(claim.Name) {
+		if claim.Status.DualStack == nil {
+			return ""
+		}
+		return claim.Status.DualStack.SelectedParentPrefix
+	}
+	if claim.Spec.ParentIpRange != nil {
+		return claim.Status.SelectedParentIpRange
+	}
+	return claim.Status.SelectedParentPrefix
+}
+
+// leaseLockParentOfIpAddress returns the parent whose lease is locked to claim the IpAddress with the given
+// name. For a parent ip range it is the parent prefix containing the ip range, so that the IpAddressClaims
+// claiming from the ip range serialize with the ones claiming the same ip addresses from the prefix.
+// The ip range itself is locked if it is not within a prefix in NetBox.
+func leaseLockParentOfIpAddress(claim *netboxv1.IpAddressClaim, ipAddressName string) string {
+	if claim.Spec.ParentIpRange != nil && claim.Status.SelectedParentPrefix != "" &&
+		(claim.Spec.DualStack == nil || ipAddressName != dualStackName(claim.Name)) {
+		return claim.Status.SelectedParentPrefix
+	}
+	return selectedParentOfIpAddress(claim, ipAddressName)
+}
+
+// generateIpAddressClaimModel returns the model to claim IP Addresses from the selected parent
+// prefix or parent ip range of the IpAddressClaim
+func generateIpAddressClaimModel(claim *netboxv1.IpAddressClaim) (*models.IPAddressClaim, error) {
+	ipAddressClaimModel := &models.IPAddressClaim{
+		ParentPrefix: claim.Status.SelectedParentPrefix,
+		Metadata: &models.NetboxMetadata{
+			Tenant: claim.Spec.Tenant,
+			Vrf:    claim.Spec.Vrf,
+		},
+	}
+	if claim.Spec.ParentIpRange != nil {
+		startAddress, endAddress, found := strings.Cut(claim.Status.SelectedParentIpRange, "-")
+		if !found {
+			return nil, fmt.Errorf("invalid parent ip range %#v", claim.Status.SelectedParentIpRange)
+		}
+		ipAddressClaimModel.ParentIpRange = &models.IpRange{
+			StartAddress: startAddress,
+			EndAddress:   endAddress,
+		}
+	}
+	return ipAddressClaimModel, nil
+}
+
+// parentIpRangeToString returns the parentIpRange of an IpAddressClaim as used in the restoration hash
+func parentIpRangeToString(parentIpRange *netboxv1.IpAddressClaimParentIpRange) string {
+	if parentIpRange == nil {
+		return ""
+	}
+	if parentIpRange.Name != "" {
+		return parentIpRange.Name
+	}
+	return parentIpRange.StartAddress + "-" + parentIpRange.EndAddress
+}
+
+// leaseLockOwnerOfIpAddress returns the name used as owner of the parent prefix lease by the IpAddress
+// with the given name. The IpAddresses of a claim with a count greater than 1 are claimed together
+// and share the lease with their claim until it expires, see reconcileIpAddressCount.
+func leaseLockOwnerOfIpAddress(claim *netboxv1.IpAddressClaim, ipAddressName string) string {
+	if sharesLeaseLock(claim.Spec.Count) {
+		return claim.Name
+	}
+	return ipAddressName
 }
 
 type IpAddressClaimRestorationData struct {
 	// only use immutable fields
-	Namespace    string
-	Name         string
-	ParentPrefix string
-	Tenant       string
+	Namespace            string
+	Name                 string
+	ParentPrefix         string
+	Tenant               string
+	ParentPrefixSelector string
+	ParentPrefixes       string
+	ParentIpRange        string
+}
+
+func (rd *IpAddressClaimRestorationData) ComputeHash() string {
+	if rd == nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha1.Sum([]byte(rd.Namespace+rd.Name+rd.ParentPrefix+rd.Tenant+rd.ParentPrefixSelector+rd.ParentPrefixes+rd.ParentIpRange)))
 }
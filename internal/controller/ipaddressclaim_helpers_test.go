@@ -0,0 +1,363 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func testIpAddressClaimHash(t *testing.T, ipAddressClaim *netboxv1.IpAddressClaim, expectedHash string) {
+	generatedHash := generateIpAddressRestorationHash(ipAddressClaim)
+
+	if generatedHash != expectedHash {
+		t.Errorf("hash mismatch: expected %#v, got %#v from %#v", expectedHash, generatedHash, ipAddressClaim)
+	}
+}
+
+func TestBackwardCompatibilityOfGenerateIpAddressRestorationHash(t *testing.T) {
+	// concatenated string = "defaultipaddressclaim-sample2.0.0.0/16Dunder-Mifflin, Inc."
+	ipAddressClaim := &netboxv1.IpAddressClaim{
+		Spec: netboxv1.IpAddressClaimSpec{
+			ParentPrefix: "2.0.0.0/16",
+			Tenant:       "Dunder-Mifflin, Inc.",
+		},
+	}
+	ipAddressClaim.Namespace = "default"
+	ipAddressClaim.Name = "ipaddressclaim-sample"
+
+	testIpAddressClaimHash(t, ipAddressClaim, "ab1d832876b8cf1d8210485d9671f770a433f087")
+}
+
+func TestGenerateIpAddressRestorationHashWithParentPrefixSelector(t *testing.T) {
+	// concatenated string = "defaultipaddressclaim-sampleMY_TENANTenvironment_Production_family_IPv4"
+	ipAddressClaim := &netboxv1.IpAddressClaim{
+		Spec: netboxv1.IpAddressClaimSpec{
+			ParentPrefixSelector: map[string]string{
+				"family":      "IPv4",
+				"environment": "Production",
+			},
+			Tenant: "MY_TENANT",
+		},
+		Status: netboxv1.IpAddressClaimStatus{
+			SelectedParentPrefix: "10.0.0.0/24", // not used, the selected parent prefix is not part of the hash
+		},
+	}
+	ipAddressClaim.Namespace = "default"
+	ipAddressClaim.Name = "ipaddressclaim-sample"
+
+	testIpAddressClaimHash(t, ipAddressClaim, "46c9d4e2eeafd96087c713c4ef40c0df65c61b23")
+}
+
+func TestGenerateIpAddressRestorationHashWithParentIpRange(t *testing.T) {
+	// concatenated string = "defaultipaddressclaim-sampleDunder-Mifflin, Inc.iprange-sample"
+	ipAddressClaim := &netboxv1.IpAddressClaim{
+		Spec: netboxv1.IpAddressClaimSpec{
+			ParentIpRange: &netboxv1.IpAddressClaimParentIpRange{
+				Name: "iprange-sample",
+			},
+			Tenant: "Dunder-Mifflin, Inc.",
+		},
+		Status: netboxv1.IpAddressClaimStatus{
+			SelectedParentIpRange: "2.0.0.10/16-2.0.0.20/16", // not used, the resolved ip range is not part of the hash
+		},
+	}
+	ipAddressClaim.Namespace = "default"
+	ipAddressClaim.Name = "ipaddressclaim-sample"
+
+	testIpAddressClaimHash(t, ipAddressClaim, "befd197f498117a6318a8ec2c5987f69c936d5a9")
+}
+
+func TestGenerateIpAddressClaimModelWithParentIpRange(t *testing.T) {
+	ipAddressClaim := &netboxv1.IpAddressClaim{
+		Spec: netboxv1.IpAddressClaimSpec{
+			ParentIpRange: &netboxv1.IpAddressClaimParentIpRange{
+				StartAddress: "2.0.0.10/16",
+				EndAddress:   "2.0.0.20/16",
+			},
+			Tenant: "Dunder-Mifflin, Inc.",
+		},
+		Status: netboxv1.IpAddressClaimStatus{
+			SelectedParentIpRange: "2.0.0.10/16-2.0.0.20/16",
+		},
+	}
+	ipAddressClaim.Name = "ipaddressclaim-sample"
+
+	if got := selectedParentOfIpAddress(ipAddressClaim, "ipaddressclaim-sample"); got != "2.0.0.10/16-2.0.0.20/16" {
+		t.Errorf("expected the parent ip range 2.0.0.10/16-2.0.0.20/16, got %#v", got)
+	}
+
+	ipAddressClaimModel, err := generateIpAddressClaimModel(ipAddressClaim)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ipAddressClaimModel.ParentIpRange == nil ||
+		ipAddressClaimModel.ParentIpRange.StartAddress != "2.0.0.10/16" ||
+		ipAddressClaimModel.ParentIpRange.EndAddress != "2.0.0.20/16" {
+		t.Errorf("unexpected parent ip range %#v", ipAddressClaimModel.ParentIpRange)
+	}
+	if ipAddressClaimModel.Metadata.Tenant != "Dunder-Mifflin, Inc." {
+		t.Errorf("unexpected tenant %#v", ipAddressClaimModel.Metadata.Tenant)
+	}
+
+	ipAddressClaim.Status.SelectedParentIpRange = "2.0.0.10/16"
+	if _, err := generateIpAddressClaimModel(ipAddressClaim); err == nil {
+		t.Errorf("expected an error for an invalid parent ip range")
+	}
+}
+
+func TestGenerateDualStackIpAddressRestorationHash(t *testing.T) {
+	// concatenated string = "defaultipaddressclaim-sample-dualstack2001:db8::/64Dunder-Mifflin, Inc."
+	ipAddressClaim := &netboxv1.IpAddressClaim{
+		Spec: netboxv1.IpAddressClaimSpec{
+			ParentPrefix: "2.0.0.0/16",
+			Tenant:       "Dunder-Mifflin, Inc.",
+			DualStack: &netboxv1.IpAddressClaimDualStackSpec{
+				ParentPrefix: "2001:db8::/64",
+			},
+		},
+	}
+	ipAddressClaim.Namespace = "default"
+	ipAddressClaim.Name = "ipaddressclaim-sample"
+
+	expectedHash := "f7b752551146d40634def2ade9d43edd462ea1b4"
+	if generatedHash := generateDualStackIpAddressRestorationHash(ipAddressClaim); generatedHash != expectedHash {
+		t.Errorf("hash mismatch: expected %#v, got %#v from %#v", expectedHash, generatedHash, ipAddressClaim)
+	}
+
+	// the hash of the first ip address is not affected by the dual-stack mode
+	testIpAddressClaimHash(t, ipAddressClaim, "ab1d832876b8cf1d8210485d9671f770a433f087")
+}
+
+func TestGenerateIndexedIpAddressRestorationHash(t *testing.T) {
+	ipAddressClaim := &netboxv1.IpAddressClaim{
+		Spec: netboxv1.IpAddressClaimSpec{
+			ParentPrefix: "2.0.0.0/16",
+			Tenant:       "Dunder-Mifflin, Inc.",
+			Count:        3,
+		},
+	}
+	ipAddressClaim.Namespace = "default"
+	ipAddressClaim.Name = "ipaddressclaim-sample"
+
+	// the hash of the ip address with index 0 is the one of the claim
+	if generatedHash := generateIndexedIpAddressRestorationHash(ipAddressClaim, 0); generatedHash != "ab1d832876b8cf1d8210485d9671f770a433f087" {
+		t.Errorf("hash mismatch for index 0: got %#v", generatedHash)
+	}
+
+	// concatenated string = "defaultipaddressclaim-sample-12.0.0.0/16Dunder-Mifflin, Inc."
+	if generatedHash := generateIndexedIpAddressRestorationHash(ipAddressClaim, 1); generatedHash != "700006b68268ebaab6a17bdcd55f5a6b3f73b3bc" {
+		t.Errorf("hash mismatch for index 1: got %#v", generatedHash)
+	}
+}
+
+func TestSelectedParentPrefixOfIpAddress(t *testing.T) {
+	ipAddressClaim := &netboxv1.IpAddressClaim{
+		Spec: netboxv1.IpAddressClaimSpec{
+			ParentPrefix: "2.0.0.0/16",
+			DualStack: &netboxv1.IpAddressClaimDualStackSpec{
+				ParentPrefix: "2001:db8::/64",
+			},
+		},
+		Status: netboxv1.IpAddressClaimStatus{
+			SelectedParentPrefix: "2.0.0.0/16",
+		},
+	}
+	ipAddressClaim.Name = "ipaddressclaim-sample"
+
+	if got := selectedParentOfIpAddress(ipAddressClaim, "ipaddressclaim-sample"); got != "2.0.0.0/16" {
+		t.Errorf("expected the parent prefix 2.0.0.0/16 for the first ip address, got %#v", got)
+	}
+	if got := selectedParentOfIpAddress(ipAddressClaim, "ipaddressclaim-sample-dualstack"); got != "" {
+		t.Errorf("expected no parent prefix for the dual-stack ip address before its selection, got %#v", got)
+	}
+
+	ipAddressClaim.Status.DualStack = &netboxv1.IpAddressClaimDualStackStatus{SelectedParentPrefix: "2001:db8::/64"}
+	if got := selectedParentOfIpAddress(ipAddressClaim, "ipaddressclaim-sample-dualstack"); got != "2001:db8::/64" {
+		t.Errorf("expected the parent prefix 2001:db8::/64 for the dual-stack ip address, got %#v", got)
+	}
+}
+
+func TestVerifyOtherIpFamily(t *testing.T) {
+	tests := []struct {
+		cidr          string
+		dualStackCidr string
+		wantErr       bool
+	}{
+		{cidr: "10.0.0.1/32", dualStackCidr: "2001:db8::/64", wantErr: false},
+		{cidr: "2001:db8::1/128", dualStackCidr: "10.0.0.0/24", wantErr: false},
+		{cidr: "10.0.0.1/32", dualStackCidr: "10.0.1.0/24", wantErr: true},
+		{cidr: "2001:db8::1/128", dualStackCidr: "2001:db8:1::/64", wantErr: true},
+		{cidr: "10.0.0.1/32", dualStackCidr: "invalid", wantErr: true},
+	}
+	for _, tt := range tests {
+		err := verifyOtherIpFamily(tt.cidr, tt.dualStackCidr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("verifyOtherIpFamily(%#v, %#v) returned %v, expected an error: %v", tt.cidr, tt.dualStackCidr, err, tt.wantErr)
+		}
+	}
+}
+
+func TestIndexOfIndexedName(t *testing.T) {
+	for index := 0; index < 3; index++ {
+		if got := indexOfIndexedName("claim", indexedName("claim", index)); got != index {
+			t.Errorf("expected index %d for %#v, got %d", index, indexedName("claim", index), got)
+		}
+	}
+
+	for _, name := range []string{"other", "claim-dualstack", "claim-0", "claim-01", "claim--1", "claim-"} {
+		if got := indexOfIndexedName("claim", name); got != -1 {
+			t.Errorf("expected no index for %#v, got %d", name, got)
+		}
+	}
+}
+
+func TestCheckNameConflict(t *testing.T) {
+	newClaim := func(name string, uid types.UID) *netboxv1.IpAddressClaim {
+		claim := &netboxv1.IpAddressClaim{}
+		claim.Name = name
+		claim.UID = uid
+		return claim
+	}
+	newIpAddress := func(name string, controller *netboxv1.IpAddressClaim) *netboxv1.IpAddress {
+		ipAddress := &netboxv1.IpAddress{}
+		ipAddress.Name = name
+		if controller != nil {
+			isController := true
+			ipAddress.OwnerReferences = []metav1.OwnerReference{{Kind: "IpAddressClaim", Name: controller.Name, UID: controller.UID, Controller: &isController}}
+		}
+		return ipAddress
+	}
+
+	// the claim "pool" with count 2 and the claim "pool-1" both use the name "pool-1"
+	pool := newClaim("pool", "uid-pool")
+	pool1 := newClaim("pool-1", "uid-pool-1")
+
+	if err := checkNameConflict(ipAddressKind, newIpAddress("pool-1", pool), pool, false); err != nil {
+		t.Errorf("expected no conflict for the IpAddress of the claim, got %v", err)
+	}
+
+	err := checkNameConflict(ipAddressKind, newIpAddress("pool-1", pool1), pool, false)
+	if !errors.Is(err, errNameConflict) {
+		t.Fatalf("expected a name conflict, got %v", err)
+	}
+	if err.Error() != "name conflict: IpAddress pool-1 is controlled by IpAddressClaim pool-1" {
+		t.Errorf("unexpected message %#v", err.Error())
+	}
+
+	if err := checkNameConflict(ipAddressKind, newIpAddress("pool-1", pool), pool1, true); !errors.Is(err, errNameConflict) {
+		t.Errorf("expected a name conflict for the claim named after the IpAddress, got %v", err)
+	}
+
+	// an IpAddress without controller is only adopted by the claim named after it
+	if err := checkNameConflict(ipAddressKind, newIpAddress("pool-1", nil), pool1, true); err != nil {
+		t.Errorf("expected the IpAddress without controller to be adopted, got %v", err)
+	}
+	if err := checkNameConflict(ipAddressKind, newIpAddress("pool-1", nil), pool, false); !errors.Is(err, errNameConflict) {
+		t.Errorf("expected a name conflict for the IpAddress without controller, got %v", err)
+	}
+}
+
+func TestLeaseLockOfIpAddress(t *testing.T) {
+	claim := &netboxv1.IpAddressClaim{Spec: netboxv1.IpAddressClaimSpec{Count: 3}}
+	claim.Name = "claim"
+
+	// all IpAddresses of the claim share the lease, including the one with index 0 which is
+	// named after the claim, none of them unlocks it before all of them are reserved
+	for index := 0; index < 3; index++ {
+		if owner := leaseLockOwnerOfIpAddress(claim, indexedName(claim.Name, index)); owner != "claim" {
+			t.Errorf("expected the claim as owner of the lease of index %d, got %#v", index, owner)
+		}
+	}
+	if !sharesLeaseLock(claim.Spec.Count) {
+		t.Errorf("expected the IpAddresses of a claim with count 3 to share the lease")
+	}
+
+	claim.Spec.Count = 1
+	if owner := leaseLockOwnerOfIpAddress(claim, "claim"); owner != "claim" {
+		t.Errorf("expected the IpAddress as owner of the lease, got %#v", owner)
+	}
+	if owner := leaseLockOwnerOfIpAddress(claim, "claim-dualstack"); owner != "claim-dualstack" {
+		t.Errorf("expected the dual-stack IpAddress as owner of the lease, got %#v", owner)
+	}
+	if sharesLeaseLock(claim.Spec.Count) {
+		t.Errorf("expected the IpAddress of a claim with count 1 to unlock the lease")
+	}
+}
+
+func TestLeaseLockParentOfIpAddress(t *testing.T) {
+	claim := &netboxv1.IpAddressClaim{Spec: netboxv1.IpAddressClaimSpec{
+		ParentIpRange: &netboxv1.IpAddressClaimParentIpRange{StartAddress: "10.0.0.10/24", EndAddress: "10.0.0.20/24"},
+	}}
+	claim.Name = "claim"
+	claim.Status.SelectedParentIpRange = "10.0.0.10/24-10.0.0.20/24"
+
+	// the ip range is locked if it is not within a prefix
+	if parent := leaseLockParentOfIpAddress(claim, "claim"); parent != "10.0.0.10/24-10.0.0.20/24" {
+		t.Errorf("expected the ip range to be locked, got %#v", parent)
+	}
+
+	// the prefix containing the ip range is locked, the same lease as for a claim with this parent prefix
+	claim.Status.SelectedParentPrefix = "10.0.0.0/24"
+	if parent := leaseLockParentOfIpAddress(claim, "claim"); parent != "10.0.0.0/24" {
+		t.Errorf("expected the prefix of the ip range to be locked, got %#v", parent)
+	}
+	if name := convertCIDRToLeaseLockName(leaseLockParentOfIpAddress(claim, "claim")); name != convertCIDRToLeaseLockName("10.0.0.0/24") {
+		t.Errorf("expected the lease of the parent prefix, got %#v", name)
+	}
+
+	claim.Spec.DualStack = &netboxv1.IpAddressClaimDualStackSpec{ParentPrefix: "2001:db8::/64"}
+	claim.Status.DualStack = &netboxv1.IpAddressClaimDualStackStatus{SelectedParentPrefix: "2001:db8::/64"}
+	if parent := leaseLockParentOfIpAddress(claim, "claim-dualstack"); parent != "2001:db8::/64" {
+		t.Errorf("expected the dual-stack parent prefix to be locked, got %#v", parent)
+	}
+}
+
+func TestGenerateDnsName(t *testing.T) {
+	tests := []struct {
+		dnsName  string
+		expected string
+		wantErr  bool
+	}{
+		{dnsName: "", expected: ""},
+		{dnsName: "web.k8s.example.com", expected: "web.k8s.example.com"},
+		{dnsName: "{{.Name}}.{{.Namespace}}.k8s.example.com", expected: "web-1.default.k8s.example.com"},
+		{dnsName: `{{index .Labels "app"}}.k8s.example.com`, expected: "frontend.k8s.example.com"},
+		{dnsName: `{{index .Labels "missing"}}.k8s.example.com`, wantErr: true},
+		{dnsName: "{{.Name}.k8s.example.com", wantErr: true},
+		{dnsName: "{{.Name}}_{{.Namespace}}.k8s.example.com", wantErr: true},
+		{dnsName: "Web.k8s.example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		claim := &netboxv1.IpAddressClaim{Spec: netboxv1.IpAddressClaimSpec{DnsName: tt.dnsName}}
+		claim.Name = "web-1"
+		claim.Namespace = "default"
+		claim.Labels = map[string]string{"app": "frontend"}
+
+		actual, err := generateDnsName(claim)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("generateDnsName(%#v) returned %v, expected an error: %v", tt.dnsName, err, tt.wantErr)
+		}
+		if actual != tt.expected {
+			t.Errorf("generateDnsName(%#v) returned %#v, expected %#v", tt.dnsName, actual, tt.expected)
+		}
+	}
+}
@@ -28,7 +28,7 @@ import (
 	"time"
 
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
-	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/netbox-community/netbox-operator/pkg/scheduler"
@@ -49,12 +49,13 @@ import (
 
 const IpRangeFinalizerName = "iprange.netbox.dev/finalizer"
 const IPRManagedCustomFieldsAnnotationName = "iprange.netbox.dev/managed-custom-fields"
+const IPRManagedTagsAnnotationName = "iprange.netbox.dev/managed-tags"
 
 // IpRangeReconciler reconciles a IpRange object
 type IpRangeReconciler struct {
 	client.Client
 	Scheme              *runtime.Scheme
-	NetboxClient        *api.NetboxCompositeClient
+	NetboxClients       *api.ClientRegistry
 	EventStatusRecorder *EventStatusRecorder
 	OperatorNamespace   string
 	RestConfig          *rest.Config
@@ -91,6 +92,18 @@ func (r *IpRangeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		logger.Info("reconcile loop finished")
 	}()
 
+	// resolve the client of the NetBox instance the resource is managed in
+	netboxClient, err := r.NetboxClients.ClientFor(ctx, o.Spec.Connection)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer r.EventStatusRecorder.ReportTokenRejected(o, netboxClient)
+
+	// short-circuit while the circuit breaker of NetBox is open instead of piling up failing requests
+	if err := netboxClient.Available(); err != nil {
+		return ctrl.Result{RequeueAfter: netboxClient.RetryAfter()}, NewDomainError("%w", err)
+	}
+
 	// if being deleted
 	if !o.DeletionTimestamp.IsZero() {
 		if !controllerutil.ContainsFinalizer(o, IpRangeFinalizerName) {
@@ -101,7 +114,7 @@ func (r *IpRangeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 			if o.Status.IpRangeId > math.MaxInt32 {
 				return ctrl.Result{}, fmt.Errorf("reconciliation of ip ranges with id's larger than 2147483647 is not supported")
 			}
-			if err := r.NetboxClient.DeleteIpRange(ctx, int32(o.Status.IpRangeId)); err != nil {
+			if err := netboxClient.DeleteIpRange(ctx, int32(o.Status.IpRangeId)); err != nil {
 				return ctrl.Result{}, NewDomainError("failed to delete ip range in netbox: %w", err)
 			}
 		}
@@ -129,29 +142,34 @@ func (r *IpRangeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 			return ctrl.Result{}, err
 		}
 
-		ll, err = leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, owner)
-		if err != nil {
-			return ctrl.Result{}, err
-		}
+		if parentPrefix != msgCanNotInferIpRangeParentPrefix {
+			// we can't restore from the restoration hash
+
+			ll, err = leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, owner)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
 
-		var lockCtx context.Context
-		lockCtx, cancelLock = context.WithTimeout(ctx, lockAcquireTimeout)
-		defer func() {
-			if cancelLock != nil {
-				cancelLock()
+			var lockCtx context.Context
+			lockCtx, cancelLock = context.WithTimeout(ctx, lockAcquireTimeout)
+			defer func() {
+				if cancelLock != nil {
+					cancelLock()
+				}
+			}()
+
+			// create lock
+			locked := ll.TryLock(lockCtx)
+			if !locked {
+				metrics.LeaseLockContentionTotal.WithLabelValues(ipRangeKind, parentPrefix).Inc()
+				errorMsg := fmt.Sprintf("failed to lock parent prefix %s", parentPrefix)
+				r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "FailedToLockParentPrefix", errorMsg)
+				return ctrl.Result{
+					RequeueAfter: 2 * time.Second,
+				}, NewDomainError("%s", errorMsg)
 			}
-		}()
-
-		// create lock
-		locked := ll.TryLock(lockCtx)
-		if !locked {
-			errorMsg := fmt.Sprintf("failed to lock parent prefix %s", parentPrefix)
-			r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "FailedToLockParentPrefix", errorMsg)
-			return ctrl.Result{
-				RequeueAfter: 2 * time.Second,
-			}, NewDomainError("%s", errorMsg)
+			logger.V(4).Info(fmt.Sprintf("successfully locked parent prefix %s", parentPrefix))
 		}
-		logger.V(4).Info(fmt.Sprintf("successfully locked parent prefix %s", parentPrefix))
 	}
 
 	// 2. reserve or update ip range in netbox
@@ -161,12 +179,12 @@ func (r *IpRangeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{}, err
 	}
 
-	ipRangeModel, err := r.generateNetboxIpRangeModelFromIpRangeSpec(o, req, annotations[IPRManagedCustomFieldsAnnotationName])
+	ipRangeModel, err := r.generateNetboxIpRangeModelFromIpRangeSpec(o, req, annotations[IPRManagedCustomFieldsAnnotationName], annotations[IPRManagedTagsAnnotationName])
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	netboxIpRangeModel, statusUpToDate, err := r.NetboxClient.ReserveOrUpdateIpRange(ctx, ipRangeModel, o)
+	netboxIpRangeModel, statusUpToDate, err := netboxClient.ReserveOrUpdateIpRange(ctx, ipRangeModel, o)
 	if err != nil {
 		overlapErr := &api.OverlapError{}
 		if (errors.Is(err, api.ErrRestorationHashMismatch) ||
@@ -204,6 +222,11 @@ func (r *IpRangeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		return ctrl.Result{}, NewDomainError("failed to generate managed custom fields annotation: %w", err)
 	}
 
+	annotations[IPRManagedTagsAnnotationName], err = generateManagedTagsAnnotation(o.Spec.Tags)
+	if err != nil {
+		return ctrl.Result{}, NewDomainError("failed to generate managed tags annotation: %w", err)
+	}
+
 	// snapshot before annotation mutation for merge-patch
 	patch := client.MergeFrom(o.DeepCopy())
 
@@ -220,7 +243,7 @@ func (r *IpRangeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 
 	// update status fields (set after r.Patch to avoid being overwritten by API response)
 	o.Status.IpRangeId = int64(netboxIpRangeModel.GetId())
-	o.Status.IpRangeUrl = config.GetBaseUrl() + "/ipam/ip-ranges/" + strconv.FormatInt(int64(netboxIpRangeModel.GetId()), 10)
+	o.Status.IpRangeUrl = netboxClient.BaseUrl() + "/ipam/ip-ranges/" + strconv.FormatInt(int64(netboxIpRangeModel.GetId()), 10)
 	if netboxIpRangeModel.LastUpdated.IsSet() {
 		o.Status.LastUpdated = metav1.NewTime(*netboxIpRangeModel.LastUpdated.Get())
 	}
@@ -252,6 +275,12 @@ func (r *IpRangeReconciler) updateStatus(ctx context.Context, o *netboxv1.IpRang
 	logger.V(4).Info("updating iprange status")
 
 	switch {
+	case errors.Is(reconcileErr, api.ErrNetboxUnavailable):
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionReadyFalseNetBoxUnavailable, corev1.EventTypeWarning, reconcileErr)
+	case o.DeletionTimestamp.IsZero() && errors.Is(reconcileErr, api.ErrInvalidCustomFieldValue):
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionReadyFalseInvalidCustomField, corev1.EventTypeWarning, reconcileErr)
 	case !o.DeletionTimestamp.IsZero() && reconcileErr != nil:
 		r.EventStatusRecorder.Report(ctx, o,
 			netboxv1.ConditionIpRangeReadyFalseDeletionFailed, corev1.EventTypeWarning, reconcileErr)
@@ -285,7 +314,12 @@ func (r *IpRangeReconciler) updateStatus(ctx context.Context, o *netboxv1.IpRang
 	return IgnoreDomainError(result, err)
 }
 
-func (r *IpRangeReconciler) generateNetboxIpRangeModelFromIpRangeSpec(o *netboxv1.IpRange, req ctrl.Request, lastIpRangeMetadata string) (*models.IpRange, error) {
+func (r *IpRangeReconciler) generateNetboxIpRangeModelFromIpRangeSpec(o *netboxv1.IpRange, req ctrl.Request, lastIpRangeMetadata string, lastManagedTags string) (*models.IpRange, error) {
+	managedTags, err := parseManagedTagsAnnotation(lastManagedTags)
+	if err != nil {
+		return nil, err
+	}
+
 	// unmarshal lastIpRangeMetadata json string to map[string]string
 	lastAppliedCustomFields := make(map[string]string)
 	if lastIpRangeMetadata != "" {
@@ -324,6 +358,11 @@ func (r *IpRangeReconciler) generateNetboxIpRangeModelFromIpRangeSpec(o *netboxv
 			Custom:      netboxCustomFields,
 			Description: description,
 			Tenant:      o.Spec.Tenant,
+			Vrf:         o.Spec.Vrf,
+			Status:      o.Spec.Status,
+			Role:        o.Spec.Role,
+			Tags:        o.Spec.Tags,
+			ManagedTags: managedTags,
 		},
 	}, nil
 }
@@ -341,11 +380,16 @@ func (r *IpRangeReconciler) getLeaseLockerNSNandOwner(ctx context.Context, o *ne
 		return types.NamespacedName{}, "", "", err
 	}
 
+	if ipRangeClaim.Status.SelectedParentPrefix == "" {
+		// the parent prefix is not selected
+		return types.NamespacedName{}, "", "", NewDomainError("the parent prefix is not selected")
+	}
+
 	// get name of parent prefix
 	leaseLockerNSN := types.NamespacedName{
-		Name:      convertCIDRToLeaseLockName(ipRangeClaim.Spec.ParentPrefix),
+		Name:      convertCIDRToLeaseLockName(ipRangeClaim.Status.SelectedParentPrefix),
 		Namespace: r.OperatorNamespace,
 	}
 
-	return leaseLockerNSN, orLookupKey.String(), ipRangeClaim.Spec.ParentPrefix, nil
+	return leaseLockerNSN, orLookupKey.String(), ipRangeClaim.Status.SelectedParentPrefix, nil
 }
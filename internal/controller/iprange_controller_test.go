@@ -35,7 +35,7 @@ var _ = Describe("IpRange Controller", func() {
 	Context("When generating NetBox IpRange  Model form IpRangeSpec", func() {
 		// dummy reconciler
 		ipRangeRecondiler = &IpRangeReconciler{
-			NetboxClient: &api.NetboxCompositeClient{},
+			NetboxClients: api.NewClientRegistry(nil, nil, "", &api.NetboxCompositeClient{}),
 		}
 
 		// default IpRange
@@ -50,12 +50,16 @@ var _ = Describe("IpRange Controller", func() {
 				Description:  "a description",
 				Tenant:       "a tenant",
 				CustomFields: map[string]string{"custom_field_2": "valueToBeSet"},
+				Tags:         []string{"tag-to-be-set"},
 			}}
 		ipRange.Name = "test-claim"
 
 		// default managedCustomFieldsAnnotation
 		managedCustomFieldsAnnotation := "{\"custom_field_1\":\"valueToBeRemoved\"}"
 
+		// default managedTagsAnnotation
+		managedTagsAnnotation := "[\"tag-to-be-removed\"]"
+
 		// default request
 		req := reconcile.Request{
 			NamespacedName: client.ObjectKey{
@@ -65,7 +69,7 @@ var _ = Describe("IpRange Controller", func() {
 		}
 
 		It("should create the correct ip range model", func() {
-			ipRangeModel, err := ipRangeRecondiler.generateNetboxIpRangeModelFromIpRangeSpec(ipRange, req, managedCustomFieldsAnnotation)
+			ipRangeModel, err := ipRangeRecondiler.generateNetboxIpRangeModelFromIpRangeSpec(ipRange, req, managedCustomFieldsAnnotation, managedTagsAnnotation)
 
 			Expect(ipRangeModel).To(Equal(&models.IpRange{
 				Metadata: &models.NetboxMetadata{
@@ -73,6 +77,8 @@ var _ = Describe("IpRange Controller", func() {
 					Description: "default/test-claim // a description // managed by netbox-operator, please don't edit it in Netbox unless you know what you're doing",
 					Custom:      map[string]string{"custom_field_2": "valueToBeSet", "custom_field_1": ""},
 					Tenant:      "a tenant",
+					Tags:        []string{"tag-to-be-set"},
+					ManagedTags: []string{"tag-to-be-removed"},
 				},
 				StartAddress: "1.0.0.1/32",
 				EndAddress:   "1.0.0.5/32",
@@ -83,7 +89,15 @@ var _ = Describe("IpRange Controller", func() {
 
 		It("should return error if parsing of annotation fails", func() {
 			invalidManagedCustomFieldsAnnotation := "{:\"valueToBeRemoved\"}"
-			ipRangeModel, err := ipRangeRecondiler.generateNetboxIpRangeModelFromIpRangeSpec(ipRange, req, invalidManagedCustomFieldsAnnotation)
+			ipRangeModel, err := ipRangeRecondiler.generateNetboxIpRangeModelFromIpRangeSpec(ipRange, req, invalidManagedCustomFieldsAnnotation, managedTagsAnnotation)
+
+			Expect(ipRangeModel).To(BeNil())
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return error if parsing of managed tags annotation fails", func() {
+			ipRangeModel, err := ipRangeRecondiler.generateNetboxIpRangeModelFromIpRangeSpec(ipRange, req, managedCustomFieldsAnnotation, "tag-to-be-removed")
 
 			Expect(ipRangeModel).To(BeNil())
 
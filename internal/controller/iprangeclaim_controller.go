@@ -24,6 +24,7 @@ import (
 	"time"
 
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/netbox-community/netbox-operator/pkg/scheduler"
@@ -43,11 +44,15 @@ import (
 
 const IpRangeClaimFinalizerName = "iprangeclaim.netbox.dev/finalizer"
 
+const (
+	msgCanNotInferIpRangeParentPrefix = "IP range restored from hash, cannot infer the parent prefix"
+)
+
 // IpRangeClaimReconciler reconciles a IpRangeClaim object
 type IpRangeClaimReconciler struct {
 	client.Client
 	Scheme              *runtime.Scheme
-	NetboxClient        *api.NetboxCompositeClient
+	NetboxClients       *api.ClientRegistry
 	EventStatusRecorder *EventStatusRecorder
 	OperatorNamespace   string
 	RestConfig          *rest.Config
@@ -109,6 +114,97 @@ func (r *IpRangeClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		logger.Info("reconcile loop finished")
 	}()
 
+	// resolve the client of the NetBox instance the resource is managed in
+	netboxClient, err := r.NetboxClients.ClientFor(ctx, o.Spec.Connection)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer r.EventStatusRecorder.ReportTokenRejected(o, netboxClient)
+
+	// short-circuit while the circuit breaker of NetBox is open instead of piling up failing requests
+	if err := netboxClient.Available(); err != nil {
+		return ctrl.Result{RequeueAfter: netboxClient.RetryAfter()}, NewDomainError("%w", err)
+	}
+
+	// compute and assign the parent prefix if required
+	// Status.SelectedParentPrefix stores the selected parent prefix and is the
+	// source of truth for future parent prefix references
+	if o.Status.SelectedParentPrefix == "" /* parent prefix not yet selected/assigned */ {
+		if o.Spec.ParentPrefix != "" {
+			o.Status.SelectedParentPrefix = o.Spec.ParentPrefix
+
+			// set status, and condition field
+			msg := fmt.Sprintf("parentPrefix is provided in CR: %v", o.Status.SelectedParentPrefix)
+			r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+		} else if len(o.Spec.ParentPrefixSelector) > 0 || len(o.Spec.ParentPrefixes) > 0 {
+			// since the parent prefix is not part of the restoration hash computation
+			// we can quickly check to see if the ip range with the restoration hash is matched in NetBox
+			h := generateIpRangeRestorationHash(o)
+			canBeRestored, err := netboxClient.RestoreExistingIpRangeByHash(ctx, h, o.Spec.Vrf)
+			if err != nil {
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+
+			if canBeRestored != nil {
+				// the ip range will be restored directly, as for prefix claims the
+				// original parent prefix can't be inferred in this case
+				o.Status.SelectedParentPrefix = msgCanNotInferIpRangeParentPrefix
+
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msgCanNotInferIpRangeParentPrefix)
+			} else if len(o.Spec.ParentPrefixes) > 0 {
+				// use the first entry of the ordered parent prefixes the ip range can be claimed from
+				i, err := selectParentPrefixFromList(o.Spec.ParentPrefixes, func(parentPrefix string) error {
+					_, err := netboxClient.GetAvailableIpRangeByClaim(
+						ctx,
+						&models.IpRangeClaim{
+							ParentPrefix: parentPrefix,
+							Size:         o.Spec.Size,
+							Metadata: &models.NetboxMetadata{
+								Tenant: o.Spec.Tenant,
+								Vrf:    o.Spec.Vrf,
+							},
+						})
+					return err
+				})
+				if err != nil {
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+				o.Status.SelectedParentPrefix = o.Spec.ParentPrefixes[i]
+
+				// set status, and condition field
+				msg := fmt.Sprintf("parentPrefix is selected from parentPrefixes[%d]: %v", i, o.Status.SelectedParentPrefix)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+			} else {
+				// fetch the prefixes matching the selector which can hold an ip range of the requested size
+				parentPrefixCandidates, err := netboxClient.GetAvailableIpRangeParentPrefixesBySelector(ctx, &o.Spec)
+				if err != nil {
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+				if len(parentPrefixCandidates) == 0 {
+					err := errors.New("no parent prefix found matching the parentPrefixSelector")
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+
+				// the candidates are in the order returned by NetBox, the first one is used
+				o.Status.SelectedParentPrefix = parentPrefixCandidates[0].Prefix
+
+				// set status, and condition field
+				msg := fmt.Sprintf("parentPrefix is selected: %v", o.Status.SelectedParentPrefix)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+			}
+		} else {
+			// this case should not be triggered anymore, as we have validation rules put in place on the CR
+			return ctrl.Result{}, NewDomainError("either ParentPrefixSelector, ParentPrefixes or ParentPrefix needs to be set")
+		}
+
+		// Persist SelectedParentPrefix to the API server before creating the
+		// IpRange CR, the IpRange controller reads it to lock the parent prefix.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	err = r.Get(ctx, ipRangeLookupKey, ipRange)
 	if err != nil {
 		// return error if not a notfound error
@@ -118,7 +214,7 @@ func (r *IpRangeClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 		logger.V(4).Info("iprange object matching iprange claim was not found, creating new iprange object")
 
-		ipRangeModel, cancelLock, res, err := r.restoreOrAssignIpRangeAndSetCondition(ctx, o)
+		ipRangeModel, cancelLock, res, err := r.restoreOrAssignIpRangeAndSetCondition(ctx, netboxClient, o)
 		if cancelLock != nil {
 			defer cancelLock()
 		}
@@ -127,7 +223,7 @@ func (r *IpRangeClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		}
 
 		// create the IpRange CR
-		ipRangeResource := generateIpRangeFromIpRangeClaim(ctx, o, ipRangeModel.StartAddress, ipRangeModel.EndAddress)
+		ipRangeResource := generateIpRangeFromIpRangeClaim(ctx, o, ipRangeModel.StartAddress, ipRangeModel.EndAddress, inheritedVrf(o.Spec.Vrf, ipRangeModel.Metadata))
 		err = controllerutil.SetControllerReference(o, ipRangeResource, r.Scheme)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -145,7 +241,7 @@ func (r *IpRangeClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	} else {
 		// update spec of IpRange object
 		logger.V(4).Info("update iprange resource")
-		ipRange.Spec = generateIpRangeSpec(o, ipRange.Spec.StartAddress, ipRange.Spec.EndAddress, logger)
+		ipRange.Spec = generateIpRangeSpec(o, ipRange.Spec.StartAddress, ipRange.Spec.EndAddress, ipRange.Spec.Vrf, logger)
 		err = controllerutil.SetControllerReference(o, ipRange, r.Scheme)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -199,6 +295,11 @@ func (r *IpRangeClaimReconciler) updateStatus(ctx context.Context, claim *netbox
 
 	logger.V(4).Info("updating iprangeclaim status")
 
+	if errors.Is(reconcileErr, api.ErrNetboxUnavailable) {
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionReadyFalseNetBoxUnavailable, corev1.EventTypeWarning, reconcileErr)
+		return result, err
+	}
+
 	// Fetch the latest IpRange object
 	ipRange := &netboxv1.IpRange{}
 	err = r.Client.Get(ctx, lookupKey, ipRange)
@@ -232,6 +333,9 @@ func (r *IpRangeClaimReconciler) updateStatus(ctx context.Context, claim *netbox
 			r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionIpRangeClaimReadyFalseStatusGen, corev1.EventTypeWarning, genErr)
 			return result, err
 		}
+		if statusBase.Status.IpRange == "" {
+			metrics.ObserveClaimAllocation(ipRangeClaimKind, claim.CreationTimestamp.Time)
+		}
 		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionIpRangeClaimReadyTrue, corev1.EventTypeNormal, nil)
 	} else {
 		logger.V(4).Info("iprange status ready false")
@@ -245,7 +349,7 @@ func (r *IpRangeClaimReconciler) tryLockOnParentPrefix(ctx context.Context, o *n
 	logger := log.FromContext(ctx)
 
 	leaseLockerNSN := types.NamespacedName{
-		Name:      convertCIDRToLeaseLockName(o.Spec.ParentPrefix),
+		Name:      convertCIDRToLeaseLockName(o.Status.SelectedParentPrefix),
 		Namespace: r.OperatorNamespace,
 	}
 
@@ -266,12 +370,13 @@ func (r *IpRangeClaimReconciler) tryLockOnParentPrefix(ctx context.Context, o *n
 	if !locked {
 		cancel()
 		// lock for parent prefix was not available, rescheduling
-		logger.Info(fmt.Sprintf("failed to lock parent prefix %s", o.Spec.ParentPrefix))
+		metrics.LeaseLockContentionTotal.WithLabelValues(ipRangeClaimKind, o.Status.SelectedParentPrefix).Inc()
+		logger.Info(fmt.Sprintf("failed to lock parent prefix %s", o.Status.SelectedParentPrefix))
 		r.EventStatusRecorder.Recorder().Eventf(o, corev1.EventTypeWarning, "FailedToLockParentPrefix", "failed to lock parent prefix %s",
-			o.Spec.ParentPrefix)
-		return nil, nil, ctrl.Result{RequeueAfter: 2 * time.Second}, NewDomainError("failed to lock parent prefix %s", o.Spec.ParentPrefix)
+			o.Status.SelectedParentPrefix)
+		return nil, nil, ctrl.Result{RequeueAfter: 2 * time.Second}, NewDomainError("failed to lock parent prefix %s", o.Status.SelectedParentPrefix)
 	}
-	logger.V(4).Info(fmt.Sprintf("successfully locked parent prefix %s", o.Spec.ParentPrefix))
+	logger.V(4).Info(fmt.Sprintf("successfully locked parent prefix %s", o.Status.SelectedParentPrefix))
 
 	cleanup := func() {
 		cancel()
@@ -300,6 +405,7 @@ func (r *IpRangeClaimReconciler) generateIpRangeClaimStatus(o *netboxv1.IpRangeC
 	}
 
 	return netboxv1.IpRangeClaimStatus{
+		SelectedParentPrefix:   o.Status.SelectedParentPrefix,
 		IpRange:                fmt.Sprintf("%s-%s", ipRange.Spec.StartAddress, ipRange.Spec.EndAddress),
 		IpRangeDotDecimal:      fmt.Sprintf("%s-%s", startAddressDotDecimal, endAddressDotDecimal),
 		IpAddresses:            ipAddresses,
@@ -313,34 +419,50 @@ func (r *IpRangeClaimReconciler) generateIpRangeClaimStatus(o *netboxv1.IpRangeC
 	}, nil
 }
 
-func (r *IpRangeClaimReconciler) restoreOrAssignIpRangeAndSetCondition(ctx context.Context, o *netboxv1.IpRangeClaim) (*models.IpRange, context.CancelFunc, ctrl.Result, error) {
+func (r *IpRangeClaimReconciler) restoreOrAssignIpRangeAndSetCondition(ctx context.Context, netboxClient *api.NetboxCompositeClient, o *netboxv1.IpRangeClaim) (*models.IpRange, context.CancelFunc, ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
-	ll, cancelLock, res, err := r.tryLockOnParentPrefix(ctx, o)
-	if err != nil || ll == nil {
-		return nil, nil, res, err
+	var cancelLock context.CancelFunc
+	if o.Status.SelectedParentPrefix != msgCanNotInferIpRangeParentPrefix {
+		// we can't restore from the restoration hash
+		ll, cleanup, res, err := r.tryLockOnParentPrefix(ctx, o)
+		if err != nil || ll == nil {
+			return nil, nil, res, err
+		}
+		cancelLock = cleanup
 	}
 
 	h := generateIpRangeRestorationHash(o)
-	ipRangeModel, err := r.NetboxClient.RestoreExistingIpRangeByHash(h)
+	ipRangeModel, err := netboxClient.RestoreExistingIpRangeByHash(ctx, h, o.Spec.Vrf)
 	if err != nil {
 		return nil, cancelLock, ctrl.Result{}, NewDomainError("%w", err)
 	}
+	metrics.ObserveRestoration(ipRangeClaimKind, ipRangeModel != nil)
 
 	if ipRangeModel == nil {
 		// ip range cannot be restored from netbox
 		// assign new available ip range
-		ipRangeModel, err = r.NetboxClient.GetAvailableIpRangeByClaim(
+		ipRangeModel, err = netboxClient.GetAvailableIpRangeByClaim(
 			ctx,
 			&models.IpRangeClaim{
-				ParentPrefix: o.Spec.ParentPrefix,
+				ParentPrefix: o.Status.SelectedParentPrefix,
 				Size:         o.Spec.Size,
 				Metadata: &models.NetboxMetadata{
 					Tenant: o.Spec.Tenant,
+					Vrf:    o.Spec.Vrf,
 				},
 			},
 		)
 		if err != nil {
+			observeParentExhausted(ipRangeClaimKind, o.Status.SelectedParentPrefix, err)
+			if ((errors.Is(err, api.ErrParentPrefixExhausted) || errors.Is(err, api.ErrNotEnoughConsecutiveIps)) && len(o.Spec.ParentPrefixSelector) > 0) ||
+				(isParentPrefixUnusableErr(err) && len(o.Spec.ParentPrefixes) > 0) {
+				// we reset the selected parent prefix, since the ip range doesn't fit into it anymore,
+				// the next reconcile loop selects the next candidate
+				o.Status.SelectedParentPrefix = ""
+				return nil, cancelLock, ctrl.Result{}, NewDomainError("ip range does not fit into parent prefix, will restart the parent prefix selection process: %w", err)
+			}
+
 			return nil, cancelLock, ctrl.Result{}, NewDomainError("%w", err)
 		}
 		logger.V(4).Info(fmt.Sprintf("ip range is not reserved in netbox, assigned new ip range: %s-%s", ipRangeModel.StartAddress, ipRangeModel.EndAddress))
@@ -43,7 +43,7 @@ var _ = Describe("IpRangeClaim Controller", func() {
 
 			claim.Name = "test-claim"
 
-			ipRange := generateIpRangeFromIpRangeClaim(ctx, claim, "1.0.0.1/32", "1.0.0.3/32")
+			ipRange := generateIpRangeFromIpRangeClaim(ctx, claim, "1.0.0.1/32", "1.0.0.3/32", "")
 			Expect(ipRange).To(Equal(&netboxv1.IpRange{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      claim.Name,
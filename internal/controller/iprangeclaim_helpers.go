@@ -21,6 +21,7 @@ import (
 	"crypto/sha1"
 	"fmt"
 	"net/netip"
+	"slices"
 
 	"github.com/go-logr/logr"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
@@ -29,19 +30,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-func generateIpRangeFromIpRangeClaim(ctx context.Context, claim *netboxv1.IpRangeClaim, startIp string, endIp string) *netboxv1.IpRange {
+func generateIpRangeFromIpRangeClaim(ctx context.Context, claim *netboxv1.IpRangeClaim, startIp string, endIp string, vrf string) *netboxv1.IpRange {
 	logger := log.FromContext(ctx)
 	ipRangeResource := &netboxv1.IpRange{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      claim.Name,
 			Namespace: claim.Namespace,
 		},
-		Spec: generateIpRangeSpec(claim, startIp, endIp, logger),
+		Spec: generateIpRangeSpec(claim, startIp, endIp, vrf, logger),
 	}
 	return ipRangeResource
 }
 
-func generateIpRangeSpec(claim *netboxv1.IpRangeClaim, startIp string, endIp string, logger logr.Logger) netboxv1.IpRangeSpec {
+// generateIpRangeSpec returns the spec of the IpRange of the claim, the vrf is the VRF of the claim
+// or the one inherited from the parent prefix
+func generateIpRangeSpec(claim *netboxv1.IpRangeClaim, startIp string, endIp string, vrf string, logger logr.Logger) netboxv1.IpRangeSpec {
 	// log a warning if the netboxOperatorRestorationHash name is a key in the customFields map of the IpRangeClaim
 	_, ok := claim.Spec.CustomFields[config.GetOperatorConfig().NetboxRestorationHashFieldName]
 	if ok {
@@ -60,31 +63,40 @@ func generateIpRangeSpec(claim *netboxv1.IpRangeClaim, startIp string, endIp str
 		StartAddress:     startIp,
 		EndAddress:       endIp,
 		Tenant:           claim.Spec.Tenant,
+		Vrf:              vrf,
+		Status:           claim.Spec.Status,
+		Role:             claim.Spec.Role,
 		CustomFields:     customFields,
+		Tags:             slices.Clone(claim.Spec.Tags),
 		Description:      claim.Spec.Description,
 		Comments:         claim.Spec.Comments,
 		PreserveInNetbox: claim.Spec.PreserveInNetbox,
+		Connection:       claim.Spec.Connection,
 	}
 }
 
 func generateIpRangeRestorationHash(claim *netboxv1.IpRangeClaim) string {
 	rd := IpRangeClaimRestorationData{
-		Namespace:    claim.Namespace,
-		Name:         claim.Name,
-		ParentPrefix: claim.Spec.ParentPrefix,
-		Tenant:       claim.Spec.Tenant,
-		Size:         fmt.Sprintf("%d", claim.Spec.Size),
+		Namespace:            claim.Namespace,
+		Name:                 claim.Name,
+		ParentPrefix:         claim.Spec.ParentPrefix,
+		Tenant:               claim.Spec.Tenant,
+		Size:                 fmt.Sprintf("%d", claim.Spec.Size),
+		ParentPrefixSelector: parentPrefixSelectorToString(claim.Spec.ParentPrefixSelector),
+		ParentPrefixes:       parentPrefixesToString(claim.Spec.ParentPrefixes),
 	}
-	return fmt.Sprintf("%x", sha1.Sum([]byte(rd.Namespace+rd.Name+rd.ParentPrefix+rd.Tenant+rd.Size)))
+	return fmt.Sprintf("%x", sha1.Sum([]byte(rd.Namespace+rd.Name+rd.ParentPrefix+rd.Tenant+rd.Size+rd.ParentPrefixSelector+rd.ParentPrefixes)))
 }
 
 type IpRangeClaimRestorationData struct {
 	// only use immutable fields
-	Namespace    string
-	Name         string
-	ParentPrefix string
-	Tenant       string
-	Size         string
+	Namespace            string
+	Name                 string
+	ParentPrefix         string
+	Tenant               string
+	Size                 string
+	ParentPrefixSelector string
+	ParentPrefixes       string
 }
 
 // ipsInRange returns all IP addresses from startAddr to endAddr (inclusive).
@@ -19,8 +19,28 @@ package controller
 import (
 	"testing"
 	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 )
 
+func TestBackwardCompatibilityOfGenerateIpRangeRestorationHash(t *testing.T) {
+	// concatenated string = "defaultiprangeclaim-sample2.0.0.0/16Dunder-Mifflin, Inc.3"
+	ipRangeClaim := &netboxv1.IpRangeClaim{
+		Spec: netboxv1.IpRangeClaimSpec{
+			ParentPrefix: "2.0.0.0/16",
+			Tenant:       "Dunder-Mifflin, Inc.",
+			Size:         3,
+		},
+	}
+	ipRangeClaim.Namespace = "default"
+	ipRangeClaim.Name = "iprangeclaim-sample"
+
+	expectedHash := "f46fe89d044fc405691cf51ade0a973e61584489"
+	if generatedHash := generateIpRangeRestorationHash(ipRangeClaim); generatedHash != expectedHash {
+		t.Errorf("hash mismatch: expected %#v, got %#v", expectedHash, generatedHash)
+	}
+}
+
 func TestIpsInRange_SingleIPv4(t *testing.T) {
 	ips, err := ipsInRange("10.0.0.1", "10.0.0.1")
 	if err != nil {
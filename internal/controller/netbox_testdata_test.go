@@ -19,10 +19,6 @@ package controller
 import (
 	"time"
 
-	"github.com/go-openapi/strfmt"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
 	v4client "github.com/netbox-community/go-netbox/v4"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
@@ -38,15 +34,15 @@ var namespace = "default"
 var description = "integration test"
 
 var comments = "integration test comment"
-var netboxIPLastUpdated = strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+var netboxIPLastUpdated = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
 var siteSlug = "mars-ip-claim"
 
 var ipAddress = "1.0.0.1/32"
-var ipAddressFamily = int64(api.IPv4Family)
+var ipAddressFamily = int32(api.IPv4Family)
 var parentPrefix = "1.0.0.0/28"
 
-var siteId = int64(2)
+var siteId = int32(2)
 var scopeType = "dcim.site"
 
 var tenantId = int32(1)
@@ -66,6 +62,13 @@ var customFieldsWithHashMismatch = map[string]interface{}{"example_field": "exam
 var netboxLabel = "Status"
 var value = "active"
 
+var vlanClaimName = "vlanclaim-test"
+var vlanGroupName = "k8s-vlans"
+var vlanGroupSlug = "k8s-vlans-slug"
+var vlanGroupId = int32(7)
+var vid = int32(100)
+var vlanId = int32(11)
+
 // -----------------------------
 // default CRs
 // -----------------------------
@@ -121,29 +124,51 @@ func defaultIpAddressClaimCR() *netboxv1.IpAddressClaim {
 	}
 }
 
+func defaultVlanClaimCR() *netboxv1.VlanClaim {
+	return &netboxv1.VlanClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vlanClaimName,
+			Namespace: namespace,
+		},
+		Spec: netboxv1.VlanClaimSpec{
+			VlanGroup:    vlanGroupName,
+			CustomFields: customFieldsCR,
+			Comments:     comments,
+			Description:  description,
+		},
+	}
+}
+
+func defaultVlanCR(preserveInNetbox bool, customFields map[string]string) *netboxv1.Vlan {
+	return &netboxv1.Vlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vlanClaimName,
+			Namespace: namespace,
+		},
+		Spec: netboxv1.VlanSpec{
+			Vid:              vid,
+			Name:             vlanClaimName,
+			CustomFields:     customFields,
+			Comments:         comments,
+			Description:      description,
+			PreserveInNetbox: preserveInNetbox,
+		},
+	}
+}
+
 // -----------------------------
 // netbox mock responses
 // -----------------------------
 
-var int32SiteId = int32(siteId)
-
 func mockedResponseScopeId() v4client.NullableInt32 {
-	return *v4client.NewNullableInt32(&int32SiteId)
+	return *v4client.NewNullableInt32(&siteId)
 }
 func mockedResponseScopeType() v4client.NullableString {
 	return *v4client.NewNullableString(&scopeType)
 }
 
-func mockedResponseNestedTenant() *netboxModels.NestedTenant {
-	return &netboxModels.NestedTenant{
-		Name: &tenant,
-		ID:   int64(tenantId),
-		Slug: &siteSlug,
-	}
-}
-
-func mockedResponseExpectedAvailableIpAddress() []*netboxModels.AvailableIP {
-	return []*netboxModels.AvailableIP{
+func mockedResponseExpectedAvailableIpAddress() []v4client.AvailableIP {
+	return []v4client.AvailableIP{
 		{
 			Address: ipAddress,
 			Family:  ipAddressFamily,
@@ -151,24 +176,28 @@ func mockedResponseExpectedAvailableIpAddress() []*netboxModels.AvailableIP {
 	}
 }
 
-func mockedResponseIPAddress() *netboxModels.IPAddress {
-	return &netboxModels.IPAddress{
-		ID:          int64(1),
-		Address:     &ipAddress,
+func mockedResponseIPAddress() *v4client.IPAddress {
+	statusValue := v4client.IPADDRESSSTATUSVALUE_ACTIVE
+	statusLabel := v4client.IPADDRESSSTATUSLABEL_ACTIVE
+	return &v4client.IPAddress{
+		Id:          int32(1),
+		Address:     ipAddress,
 		Display:     ipAddress,
-		Created:     &netboxIPLastUpdated,
-		LastUpdated: &netboxIPLastUpdated,
-		Comments:    comments,
-		Description: description,
-		Tenant:      mockedResponseNestedTenant(),
-		Status: &netboxModels.IPAddressStatus{
-			Label: &netboxLabel,
-			Value: &value,
-		}}
+		Created:     *v4client.NewNullableTime(&netboxIPLastUpdated),
+		LastUpdated: *v4client.NewNullableTime(&netboxIPLastUpdated),
+		Comments:    &comments,
+		Description: &description,
+		Tenant:      *v4client.NewNullableBriefTenant(expectedTenant),
+		Status: &v4client.IPAddressStatus{
+			Value: &statusValue,
+			Label: &statusLabel,
+		},
+	}
 }
 
 func mockedResponsePrefixList() *v4client.PaginatedPrefixList {
 	return &v4client.PaginatedPrefixList{
+		Count: 1,
 		Results: []v4client.Prefix{
 			{
 				Id:          prefixID,
@@ -184,54 +213,87 @@ func mockedResponsePrefixList() *v4client.PaginatedPrefixList {
 	}
 }
 
-func mockedResponseIPAddressListWithHash(customFields map[string]interface{}) *ipam.IpamIPAddressesListOKBody {
-	return &ipam.IpamIPAddressesListOKBody{
-		Results: []*netboxModels.IPAddress{
+func mockedResponseIPAddressListWithHash(customFields map[string]interface{}) *v4client.PaginatedIPAddressList {
+	ipAddress := mockedResponseIPAddress()
+	ipAddress.CustomFields = customFields
+	return &v4client.PaginatedIPAddressList{
+		Count:   1,
+		Results: []v4client.IPAddress{*ipAddress},
+	}
+}
+
+func mockedResponseIPAddressList() *v4client.PaginatedIPAddressList {
+	return &v4client.PaginatedIPAddressList{
+		Count:   1,
+		Results: []v4client.IPAddress{*mockedResponseIPAddress()},
+	}
+}
+
+func mockedResponseEmptyIPAddressList() *v4client.PaginatedIPAddressList {
+	return &v4client.PaginatedIPAddressList{
+		Count:   0,
+		Results: []v4client.IPAddress{},
+	}
+}
+
+func mockedResponseVlanGroupList() *v4client.PaginatedVLANGroupList {
+	return &v4client.PaginatedVLANGroupList{
+		Count: 1,
+		Results: []v4client.VLANGroup{
 			{
-				ID:           mockedResponseIPAddress().ID,
-				Address:      mockedResponseIPAddress().Address,
-				Comments:     mockedResponseIPAddress().Comments,
-				CustomFields: customFields,
-				Description:  mockedResponseIPAddress().Description,
-				Display:      mockedResponseIPAddress().Display,
-				LastUpdated:  mockedResponseIPAddress().LastUpdated,
-				Tenant:       mockedResponseIPAddress().Tenant,
+				Id:   vlanGroupId,
+				Name: vlanGroupName,
+				Slug: vlanGroupSlug,
 			},
 		},
 	}
 }
 
-func mockedResponseIPAddressList() *ipam.IpamIPAddressesListOKBody {
-	return &ipam.IpamIPAddressesListOKBody{
-		Results: []*netboxModels.IPAddress{
-			{
-				ID:          mockedResponseIPAddress().ID,
-				Address:     mockedResponseIPAddress().Address,
-				Comments:    mockedResponseIPAddress().Comments,
-				Description: mockedResponseIPAddress().Description,
-				Display:     mockedResponseIPAddress().Display,
-				LastUpdated: mockedResponseIPAddress().LastUpdated,
-				Tenant:      mockedResponseIPAddress().Tenant,
-			},
+func mockedResponseAvailableVlans() []v4client.AvailableVLAN {
+	return []v4client.AvailableVLAN{
+		{
+			Vid: vid,
 		},
 	}
 }
 
-func mockedResponseEmptyIPAddressList() *ipam.IpamIPAddressesListOKBody {
-	count := int64(0)
-	return &ipam.IpamIPAddressesListOKBody{
-		Count:   &count,
-		Results: make([]*netboxModels.IPAddress, 0),
+func mockedResponseVlan() *v4client.VLAN {
+	vlanDescription := nsnOf(vlanClaimName) + description + warningComment
+	return &v4client.VLAN{
+		Id:          vlanId,
+		Vid:         vid,
+		Name:        vlanClaimName,
+		Display:     vlanClaimName,
+		LastUpdated: *v4client.NewNullableTime(&netboxIPLastUpdated),
+		Comments:    &comments,
+		Description: &vlanDescription,
 	}
 }
 
-func mockedResponseTenancyTenantsList() *tenancy.TenancyTenantsListOKBody {
-	return &tenancy.TenancyTenantsListOKBody{
-		Results: []*netboxModels.Tenant{
+func mockedResponseVlanList(customFields map[string]interface{}) *v4client.PaginatedVLANList {
+	vlan := mockedResponseVlan()
+	vlan.CustomFields = customFields
+	return &v4client.PaginatedVLANList{
+		Count:   1,
+		Results: []v4client.VLAN{*vlan},
+	}
+}
+
+func mockedResponseEmptyVlanList() *v4client.PaginatedVLANList {
+	return &v4client.PaginatedVLANList{
+		Count:   0,
+		Results: []v4client.VLAN{},
+	}
+}
+
+func mockedResponseTenancyTenantsList() *v4client.PaginatedTenantList {
+	return &v4client.PaginatedTenantList{
+		Count: 1,
+		Results: []v4client.Tenant{
 			{
-				ID:   int64(1),
-				Name: &tenant,
-				Slug: &tenantSlug,
+				Id:   tenantId,
+				Name: tenant,
+				Slug: tenantSlug,
 			},
 		},
 	}
@@ -241,41 +303,51 @@ func mockedResponseTenancyTenantsList() *tenancy.TenancyTenantsListOKBody {
 // netbox mock expected params
 // -----------------------------
 
-// expected inputs for ipam.IpamIPAddressesUpdate()
+// expected inputs for ipam.IpamIpAddressesUpdate()
 var nsn = namespace + "/" + name + " // "
 var warningComment = " // managed by netbox-operator, please don't edit it in Netbox unless you know what you're doing"
-var expectedIpAddressID = int64(1)
-var expectedIpAddressFailID = int64(0)
-var int64TenantId = int64(tenantId)
-var expectedIpToUpdate = &netboxModels.WritableIPAddress{
-	Address:  &ipAddress,
-	Comments: comments + warningComment,
-	CustomFields: map[string]string{
-		"example_field": "example value",
-	},
-	Description: nsn + description + warningComment,
-	Status:      "active",
-	Tenant:      &int64TenantId,
+var expectedIpAddressID = int32(1)
+var expectedIpAddressFailID = int32(0)
+
+func expectedIpToUpdate(customFields map[string]interface{}) *v4client.WritableIPAddressRequest {
+	request := v4client.NewWritableIPAddressRequest(ipAddress)
+	request.SetComments(comments + warningComment)
+	request.SetCustomFields(customFields)
+	request.SetDescription(nsn + description + warningComment)
+	request.SetStatus(v4client.PATCHEDWRITABLEIPADDRESSREQUESTSTATUS_ACTIVE)
+	request.SetTenant(v4client.Int32AsASNRangeRequestTenant(&tenantId))
+	return request
 }
 
-var expectedIpToUpdateWithHash = &netboxModels.WritableIPAddress{
-	Address:  &ipAddress,
-	Comments: comments + warningComment,
-	CustomFields: map[string]string{
-		"example_field":                 "example value",
-		"netboxOperatorRestorationHash": restorationHash,
-	},
-	Description: nsn + description + warningComment,
-	Status:      "active",
-	Tenant:      &int64TenantId}
+var ExpectedIpAddressUpdateParams = expectedIpToUpdate(map[string]interface{}{
+	"example_field": "example value",
+})
 
-var ExpectedIpAddressUpdateParams = ipam.NewIpamIPAddressesUpdateParams().WithDefaults().
-	WithData(expectedIpToUpdate).WithID(expectedIpAddressID)
+var ExpectedIpAddressUpdateWithHashParams = expectedIpToUpdate(map[string]interface{}{
+	"example_field":                 "example value",
+	"netboxOperatorRestorationHash": restorationHash,
+})
 
-var ExpectedIpAddressUpdateWithHashParams = ipam.NewIpamIPAddressesUpdateParams().WithDefaults().
-	WithData(expectedIpToUpdateWithHash).WithID(expectedIpAddressID)
+// nsnOf returns the namespaced name prefix of the description of a resource with the name
+func nsnOf(name string) string {
+	return namespace + "/" + name + " // "
+}
 
-var ExpectedTenantsListParams = tenancy.NewTenancyTenantsListParams().WithName(&tenant)
+// expectedVlanToCreate returns the expected input of ipam.IpamVlansCreate, the vlan group is only set if it is not nil
+func expectedVlanToCreate(vlanGroupId *int32, customFields map[string]interface{}) *v4client.WritableVLANRequest {
+	request := v4client.NewWritableVLANRequest(vid, vlanClaimName)
+	request.SetComments(comments + warningComment)
+	request.SetCustomFields(customFields)
+	request.SetDescription(nsnOf(vlanClaimName) + description + warningComment)
+	request.SetStatus(v4client.PATCHEDWRITABLEVLANREQUESTSTATUS_ACTIVE)
+	if vlanGroupId != nil {
+		request.SetGroup(v4client.Int32AsPatchedWritableVLANRequestGroup(vlanGroupId))
+	}
+	return request
+}
+
+// expected inputs for tenancy.TenancyTenantsList method
+var ExpectedTenantsListParams = []string{tenant}
 
 // expected inputs for ipam.IpamPrefixesList method
 var ExpectedPrefixListParams = []string{parentPrefix}
@@ -283,23 +355,21 @@ var ExpectedPrefixListParams = []string{parentPrefix}
 // expected inputs for ipam.IpamPrefixesAvailableIpsList method
 var prefixID = int32(4)
 
-var ExpectedPrefixesAvailableIpsListParams = ipam.NewIpamPrefixesAvailableIpsListParams().WithID(int64(prefixID))
-
-// expected inputs for ipam.IpamIPAddressesList method
-var ExpectedIpAddressListParamsWithIpAddressData = ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
+var ExpectedPrefixesAvailableIpsListParams = prefixID
 
-var ExpectedIpAddressListParams = ipam.NewIpamIPAddressesListParams()
+// expected inputs for ipam.IpamIpAddressesList method
+var ExpectedIpAddressListParamsWithIpAddressData = []string{ipAddress}
 
-// expected inputs for ipam.IpamIPAddressesCreate method
-var ExpectedIpAddressesCreateParams = ipam.NewIpamIPAddressesCreateParams().WithDefaults().WithData(expectedIpToUpdate)
+// expected inputs for ipam.IpamIpAddressesCreate method
+var ExpectedIpAddressesCreateParams = ExpectedIpAddressUpdateParams
 
-var ExpectedIpAddressesCreateWithHashParams = ipam.NewIpamIPAddressesCreateParams().WithDefaults().WithData(expectedIpToUpdateWithHash)
+var ExpectedIpAddressesCreateWithHashParams = ExpectedIpAddressUpdateWithHashParams
 
-// expected inputs for ipam.IpamIPAddressesDelete method
-var ExpectedDeleteParams = ipam.NewIpamIPAddressesDeleteParams().WithID(expectedIpAddressID)
+// expected inputs for ipam.IpamIpAddressesDestroy method
+var ExpectedDeleteParams = expectedIpAddressID
 
-// expected inputs for ipam.IpamIPAddressesDelete method when update fails
-var ExpectedDeleteFailParams = ipam.NewIpamIPAddressesDeleteParams().WithID(expectedIpAddressFailID)
+// expected inputs for ipam.IpamIpAddressesDestroy method when update fails
+var ExpectedDeleteFailParams = expectedIpAddressFailID
 
 var ExpectedIpAddressStatus = netboxv1.IpAddressStatus{IpAddressId: 1}
 
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+	"github.com/netbox-community/netbox-operator/pkg/scheduler"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NetBoxConnectionReconciler reconciles a NetBoxConnection object
+type NetBoxConnectionReconciler struct {
+	client.Client
+	Scheme              *runtime.Scheme
+	NetboxClients       *api.ClientRegistry
+	EventStatusRecorder *EventStatusRecorder
+}
+
+//+kubebuilder:rbac:groups=netbox.dev,resources=netboxconnections,verbs=get;list;watch
+//+kubebuilder:rbac:groups=netbox.dev,resources=netboxconnections/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",namespace=system,resources=secrets,verbs=get;list;watch
+
+// Reconcile verifies that the NetBox instance of a NetBoxConnection is reachable
+// and configured for the operator, and reports the result in the Ready condition.
+func (r *NetBoxConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcileResult ctrl.Result, reconcileErr error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("reconcile loop started")
+
+	o := &netboxv1.NetBoxConnection{}
+	if err := r.Get(ctx, req.NamespacedName, o); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.NetboxClients.Forget(req.Name)
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Snapshot for status patch — taken before any status mutations so the
+	// merge-patch diff captures every change.
+	statusBase := o.DeepCopy()
+
+	// Defer status update to ensure it happens regardless of how we exit
+	defer func() {
+		reconcileResult, reconcileErr = r.updateStatus(ctx, o, statusBase, reconcileResult, reconcileErr)
+		if reconcileErr == nil && reconcileResult.IsZero() {
+			reconcileResult, reconcileErr = scheduler.CalculateNextReconcile(ctx)
+		}
+		logger.Info("reconcile loop finished")
+	}()
+
+	// re-read the referenced Secrets, so that a rotated token is swapped into the client
+	netboxClient, err := r.NetboxClients.Refresh(ctx, o.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := netboxClient.Available(); err != nil {
+		return ctrl.Result{RequeueAfter: netboxClient.RetryAfter()}, NewDomainError("%w", err)
+	}
+
+	if err := netboxClient.VerifyNetboxConfiguration(ctx); err != nil {
+		if netboxClient.TokenRejected() {
+			return ctrl.Result{}, NewDomainError("%w: %w", api.ErrTokenRejected, err)
+		}
+		return ctrl.Result{}, NewDomainError("verification of netbox configuration failed: %w", err)
+	}
+
+	// detect the version of NetBox, it is cached for the writes of the resources of the connection
+	if _, err := netboxClient.NetBoxVersion(ctx); err != nil {
+		return ctrl.Result{}, NewDomainError("%w", err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. The referenced Secrets are
+// watched by their metadata only, so that the data of all Secrets is not cached.
+func (r *NetBoxConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&netboxv1.NetBoxConnection{}).
+		WatchesMetadata(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.connectionsOfSecret)).
+		Complete(r)
+}
+
+// connectionsOfSecret returns the requests of the NetBoxConnections which reference the Secret
+func (r *NetBoxConnectionReconciler) connectionsOfSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	connectionList := &netboxv1.NetBoxConnectionList{}
+	if err := r.List(ctx, connectionList); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list NetBoxConnections")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, connection := range connectionList.Items {
+		if referencesSecret(&connection, secret.GetNamespace(), secret.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: connection.Name}})
+		}
+	}
+	return requests
+}
+
+// referencesSecret returns true if the token or the CA certificate of the connection is read from the Secret.
+// A reference without namespace is read from the namespace of the operator, the only namespace whose
+// Secrets are watched.
+func referencesSecret(connection *netboxv1.NetBoxConnection, namespace string, name string) bool {
+	refs := []*netboxv1.SecretKeyReference{&connection.Spec.TokenSecretRef, connection.Spec.CaCertSecretRef}
+	for _, ref := range refs {
+		if ref != nil && (ref.Namespace == "" || ref.Namespace == namespace) && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// updateStatus updates the NetBoxConnection status conditions based on the result of the reconciliation.
+// This function is called as a deferred function in Reconcile to ensure status is always updated.
+func (r *NetBoxConnectionReconciler) updateStatus(ctx context.Context, o *netboxv1.NetBoxConnection, statusBase *netboxv1.NetBoxConnection, reconcileRes ctrl.Result, reconcileErr error) (result ctrl.Result, err error) {
+	result = reconcileRes
+	err = reconcileErr
+
+	switch {
+	case errors.Is(reconcileErr, api.ErrNetboxUnavailable):
+		r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionReadyFalseNetBoxUnavailable, corev1.EventTypeWarning, reconcileErr)
+	case errors.Is(reconcileErr, api.ErrTokenRejected):
+		r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionNetBoxConnectionReadyFalseTokenRejected, corev1.EventTypeWarning, reconcileErr)
+	case reconcileErr != nil:
+		r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionNetBoxConnectionReadyFalse, corev1.EventTypeWarning, reconcileErr)
+	default:
+		r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionNetBoxConnectionReadyTrue, corev1.EventTypeNormal, nil)
+	}
+
+	// Align resource version so the patch targets the latest revision
+	statusBase.SetResourceVersion(o.GetResourceVersion())
+	if patchErr := client.IgnoreNotFound(r.Status().Patch(ctx, o, client.MergeFrom(statusBase))); patchErr != nil {
+		err = errors.Join(err, patchErr)
+	}
+
+	return IgnoreDomainError(result, err)
+}
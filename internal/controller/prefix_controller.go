@@ -44,18 +44,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
 	"github.com/swisscom/leaselocker"
 )
 
 const PrefixFinalizerName = "prefix.netbox.dev/finalizer"
 const PXManagedCustomFieldsAnnotationName = "prefix.netbox.dev/managed-custom-fields"
+const PXManagedTagsAnnotationName = "prefix.netbox.dev/managed-tags"
+const PXManagedVlanAnnotationName = "prefix.netbox.dev/managed-vlan"
 
 // PrefixReconciler reconciles a Prefix object
 type PrefixReconciler struct {
 	client.Client
 	Scheme              *runtime.Scheme
-	NetboxClient        *api.NetboxCompositeClient
+	NetboxClients       *api.ClientRegistry
 	EventStatusRecorder *EventStatusRecorder
 	OperatorNamespace   string
 	RestConfig          *rest.Config
@@ -91,6 +94,18 @@ func (r *PrefixReconciler) Reconcile(ctx context.Context, req ctrl.Request) (rec
 		logger.Info("reconcile loop finished")
 	}()
 
+	// resolve the client of the NetBox instance the resource is managed in
+	netboxClient, err := r.NetboxClients.ClientFor(ctx, o.Spec.Connection)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer r.EventStatusRecorder.ReportTokenRejected(o, netboxClient)
+
+	// short-circuit while the circuit breaker of NetBox is open instead of piling up failing requests
+	if err := netboxClient.Available(); err != nil {
+		return ctrl.Result{RequeueAfter: netboxClient.RetryAfter()}, NewDomainError("%w", err)
+	}
+
 	// if being deleted
 	if !o.DeletionTimestamp.IsZero() {
 		if !controllerutil.ContainsFinalizer(o, PrefixFinalizerName) {
@@ -101,7 +116,7 @@ func (r *PrefixReconciler) Reconcile(ctx context.Context, req ctrl.Request) (rec
 			if o.Status.PrefixId > math.MaxInt32 {
 				return ctrl.Result{}, fmt.Errorf("reconciliation of prefixes with id's larger than 2147483647 is not supported")
 			}
-			if err := r.NetboxClient.DeletePrefix(ctx, int32(o.Status.PrefixId)); err != nil {
+			if err := netboxClient.DeletePrefix(ctx, int32(o.Status.PrefixId)); err != nil {
 				return ctrl.Result{}, NewDomainError("failed to delete prefix in netbox: %w", err)
 			}
 		}
@@ -134,8 +149,8 @@ func (r *PrefixReconciler) Reconcile(ctx context.Context, req ctrl.Request) (rec
 	*/
 	ownerReferences := o.OwnerReferences
 	var ll *leaselocker.LeaseLocker
+	var releaseLeaseLock bool
 	var cancelLock context.CancelFunc
-	var err error
 	if len(ownerReferences) > 0 /* len(nil array) = 0 */ && !apismeta.IsStatusConditionTrue(o.Status.Conditions, "Ready") {
 		// get prefixClaim
 		ownerReferencesLookupKey := types.NamespacedName{
@@ -147,20 +162,23 @@ func (r *PrefixReconciler) Reconcile(ctx context.Context, req ctrl.Request) (rec
 			return ctrl.Result{}, err
 		}
 
-		if prefixClaim.Status.SelectedParentPrefix == "" {
+		parentPrefix := selectedParentPrefixOfPrefix(prefixClaim, o.Name)
+		leaseLockOwner := leaseLockOwnerOfPrefix(prefixClaim, o.Name)
+		releaseLeaseLock = !sharesLeaseLock(prefixClaim.Spec.Count)
+		if parentPrefix == "" {
 			// the parent prefix is not selected
 			return ctrl.Result{}, NewDomainError("the parent prefix is not selected")
 		}
 
-		if prefixClaim.Status.SelectedParentPrefix != msgCanNotInferParentPrefix {
+		if parentPrefix != msgCanNotInferParentPrefix {
 			// we can't restore from the restoration hash
 
 			// get the name of the parent prefix
 			leaseLockerNSN := types.NamespacedName{
-				Name:      convertCIDRToLeaseLockName(prefixClaim.Status.SelectedParentPrefix),
+				Name:      convertCIDRToLeaseLockName(parentPrefix),
 				Namespace: r.OperatorNamespace,
 			}
-			ll, err = leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.String())
+			ll, err = leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.Namespace+"/"+leaseLockOwner)
 			if err != nil {
 				return ctrl.Result{}, err
 			}
@@ -176,13 +194,14 @@ func (r *PrefixReconciler) Reconcile(ctx context.Context, req ctrl.Request) (rec
 			// create lock
 			locked := ll.TryLock(lockCtx)
 			if !locked {
-				errorMsg := fmt.Sprintf("failed to lock parent prefix %s", prefixClaim.Status.SelectedParentPrefix)
+				metrics.LeaseLockContentionTotal.WithLabelValues(prefixKind, parentPrefix).Inc()
+				errorMsg := fmt.Sprintf("failed to lock parent prefix %s", parentPrefix)
 				r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "FailedToLockParentPrefix", errorMsg)
 				return ctrl.Result{
 					RequeueAfter: 2 * time.Second,
 				}, NewDomainError("%s", errorMsg)
 			}
-			logger.V(4).Info("successfully locked parent prefix", "prefix", prefixClaim.Status.SelectedParentPrefix)
+			logger.V(4).Info("successfully locked parent prefix", "prefix", parentPrefix)
 		}
 	}
 
@@ -193,12 +212,12 @@ func (r *PrefixReconciler) Reconcile(ctx context.Context, req ctrl.Request) (rec
 		return ctrl.Result{}, err
 	}
 
-	prefixModel, err := generateNetboxPrefixModelFromPrefixSpec(&o.Spec, req, annotations[PXManagedCustomFieldsAnnotationName])
+	prefixModel, err := generateNetboxPrefixModelFromPrefixSpec(&o.Spec, req, annotations[PXManagedCustomFieldsAnnotationName], annotations[PXManagedTagsAnnotationName], annotations[PXManagedVlanAnnotationName])
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	netboxPrefixModel, statusUpToDate, err := r.NetboxClient.ReserveOrUpdatePrefix(ctx, prefixModel, o)
+	netboxPrefixModel, statusUpToDate, err := netboxClient.ReserveOrUpdatePrefix(ctx, prefixModel, o)
 	if err != nil {
 		if errors.Is(err, api.ErrRestorationHashMismatch) && o.Status.PrefixId == 0 {
 			logger.Info("restoration hash mismatch, deleting prefix custom resource", "prefix", o.Spec.Prefix)
@@ -215,9 +234,16 @@ func (r *PrefixReconciler) Reconcile(ctx context.Context, req ctrl.Request) (rec
 	/* 3. unlock lease of parent prefix */
 	if ll != nil {
 		cancelLock()
-		ll.UnlockWithRetry(ctx)
+		// a lease shared with the siblings of a claim with a count greater than 1 expires instead,
+		// so that no other claim allocates from the parent prefix before all siblings are reserved
+		if releaseLeaseLock {
+			ll.UnlockWithRetry(ctx)
+		}
 	}
 
+	/* 3.1 compute the utilization of the prefix, it changes independently of the prefix itself */
+	r.updateUtilization(ctx, netboxClient, o, netboxPrefixModel.Id)
+
 	// 4. if no change, then end loop
 	if statusUpToDate {
 		return ctrl.Result{}, nil
@@ -233,6 +259,22 @@ func (r *PrefixReconciler) Reconcile(ctx context.Context, req ctrl.Request) (rec
 		return ctrl.Result{}, NewDomainError("failed to generate managed custom fields annotation: %w", err)
 	}
 
+	annotations[PXManagedTagsAnnotationName], err = generateManagedTagsAnnotation(o.Spec.Tags)
+	if err != nil {
+		return ctrl.Result{}, NewDomainError("failed to generate managed tags annotation: %w", err)
+	}
+
+	// the vlan set by the operator is recorded, so that the prefix is unassigned from it in NetBox when it is removed from the spec
+	if o.Spec.Vlan != nil {
+		managedVlan, err := json.Marshal(o.Spec.Vlan)
+		if err != nil {
+			return ctrl.Result{}, NewDomainError("failed to generate managed vlan annotation: %w", err)
+		}
+		annotations[PXManagedVlanAnnotationName] = string(managedVlan)
+	} else {
+		delete(annotations, PXManagedVlanAnnotationName)
+	}
+
 	// snapshot before annotation mutation for merge-patch
 	patch := client.MergeFrom(o.DeepCopy())
 
@@ -248,7 +290,7 @@ func (r *PrefixReconciler) Reconcile(ctx context.Context, req ctrl.Request) (rec
 
 	// update status fields (set after r.Patch to avoid being overwritten by API response)
 	o.Status.PrefixId = int64(netboxPrefixModel.Id)
-	o.Status.PrefixUrl = config.GetBaseUrl() + "/ipam/prefixes/" + strconv.FormatInt(int64(netboxPrefixModel.Id), 10)
+	o.Status.PrefixUrl = netboxClient.BaseUrl() + "/ipam/prefixes/" + strconv.FormatInt(int64(netboxPrefixModel.Id), 10)
 	if netboxPrefixModel.LastUpdated.IsSet() {
 		o.Status.LastUpdated = metav1.NewTime(*netboxPrefixModel.LastUpdated.Get())
 	}
@@ -290,6 +332,12 @@ func (r *PrefixReconciler) updateStatus(ctx context.Context, o *netboxv1.Prefix,
 	logger.V(4).Info("updating prefix status")
 
 	switch {
+	case errors.Is(reconcileErr, api.ErrNetboxUnavailable):
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionReadyFalseNetBoxUnavailable, corev1.EventTypeWarning, reconcileErr)
+	case o.DeletionTimestamp.IsZero() && errors.Is(reconcileErr, api.ErrInvalidCustomFieldValue):
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionReadyFalseInvalidCustomField, corev1.EventTypeWarning, reconcileErr)
 	case !o.DeletionTimestamp.IsZero() && reconcileErr != nil:
 		r.EventStatusRecorder.Report(ctx, o,
 			netboxv1.ConditionPrefixReadyFalseDeletionFailed, corev1.EventTypeWarning, reconcileErr)
@@ -321,7 +369,40 @@ func (r *PrefixReconciler) updateStatus(ctx context.Context, o *netboxv1.Prefix,
 	return IgnoreDomainError(result, err)
 }
 
-func generateNetboxPrefixModelFromPrefixSpec(spec *netboxv1.PrefixSpec, req ctrl.Request, lastPrefixMetadata string) (*models.Prefix, error) {
+// updateUtilization computes the utilization of the Prefix in NetBox and emits a warning event
+// when it crosses the configured threshold. The utilization is informational, so failing to
+// compute it keeps the previous one instead of failing the reconciliation.
+func (r *PrefixReconciler) updateUtilization(ctx context.Context, netboxClient *api.NetboxCompositeClient, o *netboxv1.Prefix, prefixId int32) {
+	logger := log.FromContext(ctx)
+
+	utilizationModel, err := netboxClient.GetPrefixUtilization(ctx, prefixId, o.Spec.Prefix, o.Spec.Vrf)
+	if err != nil {
+		logger.Error(err, "failed to compute prefix utilization", "prefix", o.Spec.Prefix)
+		return
+	}
+
+	utilization := &netboxv1.PrefixUtilization{
+		ChildPrefixes:    utilizationModel.ChildPrefixes,
+		ChildIpAddresses: utilizationModel.ChildIpAddresses,
+		FreeAddresses:    utilizationModel.FreeAddresses.String(),
+		UsedPercent:      utilizationModel.UsedPercent,
+	}
+
+	threshold := config.GetOperatorConfig().PrefixUtilizationWarningThreshold
+	if utilizationThresholdCrossed(o.Status.Utilization, utilization, threshold) {
+		msg := fmt.Sprintf("prefix utilization is %d%%, which reaches the warning threshold of %d%%", utilization.UsedPercent, threshold)
+		r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "PrefixUtilizationThresholdExceeded", msg)
+	}
+
+	o.Status.Utilization = utilization
+}
+
+func generateNetboxPrefixModelFromPrefixSpec(spec *netboxv1.PrefixSpec, req ctrl.Request, lastPrefixMetadata string, lastManagedTags string, lastManagedVlan string) (*models.Prefix, error) {
+	managedTags, err := parseManagedTagsAnnotation(lastManagedTags)
+	if err != nil {
+		return nil, err
+	}
+
 	// unmarshal lastPrefixMetadata json string to map[string]string
 	lastAppliedCustomFields := make(map[string]string)
 	if lastPrefixMetadata != "" {
@@ -344,13 +425,32 @@ func generateNetboxPrefixModelFromPrefixSpec(spec *netboxv1.PrefixSpec, req ctrl
 	}
 
 	return &models.Prefix{
-		Prefix: spec.Prefix,
+		Prefix:    spec.Prefix,
+		Vlan:      vlanReferenceToModel(spec.Vlan),
+		ClearVlan: spec.Vlan == nil && lastManagedVlan != "",
 		Metadata: &models.NetboxMetadata{
 			Comments:    spec.Comments,
 			Custom:      netboxCustomFields,
 			Description: req.String() + " // " + spec.Description,
 			Site:        spec.Site,
 			Tenant:      spec.Tenant,
+			Vrf:         spec.Vrf,
+			Status:      spec.Status,
+			Role:        spec.Role,
+			Tags:        spec.Tags,
+			ManagedTags: managedTags,
 		},
 	}, nil
 }
+
+// vlanReferenceToModel returns the VLAN of the reference, or nil if the reference is not set
+func vlanReferenceToModel(vlan *netboxv1.VlanReference) *models.Vlan {
+	if vlan == nil {
+		return nil
+	}
+	return &models.Vlan{
+		Vid:       vlan.Vid,
+		Name:      vlan.Name,
+		VlanGroup: vlan.VlanGroup,
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
 )
 
@@ -49,7 +50,7 @@ const (
 type PrefixClaimReconciler struct {
 	client.Client
 	Scheme              *runtime.Scheme
-	NetboxClient        *api.NetboxCompositeClient
+	NetboxClients       *api.ClientRegistry
 	EventStatusRecorder *EventStatusRecorder
 	OperatorNamespace   string
 	RestConfig          *rest.Config
@@ -92,6 +93,18 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		logger.Info("reconcile loop finished")
 	}()
 
+	// resolve the client of the NetBox instance the resource is managed in
+	netboxClient, err := r.NetboxClients.ClientFor(ctx, o.Spec.Connection)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer r.EventStatusRecorder.ReportTokenRejected(o, netboxClient)
+
+	// short-circuit while the circuit breaker of NetBox is open instead of piling up failing requests
+	if err := netboxClient.Available(); err != nil {
+		return ctrl.Result{RequeueAfter: netboxClient.RetryAfter()}, NewDomainError("%w", err)
+	}
+
 	/* 1. compute and assign the parent prefix if required */
 	// The current design will use prefixClaim.Status.ParentPrefix for storing the selected parent prefix,
 	// and as the source of truth for future parent prefix references
@@ -102,13 +115,13 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			// set status, and condition field
 			msg := fmt.Sprintf("parentPrefix is provided in CR: %v", o.Status.SelectedParentPrefix)
 			r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
-		} else if len(o.Spec.ParentPrefixSelector) > 0 {
+		} else if len(o.Spec.ParentPrefixSelector) > 0 || len(o.Spec.ParentPrefixes) > 0 {
 			// we first check if a prefix can be restored from the netbox
 
 			// since the parent prefix is not part of the restoration hash computation
 			// we can quickly check to see if the prefix with the restoration hash is matched in NetBox
 			h := generatePrefixRestorationHash(o)
-			canBeRestored, err := r.NetboxClient.RestoreExistingPrefixByHash(h, o.Spec.PrefixLength)
+			canBeRestored, err := netboxClient.RestoreExistingPrefixByHash(ctx, h, o.Spec.PrefixLength, o.Spec.Vrf)
 			if err != nil {
 				return ctrl.Result{}, NewDomainError("%w", err)
 			}
@@ -145,6 +158,30 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				o.Status.SelectedParentPrefix = msgCanNotInferParentPrefix
 
 				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msgCanNotInferParentPrefix)
+			} else if len(o.Spec.ParentPrefixes) > 0 {
+				// No, so we use the first entry of the ordered parent prefixes the prefix can be claimed from
+				i, err := selectParentPrefixFromList(o.Spec.ParentPrefixes, func(parentPrefix string) error {
+					_, err := netboxClient.GetAvailablePrefixByClaim(
+						ctx,
+						&models.PrefixClaim{
+							ParentPrefix: parentPrefix,
+							PrefixLength: o.Spec.PrefixLength,
+							Metadata: &models.NetboxMetadata{
+								Tenant: o.Spec.Tenant,
+								Site:   o.Spec.Site,
+								Vrf:    o.Spec.Vrf,
+							},
+						})
+					return err
+				})
+				if err != nil {
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+				o.Status.SelectedParentPrefix = o.Spec.ParentPrefixes[i]
+
+				// set status, and condition field
+				msg := fmt.Sprintf("parentPrefix is selected from parentPrefixes[%d]: %v", i, o.Status.SelectedParentPrefix)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
 			} else {
 				// No, so we need to select one parent prefix from prefix candidates
 
@@ -152,7 +189,7 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				// The existing algorithm for prefix allocation within a ParentPrefix remains unchanged
 
 				// fetch available prefixes from netbox
-				parentPrefixCandidates, err := r.NetboxClient.GetAvailablePrefixesByParentPrefixSelector(ctx, &o.Spec)
+				parentPrefixCandidates, err := netboxClient.GetAvailablePrefixesByParentPrefixSelector(ctx, &o.Spec)
 				if err != nil {
 					return ctrl.Result{}, NewDomainError("%w", err)
 				}
@@ -160,17 +197,19 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 					return ctrl.Result{}, NewDomainError("no parent prefix found matching the parentPrefixSelector")
 				}
 
-				// TODO(henrybear327): use best-fit algorithm to pick a parent prefix
-				parentPrefixCandidate := parentPrefixCandidates[0]
+				parentPrefixCandidate, scores, err := selectParentPrefixCandidate(parentPrefixCandidates, o.Spec.ParentPrefixSelectionStrategy)
+				if err != nil {
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
 				o.Status.SelectedParentPrefix = parentPrefixCandidate.Prefix
 
 				// set status, and condition field
 				msg := fmt.Sprintf("parentPrefix is selected: %v", o.Status.SelectedParentPrefix)
-				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg, scores)
 			}
 		} else {
 			// this case should not be triggered anymore, as we have validation rules put in place on the CR
-			return ctrl.Result{}, NewDomainError("either ParentPrefixSelector or ParentPrefix needs to be set")
+			return ctrl.Result{}, NewDomainError("either ParentPrefixSelector, ParentPrefixes or ParentPrefix needs to be set")
 		}
 
 		// Persist SelectedParentPrefix to the API server before creating the
@@ -214,6 +253,7 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			locked := ll.TryLock(lockCtx)
 			if !locked {
 				// lock for parent prefix was not available, rescheduling
+				metrics.LeaseLockContentionTotal.WithLabelValues(prefixClaimKind, o.Status.SelectedParentPrefix).Inc()
 				errorMsg := fmt.Sprintf("failed to lock parent prefix %s", o.Status.SelectedParentPrefix)
 				r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "FailedToLockParentPrefix", errorMsg)
 				return ctrl.Result{
@@ -228,32 +268,46 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 		// 5. try to reclaim Prefix using restorationHash
 		h := generatePrefixRestorationHash(o)
-		prefixModel, err := r.NetboxClient.RestoreExistingPrefixByHash(h, o.Spec.PrefixLength)
+		prefixModel, err := netboxClient.RestoreExistingPrefixByHash(ctx, h, o.Spec.PrefixLength, o.Spec.Vrf)
 		if err != nil {
 			return ctrl.Result{}, NewDomainError("%w", err)
 		}
+		metrics.ObserveRestoration(prefixClaimKind, prefixModel != nil)
 
 		if prefixModel == nil {
 			// Prefix cannot be restored from netbox
-			// 6.a assign new available Prefix
-
-			// get available Prefix under parent prefix in netbox with equal mask length
-			prefixModel, err = r.NetboxClient.GetAvailablePrefixByClaim(
-				ctx,
-				&models.PrefixClaim{
-					ParentPrefix: o.Status.SelectedParentPrefix,
-					PrefixLength: o.Spec.PrefixLength,
-					Metadata: &models.NetboxMetadata{
-						Tenant: o.Spec.Tenant,
-						Site:   o.Spec.Site,
-					},
-				})
+			// 6.a assign the preferred or a new available Prefix
+			prefixClaimModel := &models.PrefixClaim{
+				ParentPrefix: o.Status.SelectedParentPrefix,
+				PrefixLength: o.Spec.PrefixLength,
+				Metadata: &models.NetboxMetadata{
+					Tenant: o.Spec.Tenant,
+					Site:   o.Spec.Site,
+					Vrf:    o.Spec.Vrf,
+				},
+			}
+			if o.Spec.PreferredPrefix != "" {
+				prefixModel, err = netboxClient.GetPreferredPrefixByClaim(ctx, prefixClaimModel, o.Spec.PreferredPrefix)
+				if errors.Is(err, api.ErrPreferredPrefixNotAvailable) && o.Spec.PreferredAllocationPolicy == netboxv1.PreferredAllocationPolicyFallbackToDynamic {
+					logger.V(4).Info(fmt.Sprintf("preferred prefix %s is not available, falling back to dynamic allocation", o.Spec.PreferredPrefix))
+					prefixModel, err = netboxClient.GetAvailablePrefixByClaim(ctx, prefixClaimModel)
+				}
+			} else {
+				// get available Prefix under parent prefix in netbox with equal mask length
+				prefixModel, err = netboxClient.GetAvailablePrefixByClaim(ctx, prefixClaimModel)
+			}
 			if err != nil {
+				observeParentExhausted(prefixClaimKind, o.Status.SelectedParentPrefix, err)
 				if errors.Is(err, api.ErrParentPrefixExhausted) {
 					// we reset the selected parent prefix, since this one is already exhausted
 					o.Status.SelectedParentPrefix = ""
 					return ctrl.Result{}, NewDomainError("parent prefix exhausted, will restart the parent prefix selection process")
 				}
+				if len(o.Spec.ParentPrefixes) > 0 && isParentPrefixUnusableErr(err) {
+					// we reset the selected parent prefix, the next entry of the parent prefixes will be selected
+					o.Status.SelectedParentPrefix = ""
+					return ctrl.Result{}, NewDomainError("will restart the parent prefix selection process: %w", err)
+				}
 
 				return ctrl.Result{}, NewDomainError("%w", err)
 			}
@@ -266,7 +320,7 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 
 		/* 7.a create the Prefix object */
-		prefixResource := generatePrefixFromPrefixClaim(o, prefixModel.Prefix, logger)
+		prefixResource := generatePrefixFromPrefixClaim(o, prefixModel.Prefix, inheritedVrf(o.Spec.Vrf, prefixModel.Metadata), logger)
 		err = controllerutil.SetControllerReference(o, prefixResource, r.Scheme)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -275,15 +329,23 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if err != nil {
 			return ctrl.Result{}, NewDomainError("failed to create prefix: %w", err)
 		}
+		prefix = prefixResource
 	} else { // Prefix object exists
 		/* 7.b update fields of the Prefix object */
+		if err := checkNameConflict(prefixKind, prefix, o, true); err != nil {
+			return ctrl.Result{}, err
+		}
 		logger.V(4).Info("update prefix resource")
 
-		updatedPrefixSpec := generatePrefixSpec(o, prefix.Spec.Prefix, logger)
+		updatedPrefixSpec := generatePrefixSpec(o, prefix.Spec.Prefix, prefix.Spec.Vrf, logger)
 		if _, err = ctrl.CreateOrUpdate(ctx, r.Client, prefix, func() error {
 			// only add the mutable fields here
 			prefix.Spec.Site = updatedPrefixSpec.Site
 			prefix.Spec.CustomFields = updatedPrefixSpec.CustomFields
+			prefix.Spec.Tags = updatedPrefixSpec.Tags
+			prefix.Spec.Status = updatedPrefixSpec.Status
+			prefix.Spec.Role = updatedPrefixSpec.Role
+			prefix.Spec.Vlan = updatedPrefixSpec.Vlan
 			prefix.Spec.Description = updatedPrefixSpec.Description
 			prefix.Spec.Comments = updatedPrefixSpec.Comments
 			prefix.Spec.PreserveInNetbox = updatedPrefixSpec.PreserveInNetbox
@@ -297,7 +359,13 @@ func (r *PrefixClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
-	return ctrl.Result{}, nil
+	/* 8. claim the Prefix of the other IP family in dual-stack mode */
+	if o.Spec.DualStack != nil {
+		return r.reconcileDualStackPrefix(ctx, req, netboxClient, o, prefix.Spec.Prefix)
+	}
+
+	/* 9. claim the remaining Prefixes if the count is greater than 1, or delete them after scale down */
+	return r.reconcilePrefixCount(ctx, req, netboxClient, o, prefix)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -339,6 +407,17 @@ func (r *PrefixClaimReconciler) updateStatus(ctx context.Context, claim *netboxv
 
 	logger.V(4).Info("updating prefixclaim status")
 
+	if errors.Is(reconcileErr, api.ErrNetboxUnavailable) {
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionReadyFalseNetBoxUnavailable, corev1.EventTypeWarning, reconcileErr)
+		return result, err
+	}
+
+	if errors.Is(reconcileErr, errNameConflict) {
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionPrefixAssignedFalse, corev1.EventTypeWarning, reconcileErr)
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionReadyFalseNameConflict, corev1.EventTypeWarning, reconcileErr)
+		return result, err
+	}
+
 	// Fetch the latest Prefix object
 	prefix := &netboxv1.Prefix{}
 	err = r.Client.Get(ctx, lookupKey, prefix)
@@ -358,15 +437,87 @@ func (r *PrefixClaimReconciler) updateStatus(ctx context.Context, claim *netboxv
 		return result, err
 	}
 
+	ready := apismeta.IsStatusConditionTrue(prefix.Status.Conditions, netboxv1.ConditionPrefixReadyTrue.Type)
+	if ready {
+		claim.Status.Prefix = prefix.Spec.Prefix
+		claim.Status.PrefixName = prefix.Name
+		claim.Status.Utilization = prefix.Status.Utilization.DeepCopy()
+		if statusBase.Status.Prefix == "" {
+			metrics.ObserveClaimAllocation(prefixClaimKind, claim.CreationTimestamp.Time)
+		}
+	}
+
+	// In dual-stack mode, the Prefix of the other IP family is required as well
+	if claim.Spec.DualStack != nil {
+		dualStackPrefix := &netboxv1.Prefix{}
+		err = r.Client.Get(ctx, types.NamespacedName{Name: dualStackName(claim.Name), Namespace: claim.Namespace}, dualStackPrefix)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				// dual-stack Prefix doesn't exist yet
+				r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionPrefixAssignedFalse, corev1.EventTypeWarning, reconcileErr)
+				r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionPrefixClaimReadyFalse, corev1.EventTypeWarning, reconcileErr)
+				if result.IsZero() {
+					result = ctrl.Result{RequeueAfter: 1 * time.Second}
+				}
+				err = nil
+				return result, err
+			}
+			err = fmt.Errorf("failed to get dual-stack Prefix for status update: %w", err)
+			return result, err
+		}
+
+		if apismeta.IsStatusConditionTrue(dualStackPrefix.Status.Conditions, netboxv1.ConditionPrefixReadyTrue.Type) {
+			if claim.Status.DualStack == nil {
+				claim.Status.DualStack = &netboxv1.PrefixClaimDualStackStatus{}
+			}
+			claim.Status.DualStack.Prefix = dualStackPrefix.Spec.Prefix
+			claim.Status.DualStack.PrefixName = dualStackPrefix.Name
+		} else {
+			ready = false
+		}
+	}
+
+	// With a count greater than 1, all Prefixes of the claim are required
+	count := claimCount(claim.Spec.Count)
+	if count > 1 {
+		var prefixes map[int]*netboxv1.Prefix
+		prefixes, err = r.listIndexedPrefixes(ctx, claim)
+		if err != nil {
+			return result, err
+		}
+
+		allocated := make([]string, 0, count)
+		allocated = append(allocated, prefix.Spec.Prefix)
+		for index := 1; index < count; index++ {
+			indexedPrefix, ok := prefixes[index]
+			if !ok {
+				// not all Prefixes exist yet
+				r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionPrefixAssignedFalse, corev1.EventTypeWarning, reconcileErr)
+				r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionPrefixClaimReadyFalse, corev1.EventTypeWarning, reconcileErr)
+				if result.IsZero() {
+					result = ctrl.Result{RequeueAfter: 1 * time.Second}
+				}
+				return result, nil
+			}
+			if !apismeta.IsStatusConditionTrue(indexedPrefix.Status.Conditions, netboxv1.ConditionPrefixReadyTrue.Type) {
+				ready = false
+			}
+			allocated = append(allocated, indexedPrefix.Spec.Prefix)
+		}
+		if ready {
+			claim.Status.Prefixes = allocated
+		}
+	} else {
+		claim.Status.Prefixes = nil
+	}
+
 	// Prefix exists - report successful prefix assignment if not already reported
 	if apismeta.FindStatusCondition(claim.Status.Conditions, netboxv1.ConditionPrefixAssignedTrue.Type) == nil || apismeta.IsStatusConditionFalse(claim.Status.Conditions, netboxv1.ConditionPrefixAssignedTrue.Type) {
 		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionPrefixAssignedTrue, corev1.EventTypeNormal, nil)
 	}
 	// Update status based on Prefix readiness
-	if apismeta.IsStatusConditionTrue(prefix.Status.Conditions, netboxv1.ConditionPrefixReadyTrue.Type) {
+	if ready {
 		logger.V(4).Info("prefix status ready true")
-		claim.Status.Prefix = prefix.Spec.Prefix
-		claim.Status.PrefixName = prefix.Name
 		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionPrefixClaimReadyTrue, corev1.EventTypeNormal, nil)
 	} else {
 		logger.V(4).Info("prefix status ready false")
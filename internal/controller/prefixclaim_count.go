@@ -0,0 +1,220 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+
+	"github.com/swisscom/leaselocker"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// listIndexedPrefixes returns the Prefixes of the PrefixClaim with an index greater than 0 by index,
+// the Prefix with index 0 is the one named after the claim.
+func (r *PrefixClaimReconciler) listIndexedPrefixes(ctx context.Context, o *netboxv1.PrefixClaim) (map[int]*netboxv1.Prefix, error) {
+	prefixList := &netboxv1.PrefixList{}
+	if err := r.List(ctx, prefixList, client.InNamespace(o.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list prefixes: %w", err)
+	}
+
+	prefixes := make(map[int]*netboxv1.Prefix)
+	for i := range prefixList.Items {
+		prefix := &prefixList.Items[i]
+		if !metav1.IsControlledBy(prefix, o) {
+			continue
+		}
+		if index := indexOfIndexedName(o.Name, prefix.Name); index > 0 {
+			prefixes[index] = prefix
+		}
+	}
+	return prefixes, nil
+}
+
+// reconcilePrefixCount scales the Prefixes of a PrefixClaim to its count. The Prefix with index 0
+// is reconciled like the one of a claim without count, prefix is that Prefix. The missing Prefixes
+// are claimed from the selected parent prefix while holding its lease, the lease is shared with the
+// Prefix controller which doesn't release it, so that no other claim allocates from the parent
+// prefix before all Prefixes are reserved in NetBox.
+func (r *PrefixClaimReconciler) reconcilePrefixCount(ctx context.Context, req ctrl.Request, netboxClient *api.NetboxCompositeClient, o *netboxv1.PrefixClaim, prefix *netboxv1.Prefix) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	count := claimCount(o.Spec.Count)
+
+	prefixes, err := r.listIndexedPrefixes(ctx, o)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	/* 9.1 delete the Prefixes with an index beyond the count */
+	for index, existing := range prefixes {
+		if index < count {
+			continue
+		}
+		logger.V(4).Info(fmt.Sprintf("deleting prefix resource %s after scale down", existing.Name))
+		if err := r.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete prefix %s: %w", existing.Name, err)
+		}
+		delete(prefixes, index)
+	}
+
+	/* 9.2 update fields of the existing Prefix objects */
+	for index, existing := range prefixes {
+		updatedPrefixSpec := generateIndexedPrefixSpec(o, index, existing.Spec.Prefix, existing.Spec.Vrf, logger)
+		if _, err := ctrl.CreateOrUpdate(ctx, r.Client, existing, func() error {
+			// only add the mutable fields here
+			existing.Spec.Site = updatedPrefixSpec.Site
+			existing.Spec.CustomFields = updatedPrefixSpec.CustomFields
+			existing.Spec.Tags = updatedPrefixSpec.Tags
+			existing.Spec.Status = updatedPrefixSpec.Status
+			existing.Spec.Role = updatedPrefixSpec.Role
+			existing.Spec.Vlan = updatedPrefixSpec.Vlan
+			existing.Spec.Description = updatedPrefixSpec.Description
+			existing.Spec.Comments = updatedPrefixSpec.Comments
+			existing.Spec.PreserveInNetbox = updatedPrefixSpec.PreserveInNetbox
+			return controllerutil.SetControllerReference(o, existing, r.Scheme)
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	missingIndices := make([]int, 0, count)
+	for index := 1; index < count; index++ {
+		if _, ok := prefixes[index]; !ok {
+			missingIndices = append(missingIndices, index)
+		}
+	}
+	if len(missingIndices) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	// the names of the missing Prefixs might be used by resources of another claim, e.g. of a claim
+	// named <claim>-<index>, check them before anything is allocated in NetBox
+	for _, index := range missingIndices {
+		existing := &netboxv1.Prefix{}
+		err := r.Get(ctx, types.NamespacedName{Name: indexedName(o.Name, index), Namespace: o.Namespace}, existing)
+		if err == nil {
+			return ctrl.Result{}, checkNameConflict(prefixKind, existing, o, false)
+		}
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get Prefix %s: %w", indexedName(o.Name, index), err)
+		}
+	}
+
+	parentPrefix := o.Status.SelectedParentPrefix
+	if parentPrefix != msgCanNotInferParentPrefix {
+		/* 9.3 lock the lease of the parent prefix, with the owner used by all Prefixes of the claim */
+		leaseLockerNSN := types.NamespacedName{
+			Name:      convertCIDRToLeaseLockName(parentPrefix),
+			Namespace: r.OperatorNamespace,
+		}
+		ll, err := leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.Namespace+"/"+leaseLockOwnerOfPrefix(o, o.Name))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		lockCtx, cancel := context.WithTimeout(ctx, lockAcquireTimeout)
+		defer cancel()
+		if !ll.TryLock(lockCtx) {
+			metrics.LeaseLockContentionTotal.WithLabelValues(prefixClaimKind, parentPrefix).Inc()
+			errorMsg := fmt.Sprintf("failed to lock parent prefix %s", parentPrefix)
+			r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "FailedToLockParentPrefix", errorMsg)
+			return ctrl.Result{
+				RequeueAfter: 2 * time.Second,
+			}, NewDomainError("%s", errorMsg)
+		}
+		logger.V(4).Info(fmt.Sprintf("successfully locked parent prefix %s", parentPrefix))
+	}
+
+	/* 9.4 try to reclaim the missing Prefixes using their restorationHash, in the VRF of the Prefix with index 0 */
+	prefixesByIndex := make(map[int]string, len(missingIndices))
+	unrestoredIndices := make([]int, 0, len(missingIndices))
+	for _, index := range missingIndices {
+		prefixModel, err := netboxClient.RestoreExistingPrefixByHash(ctx, generateIndexedPrefixRestorationHash(o, index), o.Spec.PrefixLength, prefix.Spec.Vrf)
+		if err != nil {
+			return ctrl.Result{}, NewDomainError("%w", err)
+		}
+		metrics.ObserveRestoration(prefixClaimKind, prefixModel != nil)
+		if prefixModel == nil {
+			unrestoredIndices = append(unrestoredIndices, index)
+			continue
+		}
+		logger.V(4).Info(fmt.Sprintf("reassign reserved prefix from netbox, prefix: %s", prefixModel.Prefix))
+		prefixesByIndex[index] = prefixModel.Prefix
+	}
+
+	if len(unrestoredIndices) > 0 {
+		if parentPrefix == msgCanNotInferParentPrefix {
+			return ctrl.Result{}, NewDomainError("%d of %d prefixes can't be restored and the parent prefix can't be inferred", len(unrestoredIndices), count)
+		}
+
+		/* 9.5 assign new available Prefixes, skipping the ones which are not yet reserved in netbox */
+		excludedPrefixes := make([]string, 0, len(prefixes)+len(prefixesByIndex)+1)
+		excludedPrefixes = append(excludedPrefixes, prefix.Spec.Prefix)
+		for _, existing := range prefixes {
+			excludedPrefixes = append(excludedPrefixes, existing.Spec.Prefix)
+		}
+		for _, restored := range prefixesByIndex {
+			excludedPrefixes = append(excludedPrefixes, restored)
+		}
+
+		prefixModels, err := netboxClient.GetAvailablePrefixesByClaim(
+			ctx,
+			&models.PrefixClaim{
+				ParentPrefix: parentPrefix,
+				PrefixLength: o.Spec.PrefixLength,
+				Metadata: &models.NetboxMetadata{
+					Tenant: o.Spec.Tenant,
+					Site:   o.Spec.Site,
+					Vrf:    prefix.Spec.Vrf,
+				},
+			}, len(unrestoredIndices), excludedPrefixes)
+		if err != nil {
+			observeParentExhausted(prefixClaimKind, parentPrefix, err)
+			return ctrl.Result{}, NewDomainError("%w", err)
+		}
+		for i, index := range unrestoredIndices {
+			logger.V(4).Info(fmt.Sprintf("prefix is not reserved in netbox, assigned new prefix: %s", prefixModels[i].Prefix))
+			prefixesByIndex[index] = prefixModels[i].Prefix
+		}
+	}
+
+	/* 9.6 create the missing Prefix objects */
+	for _, index := range missingIndices {
+		prefixResource := generateIndexedPrefixFromPrefixClaim(o, index, prefixesByIndex[index], prefix.Spec.Vrf, logger)
+		if err := controllerutil.SetControllerReference(o, prefixResource, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, prefixResource); err != nil {
+			return ctrl.Result{}, NewDomainError("failed to create prefix %s: %w", prefixResource.Name, err)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
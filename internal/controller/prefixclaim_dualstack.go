@@ -0,0 +1,210 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+
+	"github.com/swisscom/leaselocker"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileDualStackPrefix claims the Prefix of the other IP family of a PrefixClaim in
+// dual-stack mode. It follows the same steps as the reconciliation of the first Prefix,
+// prefix is the first Prefix in CIDR notation and is used to verify the IP family.
+func (r *PrefixClaimReconciler) reconcileDualStackPrefix(ctx context.Context, req ctrl.Request, netboxClient *api.NetboxCompositeClient, o *netboxv1.PrefixClaim, prefix string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if o.Status.DualStack == nil {
+		o.Status.DualStack = &netboxv1.PrefixClaimDualStackStatus{}
+	}
+
+	/* 8.1 compute and assign the dual-stack parent prefix if required */
+	if o.Status.DualStack.SelectedParentPrefix == "" {
+		if o.Spec.DualStack.ParentPrefix != "" {
+			if err := verifyOtherIpFamily(prefix, o.Spec.DualStack.ParentPrefix); err != nil {
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedFalse, corev1.EventTypeWarning, err)
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+			o.Status.DualStack.SelectedParentPrefix = o.Spec.DualStack.ParentPrefix
+
+			msg := fmt.Sprintf("dual-stack parentPrefix is provided in CR: %v", o.Status.DualStack.SelectedParentPrefix)
+			r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg)
+		} else {
+			h := generateDualStackPrefixRestorationHash(o)
+			canBeRestored, err := netboxClient.RestoreExistingPrefixByHash(ctx, h, o.Spec.DualStack.PrefixLength, o.Spec.Vrf)
+			if err != nil {
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+
+			if canBeRestored != nil {
+				// as for the first Prefix, the original parent prefix can't be inferred
+				o.Status.DualStack.SelectedParentPrefix = msgCanNotInferParentPrefix
+
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, "dual-stack "+msgCanNotInferParentPrefix)
+			} else {
+				parentPrefixCandidates, err := netboxClient.GetAvailablePrefixesByParentPrefixSelector(ctx, &netboxv1.PrefixClaimSpec{
+					ParentPrefixSelector: o.Spec.DualStack.ParentPrefixSelector,
+					PrefixLength:         o.Spec.DualStack.PrefixLength,
+					Tenant:               o.Spec.Tenant,
+					Site:                 o.Spec.Site,
+					Vrf:                  o.Spec.Vrf,
+				})
+				if err != nil {
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+
+				otherIpFamilyCandidates := make([]*models.ParentPrefixCandidate, 0, len(parentPrefixCandidates))
+				for _, candidate := range parentPrefixCandidates {
+					if verifyOtherIpFamily(prefix, candidate.Prefix) == nil {
+						otherIpFamilyCandidates = append(otherIpFamilyCandidates, candidate)
+					}
+				}
+				if len(otherIpFamilyCandidates) == 0 {
+					return ctrl.Result{}, NewDomainError("no parent prefix of the other IP family found matching the dual-stack parentPrefixSelector")
+				}
+
+				parentPrefixCandidate, scores, err := selectParentPrefixCandidate(otherIpFamilyCandidates, o.Spec.ParentPrefixSelectionStrategy)
+				if err != nil {
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+				o.Status.DualStack.SelectedParentPrefix = parentPrefixCandidate.Prefix
+
+				msg := fmt.Sprintf("dual-stack parentPrefix is selected: %v", o.Status.DualStack.SelectedParentPrefix)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionParentPrefixSelectedTrue, corev1.EventTypeNormal, nil, msg, scores)
+			}
+		}
+
+		// Persist the dual-stack SelectedParentPrefix to the API server before creating
+		// the second Prefix CR, the Prefix controller reads it to lock the parent prefix.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	/* 8.2 check if the dual-stack Prefix object already exists */
+	dualStackPrefix := &netboxv1.Prefix{}
+	dualStackPrefixName := dualStackName(o.Name)
+	err := r.Get(ctx, types.NamespacedName{Name: dualStackPrefixName, Namespace: o.Namespace}, dualStackPrefix)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		logger.V(4).Info("the dual-stack prefix was not found, will create a new prefix object now")
+
+		parentPrefix := o.Status.DualStack.SelectedParentPrefix
+		if parentPrefix != msgCanNotInferParentPrefix {
+			/* 8.3 lock the lease of the dual-stack parent prefix */
+			leaseLockerNSN := types.NamespacedName{
+				Name:      convertCIDRToLeaseLockName(parentPrefix),
+				Namespace: r.OperatorNamespace,
+			}
+			ll, err := leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, req.Namespace+"/"+dualStackPrefixName)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			lockCtx, cancel := context.WithTimeout(ctx, lockAcquireTimeout)
+			defer cancel()
+			if !ll.TryLock(lockCtx) {
+				metrics.LeaseLockContentionTotal.WithLabelValues(prefixClaimKind, parentPrefix).Inc()
+				errorMsg := fmt.Sprintf("failed to lock parent prefix %s", parentPrefix)
+				r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "FailedToLockParentPrefix", errorMsg)
+				return ctrl.Result{
+					RequeueAfter: 2 * time.Second,
+				}, NewDomainError("%s", errorMsg)
+			}
+			logger.V(4).Info(fmt.Sprintf("successfully locked dual-stack parent prefix %s", parentPrefix))
+		}
+
+		/* 8.4 try to reclaim the dual-stack Prefix using restorationHash */
+		prefixModel, err := netboxClient.RestoreExistingPrefixByHash(ctx, generateDualStackPrefixRestorationHash(o), o.Spec.DualStack.PrefixLength, o.Spec.Vrf)
+		if err != nil {
+			return ctrl.Result{}, NewDomainError("%w", err)
+		}
+		metrics.ObserveRestoration(prefixClaimKind, prefixModel != nil)
+
+		if prefixModel == nil {
+			/* 8.5 assign new available Prefix of the other IP family */
+			prefixModel, err = netboxClient.GetAvailablePrefixByClaim(
+				ctx,
+				&models.PrefixClaim{
+					ParentPrefix: parentPrefix,
+					PrefixLength: o.Spec.DualStack.PrefixLength,
+					Metadata: &models.NetboxMetadata{
+						Tenant: o.Spec.Tenant,
+						Site:   o.Spec.Site,
+						Vrf:    o.Spec.Vrf,
+					},
+				})
+			if err != nil {
+				observeParentExhausted(prefixClaimKind, parentPrefix, err)
+				if errors.Is(err, api.ErrParentPrefixExhausted) && len(o.Spec.DualStack.ParentPrefixSelector) > 0 {
+					// the next reconcile loop selects the next candidate
+					o.Status.DualStack.SelectedParentPrefix = ""
+					return ctrl.Result{}, NewDomainError("dual-stack parent prefix exhausted, will restart the dual-stack parent prefix selection process")
+				}
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+			logger.V(4).Info(fmt.Sprintf("dual-stack prefix is not reserved in netbox, assigned new prefix: %s", prefixModel.Prefix))
+		} else {
+			logger.V(4).Info(fmt.Sprintf("reassign reserved dual-stack prefix from netbox, prefix: %s", prefixModel.Prefix))
+		}
+
+		/* 8.6 create the dual-stack Prefix object */
+		prefixResource := generateDualStackPrefixFromPrefixClaim(o, prefixModel.Prefix, inheritedVrf(o.Spec.Vrf, prefixModel.Metadata), logger)
+		if err := controllerutil.SetControllerReference(o, prefixResource, r.Scheme); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Create(ctx, prefixResource); err != nil {
+			return ctrl.Result{}, NewDomainError("failed to create dual-stack prefix: %w", err)
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	/* 8.7 update fields of the dual-stack Prefix object */
+	updatedPrefixSpec := generateDualStackPrefixSpec(o, dualStackPrefix.Spec.Prefix, dualStackPrefix.Spec.Vrf, logger)
+	if _, err := ctrl.CreateOrUpdate(ctx, r.Client, dualStackPrefix, func() error {
+		// only add the mutable fields here
+		dualStackPrefix.Spec.Site = updatedPrefixSpec.Site
+		dualStackPrefix.Spec.CustomFields = updatedPrefixSpec.CustomFields
+		dualStackPrefix.Spec.Tags = updatedPrefixSpec.Tags
+		dualStackPrefix.Spec.Status = updatedPrefixSpec.Status
+		dualStackPrefix.Spec.Role = updatedPrefixSpec.Role
+		dualStackPrefix.Spec.Vlan = updatedPrefixSpec.Vlan
+		dualStackPrefix.Spec.Description = updatedPrefixSpec.Description
+		dualStackPrefix.Spec.Comments = updatedPrefixSpec.Comments
+		dualStackPrefix.Spec.PreserveInNetbox = updatedPrefixSpec.PreserveInNetbox
+		return controllerutil.SetControllerReference(o, dualStackPrefix, r.Scheme)
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -18,26 +18,65 @@ package controller
 
 import (
 	"crypto/sha1"
+	"errors"
 	"fmt"
+	"net"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func generatePrefixFromPrefixClaim(claim *netboxv1.PrefixClaim, prefix string, logger logr.Logger) *netboxv1.Prefix {
+func generatePrefixFromPrefixClaim(claim *netboxv1.PrefixClaim, prefix string, vrf string, logger logr.Logger) *netboxv1.Prefix {
 	return &netboxv1.Prefix{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      claim.Name,
 			Namespace: claim.Namespace,
 		},
-		Spec: generatePrefixSpec(claim, prefix, logger),
+		Spec: generatePrefixSpec(claim, prefix, vrf, logger),
 	}
 }
 
-func generatePrefixSpec(claim *netboxv1.PrefixClaim, prefix string, logger logr.Logger) netboxv1.PrefixSpec {
+func generateDualStackPrefixFromPrefixClaim(claim *netboxv1.PrefixClaim, prefix string, vrf string, logger logr.Logger) *netboxv1.Prefix {
+	return &netboxv1.Prefix{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dualStackName(claim.Name),
+			Namespace: claim.Namespace,
+		},
+		Spec: generateDualStackPrefixSpec(claim, prefix, vrf, logger),
+	}
+}
+
+func generateIndexedPrefixFromPrefixClaim(claim *netboxv1.PrefixClaim, index int, prefix string, vrf string, logger logr.Logger) *netboxv1.Prefix {
+	return &netboxv1.Prefix{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      indexedName(claim.Name, index),
+			Namespace: claim.Namespace,
+		},
+		Spec: generateIndexedPrefixSpec(claim, index, prefix, vrf, logger),
+	}
+}
+
+func generatePrefixSpec(claim *netboxv1.PrefixClaim, prefix string, vrf string, logger logr.Logger) netboxv1.PrefixSpec {
+	return generatePrefixSpecWithRestorationHash(claim, prefix, vrf, generatePrefixRestorationHash(claim), logger)
+}
+
+func generateDualStackPrefixSpec(claim *netboxv1.PrefixClaim, prefix string, vrf string, logger logr.Logger) netboxv1.PrefixSpec {
+	return generatePrefixSpecWithRestorationHash(claim, prefix, vrf, generateDualStackPrefixRestorationHash(claim), logger)
+}
+
+func generateIndexedPrefixSpec(claim *netboxv1.PrefixClaim, index int, prefix string, vrf string, logger logr.Logger) netboxv1.PrefixSpec {
+	return generatePrefixSpecWithRestorationHash(claim, prefix, vrf, generateIndexedPrefixRestorationHash(claim, index), logger)
+}
+
+// generatePrefixSpecWithRestorationHash returns the spec of a Prefix of the claim, the vrf is the VRF
+// of the claim or the one inherited from the parent prefix
+func generatePrefixSpecWithRestorationHash(claim *netboxv1.PrefixClaim, prefix string, vrf string, restorationHash string, logger logr.Logger) netboxv1.PrefixSpec {
 	// log a warning if the netboxOperatorRestorationHash name is a key in the customFields map of the IpAddressClaim
 	_, ok := claim.Spec.CustomFields[config.GetOperatorConfig().NetboxRestorationHashFieldName]
 	if ok {
@@ -50,52 +89,95 @@ func generatePrefixSpec(claim *netboxv1.PrefixClaim, prefix string, logger logr.
 		customFields[k] = v
 	}
 
-	customFields[config.GetOperatorConfig().NetboxRestorationHashFieldName] = generatePrefixRestorationHash(claim)
+	customFields[config.GetOperatorConfig().NetboxRestorationHashFieldName] = restorationHash
 
 	return netboxv1.PrefixSpec{
 		Prefix:           prefix,
 		Tenant:           claim.Spec.Tenant,
 		Site:             claim.Spec.Site,
+		Vrf:              vrf,
+		Status:           claim.Spec.Status,
+		Role:             claim.Spec.Role,
+		Vlan:             claim.Spec.Vlan.DeepCopy(),
 		CustomFields:     customFields,
+		Tags:             slices.Clone(claim.Spec.Tags),
 		Description:      claim.Spec.Description,
 		Comments:         claim.Spec.Comments,
 		PreserveInNetbox: claim.Spec.PreserveInNetbox,
+		Connection:       claim.Spec.Connection,
 	}
 }
 
 func generatePrefixRestorationHash(claim *netboxv1.PrefixClaim) string {
-	parentPrefixSelectorStr := ""
-	if len(claim.Spec.ParentPrefixSelector) > 0 {
-		// we generate the string by
-		// a) sort all keys in non-decreasing order (to avoid reordering the field in the CR causing a different hash to be generated)
-		// b) concat all the keys and values in the sequence of key1_value1_..._keyN_valueN
-
-		keyList := make([]string, 0, len(claim.Spec.ParentPrefixSelector))
-		for key := range claim.Spec.ParentPrefixSelector {
-			keyList = append(keyList, key)
-		}
-		sort.Strings(keyList)
-
-		for _, key := range keyList {
-			if len(parentPrefixSelectorStr) > 0 {
-				parentPrefixSelectorStr += "_"
-			}
-			parentPrefixSelectorStr += key + "_" + claim.Spec.ParentPrefixSelector[key]
-		}
+	rd := PrefixClaimRestorationData{
+		Namespace:            claim.Namespace,
+		Name:                 claim.Name,
+		ParentPrefix:         claim.Spec.ParentPrefix,
+		PrefixLength:         claim.Spec.PrefixLength,
+		Tenant:               claim.Spec.Tenant,
+		ParentPrefixSelector: parentPrefixSelectorToString(claim.Spec.ParentPrefixSelector),
+		ParentPrefixes:       parentPrefixesToString(claim.Spec.ParentPrefixes),
 	}
 
+	return rd.ComputeHash()
+}
+
+// generateIndexedPrefixRestorationHash computes the restoration hash of the Prefix with the given
+// index of a PrefixClaim with a count greater than 1, using the name of its Prefix CR.
+// For index 0 it is the restoration hash of the PrefixClaim.
+func generateIndexedPrefixRestorationHash(claim *netboxv1.PrefixClaim, index int) string {
 	rd := PrefixClaimRestorationData{
 		Namespace:            claim.Namespace,
-		Name:                 claim.Name,
+		Name:                 indexedName(claim.Name, index),
 		ParentPrefix:         claim.Spec.ParentPrefix,
 		PrefixLength:         claim.Spec.PrefixLength,
 		Tenant:               claim.Spec.Tenant,
-		ParentPrefixSelector: parentPrefixSelectorStr,
+		ParentPrefixSelector: parentPrefixSelectorToString(claim.Spec.ParentPrefixSelector),
+		ParentPrefixes:       parentPrefixesToString(claim.Spec.ParentPrefixes),
 	}
 
 	return rd.ComputeHash()
 }
 
+// generateDualStackPrefixRestorationHash computes the restoration hash of the Prefix of the
+// other IP family in dual-stack mode, using the name of the second Prefix CR
+func generateDualStackPrefixRestorationHash(claim *netboxv1.PrefixClaim) string {
+	rd := PrefixClaimRestorationData{
+		Namespace: claim.Namespace,
+		Name:      dualStackName(claim.Name),
+		Tenant:    claim.Spec.Tenant,
+	}
+	if claim.Spec.DualStack != nil {
+		rd.ParentPrefix = claim.Spec.DualStack.ParentPrefix
+		rd.PrefixLength = claim.Spec.DualStack.PrefixLength
+		rd.ParentPrefixSelector = parentPrefixSelectorToString(claim.Spec.DualStack.ParentPrefixSelector)
+	}
+
+	return rd.ComputeHash()
+}
+
+// selectedParentPrefixOfPrefix returns the parent prefix the Prefix with the given name is
+// claimed from, for the Prefix of the other IP family in dual-stack mode it is the dual-stack one
+func selectedParentPrefixOfPrefix(claim *netboxv1.PrefixClaim, prefixName string) string {
+	if claim.Spec.DualStack != nil && prefixName == dualStackName(claim.Name) {
+		if claim.Status.DualStack == nil {
+			return ""
+		}
+		return claim.Status.DualStack.SelectedParentPrefix
+	}
+	return claim.Status.SelectedParentPrefix
+}
+
+// leaseLockOwnerOfPrefix returns the name used as owner of the parent prefix lease by the Prefix
+// with the given name. The Prefixes of a claim with a count greater than 1 are claimed together
+// and share the lease with their claim until it expires, see reconcilePrefixCount.
+func leaseLockOwnerOfPrefix(claim *netboxv1.PrefixClaim, prefixName string) string {
+	if sharesLeaseLock(claim.Spec.Count) {
+		return claim.Name
+	}
+	return prefixName
+}
+
 type PrefixClaimRestorationData struct {
 	// only use immutable fields
 	Namespace            string
@@ -104,11 +186,63 @@ type PrefixClaimRestorationData struct {
 	PrefixLength         string
 	Tenant               string
 	ParentPrefixSelector string
+	ParentPrefixes       string
 }
 
 func (rd *PrefixClaimRestorationData) ComputeHash() string {
 	if rd == nil {
 		return ""
 	}
-	return fmt.Sprintf("%x", sha1.Sum([]byte(rd.Namespace+rd.Name+rd.ParentPrefix+rd.PrefixLength+rd.Tenant+rd.ParentPrefixSelector)))
+	return fmt.Sprintf("%x", sha1.Sum([]byte(rd.Namespace+rd.Name+rd.ParentPrefix+rd.PrefixLength+rd.Tenant+rd.ParentPrefixSelector+rd.ParentPrefixes)))
+}
+
+// selectParentPrefixCandidate picks one of the parent prefix candidates according to the
+// selection strategy and returns it together with a summary of the scores of all candidates.
+// Ties are resolved by keeping the order in which NetBox returned the candidates.
+func selectParentPrefixCandidate(candidates []*models.ParentPrefixCandidate, strategy netboxv1.ParentPrefixSelectionStrategy) (*models.ParentPrefixCandidate, string, error) {
+	if len(candidates) == 0 {
+		return nil, "", errors.New("no parent prefix candidates to select from")
+	}
+	if strategy == "" {
+		strategy = netboxv1.ParentPrefixSelectionStrategyFirstFit
+	}
+
+	ranked := make([]*models.ParentPrefixCandidate, len(candidates))
+	copy(ranked, candidates)
+
+	switch strategy {
+	case netboxv1.ParentPrefixSelectionStrategyFirstFit:
+		// keep the order returned by NetBox
+	case netboxv1.ParentPrefixSelectionStrategyBestFit:
+		freeBlockLengths := make(map[*models.ParentPrefixCandidate]int, len(ranked))
+		for _, candidate := range ranked {
+			_, freeBlock, err := net.ParseCIDR(candidate.SmallestFreeBlock)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid smallest free block of parent prefix candidate %s: %w", candidate.Prefix, err)
+			}
+			ones, bits := freeBlock.Mask.Size()
+			// the number of host bits of the free block, the smaller the better
+			freeBlockLengths[candidate] = bits - ones
+		}
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return freeBlockLengths[ranked[i]] < freeBlockLengths[ranked[j]]
+		})
+	case netboxv1.ParentPrefixSelectionStrategyLeastUtilized:
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].Utilization < ranked[j].Utilization
+		})
+	case netboxv1.ParentPrefixSelectionStrategyMostUtilized:
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].Utilization > ranked[j].Utilization
+		})
+	default:
+		return nil, "", fmt.Errorf("unknown parent prefix selection strategy %s", strategy)
+	}
+
+	scores := make([]string, 0, len(ranked))
+	for _, candidate := range ranked {
+		scores = append(scores, fmt.Sprintf("%s (smallest free block %s, utilization %.1f%%)", candidate.Prefix, candidate.SmallestFreeBlock, candidate.Utilization*100))
+	}
+
+	return ranked[0], fmt.Sprintf("strategy %s, ranked candidates: %s", strategy, strings.Join(scores, "; ")), nil
 }
@@ -19,7 +19,9 @@ package controller
 import (
 	"testing"
 
+	"github.com/go-logr/logr"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 )
 
 func testPrefixClaimHash(t *testing.T, prefixClaim *netboxv1.PrefixClaim, expectedHash string) {
@@ -52,3 +54,167 @@ func TestBackwardCompatibilityOfGeneratePrefixRestorationHash(t *testing.T) {
 		testPrefixClaimHash(t, prefixClaim, "a0601ac7e6d196a82c0e61f9be17313113c3043f")
 	}
 }
+
+func TestGenerateDualStackPrefixRestorationHash(t *testing.T) {
+	// concatenated string = "defaultprefixclaim-sample-dualstack2001:db8::/48/64Dunder-Mifflin, Inc."
+	prefixClaim := &netboxv1.PrefixClaim{
+		Spec: netboxv1.PrefixClaimSpec{
+			ParentPrefix: "2.0.0.0/16",
+			PrefixLength: "/28",
+			Tenant:       "Dunder-Mifflin, Inc.",
+			DualStack: &netboxv1.PrefixClaimDualStackSpec{
+				ParentPrefix: "2001:db8::/48",
+				PrefixLength: "/64",
+			},
+		},
+	}
+	prefixClaim.Namespace = "default"
+	prefixClaim.Name = "prefixclaim-sample"
+
+	expectedHash := "e73eb2f75ac2a68770c83e2ed7a70f9e51addd29"
+	if generatedHash := generateDualStackPrefixRestorationHash(prefixClaim); generatedHash != expectedHash {
+		t.Errorf("hash mismatch: expected %#v, got %#v from %#v", expectedHash, generatedHash, prefixClaim)
+	}
+
+	// the hash of the first prefix is not affected by the dual-stack mode
+	testPrefixClaimHash(t, prefixClaim, "a0601ac7e6d196a82c0e61f9be17313113c3043f")
+}
+
+func TestGenerateIndexedPrefixRestorationHash(t *testing.T) {
+	prefixClaim := &netboxv1.PrefixClaim{
+		Spec: netboxv1.PrefixClaimSpec{
+			ParentPrefix: "2.0.0.0/16",
+			PrefixLength: "/28",
+			Tenant:       "Dunder-Mifflin, Inc.",
+			Count:        3,
+		},
+	}
+	prefixClaim.Namespace = "default"
+	prefixClaim.Name = "prefixclaim-sample"
+
+	// the hash of the prefix with index 0 is the one of the claim
+	if generatedHash := generateIndexedPrefixRestorationHash(prefixClaim, 0); generatedHash != "a0601ac7e6d196a82c0e61f9be17313113c3043f" {
+		t.Errorf("hash mismatch for index 0: got %#v", generatedHash)
+	}
+
+	// concatenated string = "defaultprefixclaim-sample-22.0.0.0/16/28Dunder-Mifflin, Inc."
+	if generatedHash := generateIndexedPrefixRestorationHash(prefixClaim, 2); generatedHash != "0616dc83c71e1a43e3c5511867b498be92650bad" {
+		t.Errorf("hash mismatch for index 2: got %#v", generatedHash)
+	}
+}
+
+func TestGeneratePrefixSpec_Vlan(t *testing.T) {
+	prefixClaim := &netboxv1.PrefixClaim{
+		Spec: netboxv1.PrefixClaimSpec{
+			ParentPrefix: "2.0.0.0/16",
+			PrefixLength: "/28",
+			Vlan:         &netboxv1.VlanReference{Vid: 100, VlanGroup: "prod-vlans"},
+		},
+	}
+	prefixClaim.Namespace = "default"
+	prefixClaim.Name = "prefixclaim-sample"
+
+	spec := generatePrefixSpec(prefixClaim, "2.0.0.0/28", "", logr.Discard())
+	if spec.Vlan == nil || *spec.Vlan != *prefixClaim.Spec.Vlan {
+		t.Fatalf("expected vlan %#v, got %#v", prefixClaim.Spec.Vlan, spec.Vlan)
+	}
+	if spec.Vlan == prefixClaim.Spec.Vlan {
+		t.Errorf("expected a copy of the vlan of the claim")
+	}
+
+	// the vlan is mutable, it is not part of the restoration hash
+	withoutVlan := prefixClaim.DeepCopy()
+	withoutVlan.Spec.Vlan = nil
+	if generatePrefixRestorationHash(prefixClaim) != generatePrefixRestorationHash(withoutVlan) {
+		t.Errorf("expected the same restoration hash with and without vlan")
+	}
+}
+
+func TestLeaseLockOfPrefix(t *testing.T) {
+	claim := &netboxv1.PrefixClaim{Spec: netboxv1.PrefixClaimSpec{Count: 2}}
+	claim.Name = "claim"
+
+	// the Prefix with index 0 is named after the claim, it must not unlock the lease shared
+	// with the Prefix with index 1
+	for index := 0; index < 2; index++ {
+		if owner := leaseLockOwnerOfPrefix(claim, indexedName(claim.Name, index)); owner != "claim" {
+			t.Errorf("expected the claim as owner of the lease of index %d, got %#v", index, owner)
+		}
+	}
+	if !sharesLeaseLock(claim.Spec.Count) {
+		t.Errorf("expected the Prefixes of a claim with count 2 to share the lease")
+	}
+
+	claim.Spec.Count = 0
+	if owner := leaseLockOwnerOfPrefix(claim, "claim"); owner != "claim" {
+		t.Errorf("expected the Prefix as owner of the lease, got %#v", owner)
+	}
+	if sharesLeaseLock(claim.Spec.Count) {
+		t.Errorf("expected the Prefix of a claim without count to unlock the lease")
+	}
+}
+
+func parentPrefixCandidatesForSelection() []*models.ParentPrefixCandidate {
+	return []*models.ParentPrefixCandidate{
+		{Prefix: "10.0.0.0/24", SmallestFreeBlock: "10.0.0.0/24", Utilization: 0},
+		{Prefix: "10.0.1.0/24", SmallestFreeBlock: "10.0.1.192/26", Utilization: 0.75},
+		{Prefix: "10.0.2.0/24", SmallestFreeBlock: "10.0.2.128/25", Utilization: 0.5},
+		{Prefix: "10.0.3.0/24", SmallestFreeBlock: "10.0.3.224/27", Utilization: 0.875},
+	}
+}
+
+func TestSelectParentPrefixCandidate(t *testing.T) {
+	tests := []struct {
+		strategy netboxv1.ParentPrefixSelectionStrategy
+		expected string
+	}{
+		{strategy: "", expected: "10.0.0.0/24"},
+		{strategy: netboxv1.ParentPrefixSelectionStrategyFirstFit, expected: "10.0.0.0/24"},
+		{strategy: netboxv1.ParentPrefixSelectionStrategyBestFit, expected: "10.0.3.0/24"},
+		{strategy: netboxv1.ParentPrefixSelectionStrategyLeastUtilized, expected: "10.0.0.0/24"},
+		{strategy: netboxv1.ParentPrefixSelectionStrategyMostUtilized, expected: "10.0.3.0/24"},
+	}
+
+	for _, tt := range tests {
+		selected, scores, err := selectParentPrefixCandidate(parentPrefixCandidatesForSelection(), tt.strategy)
+		if err != nil {
+			t.Fatalf("strategy %q: unexpected error: %v", tt.strategy, err)
+		}
+		if selected.Prefix != tt.expected {
+			t.Errorf("strategy %q: expected %s, got %s", tt.strategy, tt.expected, selected.Prefix)
+		}
+		if scores == "" {
+			t.Errorf("strategy %q: expected scores to be reported", tt.strategy)
+		}
+	}
+}
+
+func TestSelectParentPrefixCandidate_TieKeepsNetboxOrder(t *testing.T) {
+	candidates := []*models.ParentPrefixCandidate{
+		{Prefix: "10.0.0.0/24", SmallestFreeBlock: "10.0.0.128/25", Utilization: 0.5},
+		{Prefix: "10.0.1.0/24", SmallestFreeBlock: "10.0.1.0/25", Utilization: 0.5},
+	}
+
+	for _, strategy := range []netboxv1.ParentPrefixSelectionStrategy{
+		netboxv1.ParentPrefixSelectionStrategyBestFit,
+		netboxv1.ParentPrefixSelectionStrategyLeastUtilized,
+		netboxv1.ParentPrefixSelectionStrategyMostUtilized,
+	} {
+		selected, _, err := selectParentPrefixCandidate(candidates, strategy)
+		if err != nil {
+			t.Fatalf("strategy %q: unexpected error: %v", strategy, err)
+		}
+		if selected.Prefix != "10.0.0.0/24" {
+			t.Errorf("strategy %q: expected 10.0.0.0/24, got %s", strategy, selected.Prefix)
+		}
+	}
+}
+
+func TestSelectParentPrefixCandidate_Errors(t *testing.T) {
+	if _, _, err := selectParentPrefixCandidate(nil, netboxv1.ParentPrefixSelectionStrategyFirstFit); err == nil {
+		t.Errorf("expected error for empty candidate list")
+	}
+	if _, _, err := selectParentPrefixCandidate(parentPrefixCandidatesForSelection(), "Random"); err == nil {
+		t.Errorf("expected error for unknown strategy")
+	}
+}
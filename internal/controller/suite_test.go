@@ -54,12 +54,12 @@ var k8sClient client.Client
 var k8sManagerOptions ctrl.Options
 var testEnv *envtest.Environment
 var mockCtrl *gomock.Controller
-var mockIpamAPI *mock_interfaces.MockIpamAPI
 var mockIpamPrefixesListRequest *mock_interfaces.MockIpamPrefixesListRequest
-var ipamMockIpAddress *mock_interfaces.MockIpamInterface
-var ipamMockIpAddressClaim *mock_interfaces.MockIpamInterface
-var tenancyMock *mock_interfaces.MockTenancyInterface
-var dcimMock *mock_interfaces.MockDcimInterface
+var ipamMockIpAddress *mock_interfaces.MockIpamAPI
+var ipamMockIpAddressClaim *mock_interfaces.MockIpamAPI
+var tenancyMock *mock_interfaces.MockTenancyAPI
+var dcimMock *mock_interfaces.MockDcimAPI
+var extrasMock *mock_interfaces.MockExtrasAPI
 var ctx context.Context
 var cancel context.CancelFunc
 
@@ -110,11 +110,12 @@ var _ = BeforeSuite(func() {
 
 	mockCtrl = gomock.NewController(GinkgoT(), gomock.WithOverridableExpectations())
 
-	ipamMockIpAddress = mock_interfaces.NewMockIpamInterface(mockCtrl)
-	ipamMockIpAddressClaim = mock_interfaces.NewMockIpamInterface(mockCtrl)
-	tenancyMock = mock_interfaces.NewMockTenancyInterface(mockCtrl)
-	dcimMock = mock_interfaces.NewMockDcimInterface(mockCtrl)
-	mockIpamAPI = mock_interfaces.NewMockIpamAPI(mockCtrl)
+	ipamMockIpAddress = mock_interfaces.NewMockIpamAPI(mockCtrl)
+	ipamMockIpAddressClaim = mock_interfaces.NewMockIpamAPI(mockCtrl)
+	tenancyMock = mock_interfaces.NewMockTenancyAPI(mockCtrl)
+	dcimMock = mock_interfaces.NewMockDcimAPI(mockCtrl)
+	extrasMock = mock_interfaces.NewMockExtrasAPI(mockCtrl)
+	mockExtrasCustomFieldsList(extrasMock)
 	mockIpamPrefixesListRequest = mock_interfaces.NewMockIpamPrefixesListRequest(mockCtrl)
 
 	k8sManager, err := ctrl.NewManager(cfg, k8sManagerOptions)
@@ -125,14 +126,14 @@ var _ = BeforeSuite(func() {
 		Client:              k8sManager.GetClient(),
 		Scheme:              k8sManager.GetScheme(),
 		EventStatusRecorder: NewEventStatusRecorder(k8sManager.GetEventRecorderFor("ip-address-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
-		NetboxClient: api.NewNetboxCompositeClient(
-			&api.NetboxClientV3{
-				Ipam:    ipamMockIpAddress,
-				Tenancy: tenancyMock,
-				Dcim:    dcimMock,
+		NetboxClients: api.NewClientRegistry(nil, nil, "", api.NewNetboxCompositeClient(
+			&api.NetboxClientV4{
+				IpamAPI:    ipamMockIpAddress,
+				TenancyAPI: tenancyMock,
+				DcimAPI:    dcimMock,
+				ExtrasAPI:  extrasMock,
 			},
-			&api.NetboxClientV4{IpamAPI: mockIpamAPI},
-		),
+		)),
 		OperatorNamespace: OperatorNamespace,
 		RestConfig:        k8sManager.GetConfig(),
 	}).SetupWithManager(k8sManager)
@@ -142,14 +143,14 @@ var _ = BeforeSuite(func() {
 		Client:              k8sManager.GetClient(),
 		Scheme:              k8sManager.GetScheme(),
 		EventStatusRecorder: NewEventStatusRecorder(k8sManager.GetEventRecorderFor("ip-address-claim-controller")), //nolint:staticcheck // using deprecated API until controller-runtime migration is complete
-		NetboxClient: api.NewNetboxCompositeClient(
-			&api.NetboxClientV3{
-				Ipam:    ipamMockIpAddressClaim,
-				Tenancy: tenancyMock,
-				Dcim:    dcimMock,
+		NetboxClients: api.NewClientRegistry(nil, nil, "", api.NewNetboxCompositeClient(
+			&api.NetboxClientV4{
+				IpamAPI:    ipamMockIpAddressClaim,
+				TenancyAPI: tenancyMock,
+				DcimAPI:    dcimMock,
+				ExtrasAPI:  extrasMock,
 			},
-			&api.NetboxClientV4{IpamAPI: mockIpamAPI},
-		),
+		)),
 		OperatorNamespace: OperatorNamespace,
 		RestConfig:        k8sManager.GetConfig(),
 	}).SetupWithManager(k8sManager)
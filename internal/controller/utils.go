@@ -21,9 +21,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	corev1 "k8s.io/api/core/v1"
 	apismeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
@@ -101,6 +109,30 @@ func convertCIDRToLeaseLockName(cidr string) string {
 	return strings.ReplaceAll(strings.ReplaceAll(cidr, "/", "-"), ":", "-")
 }
 
+// convertVlanGroupToLeaseLockName returns the name of the lease which locks the vlan group, the
+// characters of the name or slug of the vlan group which are not allowed in a lease name are replaced
+func convertVlanGroupToLeaseLockName(vlanGroup string) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(vlanGroup))
+	return strings.TrimRight("vlangroup-"+name, "-")
+}
+
+// kinds of the custom resources, used as label values of the operator metrics
+const (
+	ipAddressKind      = "IpAddress"
+	ipAddressClaimKind = "IpAddressClaim"
+	ipRangeKind        = "IpRange"
+	ipRangeClaimKind   = "IpRangeClaim"
+	prefixKind         = "Prefix"
+	prefixClaimKind    = "PrefixClaim"
+	vlanKind           = "Vlan"
+	vlanClaimKind      = "VlanClaim"
+)
+
 // lockAcquireTimeout limits how long TryLock can block waiting for a lease.
 // The leaselocker's default LeaseDuration is 60s, meaning TryLock would block
 // for up to 61s on a contested or stale lease. This timeout limits the blocking
@@ -110,6 +142,185 @@ func convertCIDRToLeaseLockName(cidr string) string {
 // section is still protected.
 const lockAcquireTimeout = 10 * time.Second
 
+// parentPrefixSelectorToString returns a stable string representation of a parent prefix selector,
+// to be used as input for the restoration hash
+func parentPrefixSelectorToString(parentPrefixSelector map[string]string) string {
+	parentPrefixSelectorStr := ""
+	if len(parentPrefixSelector) > 0 {
+		// we generate the string by
+		// a) sort all keys in non-decreasing order (to avoid reordering the field in the CR causing a different hash to be generated)
+		// b) concat all the keys and values in the sequence of key1_value1_..._keyN_valueN
+
+		keyList := make([]string, 0, len(parentPrefixSelector))
+		for key := range parentPrefixSelector {
+			keyList = append(keyList, key)
+		}
+		sort.Strings(keyList)
+
+		for _, key := range keyList {
+			if len(parentPrefixSelectorStr) > 0 {
+				parentPrefixSelectorStr += "_"
+			}
+			parentPrefixSelectorStr += key + "_" + parentPrefixSelector[key]
+		}
+	}
+	return parentPrefixSelectorStr
+}
+
+// parentPrefixesToString returns a string representation of the ordered parent prefixes,
+// to be used as input for the restoration hash
+func parentPrefixesToString(parentPrefixes []string) string {
+	return strings.Join(parentPrefixes, "_")
+}
+
+// isParentPrefixUnusableErr reports whether err signals that nothing can be claimed from
+// the parent prefix anymore, so another parent prefix should be selected
+func isParentPrefixUnusableErr(err error) bool {
+	return errors.Is(err, api.ErrParentPrefixExhausted) ||
+		errors.Is(err, api.ErrParentPrefixNotFound) ||
+		errors.Is(err, api.ErrNoPrefixMatchsSizeCriteria) ||
+		errors.Is(err, api.ErrNotEnoughConsecutiveIps)
+}
+
+// selectParentPrefixFromList walks the ordered parent prefixes and returns the index of the
+// first entry the claim can be fulfilled from. Entries for which claimFromParentPrefix returns
+// an error matched by isParentPrefixUnusableErr are skipped, any other error is returned as is.
+func selectParentPrefixFromList(parentPrefixes []string, claimFromParentPrefix func(parentPrefix string) error) (int, error) {
+	if len(parentPrefixes) == 0 {
+		return -1, errors.New("no parent prefix given in parentPrefixes")
+	}
+
+	var skipped error
+	for i, parentPrefix := range parentPrefixes {
+		err := claimFromParentPrefix(parentPrefix)
+		if err == nil {
+			return i, nil
+		}
+		if !isParentPrefixUnusableErr(err) {
+			return -1, err
+		}
+		skipped = errors.Join(skipped, fmt.Errorf("parent prefix %s skipped, %w", parentPrefix, err))
+	}
+	return -1, fmt.Errorf("no usable parent prefix found in parentPrefixes: %w", skipped)
+}
+
+// observeParentExhausted counts the failed allocations of a claim caused by an exhausted parent
+func observeParentExhausted(kind string, parent string, err error) {
+	if errors.Is(err, api.ErrParentPrefixExhausted) ||
+		errors.Is(err, api.ErrParentIpRangeExhausted) ||
+		errors.Is(err, api.ErrNotEnoughConsecutiveIps) ||
+		errors.Is(err, api.ErrVlanGroupExhausted) {
+		metrics.ParentExhaustedTotal.WithLabelValues(kind, parent).Inc()
+	}
+}
+
+// dualStackNameSuffix is appended to the name of a claim to get the name of the
+// child resource of the other IP family in dual-stack mode
+const dualStackNameSuffix = "-dualstack"
+
+// dualStackName returns the name of the child resource of the other IP family in dual-stack mode
+func dualStackName(claimName string) string {
+	return claimName + dualStackNameSuffix
+}
+
+// claimCount returns the number of child resources of a claim, an unset count means 1
+func claimCount(count int32) int {
+	if count < 1 {
+		return 1
+	}
+	return int(count)
+}
+
+// sharesLeaseLock returns whether the resources of a claim with the count share the lease of
+// their parent. The shared lease is not unlocked by any of the resources, including the one with
+// index 0 which is named after the claim, it expires once all of them are reserved.
+func sharesLeaseLock(count int32) bool {
+	return claimCount(count) > 1
+}
+
+// indexedName returns the name of the child resource with the given index of a claim,
+// the child resource with index 0 is named after the claim
+func indexedName(claimName string, index int) string {
+	if index == 0 {
+		return claimName
+	}
+	return claimName + "-" + strconv.Itoa(index)
+}
+
+// errNameConflict is returned if the name of a resource of a claim is already used by a resource
+// which is not controlled by the claim, e.g. the resource with index 1 of a claim named "pool" and
+// the resource of a claim named "pool-1"
+var errNameConflict = errors.New("name conflict")
+
+// checkNameConflict returns a domain error wrapping errNameConflict if the existing resource with
+// the name of a resource of the claim is controlled by another owner. A resource without controller
+// is adopted by the claim, unless adoptable is false.
+func checkNameConflict(kind string, existing metav1.Object, claim metav1.Object, adoptable bool) error {
+	controller := metav1.GetControllerOf(existing)
+	switch {
+	case metav1.IsControlledBy(existing, claim):
+		return nil
+	case controller != nil:
+		return NewDomainError("%w: %s %s is controlled by %s %s", errNameConflict, kind, existing.GetName(), controller.Kind, controller.Name)
+	case !adoptable:
+		return NewDomainError("%w: %s %s already exists and is not controlled by %s", errNameConflict, kind, existing.GetName(), claim.GetName())
+	}
+	return nil
+}
+
+// indexOfIndexedName returns the index of the child resource name of a claim, or -1 if the
+// name was not generated with indexedName
+func indexOfIndexedName(claimName string, name string) int {
+	if name == claimName {
+		return 0
+	}
+	suffix, found := strings.CutPrefix(name, claimName+"-")
+	if !found {
+		return -1
+	}
+	index, err := strconv.Atoi(suffix)
+	if err != nil || index < 1 || strconv.Itoa(index) != suffix {
+		return -1
+	}
+	return index
+}
+
+// verifyOtherIpFamily returns an error if the dual-stack parent prefix has the same
+// IP family as the parent prefix of the claim
+func verifyOtherIpFamily(parentPrefix string, dualStackParentPrefix string) error {
+	primary, err := netip.ParsePrefix(parentPrefix)
+	if err != nil {
+		return fmt.Errorf("invalid parent prefix %s: %w", parentPrefix, err)
+	}
+	secondary, err := netip.ParsePrefix(dualStackParentPrefix)
+	if err != nil {
+		return fmt.Errorf("invalid dual-stack parent prefix %s: %w", dualStackParentPrefix, err)
+	}
+	if primary.Addr().Is4() == secondary.Addr().Is4() {
+		return fmt.Errorf("the dual-stack parent prefix %s must be of the other IP family than the parent prefix %s", dualStackParentPrefix, parentPrefix)
+	}
+	return nil
+}
+
+// inheritedVrf returns the VRF of the claim if it is set, and otherwise the VRF of the parent
+// which NetBox passes on in the metadata of the claimed Prefix, IP Address or IP Range
+func inheritedVrf(claimVrf string, metadata *models.NetboxMetadata) string {
+	if claimVrf != "" || metadata == nil {
+		return claimVrf
+	}
+	return metadata.Vrf
+}
+
+// utilizationThresholdCrossed returns true if the utilization reaches the threshold and the
+// previous one didn't, so that the warning is emitted once and not on every reconciliation.
+// A threshold of 0 disables the warning.
+func utilizationThresholdCrossed(previous *netboxv1.PrefixUtilization, current *netboxv1.PrefixUtilization, threshold int) bool {
+	if threshold <= 0 || int(current.UsedPercent) < threshold {
+		return false
+	}
+	return previous == nil || int(previous.UsedPercent) < threshold
+}
+
 func generateManagedCustomFieldsAnnotation(customFields map[string]string) (string, error) {
 	if customFields == nil {
 		customFields = make(map[string]string)
@@ -123,6 +334,33 @@ func generateManagedCustomFieldsAnnotation(customFields map[string]string) (stri
 	return string(metadataJSON), nil
 }
 
+// generateManagedTagsAnnotation returns the annotation which records the tags the operator
+// assigned to a resource in NetBox, so that tags removed from the spec can be detached
+func generateManagedTagsAnnotation(tags []string) (string, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal tags to JSON: %w", err)
+	}
+
+	return string(tagsJSON), nil
+}
+
+// parseManagedTagsAnnotation returns the tags of an annotation generated by generateManagedTagsAnnotation
+func parseManagedTagsAnnotation(annotation string) ([]string, error) {
+	var tags []string
+	if annotation == "" {
+		return tags, nil
+	}
+	if err := json.Unmarshal([]byte(annotation), &tags); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal managed tags annotation: %w", err)
+	}
+	return tags, nil
+}
+
 func removeFinalizer(ctx context.Context, c client.Client, o client.Object, finalizerName string) error {
 	logger := log.FromContext(ctx)
 	if controllerutil.ContainsFinalizer(o, finalizerName) {
@@ -177,6 +415,15 @@ func (esr *EventStatusRecorder) Report(ctx context.Context, o ObjectWithConditio
 	}
 }
 
+// ReportTokenRejected emits a warning event on o if NetBox rejected the API token of
+// netboxClient as invalid or expired, e.g. because the token was rotated in NetBox but
+// not yet in the Secret the operator reads it from.
+func (esr *EventStatusRecorder) ReportTokenRejected(o client.Object, netboxClient *api.NetboxCompositeClient) {
+	if netboxClient != nil && netboxClient.TokenRejected() {
+		esr.rec.Event(o, corev1.EventTypeWarning, netboxv1.ConditionNetBoxConnectionReadyFalseTokenRejected.Reason, api.ErrTokenRejected.Error())
+	}
+}
+
 func (esr *EventStatusRecorder) Recorder() record.EventRecorder {
 	return esr.rec
 }
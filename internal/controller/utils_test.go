@@ -20,8 +20,13 @@ import (
 	"errors"
 	"fmt"
 
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
 )
 
 var _ = Describe("excludeDomainErrors", func() {
@@ -92,3 +97,141 @@ var _ = Describe("excludeDomainErrors", func() {
 		Expect(errors.As(remaining, &domainErr)).To(BeFalse())
 	})
 })
+
+var _ = Describe("selectParentPrefixFromList", func() {
+	parentPrefixes := []string{"10.0.0.0/20", "10.0.16.0/20", "10.0.32.0/20"}
+
+	It("selects the first entry if it can be claimed from", func() {
+		i, err := selectParentPrefixFromList(parentPrefixes, func(parentPrefix string) error {
+			return nil
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(i).To(Equal(0))
+	})
+
+	It("skips exhausted and not found entries", func() {
+		i, err := selectParentPrefixFromList(parentPrefixes, func(parentPrefix string) error {
+			switch parentPrefix {
+			case "10.0.0.0/20":
+				return api.ErrParentPrefixExhausted
+			case "10.0.16.0/20":
+				return api.ErrParentPrefixNotFound
+			}
+			return nil
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(i).To(Equal(2))
+	})
+
+	It("returns other errors without trying the next entries", func() {
+		otherErr := errors.New("failed to fetch tenant")
+		calls := 0
+
+		i, err := selectParentPrefixFromList(parentPrefixes, func(parentPrefix string) error {
+			calls++
+			return otherErr
+		})
+
+		Expect(errors.Is(err, otherErr)).To(BeTrue())
+		Expect(i).To(Equal(-1))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("fails if no entry can be claimed from", func() {
+		i, err := selectParentPrefixFromList(parentPrefixes, func(parentPrefix string) error {
+			return fmt.Errorf("wrapped: %w", api.ErrParentPrefixExhausted)
+		})
+
+		Expect(errors.Is(err, api.ErrParentPrefixExhausted)).To(BeTrue())
+		Expect(i).To(Equal(-1))
+	})
+})
+
+var _ = Describe("utilizationThresholdCrossed", func() {
+	utilization := func(usedPercent int32) *netboxv1.PrefixUtilization {
+		return &netboxv1.PrefixUtilization{UsedPercent: usedPercent}
+	}
+
+	It("reports the first utilization reaching the threshold", func() {
+		Expect(utilizationThresholdCrossed(nil, utilization(90), 90)).To(BeTrue())
+	})
+
+	It("reports a utilization crossing the threshold", func() {
+		Expect(utilizationThresholdCrossed(utilization(89), utilization(95), 90)).To(BeTrue())
+	})
+
+	It("doesn't report a utilization which already reached the threshold", func() {
+		Expect(utilizationThresholdCrossed(utilization(91), utilization(95), 90)).To(BeFalse())
+	})
+
+	It("doesn't report a utilization below the threshold", func() {
+		Expect(utilizationThresholdCrossed(nil, utilization(89), 90)).To(BeFalse())
+	})
+
+	It("doesn't report anything if the threshold is disabled", func() {
+		Expect(utilizationThresholdCrossed(nil, utilization(100), 0)).To(BeFalse())
+	})
+})
+
+var _ = Describe("observeParentExhausted", func() {
+	exhaustedCount := func(parent string) float64 {
+		m := &dto.Metric{}
+		Expect(metrics.ParentExhaustedTotal.WithLabelValues(prefixClaimKind, parent).Write(m)).To(Succeed())
+		return m.GetCounter().GetValue()
+	}
+
+	It("counts allocations failing with an exhausted parent", func() {
+		observeParentExhausted(prefixClaimKind, "10.0.0.0/24", fmt.Errorf("wrapped: %w", api.ErrParentPrefixExhausted))
+		Expect(exhaustedCount("10.0.0.0/24")).To(Equal(float64(1)))
+	})
+
+	It("ignores other errors", func() {
+		observeParentExhausted(prefixClaimKind, "10.0.1.0/24", api.ErrParentPrefixNotFound)
+		Expect(exhaustedCount("10.0.1.0/24")).To(Equal(float64(0)))
+	})
+})
+
+var _ = Describe("managed tags annotation", func() {
+	It("round-trips the tags", func() {
+		annotation, err := generateManagedTagsAnnotation([]string{"production", "team-network"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotation).To(Equal(`["production","team-network"]`))
+
+		tags, err := parseManagedTagsAnnotation(annotation)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tags).To(Equal([]string{"production", "team-network"}))
+	})
+
+	It("records an empty list without tags", func() {
+		annotation, err := generateManagedTagsAnnotation(nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotation).To(Equal("[]"))
+	})
+
+	It("returns no tags for a missing annotation", func() {
+		tags, err := parseManagedTagsAnnotation("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tags).To(BeEmpty())
+	})
+
+	It("returns an error for an invalid annotation", func() {
+		_, err := parseManagedTagsAnnotation("production")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("inherited VRF", func() {
+	It("uses the VRF of the claim", func() {
+		Expect(inheritedVrf("customer-a", &models.NetboxMetadata{Vrf: "customer-b"})).To(Equal("customer-a"))
+	})
+
+	It("inherits the VRF of the parent if the claim has none", func() {
+		Expect(inheritedVrf("", &models.NetboxMetadata{Vrf: "customer-b"})).To(Equal("customer-b"))
+	})
+
+	It("uses the global table if neither has a VRF", func() {
+		Expect(inheritedVrf("", nil)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,386 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/scheduler"
+
+	"github.com/swisscom/leaselocker"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apismeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const VlanFinalizerName = "vlan.netbox.dev/finalizer"
+const VLManagedCustomFieldsAnnotationName = "vlan.netbox.dev/managed-custom-fields"
+const VLManagedTagsAnnotationName = "vlan.netbox.dev/managed-tags"
+
+// VlanReconciler reconciles a Vlan object
+type VlanReconciler struct {
+	client.Client
+	Scheme              *runtime.Scheme
+	NetboxClients       *api.ClientRegistry
+	EventStatusRecorder *EventStatusRecorder
+	OperatorNamespace   string
+	RestConfig          *rest.Config
+}
+
+//+kubebuilder:rbac:groups=netbox.dev,resources=vlans,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=netbox.dev,resources=vlans/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=netbox.dev,resources=vlans/finalizers,verbs=update
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *VlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcileResult ctrl.Result, reconcileErr error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("reconcile loop started")
+
+	o := &netboxv1.Vlan{}
+	err := r.Get(ctx, req.NamespacedName, o)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Snapshot for status patch — taken before any status mutations so the
+	// merge-patch diff captures every change (VlanId, conditions, etc.).
+	statusBase := o.DeepCopy()
+
+	// Defer status update to ensure it happens regardless of how we exit
+	defer func() {
+		reconcileResult, reconcileErr = r.updateStatus(ctx, o, statusBase, reconcileResult, reconcileErr)
+		if reconcileErr == nil && reconcileResult.IsZero() {
+			reconcileResult, reconcileErr = scheduler.CalculateNextReconcile(ctx)
+		}
+		logger.Info("reconcile loop finished")
+	}()
+
+	// resolve the client of the NetBox instance the resource is managed in
+	netboxClient, err := r.NetboxClients.ClientFor(ctx, o.Spec.Connection)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer r.EventStatusRecorder.ReportTokenRejected(o, netboxClient)
+
+	// short-circuit while the circuit breaker of NetBox is open instead of piling up failing requests
+	if err := netboxClient.Available(); err != nil {
+		return ctrl.Result{RequeueAfter: netboxClient.RetryAfter()}, NewDomainError("%w", err)
+	}
+
+	// if being deleted
+	if !o.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(o, VlanFinalizerName) {
+			return ctrl.Result{}, nil
+		}
+
+		if !o.Spec.PreserveInNetbox {
+			if o.Status.VlanId > math.MaxInt32 {
+				return ctrl.Result{}, fmt.Errorf("reconciliation of vlans with id's larger than 2147483647 is not supported")
+			}
+			if err := netboxClient.DeleteVlan(ctx, int32(o.Status.VlanId)); err != nil {
+				return ctrl.Result{}, NewDomainError("failed to delete vlan in netbox: %w", err)
+			}
+		}
+
+		return ctrl.Result{}, removeFinalizer(ctx, r.Client, o, VlanFinalizerName)
+	}
+
+	// if PreserveInNetbox flag is false then register finalizer if not yet registered
+	if !o.Spec.PreserveInNetbox {
+		err = addFinalizer(ctx, r.Client, o, VlanFinalizerName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// 1. try to lock lease of vlan group if Vlan status condition is not true
+	// and Vlan is owned by a VlanClaim
+	or := o.OwnerReferences
+	var ll *leaselocker.LeaseLocker
+	var cancelLock context.CancelFunc
+	if len(or) > 0 && !apismeta.IsStatusConditionTrue(o.Status.Conditions, "Ready") && o.Spec.VlanGroup != "" {
+
+		leaseLockerNSN, owner, vlanGroup, err := r.getLeaseLockerNSNandOwner(ctx, o)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		ll, err = leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, owner)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		var lockCtx context.Context
+		lockCtx, cancelLock = context.WithTimeout(ctx, lockAcquireTimeout)
+		defer func() {
+			if cancelLock != nil {
+				cancelLock()
+			}
+		}()
+
+		// create lock
+		locked := ll.TryLock(lockCtx)
+		if !locked {
+			metrics.LeaseLockContentionTotal.WithLabelValues(vlanKind, vlanGroup).Inc()
+			errorMsg := fmt.Sprintf("failed to lock vlan group %s", vlanGroup)
+			r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "FailedToLockVlanGroup", errorMsg)
+			return ctrl.Result{
+				RequeueAfter: 2 * time.Second,
+			}, NewDomainError("%s", errorMsg)
+		}
+		logger.V(4).Info(fmt.Sprintf("successfully locked vlan group %s", vlanGroup))
+	}
+
+	// 2. reserve or update vlan in netbox
+	accessor := apismeta.NewAccessor()
+	annotations, err := accessor.Annotations(o)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	vlanModel, err := r.generateNetboxVlanModelFromVlanSpec(o, req, annotations[VLManagedCustomFieldsAnnotationName], annotations[VLManagedTagsAnnotationName])
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	netboxVlanModel, statusUpToDate, err := netboxClient.ReserveOrUpdateVlan(ctx, vlanModel, o)
+	if err != nil {
+		if errors.Is(err, api.ErrRestorationHashMismatch) && o.Status.VlanId == 0 {
+			// if there is a restoration hash mismatch and the vlanId status field is not set,
+			// delete the vlan so it can be recreated by the vlan claim controller
+			// this will only affect resources that are created by a claim controller (and have a restoration hash custom field
+			logger.Info("restoration hash mismatch, deleting vlan custom resource", "vid", o.Spec.Vid)
+			if deleteErr := r.Delete(ctx, o); deleteErr != nil {
+				return ctrl.Result{}, NewDomainError("failed to delete Vlan CR with restoration hash mismatch: %w", deleteErr)
+			}
+			// Object deleted - status update in deferred function will be ignored via client.IgnoreNotFound
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, NewDomainError("%w", err)
+	}
+
+	// 3. unlock lease of vlan group
+	if ll != nil {
+		cancelLock()
+		ll.UnlockWithRetry(ctx)
+	}
+
+	// 4. if no change, then end loop
+	if statusUpToDate {
+		return ctrl.Result{}, nil
+	}
+
+	// 4.1 update annotation
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+
+	annotations[VLManagedCustomFieldsAnnotationName], err = generateManagedCustomFieldsAnnotation(o.Spec.CustomFields)
+	if err != nil {
+		return ctrl.Result{}, NewDomainError("failed to generate managed custom fields annotation: %w", err)
+	}
+
+	annotations[VLManagedTagsAnnotationName], err = generateManagedTagsAnnotation(o.Spec.Tags)
+	if err != nil {
+		return ctrl.Result{}, NewDomainError("failed to generate managed tags annotation: %w", err)
+	}
+
+	// snapshot before annotation mutation for merge-patch
+	patch := client.MergeFrom(o.DeepCopy())
+
+	err = accessor.SetAnnotations(o, annotations)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// patch object to store the managed custom fields and tags annotations
+	err = r.Patch(ctx, o, patch)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// update status fields (set after r.Patch to avoid being overwritten by API response)
+	o.Status.VlanId = int64(netboxVlanModel.GetId())
+	o.Status.VlanUrl = netboxClient.BaseUrl() + "/ipam/vlans/" + strconv.FormatInt(int64(netboxVlanModel.GetId()), 10)
+	if netboxVlanModel.LastUpdated.IsSet() {
+		o.Status.LastUpdated = metav1.NewTime(*netboxVlanModel.LastUpdated.Get())
+	}
+
+	// check if the created vlan contains the entire description from spec
+	if netboxVlanModel.Description == nil {
+		return ctrl.Result{}, NewDomainError("vlan in netbox is missing a description")
+	}
+	if _, found := strings.CutPrefix(*netboxVlanModel.Description, req.String()+" // "+o.Spec.Description); !found {
+		r.EventStatusRecorder.Recorder().Event(o, corev1.EventTypeWarning, "VlanDescriptionTruncated", "vlan was created with truncated description")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VlanReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&netboxv1.Vlan{}).
+		Complete(r)
+}
+
+// updateStatus updates the Vlan status conditions based on the current state of the object.
+// This function is called as a deferred function in Reconcile to ensure status is always updated.
+func (r *VlanReconciler) updateStatus(ctx context.Context, o *netboxv1.Vlan, statusBase *netboxv1.Vlan, reconcileRes ctrl.Result, reconcileErr error) (result ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+
+	// Set default return values
+	result = reconcileRes
+	err = reconcileErr
+
+	if apierrors.IsConflict(err) {
+		// Object was modified concurrently — skip status update, will retry on requeue
+		return IgnoreDomainError(result, err)
+	}
+
+	logger.V(4).Info("updating vlan status")
+
+	switch {
+	case errors.Is(reconcileErr, api.ErrNetboxUnavailable):
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionReadyFalseNetBoxUnavailable, corev1.EventTypeWarning, reconcileErr)
+	case o.DeletionTimestamp.IsZero() && errors.Is(reconcileErr, api.ErrInvalidCustomFieldValue):
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionReadyFalseInvalidCustomField, corev1.EventTypeWarning, reconcileErr)
+	case !o.DeletionTimestamp.IsZero() && reconcileErr != nil:
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionVlanReadyFalseDeletionFailed, corev1.EventTypeWarning, reconcileErr)
+	case !o.DeletionTimestamp.IsZero():
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionVlanReadyFalseDeletionInProgress, corev1.EventTypeNormal, nil)
+	case o.Status.VlanUrl == "" || reconcileErr != nil:
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionVlanReadyFalse, corev1.EventTypeWarning, reconcileErr,
+			fmt.Sprintf("vid: %d", o.Spec.Vid))
+	default:
+		r.EventStatusRecorder.Report(ctx, o,
+			netboxv1.ConditionVlanReadyTrue, corev1.EventTypeNormal, nil)
+	}
+
+	// Align resource version so the patch targets the latest revision
+	statusBase.SetResourceVersion(o.GetResourceVersion())
+	statusPatch := client.MergeFrom(statusBase)
+	patchErr := r.Status().Patch(ctx, o, statusPatch)
+	if patchErr != nil {
+		patchErr = client.IgnoreNotFound(patchErr)
+		if patchErr != nil {
+			err = errors.Join(err, patchErr)
+		}
+	}
+
+	return IgnoreDomainError(result, err)
+}
+
+func (r *VlanReconciler) generateNetboxVlanModelFromVlanSpec(o *netboxv1.Vlan, req ctrl.Request, lastVlanMetadata string, lastManagedTags string) (*models.Vlan, error) {
+	managedTags, err := parseManagedTagsAnnotation(lastManagedTags)
+	if err != nil {
+		return nil, err
+	}
+
+	// unmarshal lastVlanMetadata json string to map[string]string
+	lastAppliedCustomFields := make(map[string]string)
+	if lastVlanMetadata != "" {
+		if err := json.Unmarshal([]byte(lastVlanMetadata), &lastAppliedCustomFields); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal lastVlanMetadata annotation: %w", err)
+		}
+	}
+
+	netboxCustomFields := make(map[string]string)
+	if len(o.Spec.CustomFields) > 0 {
+		netboxCustomFields = maps.Clone(o.Spec.CustomFields)
+	}
+
+	// if a custom field was removed from the spec, add it with an empty value
+	for key := range lastAppliedCustomFields {
+		_, ok := netboxCustomFields[key]
+		if !ok {
+			netboxCustomFields[key] = ""
+		}
+	}
+
+	return &models.Vlan{
+		Vid:       o.Spec.Vid,
+		Name:      o.Spec.Name,
+		VlanGroup: o.Spec.VlanGroup,
+		Metadata: &models.NetboxMetadata{
+			Comments:    o.Spec.Comments,
+			Custom:      netboxCustomFields,
+			Description: req.String() + " // " + o.Spec.Description,
+			Tenant:      o.Spec.Tenant,
+			Status:      o.Spec.Status,
+			Role:        o.Spec.Role,
+			Tags:        o.Spec.Tags,
+			ManagedTags: managedTags,
+		},
+	}, nil
+}
+
+func (r *VlanReconciler) getLeaseLockerNSNandOwner(ctx context.Context, o *netboxv1.Vlan) (types.NamespacedName, string, string, error) {
+
+	orLookupKey := types.NamespacedName{
+		Name:      o.ObjectMeta.OwnerReferences[0].Name,
+		Namespace: o.Namespace,
+	}
+
+	vlanClaim := &netboxv1.VlanClaim{}
+	err := r.Get(ctx, orLookupKey, vlanClaim)
+	if err != nil {
+		return types.NamespacedName{}, "", "", err
+	}
+
+	if vlanClaim.Status.SelectedVlanGroup == "" {
+		// the vlan group is not selected
+		return types.NamespacedName{}, "", "", NewDomainError("the vlan group is not selected")
+	}
+
+	// get name of vlan group
+	leaseLockerNSN := types.NamespacedName{
+		Name:      convertVlanGroupToLeaseLockName(vlanClaim.Status.SelectedVlanGroup),
+		Namespace: r.OperatorNamespace,
+	}
+
+	return leaseLockerNSN, orLookupKey.String(), vlanClaim.Status.SelectedVlanGroup, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apismeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const vlanTestTimeout = time.Second * 4
+const vlanTestInterval = time.Millisecond * 250
+
+// newVlanTestClients returns the client registry of the vlan tests, in which the default client uses the ipam mock
+func newVlanTestClients(ipamMock *mock_interfaces.MockIpamAPI) *api.ClientRegistry {
+	return api.NewClientRegistry(nil, nil, "", api.NewNetboxCompositeClient(
+		&api.NetboxClientV4{
+			IpamAPI:    ipamMock,
+			TenancyAPI: tenancyMock,
+			DcimAPI:    dcimMock,
+			ExtrasAPI:  extrasMock,
+		},
+	))
+}
+
+// removeFinalizersAndDelete deletes the object without its finalizers, the reconcilers of the
+// vlan tests are called directly and don't run in the manager of the test environment
+func removeFinalizersAndDelete(ctx context.Context, obj client.Object) {
+	key := client.ObjectKeyFromObject(obj)
+	err := k8sClient.Get(ctx, key, obj)
+	if apierrors.IsNotFound(err) {
+		return
+	}
+	Expect(err).NotTo(HaveOccurred())
+
+	obj.SetFinalizers(nil)
+	Expect(client.IgnoreNotFound(k8sClient.Update(ctx, obj))).To(Succeed())
+	Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+
+	Eventually(func() bool {
+		return apierrors.IsNotFound(k8sClient.Get(ctx, key, obj))
+	}, vlanTestTimeout, vlanTestInterval).Should(BeTrue())
+}
+
+var _ = Describe("Vlan Controller", Ordered, func() {
+
+	var unexpectedCallCh chan error
+	var ipamMockVlan *mock_interfaces.MockIpamAPI
+	var vlanReconciler *VlanReconciler
+
+	vlanKey := types.NamespacedName{Name: vlanClaimName, Namespace: namespace}
+
+	BeforeEach(func() {
+		// the mocks report calls with unexpected parameters without blocking the reconciler
+		unexpectedCallCh = make(chan error, 10)
+		ipamMockVlan = mock_interfaces.NewMockIpamAPI(mockCtrl)
+
+		vlanReconciler = &VlanReconciler{
+			Client:              k8sClient,
+			Scheme:              scheme.Scheme,
+			EventStatusRecorder: NewEventStatusRecorder(record.NewFakeRecorder(100)),
+			NetboxClients:       newVlanTestClients(ipamMockVlan),
+			OperatorNamespace:   OperatorNamespace,
+			RestConfig:          cfg,
+		}
+	})
+
+	AfterEach(func() {
+		Expect(unexpectedCallCh).NotTo(Receive())
+
+		By("Cleaning up the Vlan CR")
+		removeFinalizersAndDelete(ctx, &netboxv1.Vlan{ObjectMeta: metav1.ObjectMeta{Name: vlanKey.Name, Namespace: vlanKey.Namespace}})
+	})
+
+	It("reserves the vlan in NetBox and deletes it when the Vlan CR is deleted", func() {
+		By("Setting up mocks")
+		mockVlansListEmptyResult(ipamMockVlan, unexpectedCallCh)
+		mockVlansCreate(ipamMockVlan, unexpectedCallCh, expectedVlanToCreate(nil, map[string]interface{}{
+			"example_field": "example value",
+		}))
+		mockVlansDestroy(ipamMockVlan, unexpectedCallCh)
+
+		By("Creating Vlan CR")
+		Expect(k8sClient.Create(ctx, defaultVlanCR(false, customFieldsCR))).To(Succeed())
+
+		By("Reserving the vlan in NetBox")
+		_, err := vlanReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: vlanKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		createdCR := &netboxv1.Vlan{}
+		Expect(k8sClient.Get(ctx, vlanKey, createdCR)).To(Succeed())
+		Expect(createdCR.Status.VlanId).To(Equal(int64(vlanId)))
+		Expect(apismeta.IsStatusConditionTrue(createdCR.Status.Conditions, netboxv1.ConditionVlanReadyTrue.Type)).To(BeTrue())
+		Expect(createdCR.Finalizers).To(ContainElement(VlanFinalizerName))
+
+		By("Deleting the vlan in NetBox")
+		Expect(k8sClient.Delete(ctx, createdCR)).To(Succeed())
+		_, err = vlanReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: vlanKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, vlanKey, createdCR))
+		}, vlanTestTimeout, vlanTestInterval).Should(BeTrue())
+	})
+
+	It("deletes the Vlan CR if the restoration hash does not match", func() {
+		By("Setting up mocks")
+		mockVlansListWithHashMismatch(ipamMockVlan, unexpectedCallCh)
+
+		By("Creating Vlan CR")
+		Expect(k8sClient.Create(ctx, defaultVlanCR(true, customFieldsWithHashCR))).To(Succeed())
+
+		By("Reconciling the Vlan CR")
+		_, err := vlanReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: vlanKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		// the Vlan CR is deleted, so that the vlan claim controller assigns a new vlan
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, vlanKey, &netboxv1.Vlan{}))
+		}, vlanTestTimeout, vlanTestInterval).Should(BeTrue())
+	})
+})
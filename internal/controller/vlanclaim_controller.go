@@ -0,0 +1,397 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/api"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/scheduler"
+
+	"github.com/swisscom/leaselocker"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apismeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const VlanClaimFinalizerName = "vlanclaim.netbox.dev/finalizer"
+
+// VlanClaimReconciler reconciles a VlanClaim object
+type VlanClaimReconciler struct {
+	client.Client
+	Scheme              *runtime.Scheme
+	NetboxClients       *api.ClientRegistry
+	EventStatusRecorder *EventStatusRecorder
+	OperatorNamespace   string
+	RestConfig          *rest.Config
+}
+
+//+kubebuilder:rbac:groups=netbox.dev,resources=vlanclaims,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=netbox.dev,resources=vlanclaims/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=netbox.dev,resources=vlanclaims/finalizers,verbs=update
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *VlanClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcileResult ctrl.Result, reconcileErr error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("reconcile loop started")
+
+	o := &netboxv1.VlanClaim{}
+	err := r.Get(ctx, req.NamespacedName, o)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Snapshot for status patch — taken before any status mutations so the
+	// merge-patch diff captures every change.
+	statusBase := o.DeepCopy()
+
+	vlan := &netboxv1.Vlan{}
+	vlanLookupKey := types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+
+	// if being deleted
+	if !o.DeletionTimestamp.IsZero() {
+		err = r.Get(ctx, vlanLookupKey, vlan)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, removeFinalizer(ctx, r.Client, o, VlanClaimFinalizerName)
+		}
+
+		if err = r.Delete(ctx, vlan); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		// requeue if owned vlan was still found
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// Defer status update to ensure it happens regardless of how we exit
+	defer func() {
+		reconcileResult, reconcileErr = r.updateStatus(ctx, o, statusBase, vlanLookupKey, reconcileResult, reconcileErr)
+		if reconcileErr == nil && reconcileResult.IsZero() {
+			reconcileResult, reconcileErr = scheduler.CalculateNextReconcile(ctx)
+		}
+		logger.Info("reconcile loop finished")
+	}()
+
+	// resolve the client of the NetBox instance the resource is managed in
+	netboxClient, err := r.NetboxClients.ClientFor(ctx, o.Spec.Connection)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	defer r.EventStatusRecorder.ReportTokenRejected(o, netboxClient)
+
+	// short-circuit while the circuit breaker of NetBox is open instead of piling up failing requests
+	if err := netboxClient.Available(); err != nil {
+		return ctrl.Result{RequeueAfter: netboxClient.RetryAfter()}, NewDomainError("%w", err)
+	}
+
+	// compute and assign the vlan group if required
+	// Status.SelectedVlanGroup stores the selected vlan group and is the
+	// source of truth for future vlan group references
+	if o.Status.SelectedVlanGroup == "" /* vlan group not yet selected/assigned */ {
+		if o.Spec.VlanGroup != "" {
+			o.Status.SelectedVlanGroup = o.Spec.VlanGroup
+
+			// set status, and condition field
+			msg := fmt.Sprintf("vlanGroup is provided in CR: %v", o.Status.SelectedVlanGroup)
+			r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionVlanGroupSelectedTrue, corev1.EventTypeNormal, nil, msg)
+		} else if len(o.Spec.VlanGroupSelector) > 0 {
+			// since the vlan group is not part of the restoration hash computation
+			// we can quickly check to see if the vlan with the restoration hash is matched in NetBox
+			h := generateVlanRestorationHash(o)
+			canBeRestored, err := netboxClient.RestoreExistingVlanByHash(ctx, h)
+			if err != nil {
+				return ctrl.Result{}, NewDomainError("%w", err)
+			}
+
+			if canBeRestored != nil && canBeRestored.VlanGroup != "" {
+				// unlike the parent prefix of a restored prefix, the vlan group of a restored vlan
+				// is known, so it is selected directly
+				o.Status.SelectedVlanGroup = canBeRestored.VlanGroup
+
+				msg := fmt.Sprintf("vlanGroup of the restored vlan is selected: %v", o.Status.SelectedVlanGroup)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionVlanGroupSelectedTrue, corev1.EventTypeNormal, nil, msg)
+			} else {
+				// fetch the vlan groups matching the selector which have an available vlan
+				vlanGroupCandidates, err := netboxClient.GetAvailableVlanGroupsBySelector(ctx, &o.Spec)
+				if err != nil {
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionVlanGroupSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+				if len(vlanGroupCandidates) == 0 {
+					err := errors.New("no vlan group found matching the vlanGroupSelector")
+					r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionVlanGroupSelectedFalse, corev1.EventTypeWarning, err)
+					return ctrl.Result{}, NewDomainError("%w", err)
+				}
+
+				// the candidates are in the order returned by NetBox, the first one is used
+				o.Status.SelectedVlanGroup = vlanGroupCandidates[0].Name
+
+				// set status, and condition field
+				msg := fmt.Sprintf("vlanGroup is selected: %v", o.Status.SelectedVlanGroup)
+				r.EventStatusRecorder.Report(ctx, o, netboxv1.ConditionVlanGroupSelectedTrue, corev1.EventTypeNormal, nil, msg)
+			}
+		} else {
+			// this case should not be triggered anymore, as we have validation rules put in place on the CR
+			return ctrl.Result{}, NewDomainError("either VlanGroupSelector or VlanGroup needs to be set")
+		}
+
+		// Persist SelectedVlanGroup to the API server before creating the
+		// Vlan CR, the Vlan controller reads it to lock the vlan group.
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	err = r.Get(ctx, vlanLookupKey, vlan)
+	if err != nil {
+		// return error if not a notfound error
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+
+		logger.V(4).Info("vlan object matching vlan claim was not found, creating new vlan object")
+
+		vlanModel, cancelLock, res, err := r.restoreOrAssignVlanAndSetCondition(ctx, netboxClient, o)
+		if cancelLock != nil {
+			defer cancelLock()
+		}
+		if vlanModel == nil {
+			return res, err
+		}
+
+		// create the Vlan CR
+		vlanResource := generateVlanFromVlanClaim(ctx, o, vlanModel.Vid, vlanModel.VlanGroup)
+		err = controllerutil.SetControllerReference(o, vlanResource, r.Scheme)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		err = addFinalizer(ctx, r.Client, o, VlanClaimFinalizerName)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		err = r.Create(ctx, vlanResource)
+		if err != nil {
+			return ctrl.Result{}, NewDomainError("failed to create Vlan: %w", err)
+		}
+	} else {
+		// update spec of Vlan object
+		logger.V(4).Info("update vlan resource")
+		vlan.Spec = generateVlanSpec(o, vlan.Spec.Vid, vlan.Spec.VlanGroup, logger)
+		err = controllerutil.SetControllerReference(o, vlan, r.Scheme)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		err = r.Update(ctx, vlan)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VlanClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&netboxv1.VlanClaim{}).
+		Owns(&netboxv1.Vlan{}).
+		Complete(r)
+}
+
+// updateStatus updates the VlanClaim status based on the current state of the owned Vlan.
+// This function is called as a deferred function in Reconcile to ensure status is always updated.
+func (r *VlanClaimReconciler) updateStatus(ctx context.Context, claim *netboxv1.VlanClaim, statusBase *netboxv1.VlanClaim, lookupKey types.NamespacedName, reconcileRes ctrl.Result, reconcileErr error) (result ctrl.Result, err error) {
+	logger := log.FromContext(ctx)
+
+	// Set default return values
+	result = reconcileRes
+	err = reconcileErr
+
+	// Ensure status update is always called, even on early returns
+	defer func() {
+		if apierrors.IsConflict(err) {
+			// Object was modified concurrently — skip status update, will retry on requeue
+			result, err = IgnoreDomainError(result, err)
+			return
+		}
+		// Align resource version so the patch targets the latest revision
+		statusBase.SetResourceVersion(claim.GetResourceVersion())
+		statusPatch := client.MergeFrom(statusBase)
+		patchErr := r.Status().Patch(ctx, claim, statusPatch)
+		if patchErr != nil {
+			patchErr = client.IgnoreNotFound(patchErr)
+			if patchErr != nil {
+				err = errors.Join(err, patchErr)
+			}
+		}
+		result, err = IgnoreDomainError(result, err)
+	}()
+
+	logger.V(4).Info("updating vlanclaim status")
+
+	if errors.Is(reconcileErr, api.ErrNetboxUnavailable) {
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionReadyFalseNetBoxUnavailable, corev1.EventTypeWarning, reconcileErr)
+		return result, err
+	}
+
+	// Fetch the latest Vlan object
+	vlan := &netboxv1.Vlan{}
+	err = r.Client.Get(ctx, lookupKey, vlan)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Vlan doesn't exist yet
+			r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionVlanAssignedFalse, corev1.EventTypeWarning, reconcileErr)
+			r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionVlanClaimReadyFalse, corev1.EventTypeWarning, reconcileErr)
+			// Preserve original result (e.g. RequeueAfter from lock contention)
+			if result.IsZero() {
+				result = ctrl.Result{RequeueAfter: 1 * time.Second}
+			}
+			err = nil
+			return result, err
+		}
+		err = fmt.Errorf("failed to get Vlan for status update: %w", err)
+		return result, err
+	}
+
+	// Vlan exists - report successful assignment if not already reported
+	if apismeta.FindStatusCondition(claim.Status.Conditions, netboxv1.ConditionVlanAssignedTrue.Type) == nil || apismeta.IsStatusConditionFalse(claim.Status.Conditions, netboxv1.ConditionVlanAssignedTrue.Type) {
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionVlanAssignedTrue, corev1.EventTypeNormal,
+			nil, fmt.Sprintf(" assigned vlan: %d", vlan.Spec.Vid))
+	}
+	// Update status based on Vlan readiness
+	if apismeta.IsStatusConditionTrue(vlan.Status.Conditions, netboxv1.ConditionVlanReadyTrue.Type) {
+		logger.V(4).Info("vlan status ready true")
+		if statusBase.Status.Vid == 0 {
+			metrics.ObserveClaimAllocation(vlanClaimKind, claim.CreationTimestamp.Time)
+		}
+		claim.Status.Vid = vlan.Spec.Vid
+		claim.Status.VlanName = vlan.Name
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionVlanClaimReadyTrue, corev1.EventTypeNormal, nil)
+	} else {
+		logger.V(4).Info("vlan status ready false")
+		r.EventStatusRecorder.Report(ctx, claim, netboxv1.ConditionVlanClaimReadyFalse, corev1.EventTypeWarning, reconcileErr)
+	}
+
+	return result, err
+}
+
+func (r *VlanClaimReconciler) tryLockOnVlanGroup(ctx context.Context, o *netboxv1.VlanClaim) (*leaselocker.LeaseLocker, context.CancelFunc, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	leaseLockerNSN := types.NamespacedName{
+		Name:      convertVlanGroupToLeaseLockName(o.Status.SelectedVlanGroup),
+		Namespace: r.OperatorNamespace,
+	}
+
+	claimNSN := types.NamespacedName{
+		Name:      o.Name,
+		Namespace: o.Namespace,
+	}
+
+	ll, err := leaselocker.NewLeaseLocker(r.RestConfig, leaseLockerNSN, claimNSN.String())
+	if err != nil {
+		return nil, nil, ctrl.Result{}, err
+	}
+
+	lockCtx, cancel := context.WithTimeout(ctx, lockAcquireTimeout)
+
+	// try to lock lease for vlan group
+	locked := ll.TryLock(lockCtx)
+	if !locked {
+		cancel()
+		// lock for vlan group was not available, rescheduling
+		metrics.LeaseLockContentionTotal.WithLabelValues(vlanClaimKind, o.Status.SelectedVlanGroup).Inc()
+		logger.Info(fmt.Sprintf("failed to lock vlan group %s", o.Status.SelectedVlanGroup))
+		r.EventStatusRecorder.Recorder().Eventf(o, corev1.EventTypeWarning, "FailedToLockVlanGroup", "failed to lock vlan group %s",
+			o.Status.SelectedVlanGroup)
+		return nil, nil, ctrl.Result{RequeueAfter: 2 * time.Second}, NewDomainError("failed to lock vlan group %s", o.Status.SelectedVlanGroup)
+	}
+	logger.V(4).Info(fmt.Sprintf("successfully locked vlan group %s", o.Status.SelectedVlanGroup))
+
+	// the lease is not unlocked here, the vid is only reserved in NetBox by the Vlan controller,
+	// which takes over the lease with the same owner and unlocks it after the reservation
+	return ll, cancel, ctrl.Result{}, nil
+}
+
+func (r *VlanClaimReconciler) restoreOrAssignVlanAndSetCondition(ctx context.Context, netboxClient *api.NetboxCompositeClient, o *netboxv1.VlanClaim) (*models.Vlan, context.CancelFunc, ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	ll, cancelLock, res, err := r.tryLockOnVlanGroup(ctx, o)
+	if err != nil || ll == nil {
+		return nil, nil, res, err
+	}
+
+	h := generateVlanRestorationHash(o)
+	vlanModel, err := netboxClient.RestoreExistingVlanByHash(ctx, h)
+	if err != nil {
+		return nil, cancelLock, ctrl.Result{}, NewDomainError("%w", err)
+	}
+	metrics.ObserveRestoration(vlanClaimKind, vlanModel != nil)
+
+	if vlanModel == nil {
+		// vlan cannot be restored from netbox
+		// assign new available vlan
+		vlanModel, err = netboxClient.GetAvailableVlanByClaim(
+			ctx,
+			&models.VlanClaim{
+				VlanGroup: o.Status.SelectedVlanGroup,
+				Metadata: &models.NetboxMetadata{
+					Tenant: o.Spec.Tenant,
+				},
+			},
+		)
+		if err != nil {
+			observeParentExhausted(vlanClaimKind, o.Status.SelectedVlanGroup, err)
+			if (errors.Is(err, api.ErrVlanGroupExhausted) || errors.Is(err, api.ErrVlanGroupNotFound)) && len(o.Spec.VlanGroupSelector) > 0 {
+				// we reset the selected vlan group, since it has no available vlan anymore,
+				// the next reconcile loop selects the next candidate
+				o.Status.SelectedVlanGroup = ""
+				return nil, cancelLock, ctrl.Result{}, NewDomainError("no vlan available in vlan group, will restart the vlan group selection process: %w", err)
+			}
+
+			return nil, cancelLock, ctrl.Result{}, NewDomainError("%w", err)
+		}
+		logger.V(4).Info(fmt.Sprintf("vlan is not reserved in netbox, assigned new vlan: %d", vlanModel.Vid))
+	} else {
+		// reassign reserved vlan from netbox
+		logger.V(4).Info(fmt.Sprintf("reassign reserved vlan from netbox, vid: %d", vlanModel.Vid))
+	}
+	return vlanModel, cancelLock, ctrl.Result{}, nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/swisscom/leaselocker"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("VlanClaim Controller", Ordered, func() {
+
+	var unexpectedCallCh chan error
+	var ipamMockVlan *mock_interfaces.MockIpamAPI
+	var vlanClaimReconciler *VlanClaimReconciler
+	var vlanReconciler *VlanReconciler
+
+	claimKey := types.NamespacedName{Name: vlanClaimName, Namespace: namespace}
+	leaseLockerNSN := types.NamespacedName{Name: convertVlanGroupToLeaseLockName(vlanGroupName), Namespace: OperatorNamespace}
+
+	// tryLockByOtherOwner returns whether another owner can lock the lease of the vlan group,
+	// the lease is unlocked again if it could be locked
+	tryLockByOtherOwner := func() bool {
+		ll, err := leaselocker.NewLeaseLocker(cfg, leaseLockerNSN, "default/some-other-owner")
+		Expect(err).NotTo(HaveOccurred())
+
+		lockCtx, lockCancel := context.WithTimeout(ctx, 2*time.Second)
+		defer lockCancel()
+
+		locked := ll.TryLock(lockCtx)
+		if locked {
+			ll.UnlockWithRetry(ctx)
+		}
+		return locked
+	}
+
+	BeforeEach(func() {
+		// the mocks report calls with unexpected parameters without blocking the reconcilers
+		unexpectedCallCh = make(chan error, 10)
+		ipamMockVlan = mock_interfaces.NewMockIpamAPI(mockCtrl)
+
+		vlanClaimReconciler = &VlanClaimReconciler{
+			Client:              k8sClient,
+			Scheme:              scheme.Scheme,
+			EventStatusRecorder: NewEventStatusRecorder(record.NewFakeRecorder(100)),
+			NetboxClients:       newVlanTestClients(ipamMockVlan),
+			OperatorNamespace:   OperatorNamespace,
+			RestConfig:          cfg,
+		}
+		vlanReconciler = &VlanReconciler{
+			Client:              k8sClient,
+			Scheme:              scheme.Scheme,
+			EventStatusRecorder: NewEventStatusRecorder(record.NewFakeRecorder(100)),
+			NetboxClients:       newVlanTestClients(ipamMockVlan),
+			OperatorNamespace:   OperatorNamespace,
+			RestConfig:          cfg,
+		}
+	})
+
+	AfterEach(func() {
+		Expect(unexpectedCallCh).NotTo(Receive())
+
+		By("Cleaning up the VlanClaim and Vlan CRs")
+		objectMeta := metav1.ObjectMeta{Name: claimKey.Name, Namespace: claimKey.Namespace}
+		removeFinalizersAndDelete(ctx, &netboxv1.VlanClaim{ObjectMeta: objectMeta})
+		removeFinalizersAndDelete(ctx, &netboxv1.Vlan{ObjectMeta: objectMeta})
+	})
+
+	// assignVlan creates the VlanClaim CR and reconciles it until the Vlan CR is created
+	assignVlan := func() *netboxv1.Vlan {
+		By("Creating VlanClaim CR")
+		Expect(k8sClient.Create(ctx, defaultVlanClaimCR())).To(Succeed())
+
+		By("Selecting the vlan group")
+		_, err := vlanClaimReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: claimKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		claim := &netboxv1.VlanClaim{}
+		Expect(k8sClient.Get(ctx, claimKey, claim)).To(Succeed())
+		Expect(claim.Status.SelectedVlanGroup).To(Equal(vlanGroupName))
+
+		By("Assigning a vlan of the vlan group")
+		_, err = vlanClaimReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: claimKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		vlan := &netboxv1.Vlan{}
+		Expect(k8sClient.Get(ctx, claimKey, vlan)).To(Succeed())
+		Expect(vlan.Spec.Vid).To(Equal(vid))
+		Expect(vlan.Spec.VlanGroup).To(Equal(vlanGroupName))
+		Expect(vlan.Spec.CustomFields).To(HaveKeyWithValue("netboxOperatorRestorationHash", generateVlanRestorationHash(claim)))
+		return vlan
+	}
+
+	It("keeps the lease of the vlan group until the Vlan is reserved in NetBox", func() {
+		By("Setting up mocks")
+		// the vlans are listed by the restoration hash and by the vid of the assigned vlan
+		mockVlansListEmptyResult(ipamMockVlan, unexpectedCallCh)
+		mockVlanGroupsList(ipamMockVlan, unexpectedCallCh)
+		mockVlanGroupsAvailableVlansList(ipamMockVlan, unexpectedCallCh)
+		mockVlansCreate(ipamMockVlan, unexpectedCallCh, expectedVlanToCreate(&vlanGroupId, map[string]interface{}{
+			"example_field":                 "example value",
+			"netboxOperatorRestorationHash": generateVlanRestorationHash(defaultVlanClaimCR()),
+		}))
+
+		assignVlan()
+
+		// the vid is not reserved in NetBox yet, another claim must not get the same vid
+		By("Checking that the lease of the vlan group is still held")
+		Expect(tryLockByOtherOwner()).To(BeFalse())
+
+		By("Reserving the vlan in NetBox")
+		_, err := vlanReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: claimKey})
+		Expect(err).NotTo(HaveOccurred())
+
+		vlan := &netboxv1.Vlan{}
+		Expect(k8sClient.Get(ctx, claimKey, vlan)).To(Succeed())
+		Expect(vlan.Status.VlanId).To(Equal(int64(vlanId)))
+
+		By("Checking that the lease of the vlan group is released")
+		Expect(tryLockByOtherOwner()).To(BeTrue())
+	})
+
+	It("deletes the Vlan CR when the VlanClaim CR is deleted", func() {
+		By("Setting up mocks")
+		mockVlansListEmptyResult(ipamMockVlan, unexpectedCallCh)
+		mockVlanGroupsList(ipamMockVlan, unexpectedCallCh)
+		mockVlanGroupsAvailableVlansList(ipamMockVlan, unexpectedCallCh)
+
+		vlan := assignVlan()
+
+		By("Deleting VlanClaim CR")
+		claim := &netboxv1.VlanClaim{}
+		Expect(k8sClient.Get(ctx, claimKey, claim)).To(Succeed())
+		Expect(claim.Finalizers).To(ContainElement(VlanClaimFinalizerName))
+		Expect(k8sClient.Delete(ctx, claim)).To(Succeed())
+
+		_, err := vlanClaimReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: claimKey})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, claimKey, vlan))
+		}, vlanTestTimeout, vlanTestInterval).Should(BeTrue())
+
+		By("Removing the finalizer of the VlanClaim CR")
+		_, err = vlanClaimReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: claimKey})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, claimKey, claim))
+		}, vlanTestTimeout, vlanTestInterval).Should(BeTrue())
+	})
+})
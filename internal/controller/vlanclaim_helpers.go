@@ -0,0 +1,102 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func generateVlanFromVlanClaim(ctx context.Context, claim *netboxv1.VlanClaim, vid int32, vlanGroup string) *netboxv1.Vlan {
+	logger := log.FromContext(ctx)
+	return &netboxv1.Vlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+		},
+		Spec: generateVlanSpec(claim, vid, vlanGroup, logger),
+	}
+}
+
+// generateVlanSpec returns the spec of the Vlan of the claim, the vlanGroup is the VLAN Group
+// selected for the claim or the one of the restored VLAN
+func generateVlanSpec(claim *netboxv1.VlanClaim, vid int32, vlanGroup string, logger logr.Logger) netboxv1.VlanSpec {
+	// log a warning if the netboxOperatorRestorationHash name is a key in the customFields map of the VlanClaim
+	_, ok := claim.Spec.CustomFields[config.GetOperatorConfig().NetboxRestorationHashFieldName]
+	if ok {
+		logger.Info(fmt.Sprintf("Warning: restoration hash is calculated from spec, custom field with key %s will be ignored", config.GetOperatorConfig().NetboxRestorationHashFieldName))
+	}
+
+	// Copy customFields from claim and add restoration hash
+	customFields := make(map[string]string, len(claim.Spec.CustomFields)+1)
+	for k, v := range claim.Spec.CustomFields {
+		customFields[k] = v
+	}
+
+	customFields[config.GetOperatorConfig().NetboxRestorationHashFieldName] = generateVlanRestorationHash(claim)
+
+	return netboxv1.VlanSpec{
+		Vid:              vid,
+		Name:             vlanName(claim),
+		VlanGroup:        vlanGroup,
+		Tenant:           claim.Spec.Tenant,
+		Status:           claim.Spec.Status,
+		Role:             claim.Spec.Role,
+		CustomFields:     customFields,
+		Tags:             slices.Clone(claim.Spec.Tags),
+		Description:      claim.Spec.Description,
+		Comments:         claim.Spec.Comments,
+		PreserveInNetbox: claim.Spec.PreserveInNetbox,
+		Connection:       claim.Spec.Connection,
+	}
+}
+
+// vlanName returns the name of the VLAN of the claim in NetBox, the name of the claim if it is not set
+func vlanName(claim *netboxv1.VlanClaim) string {
+	if claim.Spec.Name != "" {
+		return claim.Spec.Name
+	}
+	return claim.Name
+}
+
+func generateVlanRestorationHash(claim *netboxv1.VlanClaim) string {
+	rd := VlanClaimRestorationData{
+		Namespace:         claim.Namespace,
+		Name:              claim.Name,
+		VlanGroup:         claim.Spec.VlanGroup,
+		Tenant:            claim.Spec.Tenant,
+		VlanGroupSelector: parentPrefixSelectorToString(claim.Spec.VlanGroupSelector),
+	}
+	return fmt.Sprintf("%x", sha1.Sum([]byte(rd.Namespace+rd.Name+rd.VlanGroup+rd.Tenant+rd.VlanGroupSelector)))
+}
+
+type VlanClaimRestorationData struct {
+	// only use immutable fields
+	Namespace         string
+	Name              string
+	VlanGroup         string
+	Tenant            string
+	VlanGroupSelector string
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+)
+
+func TestBackwardCompatibilityOfGenerateVlanRestorationHash(t *testing.T) {
+	// concatenated string = "defaultvlanclaim-sampleprod-vlansDunder-Mifflin, Inc."
+	vlanClaim := &netboxv1.VlanClaim{
+		Spec: netboxv1.VlanClaimSpec{
+			VlanGroup: "prod-vlans",
+			Tenant:    "Dunder-Mifflin, Inc.",
+		},
+	}
+	vlanClaim.Namespace = "default"
+	vlanClaim.Name = "vlanclaim-sample"
+
+	expectedHash := "45cfa449c79688cf720e2c04500ae0217feeea88"
+	if generatedHash := generateVlanRestorationHash(vlanClaim); generatedHash != expectedHash {
+		t.Errorf("hash mismatch: expected %#v, got %#v", expectedHash, generatedHash)
+	}
+}
+
+func TestBackwardCompatibilityOfGenerateVlanRestorationHash_VlanGroupSelector(t *testing.T) {
+	// concatenated string = "defaultvlanclaim-sampleDunder-Mifflin, Inc.environment_prod_site_dc1"
+	vlanClaim := &netboxv1.VlanClaim{
+		Spec: netboxv1.VlanClaimSpec{
+			VlanGroupSelector: map[string]string{
+				"site":        "dc1",
+				"environment": "prod",
+			},
+			Tenant: "Dunder-Mifflin, Inc.",
+		},
+	}
+	vlanClaim.Namespace = "default"
+	vlanClaim.Name = "vlanclaim-sample"
+
+	expectedHash := "c56d9f7228121c35080ea48f5c5043fcfdff50e2"
+	if generatedHash := generateVlanRestorationHash(vlanClaim); generatedHash != expectedHash {
+		t.Errorf("hash mismatch: expected %#v, got %#v", expectedHash, generatedHash)
+	}
+}
+
+func TestGenerateVlanSpec_NameDefaultsToClaimName(t *testing.T) {
+	vlanClaim := &netboxv1.VlanClaim{
+		Spec: netboxv1.VlanClaimSpec{
+			VlanGroupSelector: map[string]string{"site": "dc1"},
+		},
+	}
+	vlanClaim.Namespace = "default"
+	vlanClaim.Name = "vlanclaim-sample"
+
+	vlan := generateVlanFromVlanClaim(context.TODO(), vlanClaim, 100, "dc1-vlans")
+	if vlan.Spec.Name != "vlanclaim-sample" {
+		t.Errorf("expected the name of the claim, got %#v", vlan.Spec.Name)
+	}
+	if vlan.Spec.Vid != 100 || vlan.Spec.VlanGroup != "dc1-vlans" {
+		t.Errorf("expected vid 100 in vlan group dc1-vlans, got %d in %#v", vlan.Spec.Vid, vlan.Spec.VlanGroup)
+	}
+
+	vlanClaim.Spec.Name = "k8s-nodes"
+	if name := vlanName(vlanClaim); name != "k8s-nodes" {
+		t.Errorf("expected the name of the spec, got %#v", name)
+	}
+}
+
+func TestConvertVlanGroupToLeaseLockName(t *testing.T) {
+	tests := map[string]string{
+		"prod-vlans":       "vlangroup-prod-vlans",
+		"DC1 Access VLANs": "vlangroup-dc1-access-vlans",
+		"Group (Test)":     "vlangroup-group--test",
+	}
+	for vlanGroup, expected := range tests {
+		if actual := convertVlanGroupToLeaseLockName(vlanGroup); actual != expected {
+			t.Errorf("lease lock name of %#v: expected %#v, got %#v", vlanGroup, expected, actual)
+		}
+	}
+}
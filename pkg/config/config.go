@@ -42,6 +42,20 @@ type OperatorConfig struct {
 	DebugEnable                    bool   `mapstructure:"DEBUG_ENABLE"`
 	NetboxRestorationHashFieldName string `mapstructure:"NETBOX_RESTORATION_HASH_FIELD_NAME"`
 
+	// path of a file holding the NetBox API token, e.g. the key of a Secret mounted as a volume
+	// the file is re-read periodically, so that a rotated token is used without a restart
+	// if set, AUTH_TOKEN is ignored
+	// defaults to empty (disabled)
+	AuthTokenFile string `mapstructure:"AUTH_TOKEN_FILE"`
+	// name of a Secret in the namespace of the operator holding the NetBox API token
+	// the Secret is watched, so that a rotated token is used without a restart
+	// if set, AUTH_TOKEN is ignored, can not be combined with AUTH_TOKEN_FILE
+	// defaults to empty (disabled)
+	AuthTokenSecretName string `mapstructure:"AUTH_TOKEN_SECRET_NAME"`
+	// key of the NetBox API token in the data of the Secret AUTH_TOKEN_SECRET_NAME
+	// defaults to "token"
+	AuthTokenSecretKey string `mapstructure:"AUTH_TOKEN_SECRET_KEY"`
+
 	// cron schedule for scheduled reconciliation of all custom resources
 	// if set, all custom resources will be reconciled at the defined schedule, in addition to the regular event-based reconciliation
 	// if empty, scheduled reconciliation is disabled
@@ -55,9 +69,88 @@ type OperatorConfig struct {
 	// defaults to 1 hour
 	ReconcileJitterRaw string `mapstructure:"RECONCILE_JITTER"`
 
+	// utilization of a prefix in percent at which a warning event is emitted on the Prefix resource
+	// the event is emitted once when the utilization crosses the threshold
+	// if set to 0, no warning events are emitted
+	// defaults to 90
+	PrefixUtilizationWarningThreshold int `mapstructure:"PREFIX_UTILIZATION_WARNING_THRESHOLD"`
+
+	// number of times a failed request to NetBox is retried, requests with an idempotent method
+	// are retried on network errors and 5xx responses, all requests are retried on 429 responses
+	// if set to 0, requests are not retried
+	// defaults to 3
+	NetboxRetryMax int `mapstructure:"NETBOX_RETRY_MAX"`
+	// initial backoff between the retries of a request, it is doubled with every retry and
+	// a random jitter is applied, a Retry-After header of the response takes precedence
+	// format: duration, needs to be parseable by time.ParseDuration, e.g. "500ms", "1s"
+	// defaults to 500ms
+	NetboxRetryInitialBackoffRaw string `mapstructure:"NETBOX_RETRY_INITIAL_BACKOFF"`
+	// maximum backoff between the retries of a request, a request with a Retry-After
+	// header beyond it is not retried
+	// format: duration, needs to be parseable by time.ParseDuration, e.g. "30s", "1m"
+	// defaults to 30s
+	NetboxRetryMaxBackoffRaw string `mapstructure:"NETBOX_RETRY_MAX_BACKOFF"`
+	// maximum average number of requests per second sent to a NetBox instance, the
+	// requests beyond it are delayed
+	// if set to 0, requests are not rate limited
+	// defaults to 20
+	NetboxRateLimitQPS float64 `mapstructure:"NETBOX_RATE_LIMIT_QPS"`
+	// maximum number of requests sent to a NetBox instance in a burst, only used
+	// if NETBOX_RATE_LIMIT_QPS is set
+	// defaults to 40
+	NetboxRateLimitBurst int `mapstructure:"NETBOX_RATE_LIMIT_BURST"`
+	// number of consecutive failed requests to a NetBox instance after which the circuit breaker
+	// opens, while it is open no requests are sent and reconciles are requeued with a backoff
+	// if set to 0, the circuit breaker is disabled
+	// defaults to 5
+	NetboxCircuitBreakerThreshold int `mapstructure:"NETBOX_CIRCUIT_BREAKER_THRESHOLD"`
+	// time the circuit breaker stays open before requests are let through again, it is doubled
+	// every time the circuit breaker opens again right away, up to 5 minutes
+	// format: duration, needs to be parseable by time.ParseDuration, e.g. "10s", "1m"
+	// defaults to 10s
+	NetboxCircuitBreakerOpenDurationRaw string `mapstructure:"NETBOX_CIRCUIT_BREAKER_OPEN_DURATION"`
+	// interval at which the health of the NetBox instances is probed with the status api
+	// format: duration, needs to be parseable by time.ParseDuration, e.g. "30s", "1m"
+	// defaults to 30s
+	NetboxHealthCheckIntervalRaw string `mapstructure:"NETBOX_HEALTH_CHECK_INTERVAL"`
+	// time for which the detected version of a NetBox instance is cached, the version is
+	// detected again earlier if a response suggests that NetBox was upgraded
+	// format: duration, needs to be parseable by time.ParseDuration, e.g. "30m", "1h"
+	// defaults to 1h
+	NetboxVersionCacheTTLRaw string `mapstructure:"NETBOX_VERSION_CACHE_TTL"`
+	// time for which the tenants, sites, tags, VRFs, roles, VLAN groups and custom field definitions looked up in NetBox are cached
+	// if set to 0, the lookups are not cached
+	// format: duration, needs to be parseable by time.ParseDuration, e.g. "5m", "1h"
+	// defaults to 5m
+	NetboxLookupCacheTTLRaw string `mapstructure:"NETBOX_LOOKUP_CACHE_TTL"`
+	// time for which a tenant, site, tag, VRF, role or custom field definition which was not found in NetBox is
+	// remembered as missing, so that a claim referencing it does not look it up on every reconcile
+	// if set to 0, missing objects are not cached
+	// format: duration, needs to be parseable by time.ParseDuration, e.g. "30s", "1m"
+	// defaults to 30s
+	NetboxLookupCacheNegativeTTLRaw string `mapstructure:"NETBOX_LOOKUP_CACHE_NEGATIVE_TTL"`
+	// number of results requested per page from the list and available ips apis of NetBox,
+	// further pages of a list are followed through the next links of the responses
+	// if set to 0, the MAX_PAGE_SIZE configured in NetBox is requested
+	// defaults to 0
+	NetboxPageLimit int `mapstructure:"NETBOX_PAGE_LIMIT"`
+	// if true, the tags referenced by the resources which don't exist in NetBox are created,
+	// with the referenced value as name and a slug derived from it
+	// if false, a resource referencing a tag which doesn't exist is not written to NetBox
+	// defaults to false
+	NetboxAutoCreateTags bool `mapstructure:"NETBOX_AUTO_CREATE_TAGS"`
+
 	// Parsed fields (not from config file/env)
-	ReconcileSchedule       cron.Schedule
-	ReconcileJitterDuration time.Duration
+	ReconcileSchedule         cron.Schedule
+	ReconcileJitterDuration   time.Duration
+	NetboxRetryInitialBackoff time.Duration
+	NetboxRetryMaxBackoff     time.Duration
+	// parsed fields of the circuit breaker and health check
+	NetboxCircuitBreakerOpenDuration time.Duration
+	NetboxHealthCheckInterval        time.Duration
+	NetboxVersionCacheTTL            time.Duration
+	NetboxLookupCacheTTL             time.Duration
+	NetboxLookupCacheNegativeTTL     time.Duration
 }
 
 func (c *OperatorConfig) setDefaults() {
@@ -65,12 +158,31 @@ func (c *OperatorConfig) setDefaults() {
 	c.viper.SetDefault("NETBOX_HOST", "")
 	c.viper.SetDefault("AUTH_TOKEN", "")
 	c.viper.SetDefault("HTTPS_ENABLE", true)
+	c.viper.SetDefault("AUTH_TOKEN_FILE", "")
+	c.viper.SetDefault("AUTH_TOKEN_SECRET_NAME", "")
+	c.viper.SetDefault("AUTH_TOKEN_SECRET_KEY", "token")
 	c.viper.SetDefault("DEBUG_ENABLE", false)
 	c.viper.SetDefault("NETBOX_RESTORATION_HASH_FIELD_NAME", "netboxOperatorRestorationHash")
 
 	c.viper.SetDefault("RECONCILE_JITTER", "")
 	c.viper.SetDefault("RECONCILE_SCHEDULE", "")
 
+	c.viper.SetDefault("PREFIX_UTILIZATION_WARNING_THRESHOLD", 90)
+
+	c.viper.SetDefault("NETBOX_RETRY_MAX", 3)
+	c.viper.SetDefault("NETBOX_RETRY_INITIAL_BACKOFF", "500ms")
+	c.viper.SetDefault("NETBOX_RETRY_MAX_BACKOFF", "30s")
+	c.viper.SetDefault("NETBOX_RATE_LIMIT_QPS", 20)
+	c.viper.SetDefault("NETBOX_RATE_LIMIT_BURST", 40)
+
+	c.viper.SetDefault("NETBOX_CIRCUIT_BREAKER_THRESHOLD", 5)
+	c.viper.SetDefault("NETBOX_CIRCUIT_BREAKER_OPEN_DURATION", "10s")
+	c.viper.SetDefault("NETBOX_HEALTH_CHECK_INTERVAL", "30s")
+	c.viper.SetDefault("NETBOX_VERSION_CACHE_TTL", "1h")
+	c.viper.SetDefault("NETBOX_LOOKUP_CACHE_TTL", "5m")
+	c.viper.SetDefault("NETBOX_LOOKUP_CACHE_NEGATIVE_TTL", "30s")
+	c.viper.SetDefault("NETBOX_PAGE_LIMIT", 0)
+	c.viper.SetDefault("NETBOX_AUTO_CREATE_TAGS", false)
 }
 
 func (c *OperatorConfig) LoadCaCert() (cert []byte, err error) {
@@ -117,6 +229,48 @@ func GetOperatorConfig() *OperatorConfig {
 			return
 		}
 
+		err = c.validatePrefixUtilizationWarningThreshold()
+		if err != nil {
+			log.Fatalf("error validating prefix utilization warning threshold: %s", err)
+			return
+		}
+
+		err = c.parseRetryAndRateLimit()
+		if err != nil {
+			log.Fatalf("error parsing netbox retry and rate limit settings: %s", err)
+			return
+		}
+
+		err = c.parseHealthCheck()
+		if err != nil {
+			log.Fatalf("error parsing netbox circuit breaker and health check settings: %s", err)
+			return
+		}
+
+		err = c.parseVersionCacheTTL()
+		if err != nil {
+			log.Fatalf("error parsing netbox version cache ttl: %s", err)
+			return
+		}
+
+		err = c.parseLookupCacheTTL()
+		if err != nil {
+			log.Fatalf("error parsing netbox lookup cache ttl: %s", err)
+			return
+		}
+
+		err = c.validatePageLimit()
+		if err != nil {
+			log.Fatalf("error validating netbox page limit: %s", err)
+			return
+		}
+
+		err = c.validateAuthTokenSource()
+		if err != nil {
+			log.Fatalf("error validating auth token source: %s", err)
+			return
+		}
+
 		configuration = c
 	})
 
@@ -165,6 +319,115 @@ func (c *OperatorConfig) parseScheduleAndJitter() (err error) {
 	return nil
 }
 
+func (c *OperatorConfig) validatePrefixUtilizationWarningThreshold() error {
+	if c.PrefixUtilizationWarningThreshold < 0 || c.PrefixUtilizationWarningThreshold > 100 {
+		return fmt.Errorf("invalid prefix utilization warning threshold %d: must be between 0 and 100", c.PrefixUtilizationWarningThreshold)
+	}
+	return nil
+}
+
+func (c *OperatorConfig) parseRetryAndRateLimit() (err error) {
+	if c.NetboxRetryMax < 0 {
+		return fmt.Errorf("invalid netbox retry max %d: must be greater than or equal to 0", c.NetboxRetryMax)
+	}
+
+	c.NetboxRetryInitialBackoff, err = time.ParseDuration(c.NetboxRetryInitialBackoffRaw)
+	if err != nil {
+		return fmt.Errorf("invalid netbox retry initial backoff %q: %w", c.NetboxRetryInitialBackoffRaw, err)
+	}
+	if c.NetboxRetryInitialBackoff <= 0 {
+		return fmt.Errorf("invalid netbox retry initial backoff %q: must be greater than 0", c.NetboxRetryInitialBackoffRaw)
+	}
+
+	c.NetboxRetryMaxBackoff, err = time.ParseDuration(c.NetboxRetryMaxBackoffRaw)
+	if err != nil {
+		return fmt.Errorf("invalid netbox retry max backoff %q: %w", c.NetboxRetryMaxBackoffRaw, err)
+	}
+	if c.NetboxRetryMaxBackoff < c.NetboxRetryInitialBackoff {
+		return fmt.Errorf("invalid netbox retry max backoff %q: must be greater than or equal to the initial backoff", c.NetboxRetryMaxBackoffRaw)
+	}
+
+	if c.NetboxRateLimitQPS < 0 {
+		return fmt.Errorf("invalid netbox rate limit qps %v: must be greater than or equal to 0", c.NetboxRateLimitQPS)
+	}
+	if c.NetboxRateLimitQPS > 0 && c.NetboxRateLimitBurst < 1 {
+		return fmt.Errorf("invalid netbox rate limit burst %d: must be greater than 0", c.NetboxRateLimitBurst)
+	}
+
+	return nil
+}
+
+func (c *OperatorConfig) parseHealthCheck() (err error) {
+	if c.NetboxCircuitBreakerThreshold < 0 {
+		return fmt.Errorf("invalid netbox circuit breaker threshold %d: must be greater than or equal to 0", c.NetboxCircuitBreakerThreshold)
+	}
+
+	c.NetboxCircuitBreakerOpenDuration, err = time.ParseDuration(c.NetboxCircuitBreakerOpenDurationRaw)
+	if err != nil {
+		return fmt.Errorf("invalid netbox circuit breaker open duration %q: %w", c.NetboxCircuitBreakerOpenDurationRaw, err)
+	}
+	if c.NetboxCircuitBreakerOpenDuration <= 0 {
+		return fmt.Errorf("invalid netbox circuit breaker open duration %q: must be greater than 0", c.NetboxCircuitBreakerOpenDurationRaw)
+	}
+
+	c.NetboxHealthCheckInterval, err = time.ParseDuration(c.NetboxHealthCheckIntervalRaw)
+	if err != nil {
+		return fmt.Errorf("invalid netbox health check interval %q: %w", c.NetboxHealthCheckIntervalRaw, err)
+	}
+	if c.NetboxHealthCheckInterval <= 0 {
+		return fmt.Errorf("invalid netbox health check interval %q: must be greater than 0", c.NetboxHealthCheckIntervalRaw)
+	}
+
+	return nil
+}
+
+func (c *OperatorConfig) parseVersionCacheTTL() (err error) {
+	c.NetboxVersionCacheTTL, err = time.ParseDuration(c.NetboxVersionCacheTTLRaw)
+	if err != nil {
+		return fmt.Errorf("invalid netbox version cache ttl %q: %w", c.NetboxVersionCacheTTLRaw, err)
+	}
+	if c.NetboxVersionCacheTTL <= 0 {
+		return fmt.Errorf("invalid netbox version cache ttl %q: must be greater than 0", c.NetboxVersionCacheTTLRaw)
+	}
+	return nil
+}
+
+func (c *OperatorConfig) parseLookupCacheTTL() (err error) {
+	c.NetboxLookupCacheTTL, err = time.ParseDuration(c.NetboxLookupCacheTTLRaw)
+	if err != nil {
+		return fmt.Errorf("invalid netbox lookup cache ttl %q: %w", c.NetboxLookupCacheTTLRaw, err)
+	}
+	if c.NetboxLookupCacheTTL < 0 {
+		return fmt.Errorf("invalid netbox lookup cache ttl %q: must be greater than or equal to 0", c.NetboxLookupCacheTTLRaw)
+	}
+
+	c.NetboxLookupCacheNegativeTTL, err = time.ParseDuration(c.NetboxLookupCacheNegativeTTLRaw)
+	if err != nil {
+		return fmt.Errorf("invalid netbox lookup cache negative ttl %q: %w", c.NetboxLookupCacheNegativeTTLRaw, err)
+	}
+	if c.NetboxLookupCacheNegativeTTL < 0 {
+		return fmt.Errorf("invalid netbox lookup cache negative ttl %q: must be greater than or equal to 0", c.NetboxLookupCacheNegativeTTLRaw)
+	}
+	return nil
+}
+
+func (c *OperatorConfig) validatePageLimit() error {
+	if c.NetboxPageLimit < 0 {
+		return fmt.Errorf("invalid netbox page limit %d: must be greater than or equal to 0", c.NetboxPageLimit)
+	}
+	return nil
+}
+
+func (c *OperatorConfig) validateAuthTokenSource() error {
+	if c.AuthTokenFile != "" && c.AuthTokenSecretName != "" {
+		return fmt.Errorf("invalid auth token source: AUTH_TOKEN_FILE and AUTH_TOKEN_SECRET_NAME can not be combined")
+	}
+	if c.AuthTokenSecretName != "" && c.AuthTokenSecretKey == "" {
+		return fmt.Errorf("invalid auth token source: AUTH_TOKEN_SECRET_KEY must not be empty")
+	}
+	return nil
+}
+
 func parseCronSchedule(cronExpr string) (cron.Schedule, error) {
 	schedule, err := cron.ParseStandard(cronExpr)
 	if err != nil {
@@ -41,6 +41,199 @@ func TestLoadConfigFromEnv(t *testing.T) {
 	assert.Equal(t, 30*time.Minute, configuration.ReconcileJitterDuration)
 	assert.Equal(t, configuration.ReconcileSchedule, expectedSchedule)
 
+	assert.Equal(t, 90, configuration.PrefixUtilizationWarningThreshold)
+}
+
+func TestLoadPrefixUtilizationWarningThresholdFromEnv(t *testing.T) {
+	t.Setenv("PREFIX_UTILIZATION_WARNING_THRESHOLD", "75")
+	ResetForTesting()
+
+	configuration := GetOperatorConfig()
+
+	assert.Equal(t, 75, configuration.PrefixUtilizationWarningThreshold)
+}
+
+func TestValidatePrefixUtilizationWarningThreshold(t *testing.T) {
+	for _, threshold := range []int{0, 90, 100} {
+		c := &OperatorConfig{PrefixUtilizationWarningThreshold: threshold}
+		assert.NoError(t, c.validatePrefixUtilizationWarningThreshold())
+	}
+	for _, threshold := range []int{-1, 101} {
+		c := &OperatorConfig{PrefixUtilizationWarningThreshold: threshold}
+		err := c.validatePrefixUtilizationWarningThreshold()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid prefix utilization warning threshold")
+	}
+}
+
+func TestLoadAuthTokenSecretFromEnv(t *testing.T) {
+	t.Setenv("AUTH_TOKEN_SECRET_NAME", "netbox-auth-token")
+	ResetForTesting()
+
+	c := GetOperatorConfig()
+	assert.Equal(t, "netbox-auth-token", c.AuthTokenSecretName)
+	assert.Equal(t, "token", c.AuthTokenSecretKey)
+	assert.Empty(t, c.AuthTokenFile)
+}
+
+func TestValidateAuthTokenSource(t *testing.T) {
+	valid := []*OperatorConfig{
+		{},
+		{AuthTokenFile: "/etc/netbox/token"},
+		{AuthTokenSecretName: "netbox-auth-token", AuthTokenSecretKey: "token"},
+	}
+	for _, c := range valid {
+		assert.NoError(t, c.validateAuthTokenSource())
+	}
+
+	invalid := []*OperatorConfig{
+		{AuthTokenFile: "/etc/netbox/token", AuthTokenSecretName: "netbox-auth-token", AuthTokenSecretKey: "token"},
+		{AuthTokenSecretName: "netbox-auth-token"},
+	}
+	for _, c := range invalid {
+		err := c.validateAuthTokenSource()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid auth token source")
+	}
+}
+
+func TestLoadRetryAndRateLimitDefaults(t *testing.T) {
+	ResetForTesting()
+
+	c := GetOperatorConfig()
+	assert.Equal(t, 3, c.NetboxRetryMax)
+	assert.Equal(t, 500*time.Millisecond, c.NetboxRetryInitialBackoff)
+	assert.Equal(t, 30*time.Second, c.NetboxRetryMaxBackoff)
+	assert.Equal(t, float64(20), c.NetboxRateLimitQPS)
+	assert.Equal(t, 40, c.NetboxRateLimitBurst)
+}
+
+func TestLoadRetryAndRateLimitFromEnv(t *testing.T) {
+	t.Setenv("NETBOX_RETRY_MAX", "5")
+	t.Setenv("NETBOX_RETRY_INITIAL_BACKOFF", "1s")
+	t.Setenv("NETBOX_RETRY_MAX_BACKOFF", "1m")
+	t.Setenv("NETBOX_RATE_LIMIT_QPS", "2.5")
+	t.Setenv("NETBOX_RATE_LIMIT_BURST", "5")
+	ResetForTesting()
+
+	c := GetOperatorConfig()
+	assert.Equal(t, 5, c.NetboxRetryMax)
+	assert.Equal(t, time.Second, c.NetboxRetryInitialBackoff)
+	assert.Equal(t, time.Minute, c.NetboxRetryMaxBackoff)
+	assert.Equal(t, 2.5, c.NetboxRateLimitQPS)
+	assert.Equal(t, 5, c.NetboxRateLimitBurst)
+}
+
+func TestParseRetryAndRateLimit_Invalid(t *testing.T) {
+	valid := OperatorConfig{
+		NetboxRetryMax:               3,
+		NetboxRetryInitialBackoffRaw: "500ms",
+		NetboxRetryMaxBackoffRaw:     "30s",
+		NetboxRateLimitQPS:           20,
+		NetboxRateLimitBurst:         40,
+	}
+	assert.NoError(t, valid.parseRetryAndRateLimit())
+
+	tests := []struct {
+		name    string
+		modify  func(c *OperatorConfig)
+		wantErr string
+	}{
+		{"negative retry max", func(c *OperatorConfig) { c.NetboxRetryMax = -1 }, "invalid netbox retry max"},
+		{"invalid initial backoff", func(c *OperatorConfig) { c.NetboxRetryInitialBackoffRaw = "soon" }, "invalid netbox retry initial backoff"},
+		{"zero initial backoff", func(c *OperatorConfig) { c.NetboxRetryInitialBackoffRaw = "0s" }, "invalid netbox retry initial backoff"},
+		{"max backoff below initial backoff", func(c *OperatorConfig) { c.NetboxRetryMaxBackoffRaw = "100ms" }, "invalid netbox retry max backoff"},
+		{"negative qps", func(c *OperatorConfig) { c.NetboxRateLimitQPS = -1 }, "invalid netbox rate limit qps"},
+		{"zero burst", func(c *OperatorConfig) { c.NetboxRateLimitBurst = 0 }, "invalid netbox rate limit burst"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			err := c.parseRetryAndRateLimit()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadHealthCheckDefaults(t *testing.T) {
+	ResetForTesting()
+
+	c := GetOperatorConfig()
+	assert.Equal(t, 5, c.NetboxCircuitBreakerThreshold)
+	assert.Equal(t, 10*time.Second, c.NetboxCircuitBreakerOpenDuration)
+	assert.Equal(t, 30*time.Second, c.NetboxHealthCheckInterval)
+}
+
+func TestParseHealthCheck_Invalid(t *testing.T) {
+	valid := OperatorConfig{
+		NetboxCircuitBreakerThreshold:       5,
+		NetboxCircuitBreakerOpenDurationRaw: "10s",
+		NetboxHealthCheckIntervalRaw:        "30s",
+	}
+	assert.NoError(t, valid.parseHealthCheck())
+
+	tests := []struct {
+		name    string
+		modify  func(c *OperatorConfig)
+		wantErr string
+	}{
+		{"negative threshold", func(c *OperatorConfig) { c.NetboxCircuitBreakerThreshold = -1 }, "invalid netbox circuit breaker threshold"},
+		{"invalid open duration", func(c *OperatorConfig) { c.NetboxCircuitBreakerOpenDurationRaw = "long" }, "invalid netbox circuit breaker open duration"},
+		{"zero open duration", func(c *OperatorConfig) { c.NetboxCircuitBreakerOpenDurationRaw = "0s" }, "invalid netbox circuit breaker open duration"},
+		{"invalid interval", func(c *OperatorConfig) { c.NetboxHealthCheckIntervalRaw = "often" }, "invalid netbox health check interval"},
+		{"negative interval", func(c *OperatorConfig) { c.NetboxHealthCheckIntervalRaw = "-1s" }, "invalid netbox health check interval"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			err := c.parseHealthCheck()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseVersionCacheTTL(t *testing.T) {
+	ResetForTesting()
+	assert.Equal(t, time.Hour, GetOperatorConfig().NetboxVersionCacheTTL)
+
+	c := OperatorConfig{NetboxVersionCacheTTLRaw: "30m"}
+	assert.NoError(t, c.parseVersionCacheTTL())
+	assert.Equal(t, 30*time.Minute, c.NetboxVersionCacheTTL)
+
+	c = OperatorConfig{NetboxVersionCacheTTLRaw: "forever"}
+	assert.ErrorContains(t, c.parseVersionCacheTTL(), "invalid netbox version cache ttl")
+
+	c = OperatorConfig{NetboxVersionCacheTTLRaw: "0s"}
+	assert.ErrorContains(t, c.parseVersionCacheTTL(), "must be greater than 0")
+}
+
+func TestParseLookupCacheTTL(t *testing.T) {
+	ResetForTesting()
+	assert.Equal(t, 5*time.Minute, GetOperatorConfig().NetboxLookupCacheTTL)
+	assert.Equal(t, 30*time.Second, GetOperatorConfig().NetboxLookupCacheNegativeTTL)
+
+	c := OperatorConfig{NetboxLookupCacheTTLRaw: "0s", NetboxLookupCacheNegativeTTLRaw: "0s"}
+	assert.NoError(t, c.parseLookupCacheTTL())
+
+	c = OperatorConfig{NetboxLookupCacheTTLRaw: "-1m", NetboxLookupCacheNegativeTTLRaw: "30s"}
+	assert.ErrorContains(t, c.parseLookupCacheTTL(), "invalid netbox lookup cache ttl")
+
+	c = OperatorConfig{NetboxLookupCacheTTLRaw: "5m", NetboxLookupCacheNegativeTTLRaw: "briefly"}
+	assert.ErrorContains(t, c.parseLookupCacheTTL(), "invalid netbox lookup cache negative ttl")
+}
+
+func TestValidatePageLimit(t *testing.T) {
+	t.Setenv("NETBOX_PAGE_LIMIT", "250")
+	ResetForTesting()
+	assert.Equal(t, 250, GetOperatorConfig().NetboxPageLimit)
+
+	c := OperatorConfig{NetboxPageLimit: 0}
+	assert.NoError(t, c.validatePageLimit())
+
+	c = OperatorConfig{NetboxPageLimit: -1}
+	assert.ErrorContains(t, c.validatePageLimit(), "invalid netbox page limit")
 }
 
 func TestParseScheduleAndJitter_Defaults(t *testing.T) {
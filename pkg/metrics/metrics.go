@@ -0,0 +1,187 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package metrics contains the operator specific Prometheus collectors, they are registered
+on the controller-runtime metrics registry and exposed on its metrics endpoint.
+*/
+package metrics
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "netbox_operator"
+
+var (
+	ParentPrefixFreeAddresses = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "parent_prefix_free_addresses",
+		Help:      "Number of free addresses of a parent prefix, as observed by the last allocation from or utilization computation of the prefix",
+	}, []string{"parent_prefix"})
+
+	ParentPrefixUsedAddresses = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "parent_prefix_used_addresses",
+		Help:      "Number of used addresses of a parent prefix, as observed by the last allocation from or utilization computation of the prefix",
+	}, []string{"parent_prefix"})
+
+	ClaimAllocationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "claim_allocation_duration_seconds",
+		Help:      "Time from the creation of a claim until its resource is assigned and ready",
+		Buckets:   []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"kind"})
+
+	ParentExhaustedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "parent_exhausted_total",
+		Help:      "Number of allocations of a claim which failed because the parent prefix, ip range or vlan group is exhausted",
+	}, []string{"kind", "parent"})
+
+	RestorationHitsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "restoration_hits_total",
+		Help:      "Number of claims whose resource was restored from NetBox using the restoration hash",
+	}, []string{"kind"})
+
+	RestorationMissesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "restoration_misses_total",
+		Help:      "Number of claims whose resource could not be restored from NetBox and is allocated instead",
+	}, []string{"kind"})
+
+	LeaseLockContentionTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "lease_lock_contention_total",
+		Help:      "Number of failed attempts to lock the lease of a parent prefix or vlan group because it is held by another resource",
+	}, []string{"kind", "parent_prefix"})
+
+	NetboxRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "netbox_request_duration_seconds",
+		Help:      "Duration of the requests to the NetBox API by endpoint, method and status code",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint", "method", "status"})
+
+	NetboxRequestRetriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "netbox_request_retries_total",
+		Help:      "Number of retried requests to the NetBox API by method and status code of the failed attempt",
+	}, []string{"method", "status"})
+
+	NetboxInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "netbox_info",
+		Help:      "Version of a NetBox instance as detected by the operator, the value is always 1",
+	}, []string{"host", "version"})
+
+	NetboxLookupCacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "netbox_lookup_cache_requests_total",
+		Help:      "Number of lookups of tenants, sites, tags, VRFs, roles, VLAN groups and custom fields by kind and result, hit or miss of the cache",
+	}, []string{"kind", "result"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		ParentPrefixFreeAddresses,
+		ParentPrefixUsedAddresses,
+		ClaimAllocationDuration,
+		ParentExhaustedTotal,
+		RestorationHitsTotal,
+		RestorationMissesTotal,
+		LeaseLockContentionTotal,
+		NetboxRequestDuration,
+		NetboxRequestRetriesTotal,
+		NetboxInfo,
+		NetboxLookupCacheRequestsTotal,
+	)
+}
+
+// SetParentPrefixCapacity sets the free and used addresses of the parent prefix
+func SetParentPrefixCapacity(parentPrefix string, free *big.Int, used *big.Int) {
+	freeValue, _ := new(big.Float).SetInt(free).Float64()
+	usedValue, _ := new(big.Float).SetInt(used).Float64()
+	ParentPrefixFreeAddresses.WithLabelValues(parentPrefix).Set(freeValue)
+	ParentPrefixUsedAddresses.WithLabelValues(parentPrefix).Set(usedValue)
+}
+
+// ObserveRestoration counts a restoration attempt of a resource of a claim of the given kind
+func ObserveRestoration(kind string, restored bool) {
+	if restored {
+		RestorationHitsTotal.WithLabelValues(kind).Inc()
+	} else {
+		RestorationMissesTotal.WithLabelValues(kind).Inc()
+	}
+}
+
+// ObserveClaimAllocation records the time since the creation of a claim of the given kind
+func ObserveClaimAllocation(kind string, created time.Time) {
+	ClaimAllocationDuration.WithLabelValues(kind).Observe(time.Since(created).Seconds())
+}
+
+// ObserveNetboxRequest records the duration of a request to the NetBox API, statusCode is 0
+// if the request failed without a response
+func ObserveNetboxRequest(method string, path string, statusCode int, duration time.Duration) {
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	NetboxRequestDuration.WithLabelValues(NetboxEndpoint(path), method, status).Observe(duration.Seconds())
+}
+
+// ObserveNetboxRequestRetry counts a retry of a request to the NetBox API, statusCode is 0
+// if the failed attempt returned no response
+func ObserveNetboxRequestRetry(method string, statusCode int) {
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	NetboxRequestRetriesTotal.WithLabelValues(method, status).Inc()
+}
+
+// SetNetboxVersion sets the detected version of the NetBox instance at host, replacing the
+// version detected before
+func SetNetboxVersion(host string, version string) {
+	NetboxInfo.DeletePartialMatch(prometheus.Labels{"host": host})
+	NetboxInfo.WithLabelValues(host, version).Set(1)
+}
+
+// ObserveLookupCache counts a lookup of an object of the given kind in the lookup cache
+func ObserveLookupCache(kind string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	NetboxLookupCacheRequestsTotal.WithLabelValues(kind, result).Inc()
+}
+
+var netboxObjectIdPattern = regexp.MustCompile(`/[0-9]+(/|$)`)
+
+// NetboxEndpoint returns the path of a NetBox API request with the object ids replaced by a
+// placeholder, to keep the number of endpoint label values bounded
+func NetboxEndpoint(path string) string {
+	// replace twice since adjacent ids share the slash between them
+	endpoint := netboxObjectIdPattern.ReplaceAllString(path, "/{id}$1")
+	return netboxObjectIdPattern.ReplaceAllString(endpoint, "/{id}$1")
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeMetric(t *testing.T, metric prometheus.Metric) *dto.Metric {
+	m := &dto.Metric{}
+	assert.NoError(t, metric.Write(m))
+	return m
+}
+
+func TestNetboxEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"/api/ipam/prefixes/":                         "/api/ipam/prefixes/",
+		"/api/ipam/prefixes/12/":                      "/api/ipam/prefixes/{id}/",
+		"/api/ipam/prefixes/12/available-prefixes/":   "/api/ipam/prefixes/{id}/available-prefixes/",
+		"/api/ipam/ip-ranges/7/available-ips":         "/api/ipam/ip-ranges/{id}/available-ips",
+		"/api/ipam/ip-addresses/3":                    "/api/ipam/ip-addresses/{id}",
+		"/api/extras/object-changes/1/2/":             "/api/extras/object-changes/{id}/{id}/",
+		"/api/ipam/vlan-groups/4/available-vlans/":    "/api/ipam/vlan-groups/{id}/available-vlans/",
+		"/api/ipam/prefixes/10.0.0.0-24/not-a-number": "/api/ipam/prefixes/10.0.0.0-24/not-a-number",
+	}
+	for path, expected := range tests {
+		assert.Equal(t, expected, NetboxEndpoint(path), path)
+	}
+}
+
+func TestObserveRestoration(t *testing.T) {
+	ObserveRestoration("TestClaim", true)
+	ObserveRestoration("TestClaim", false)
+	ObserveRestoration("TestClaim", false)
+
+	assert.Equal(t, float64(1), writeMetric(t, RestorationHitsTotal.WithLabelValues("TestClaim")).GetCounter().GetValue())
+	assert.Equal(t, float64(2), writeMetric(t, RestorationMissesTotal.WithLabelValues("TestClaim")).GetCounter().GetValue())
+}
+
+func TestSetParentPrefixCapacity(t *testing.T) {
+	// the used addresses of an IPv6 /48 exceed an int64
+	used := new(big.Int).Lsh(big.NewInt(1), 79)
+	SetParentPrefixCapacity("2001:db8::/48", big.NewInt(256), used)
+
+	assert.Equal(t, float64(256), writeMetric(t, ParentPrefixFreeAddresses.WithLabelValues("2001:db8::/48")).GetGauge().GetValue())
+	assert.Equal(t, float64(1<<79), writeMetric(t, ParentPrefixUsedAddresses.WithLabelValues("2001:db8::/48")).GetGauge().GetValue())
+}
+
+func TestObserveNetboxRequest(t *testing.T) {
+	ObserveNetboxRequest("GET", "/api/ipam/prefixes/5/available-prefixes/", 200, 100*time.Millisecond)
+	ObserveNetboxRequest("GET", "/api/ipam/prefixes/6/available-prefixes/", 200, 300*time.Millisecond)
+	ObserveNetboxRequest("POST", "/api/ipam/prefixes/", 0, time.Second)
+
+	observer, err := NetboxRequestDuration.GetMetricWithLabelValues("/api/ipam/prefixes/{id}/available-prefixes/", "GET", "200")
+	assert.NoError(t, err)
+	histogram := writeMetric(t, observer.(prometheus.Metric)).GetHistogram()
+	assert.Equal(t, uint64(2), histogram.GetSampleCount())
+	assert.InDelta(t, 0.4, histogram.GetSampleSum(), 1e-9)
+
+	observer, err = NetboxRequestDuration.GetMetricWithLabelValues("/api/ipam/prefixes/", "POST", "error")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), writeMetric(t, observer.(prometheus.Metric)).GetHistogram().GetSampleCount())
+}
+
+func TestObserveNetboxRequestRetry(t *testing.T) {
+	ObserveNetboxRequestRetry("GET", 502)
+	ObserveNetboxRequestRetry("GET", 502)
+	ObserveNetboxRequestRetry("PUT", 0)
+
+	assert.Equal(t, float64(2), writeMetric(t, NetboxRequestRetriesTotal.WithLabelValues("GET", "502")).GetCounter().GetValue())
+	assert.Equal(t, float64(1), writeMetric(t, NetboxRequestRetriesTotal.WithLabelValues("PUT", "error")).GetCounter().GetValue())
+}
+
+func TestSetNetboxVersion(t *testing.T) {
+	SetNetboxVersion("netbox.example.com", "4.1.11")
+	SetNetboxVersion("netbox.example.com", "4.2.3")
+
+	assert.Equal(t, float64(1), writeMetric(t, NetboxInfo.WithLabelValues("netbox.example.com", "4.2.3")).GetGauge().GetValue())
+
+	// the version detected before is removed
+	metrics := make(chan prometheus.Metric, 10)
+	NetboxInfo.Collect(metrics)
+	close(metrics)
+	assert.Len(t, metrics, 1)
+}
+
+func TestObserveLookupCache(t *testing.T) {
+	ObserveLookupCache("tenant", true)
+	ObserveLookupCache("tenant", true)
+	ObserveLookupCache("tenant", false)
+
+	assert.Equal(t, float64(2), writeMetric(t, NetboxLookupCacheRequestsTotal.WithLabelValues("tenant", "hit")).GetCounter().GetValue())
+	assert.Equal(t, float64(1), writeMetric(t, NetboxLookupCacheRequestsTotal.WithLabelValues("tenant", "miss")).GetCounter().GetValue())
+}
@@ -19,7 +19,7 @@ package api
 import (
 	"testing"
 
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
+	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -34,13 +34,13 @@ func AssertError(t *testing.T, err error, msg string) {
 	assert.EqualError(t, err, msg)
 }
 
-func AssertIpAddress(t *testing.T, given *netboxModels.WritableIPAddress, actual *netboxModels.IPAddress) {
+func AssertIpAddress(t *testing.T, given *v4client.WritableIPAddressRequest, actual *v4client.IPAddress) {
 
 	t.Helper()
 
-	assert.Greater(t, actual.ID, int64(0))
+	assert.Greater(t, actual.Id, int32(0))
 	assert.Equal(t, given.Address, actual.Address)
-	assert.Equal(t, given.Comments, actual.Comments)
-	assert.Equal(t, given.Description, actual.Description)
-	assert.Equal(t, int64(2), actual.Tenant.ID)
+	assert.Equal(t, given.GetComments(), actual.GetComments())
+	assert.Equal(t, given.GetDescription(), actual.GetDescription())
+	assert.Equal(t, int32(2), actual.GetTenant().Id)
 }
@@ -25,13 +25,10 @@ import (
 	"strconv"
 	"time"
 
-	httptransport "github.com/go-openapi/runtime/client"
-	v3client "github.com/netbox-community/go-netbox/v3/netbox/client"
 	"github.com/netbox-community/netbox-operator/pkg/config"
-	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/extras"
-	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
+	operatormetrics "github.com/netbox-community/netbox-operator/pkg/metrics"
 	"k8s.io/client-go/tools/metrics"
 )
 
@@ -39,22 +36,15 @@ const (
 	RequestTimeout = 1200
 )
 
-type NetboxClientV3 struct {
-	Ipam    interfaces.IpamInterface
-	Tenancy interfaces.TenancyInterface
-	Extras  interfaces.ExtrasInterface
-	Dcim    interfaces.DcimInterface
-}
-
 // Checks that the Netbox host is properly configured for the operator to function.
 // Currently only checks that the required custom fields for IP address handling have been added.
-func (c *NetboxCompositeClient) VerifyNetboxConfiguration() error {
-	customFields, err := c.clientV3.Extras.ExtrasCustomFieldsList(extras.NewExtrasCustomFieldsListParams().WithName(&config.GetOperatorConfig().NetboxRestorationHashFieldName), nil)
+func (c *NetboxCompositeClient) VerifyNetboxConfiguration(ctx context.Context) error {
+	count, err := c.countCustomFields(ctx, config.GetOperatorConfig().NetboxRestorationHashFieldName)
 	if err != nil {
 		return err
 	}
 
-	if len(customFields.Payload.Results) != 1 {
+	if count != 1 {
 		return fmt.Errorf("netbox missing custom field '%s' for restoration hash", config.GetOperatorConfig().NetboxRestorationHashFieldName)
 	}
 	return nil
@@ -65,64 +55,131 @@ type InstrumentedRoundTripper struct {
 }
 
 func (irt *InstrumentedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
 	resp, err := irt.Transport.RoundTrip(req)
 	if err != nil {
+		operatormetrics.ObserveNetboxRequest(req.Method, req.URL.Path, 0, time.Since(start))
 		return nil, err
 	}
 
+	operatormetrics.ObserveNetboxRequest(req.Method, req.URL.Path, resp.StatusCode, time.Since(start))
 	metrics.RequestResult.Increment(context.TODO(), strconv.Itoa(resp.StatusCode), req.Method, req.Host)
 	return resp, nil
 }
 
-func GetNetboxClient() (*NetboxClientV3, error) {
+// ConnectionConfig holds the settings to connect to a NetBox instance
+type ConnectionConfig struct {
+	// Host of the NetBox instance, optionally with the port, without the scheme
+	Host string
+	// Tokens holds the NetBox API token, which can be swapped while the client is in use
+	Tokens *TokenProvider
+	// HttpsEnable defines whether NetBox is reached over HTTPS
+	HttpsEnable bool
+	// CaCert is the PEM encoded CA certificate used to verify the TLS certificate
+	// of NetBox, the system CA certificates are used if empty
+	CaCert []byte
+	// Retry defines how failed requests to NetBox are retried
+	Retry RetryConfig
+	// RateLimiter limits the rate of the requests to NetBox, it is shared by the
+	// clients of the connection, nil disables rate limiting
+	RateLimiter *rate.Limiter
+	// Breaker suspends the requests to NetBox after repeated failures, it is shared
+	// by the clients of the connection, nil disables the circuit breaker
+	Breaker *CircuitBreaker
+	// Versions caches the detected version of NetBox, nil disables the cache
+	Versions *VersionCache
+	// Lookups caches the tenants, sites, tags, VRFs, roles, VLAN groups and custom field definitions looked up
+	// in NetBox, nil disables the cache
+	Lookups *LookupCache
+}
 
-	logger := log.StandardLogger()
-	logger.Debug(fmt.Sprintf("Initializing netbox client at host %v", config.GetOperatorConfig().NetboxHost))
+// BaseUrl returns the url of the NetBox instance, e.g. https://netbox.example.com
+func (c *ConnectionConfig) BaseUrl() string {
+	return c.scheme() + "://" + c.Host
+}
 
-	var desiredRuntimeClientSchemes []string
-	desiredRuntimeClientSchemes = []string{"http"}
-	if config.GetOperatorConfig().HttpsEnable {
-		desiredRuntimeClientSchemes = []string{"https"}
+func (c *ConnectionConfig) scheme() string {
+	if c.HttpsEnable {
+		return "https"
 	}
+	return "http"
+}
 
+// newHttpClient returns the instrumented, retrying and circuit breaking http client used by the v4 client
+func (c *ConnectionConfig) newHttpClient() (*http.Client, error) {
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: false,
 	}
-	if config.GetOperatorConfig().CaCert != "" {
+	if len(c.CaCert) > 0 {
 		caRootPool := x509.NewCertPool()
-		certData, err := config.GetOperatorConfig().LoadCaCert()
-		if err != nil {
-			return nil, err
-		}
-		ok := caRootPool.AppendCertsFromPEM(certData)
-		if !ok {
-			logger.Fatalf("Unable to parse certificate at path %s with contents: %s", config.GetOperatorConfig().CaCert, certData)
+		if ok := caRootPool.AppendCertsFromPEM(c.CaCert); !ok {
+			return nil, fmt.Errorf("unable to parse the ca certificate of netbox host %s", c.Host)
 		}
 		tlsConfig.RootCAs = caRootPool
 	}
 
-	httpClient := &http.Client{
-		Transport: &InstrumentedRoundTripper{
-			Transport: &http.Transport{
-				TLSClientConfig: tlsConfig,
+	return &http.Client{
+		Transport: &QueryFilterRoundTripper{
+			Transport: &TokenRoundTripper{
+				Tokens: c.Tokens,
+				Transport: &VersionRoundTripper{
+					Versions: c.Versions,
+					Transport: &LookupCacheRoundTripper{
+						Lookups: c.Lookups,
+						Transport: &CircuitBreakerRoundTripper{
+							Breaker: c.Breaker,
+							Transport: &RetryRoundTripper{
+								Config:  c.Retry,
+								Limiter: c.RateLimiter,
+								Transport: &InstrumentedRoundTripper{
+									Transport: &http.Transport{
+										TLSClientConfig: tlsConfig,
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 		Timeout: time.Second * time.Duration(RequestTimeout),
-	}
+	}, nil
+}
 
-	transport := httptransport.NewWithClient(config.GetOperatorConfig().NetboxHost, v3client.DefaultBasePath, desiredRuntimeClientSchemes, httpClient)
-	transport.DefaultAuthentication = httptransport.APIKeyAuth("Authorization",
-		"header",
-		fmt.Sprintf("Token %v", config.GetOperatorConfig().AuthToken))
-	transport.SetLogger(log.StandardLogger())
-
-	auxNetboxClient := v3client.New(transport, nil)
-	netboxClient := &NetboxClientV3{
-		Ipam:    auxNetboxClient.Ipam,
-		Tenancy: auxNetboxClient.Tenancy,
-		Extras:  auxNetboxClient.Extras,
-		Dcim:    auxNetboxClient.Dcim,
+// GetConnectionConfig returns the connection settings of the NetBox instance
+// of the operator configuration. If the token is read from a Secret, the token
+// is empty and must be set by the caller.
+func GetConnectionConfig() (*ConnectionConfig, error) {
+	operatorConfig := config.GetOperatorConfig()
+
+	token := operatorConfig.AuthToken
+	switch {
+	case operatorConfig.AuthTokenFile != "":
+		var err error
+		token, err = ReadTokenFile(operatorConfig.AuthTokenFile)
+		if err != nil {
+			return nil, err
+		}
+	case operatorConfig.AuthTokenSecretName != "":
+		token = ""
 	}
 
-	return netboxClient, nil
+	connectionConfig := &ConnectionConfig{
+		Host:        operatorConfig.NetboxHost,
+		Tokens:      NewTokenProvider(token),
+		HttpsEnable: operatorConfig.HttpsEnable,
+		Retry:       GetRetryConfig(),
+		RateLimiter: NewRateLimiter(),
+		Breaker:     NewCircuitBreakerFromConfig(operatorConfig.NetboxHost),
+		Versions:    NewVersionCacheFromConfig(operatorConfig.NetboxHost),
+		Lookups:     NewLookupCacheFromConfig(),
+	}
+	if operatorConfig.CaCert != "" {
+		certData, err := operatorConfig.LoadCaCert()
+		if err != nil {
+			return nil, err
+		}
+		connectionConfig.CaCert = certData
+	}
+	return connectionConfig, nil
 }
@@ -17,70 +17,52 @@ limitations under the License.
 package api
 
 import (
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	v4client "github.com/netbox-community/go-netbox/v4"
-	"github.com/netbox-community/netbox-operator/pkg/config"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
 	log "github.com/sirupsen/logrus"
 )
 
 type NetboxClientV4 struct {
-	client    *v4client.APIClient
-	IpamAPI   interfaces.IpamAPI
-	StatusAPI interfaces.StatusAPI
+	client     *v4client.APIClient
+	IpamAPI    interfaces.IpamAPI
+	TenancyAPI interfaces.TenancyAPI
+	DcimAPI    interfaces.DcimAPI
+	ExtrasAPI  interfaces.ExtrasAPI
+	StatusAPI  interfaces.StatusAPI
+	// versions caches the detected version of NetBox, the version is
+	// requested before every write if nil
+	versions *VersionCache
 }
 
-func GetNetboxClientV4() (*NetboxClientV4, error) {
+// NewNetboxClientV4 returns the v4 client of the NetBox instance of the connection settings
+func NewNetboxClientV4(connectionConfig *ConnectionConfig) (*NetboxClientV4, error) {
 	logger := log.StandardLogger()
-	logger.Debug(fmt.Sprintf("Initializing netbox client v4 at host %v", config.GetOperatorConfig().NetboxHost))
+	logger.Debug(fmt.Sprintf("Initializing netbox client at host %v", connectionConfig.Host))
 
-	tlsConfig := &tls.Config{
-		InsecureSkipVerify: false,
-	}
-	if config.GetOperatorConfig().CaCert != "" {
-		caRootPool := x509.NewCertPool()
-		certData, err := config.GetOperatorConfig().LoadCaCert()
-		if err != nil {
-			return nil, err
-		}
-		ok := caRootPool.AppendCertsFromPEM(certData)
-		if !ok {
-			logger.Fatalf("Unable to parse certificate at path %s with contents: %s", config.GetOperatorConfig().CaCert, certData)
-		}
-		tlsConfig.RootCAs = caRootPool
-	}
-
-	httpClient := &http.Client{
-		Transport: &InstrumentedRoundTripper{
-			Transport: &http.Transport{
-				TLSClientConfig: tlsConfig,
-			},
-		},
-		Timeout: time.Second * time.Duration(RequestTimeout),
-	}
-
-	desiredRuntimeClientScheme := "http"
-	if config.GetOperatorConfig().HttpsEnable {
-		desiredRuntimeClientScheme = "https"
+	httpClient, err := connectionConfig.newHttpClient()
+	if err != nil {
+		return nil, err
 	}
 
 	cfg := v4client.NewConfiguration()
-	cfg.Scheme = desiredRuntimeClientScheme
-	cfg.Host = config.GetOperatorConfig().NetboxHost
-	cfg.DefaultHeader["Authorization"] = fmt.Sprintf("Token %v", config.GetOperatorConfig().AuthToken)
+	cfg.Scheme = connectionConfig.scheme()
+	cfg.Host = connectionConfig.Host
+	// the Authorization header is set by the TokenRoundTripper of the http client
 	cfg.HTTPClient = httpClient
 	client := v4client.NewAPIClient(cfg)
 
 	return &NetboxClientV4{
-		client:    client,
-		IpamAPI:   &ipamV4APIAdapter{api: client.IpamAPI},
-		StatusAPI: &statusV4APIAdapter{api: client.StatusAPI},
+		client:     client,
+		IpamAPI:    &ipamV4APIAdapter{api: client.IpamAPI},
+		TenancyAPI: &tenancyV4APIAdapter{api: client.TenancyAPI},
+		DcimAPI:    &dcimV4APIAdapter{api: client.DcimAPI},
+		ExtrasAPI:  &extrasV4APIAdapter{api: client.ExtrasAPI},
+		StatusAPI:  &statusV4APIAdapter{api: client.StatusAPI},
+		versions:   connectionConfig.Versions,
 	}, nil
 }
 
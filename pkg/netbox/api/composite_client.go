@@ -16,20 +16,126 @@ limitations under the License.
 
 package api
 
-// NetboxCompositeClient holds both the v3 and v4 clients,
-// presenting a single unified interface to callers (controllers).
-// The v4 client was introduced because of braking changes in the
-// ip ranges and prefixes endpoints of the NetBox api in newer (4.2+) NetBox versions
-// for requests where the v3 client is still compatible the client was not replaced
+import (
+	"context"
+	"time"
+
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// healthProbeTimeout limits how long a health probe waits for the status of NetBox
+const healthProbeTimeout = 10 * time.Second
+
+// NetboxCompositeClient wraps the v4 client together with the state shared by the
+// clients of a connection, presenting a single unified interface to callers (controllers).
+// Writes which changed in NetBox 4.2, e.g. the scope of prefixes, are sent in the format
+// of the detected NetBox version, so that older NetBox versions are still supported.
 type NetboxCompositeClient struct {
-	clientV3 *NetboxClientV3
 	clientV4 *NetboxClientV4
+	baseUrl  string
+	tokens   *TokenProvider
+	breaker  *CircuitBreaker
+	lookups  *LookupCache
 }
 
-// NewNetboxCompositeClient creates a new composite client wrapping both v3 and v4 clients.
-func NewNetboxCompositeClient(clientV3 *NetboxClientV3, clientV4 *NetboxClientV4) *NetboxCompositeClient {
+// NewNetboxCompositeClient creates a new composite client wrapping the v4 client.
+func NewNetboxCompositeClient(clientV4 *NetboxClientV4) *NetboxCompositeClient {
 	return &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 }
+
+// NewNetboxCompositeClientForConnection creates the v4 client of the NetBox instance
+// of the connection settings and wraps it in a composite client.
+func NewNetboxCompositeClientForConnection(connectionConfig *ConnectionConfig) (*NetboxCompositeClient, error) {
+	clientV4, err := NewNetboxClientV4(connectionConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	compositeClient := NewNetboxCompositeClient(clientV4)
+	compositeClient.baseUrl = connectionConfig.BaseUrl()
+	compositeClient.tokens = connectionConfig.Tokens
+	compositeClient.breaker = connectionConfig.Breaker
+	compositeClient.lookups = connectionConfig.Lookups
+	return compositeClient, nil
+}
+
+// GetNetboxCompositeClient creates the composite client of the NetBox instance of the operator configuration
+func GetNetboxCompositeClient() (*NetboxCompositeClient, error) {
+	connectionConfig, err := GetConnectionConfig()
+	if err != nil {
+		return nil, err
+	}
+	return NewNetboxCompositeClientForConnection(connectionConfig)
+}
+
+// BaseUrl returns the url of the NetBox instance of the client, which is used
+// to link the NetBox objects in the status of the resources.
+func (c *NetboxCompositeClient) BaseUrl() string {
+	if c.baseUrl == "" {
+		return config.GetBaseUrl()
+	}
+	return c.baseUrl
+}
+
+// Tokens returns the token provider of the client, which is used to swap the API token
+func (c *NetboxCompositeClient) Tokens() *TokenProvider {
+	return c.tokens
+}
+
+// TokenRejected returns true if NetBox rejected the API token of the client as invalid or expired
+func (c *NetboxCompositeClient) TokenRejected() bool {
+	return c.tokens != nil && c.tokens.Rejected()
+}
+
+// Available returns an error wrapping ErrNetboxUnavailable while the circuit breaker of the
+// client is open, reconciles should be requeued after RetryAfter instead of calling NetBox
+func (c *NetboxCompositeClient) Available() error {
+	if c.breaker == nil {
+		return nil
+	}
+	return c.breaker.Allow()
+}
+
+// Healthy returns an error wrapping ErrNetboxUnavailable if the last requests to NetBox failed
+func (c *NetboxCompositeClient) Healthy() error {
+	if c.breaker == nil {
+		return nil
+	}
+	return c.breaker.Healthy()
+}
+
+// RetryAfter returns the time after which a reconcile short-circuited by Available should be retried
+func (c *NetboxCompositeClient) RetryAfter() time.Duration {
+	if c.breaker == nil {
+		return 0
+	}
+	return c.breaker.RetryAfter()
+}
+
+// ProbeHealth requests the status of NetBox, the outcome is recorded by the circuit breaker
+// of the client and the version in the status is cached. The probe is skipped while the
+// circuit breaker is open.
+func (c *NetboxCompositeClient) ProbeHealth(ctx context.Context) {
+	if c.breaker == nil || c.Available() != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+	status, httpResp, err := c.clientV4.StatusAPI.StatusRetrieve(ctx).Execute()
+	if httpResp != nil && httpResp.Body != nil {
+		_ = httpResp.Body.Close()
+	}
+	if err != nil {
+		log.StandardLogger().Debugf("netbox health probe of %s failed: %v", c.BaseUrl(), err)
+		return
+	}
+
+	// the status contains the version, which keeps the cached version up to date
+	if version, ok := status["netbox-version"].(string); ok && version != "" && c.clientV4.versions != nil {
+		c.clientV4.versions.set(version)
+	}
+}
@@ -18,6 +18,7 @@ package api
 
 const (
 	warningComment              = " // managed by netbox-operator, please don't edit it in Netbox unless you know what you're doing"
-	maxAllowedDescriptionLength = 200 // max length of description field in Netbox
-	minWarningCommentLength     = 30  // len(" // managed by netbox-operator")
+	maxAllowedDescriptionLength = 200      // max length of description field in Netbox
+	minWarningCommentLength     = 30       // len(" // managed by netbox-operator")
+	defaultStatus               = "active" // status of the resources which don't set a status
 )
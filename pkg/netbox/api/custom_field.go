@@ -0,0 +1,240 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+)
+
+// countCustomFields returns the number of custom fields with the name in NetBox
+func (c *NetboxCompositeClient) countCustomFields(ctx context.Context, name string) (count int32, err error) {
+	list, httpResp, execErr := c.clientV4.ExtrasAPI.ExtrasCustomFieldsList(ctx).Name([]string{name}).Execute()
+	if execErr != nil && httpResp != nil && httpResp.StatusCode == http.StatusOK {
+		// NetBox versions before 4.0 return the content_types instead of the object_types
+		// of a custom field, which the v4 client requires, so only the count is decoded
+		if count, ok := decodeListCount(execErr); ok {
+			if httpResp.Body != nil {
+				_ = httpResp.Body.Close()
+			}
+			return count, nil
+		}
+	}
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list custom fields")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return 0, handleErr
+	}
+
+	return list.Count, nil
+}
+
+// decodeListCount returns the count of the list in the body of a response which the
+// v4 client failed to decode
+func decodeListCount(err error) (int32, bool) {
+	var openAPIErr *v4client.GenericOpenAPIError
+	if !errors.As(err, &openAPIErr) {
+		return 0, false
+	}
+	var list struct {
+		Count *int32 `json:"count"`
+	}
+	if json.Unmarshal(openAPIErr.Body(), &list) != nil || list.Count == nil {
+		return 0, false
+	}
+	return *list.Count, true
+}
+
+// customFieldsRequest converts the custom fields of a resource, whose values are strings in
+// the specs, to the values of a write request with the types of the custom fields in NetBox.
+// It returns an ErrInvalidCustomFieldValue if a value can't be converted to the type.
+func (c *NetboxCompositeClient) customFieldsRequest(ctx context.Context, custom map[string]string) (map[string]interface{}, error) {
+	customFields := make(map[string]interface{}, len(custom))
+	for name, value := range custom {
+		// the restoration hash is always a text custom field, its type is not looked up
+		if name == config.GetOperatorConfig().NetboxRestorationHashFieldName {
+			customFields[name] = value
+			continue
+		}
+
+		fieldType, err := cachedLookup(c.lookups, customFieldTypeLookupKind, name, func() (v4client.CustomFieldTypeValue, error) {
+			return c.fetchCustomFieldType(ctx, name)
+		})
+		if errors.Is(err, utils.ErrNotFound) {
+			// the value is written as is, NetBox rejects the request with the name of the unknown custom field
+			customFields[name] = value
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch the type of custom field %s: %w", name, err)
+		}
+
+		customFields[name], err = customFieldValue(fieldType, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: custom field %s has type %s, %w", ErrInvalidCustomFieldValue, name, fieldType, err)
+		}
+	}
+	return customFields, nil
+}
+
+// customFieldValue converts the string value of a custom field to the type of the custom field.
+// An empty value clears a custom field which is not a text, e.g. after it was removed from a spec.
+func customFieldValue(fieldType v4client.CustomFieldTypeValue, value string) (interface{}, error) {
+	switch fieldType {
+	case v4client.CUSTOMFIELDTYPEVALUE_TEXT, v4client.CUSTOMFIELDTYPEVALUE_LONGTEXT:
+		return value, nil
+	}
+	if value == "" {
+		return nil, nil
+	}
+
+	switch fieldType {
+	case v4client.CUSTOMFIELDTYPEVALUE_INTEGER:
+		integer, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", value)
+		}
+		return integer, nil
+	case v4client.CUSTOMFIELDTYPEVALUE_DECIMAL:
+		decimal, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a decimal number", value)
+		}
+		return decimal, nil
+	case v4client.CUSTOMFIELDTYPEVALUE_BOOLEAN:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean, use true or false", value)
+		}
+		return boolean, nil
+	case v4client.CUSTOMFIELDTYPEVALUE_JSON:
+		if !json.Valid([]byte(value)) {
+			return nil, fmt.Errorf("%q is not valid JSON", value)
+		}
+		return json.RawMessage(value), nil
+	case v4client.CUSTOMFIELDTYPEVALUE_MULTISELECT:
+		return splitCustomFieldList(value)
+	case v4client.CUSTOMFIELDTYPEVALUE_OBJECT:
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not the id of an object", value)
+		}
+		return id, nil
+	case v4client.CUSTOMFIELDTYPEVALUE_MULTIOBJECT:
+		items, err := splitCustomFieldList(value)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]int64, 0, len(items))
+		for _, item := range items {
+			id, err := strconv.ParseInt(item, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not the id of an object", item)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	default:
+		// select, date, datetime and url custom fields are written as strings
+		return value, nil
+	}
+}
+
+// splitCustomFieldList returns the items of the value of a multiple selection or multiple objects
+// custom field, which is either a JSON array of strings or a comma separated list
+func splitCustomFieldList(value string) ([]string, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "[") {
+		var items []string
+		if err := json.Unmarshal([]byte(value), &items); err != nil {
+			return nil, fmt.Errorf("%q is not a JSON array of strings", value)
+		}
+		return items, nil
+	}
+
+	items := strings.Split(value, ",")
+	for i := range items {
+		items[i] = strings.TrimSpace(items[i])
+	}
+	return items, nil
+}
+
+// fetchCustomFieldType returns the type of the custom field with the name in NetBox
+func (c *NetboxCompositeClient) fetchCustomFieldType(ctx context.Context, name string) (fieldType v4client.CustomFieldTypeValue, err error) {
+	list, httpResp, execErr := c.clientV4.ExtrasAPI.ExtrasCustomFieldsList(ctx).Name([]string{name}).Execute()
+	if execErr != nil && httpResp != nil && httpResp.StatusCode == http.StatusOK {
+		// NetBox versions before 4.0 return a custom field which the v4 client fails to decode,
+		// see countCustomFields, so only the types are decoded
+		if fieldTypes, ok := decodeCustomFieldTypes(execErr); ok {
+			if httpResp.Body != nil {
+				_ = httpResp.Body.Close()
+			}
+			if len(fieldTypes) == 0 {
+				return "", utils.NetboxNotFoundError("custom field " + name)
+			}
+			return fieldTypes[0], nil
+		}
+	}
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list custom fields")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return "", handleErr
+	}
+
+	if len(list.Results) == 0 {
+		return "", utils.NetboxNotFoundError("custom field " + name)
+	}
+	return list.Results[0].Type.GetValue(), nil
+}
+
+// decodeCustomFieldTypes returns the types of the custom fields of the list in the body of a
+// response which the v4 client failed to decode
+func decodeCustomFieldTypes(err error) ([]v4client.CustomFieldTypeValue, bool) {
+	var openAPIErr *v4client.GenericOpenAPIError
+	if !errors.As(err, &openAPIErr) {
+		return nil, false
+	}
+	var list struct {
+		Results *[]struct {
+			Type struct {
+				Value v4client.CustomFieldTypeValue `json:"value"`
+			} `json:"type"`
+		} `json:"results"`
+	}
+	if json.Unmarshal(openAPIErr.Body(), &list) != nil || list.Results == nil {
+		return nil, false
+	}
+	fieldTypes := make([]v4client.CustomFieldTypeValue, 0, len(*list.Results))
+	for _, result := range *list.Results {
+		fieldTypes = append(fieldTypes, result.Type.Value)
+	}
+	return fieldTypes, true
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// mockExtrasAPI returns an extras api in which only the text custom fields with the names exist
+func mockExtrasAPI(ctrl *gomock.Controller, names ...string) *mock_interfaces.MockExtrasAPI {
+	fieldTypes := make(map[string]v4client.CustomFieldTypeValue, len(names))
+	for _, name := range names {
+		fieldTypes[name] = v4client.CUSTOMFIELDTYPEVALUE_TEXT
+	}
+	return mockExtrasAPIWithTypes(ctrl, fieldTypes)
+}
+
+// mockExtrasAPIWithTypes returns an extras api in which only the custom fields with the names
+// and types exist
+func mockExtrasAPIWithTypes(ctrl *gomock.Controller, fieldTypes map[string]v4client.CustomFieldTypeValue) *mock_interfaces.MockExtrasAPI {
+	mockExtras := mock_interfaces.NewMockExtrasAPI(ctrl)
+	mockExtras.EXPECT().ExtrasCustomFieldsList(gomock.Any()).DoAndReturn(func(_ context.Context) interfaces.ExtrasCustomFieldsListRequest {
+		list := &v4client.PaginatedCustomFieldList{Results: []v4client.CustomField{}}
+		mockListRequest := mock_interfaces.NewMockExtrasCustomFieldsListRequest(ctrl)
+		mockListRequest.EXPECT().Name(gomock.Any()).DoAndReturn(func(name []string) interfaces.ExtrasCustomFieldsListRequest {
+			if fieldType, ok := fieldTypes[name[0]]; ok {
+				list.Count = 1
+				list.Results = append(list.Results, v4client.CustomField{Name: name[0], Type: v4client.CustomFieldType{Value: &fieldType}})
+			}
+			return mockListRequest
+		})
+		mockListRequest.EXPECT().Execute().DoAndReturn(func() (*v4client.PaginatedCustomFieldList, *http.Response, error) {
+			return list, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})
+		return mockListRequest
+	}).AnyTimes()
+	return mockExtras
+}
+
+func TestCountCustomFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		expectedCount int32
+		expectedErr   string
+	}{
+		{
+			name:       "netbox 4",
+			statusCode: http.StatusOK,
+			body: `{"count": 1, "next": null, "previous": null, "results": [{"id": 1, "url": "", "display": "netboxOperatorRestorationHash",
+				"object_types": ["ipam.prefix"], "type": {"value": "text", "label": "Text"}, "name": "netboxOperatorRestorationHash"}]}`,
+			expectedCount: 1,
+		},
+		{
+			name:       "netbox 3 returns content types instead of object types",
+			statusCode: http.StatusOK,
+			body: `{"count": 1, "next": null, "previous": null, "results": [{"id": 1, "url": "", "display": "netboxOperatorRestorationHash",
+				"content_types": ["ipam.prefix"], "type": {"value": "text", "label": "Text"}, "name": "netboxOperatorRestorationHash"}]}`,
+			expectedCount: 1,
+		},
+		{
+			name:          "no custom field",
+			statusCode:    http.StatusOK,
+			body:          `{"count": 0, "next": null, "previous": null, "results": []}`,
+			expectedCount: 0,
+		},
+		{
+			name:        "invalid response",
+			statusCode:  http.StatusOK,
+			body:        `{"results": [{"id": 1}]}`,
+			expectedErr: "failed to list custom fields",
+		},
+		{
+			name:        "server error",
+			statusCode:  http.StatusInternalServerError,
+			body:        `{"detail": "Internal Server Error"}`,
+			expectedErr: "failed to list custom fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/extras/custom-fields/", r.URL.Path)
+				assert.Equal(t, "netboxOperatorRestorationHash", r.URL.Query().Get("name"))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			netboxClient, err := NewNetboxCompositeClientForConnection(&ConnectionConfig{
+				Host:   server.Listener.Addr().String(),
+				Tokens: NewTokenProvider("0123456789abcdef"),
+			})
+			require.NoError(t, err)
+
+			count, err := netboxClient.countCustomFields(context.TODO(), "netboxOperatorRestorationHash")
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, count)
+		})
+	}
+}
+
+func TestFetchCustomFieldType(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		expectedType v4client.CustomFieldTypeValue
+		expectedErr  string
+	}{
+		{
+			name: "netbox 4",
+			body: `{"count": 1, "next": null, "previous": null, "results": [{"id": 1, "url": "http://netbox/api/extras/custom-fields/1/", "display": "vlan_id",
+				"object_types": ["ipam.prefix"], "type": {"value": "integer", "label": "Integer"}, "data_type": "integer", "name": "vlan_id"}]}`,
+			expectedType: v4client.CUSTOMFIELDTYPEVALUE_INTEGER,
+		},
+		{
+			name: "netbox 3 returns content types instead of object types",
+			body: `{"count": 1, "next": null, "previous": null, "results": [{"id": 1, "url": "", "display": "vlan_id",
+				"content_types": ["ipam.prefix"], "type": {"value": "integer", "label": "Integer"}, "name": "vlan_id"}]}`,
+			expectedType: v4client.CUSTOMFIELDTYPEVALUE_INTEGER,
+		},
+		{
+			name:        "no custom field",
+			body:        `{"count": 0, "next": null, "previous": null, "results": []}`,
+			expectedErr: "failed to fetch custom field vlan_id: not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "vlan_id", r.URL.Query().Get("name"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			netboxClient, err := NewNetboxCompositeClientForConnection(&ConnectionConfig{
+				Host:   server.Listener.Addr().String(),
+				Tokens: NewTokenProvider("0123456789abcdef"),
+			})
+			require.NoError(t, err)
+
+			fieldType, err := netboxClient.fetchCustomFieldType(context.TODO(), "vlan_id")
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedType, fieldType)
+		})
+	}
+}
+
+func TestCustomFieldValue(t *testing.T) {
+	tests := []struct {
+		fieldType     v4client.CustomFieldTypeValue
+		value         string
+		expectedValue interface{}
+		expectedErr   string
+	}{
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_TEXT, value: "Production", expectedValue: "Production"},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_TEXT, value: "", expectedValue: ""},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_SELECT, value: "gold", expectedValue: "gold"},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_INTEGER, value: "100", expectedValue: int64(100)},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_INTEGER, value: "", expectedValue: nil},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_INTEGER, value: "1.5", expectedErr: `"1.5" is not an integer`},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_DECIMAL, value: "1.5", expectedValue: 1.5},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_BOOLEAN, value: "true", expectedValue: true},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_BOOLEAN, value: "yes", expectedErr: `"yes" is not a boolean`},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_JSON, value: `{"vlan": 100}`, expectedValue: json.RawMessage(`{"vlan": 100}`)},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_JSON, value: `{"vlan": `, expectedErr: "is not valid JSON"},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_MULTISELECT, value: "gold, silver", expectedValue: []string{"gold", "silver"}},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_MULTISELECT, value: `["gold", "silver"]`, expectedValue: []string{"gold", "silver"}},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_OBJECT, value: "7", expectedValue: int64(7)},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_OBJECT, value: "tenant1", expectedErr: `"tenant1" is not the id of an object`},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_MULTIOBJECT, value: "7,8", expectedValue: []int64{7, 8}},
+		{fieldType: v4client.CUSTOMFIELDTYPEVALUE_MULTIOBJECT, value: "7,x", expectedErr: `"x" is not the id of an object`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.fieldType)+" "+tt.value, func(t *testing.T) {
+			value, err := customFieldValue(tt.fieldType, tt.value)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedValue, value)
+		})
+	}
+}
@@ -16,12 +16,48 @@ limitations under the License.
 
 package api
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+)
 
 var (
 	ErrParentPrefixExhausted           = errors.New("parent prefix exhausted")
 	ErrParentPrefixNotFound            = errors.New("parent prefix not found")
+	ErrParentIpRangeExhausted          = errors.New("parent ip range exhausted")
+	ErrParentIpRangeNotFound           = errors.New("parent ip range not found")
 	ErrWrongMatchingPrefixSubnetFormat = errors.New("wrong matchingPrefix subnet format")
 	ErrInvalidIpFamily                 = errors.New("invalid IP Family")
+	ErrInvalidVlanId                   = errors.New("invalid VLAN ID")
+	ErrVlanGroupExhausted              = errors.New("vlan group exhausted")
+	ErrVlanGroupNotFound               = errors.New("vlan group not found")
 	ErrRestorationHashMismatch         = errors.New("restoration hash mismatch")
+	ErrNotEnoughConsecutiveIps         = errors.New("not enough consecutive IPs available")
+	ErrPreferredAddressNotAvailable    = errors.New("preferred ip address not available")
+	ErrPreferredPrefixNotAvailable     = errors.New("preferred prefix not available")
+	ErrTokenRejected                   = errors.New("netbox rejected the api token, the token is invalid or expired")
+	ErrNetboxUnavailable               = errors.New("netbox is unavailable")
+	ErrInvalidCustomFieldValue         = errors.New("invalid custom field value")
 )
+
+// parentPrefixNotFoundError returns the error of a parent prefix of an IP Address or IP Range which
+// does not exist in NetBox. It keeps the message of the NetBox lookup and matches both
+// ErrParentPrefixNotFound and utils.ErrNotFound.
+func parentPrefixNotFoundError() error {
+	return &sentinelError{err: utils.NetboxNotFoundError("parent prefix"), sentinel: ErrParentPrefixNotFound}
+}
+
+// sentinelError is an error with the message of err which also matches the sentinel
+type sentinelError struct {
+	err      error
+	sentinel error
+}
+
+func (e *sentinelError) Error() string {
+	return e.err.Error()
+}
+
+func (e *sentinelError) Unwrap() []error {
+	return []error{e.err, e.sentinel}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxCircuitOpenDuration caps the time the circuit breaker stays open when it opens repeatedly
+const maxCircuitOpenDuration = 5 * time.Minute
+
+// CircuitBreaker stops the requests to a NetBox instance after a number of consecutive
+// failures. Once the open duration has passed, requests are let through again, the next
+// failure opens the circuit again for twice the duration, a success closes it.
+type CircuitBreaker struct {
+	host         string
+	threshold    int
+	openDuration time.Duration
+
+	mu       sync.Mutex
+	failures int
+	// backoff is the duration of the current open period, it is 0 while the circuit is closed
+	backoff   time.Duration
+	openUntil time.Time
+	lastErr   error
+}
+
+// NewCircuitBreaker returns a closed circuit breaker of the NetBox instance at host
+func NewCircuitBreaker(host string, threshold int, openDuration time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		host:         host,
+		threshold:    threshold,
+		openDuration: openDuration,
+	}
+}
+
+// NewCircuitBreakerFromConfig returns a circuit breaker of the NetBox instance at host with
+// the settings of the operator configuration, it returns nil if the circuit breaker is disabled
+func NewCircuitBreakerFromConfig(host string) *CircuitBreaker {
+	operatorConfig := config.GetOperatorConfig()
+	if operatorConfig.NetboxCircuitBreakerThreshold <= 0 {
+		return nil
+	}
+	return NewCircuitBreaker(host, operatorConfig.NetboxCircuitBreakerThreshold, operatorConfig.NetboxCircuitBreakerOpenDuration)
+}
+
+// Allow returns an error wrapping ErrNetboxUnavailable while the circuit is open
+func (cb *CircuitBreaker) Allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if time.Now().Before(cb.openUntil) {
+		return fmt.Errorf("%w, the requests to host %s are suspended after %d consecutive failures", ErrNetboxUnavailable, cb.host, cb.failures)
+	}
+	return nil
+}
+
+// Healthy returns an error if the last requests to NetBox failed at least threshold times
+// in a row, also once the circuit lets requests through again until one of them succeeds
+func (cb *CircuitBreaker) Healthy() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.backoff > 0 {
+		return fmt.Errorf("%w, host %s, last error: %w", ErrNetboxUnavailable, cb.host, cb.lastErr)
+	}
+	return nil
+}
+
+// RetryAfter returns the time after which a request short-circuited by Allow should be
+// retried, it includes a jitter so that the requeued reconciles do not hit NetBox at once
+func (cb *CircuitBreaker) RetryAfter() time.Duration {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	wait := max(time.Until(cb.openUntil), 0)
+	return wait + rand.N(cb.openDuration/2+1)
+}
+
+// Success records a successful request and closes the circuit
+func (cb *CircuitBreaker) Success() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.backoff > 0 {
+		log.StandardLogger().Infof("netbox at host %s is available again, closing the circuit breaker", cb.host)
+	}
+	cb.failures = 0
+	cb.backoff = 0
+	cb.openUntil = time.Time{}
+	cb.lastErr = nil
+}
+
+// Failure records a failed request and opens the circuit if the threshold is reached
+func (cb *CircuitBreaker) Failure(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failures++
+	cb.lastErr = err
+	if cb.failures < cb.threshold {
+		return
+	}
+
+	if cb.backoff == 0 {
+		cb.backoff = cb.openDuration
+	} else {
+		cb.backoff = min(2*cb.backoff, max(maxCircuitOpenDuration, cb.openDuration))
+	}
+	cb.openUntil = time.Now().Add(cb.backoff)
+	log.StandardLogger().Warnf("netbox at host %s is unavailable after %d consecutive failures, opening the circuit breaker for %v: %v", cb.host, cb.failures, cb.backoff, err)
+}
+
+// CircuitBreakerRoundTripper fails requests right away while the circuit breaker is open and
+// records the outcome of the other requests. Network errors and 5xx responses are failures,
+// any other response shows that NetBox is available.
+type CircuitBreakerRoundTripper struct {
+	Breaker   *CircuitBreaker
+	Transport http.RoundTripper
+}
+
+func (cbrt *CircuitBreakerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if cbrt.Breaker == nil {
+		return cbrt.Transport.RoundTrip(req)
+	}
+	if err := cbrt.Breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := cbrt.Transport.RoundTrip(req)
+	switch {
+	case errors.Is(err, context.Canceled):
+		// the caller gave up, this tells nothing about NetBox
+	case err != nil:
+		cbrt.Breaker.Failure(err)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		cbrt.Breaker.Failure(fmt.Errorf("status %d", resp.StatusCode))
+	default:
+		cbrt.Breaker.Success()
+	}
+	return resp, err
+}
+
+// HealthMonitor periodically probes the NetBox instances of the client registry with the
+// status api, so that the circuit breakers reflect the health of NetBox also while no
+// resources are reconciled, and reports the health of the default NetBox instance.
+type HealthMonitor struct {
+	Clients  *ClientRegistry
+	Interval time.Duration
+}
+
+// Start probes the NetBox instances every interval until ctx is done
+func (m *HealthMonitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			for _, netboxClient := range m.Clients.Clients() {
+				netboxClient.ProbeHealth(ctx)
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns false, the health of NetBox is probed on every replica
+// since it feeds the readiness probe of the replica
+func (m *HealthMonitor) NeedLeaderElection() bool {
+	return false
+}
+
+// ReadyzCheck returns an error if the default NetBox instance is unavailable,
+// it can be added as a readiness check to the manager
+func (m *HealthMonitor) ReadyzCheck(_ *http.Request) error {
+	if m.Clients.DefaultClient() == nil {
+		return nil
+	}
+	return m.Clients.DefaultClient().Healthy()
+}
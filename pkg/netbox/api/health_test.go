@@ -0,0 +1,162 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := NewCircuitBreaker("netbox.example.com", 2, 50*time.Millisecond)
+	assert.NoError(t, breaker.Allow())
+	assert.NoError(t, breaker.Healthy())
+
+	breaker.Failure(errors.New("connection refused"))
+	assert.NoError(t, breaker.Allow())
+	assert.NoError(t, breaker.Healthy())
+
+	// the threshold is reached, the circuit opens
+	breaker.Failure(errors.New("connection refused"))
+	assert.ErrorIs(t, breaker.Allow(), ErrNetboxUnavailable)
+	assert.ErrorIs(t, breaker.Healthy(), ErrNetboxUnavailable)
+	assert.ErrorContains(t, breaker.Healthy(), "connection refused")
+	assert.Greater(t, breaker.RetryAfter(), time.Duration(0))
+
+	// after the open duration requests are let through, but NetBox is not healthy until one succeeds
+	assert.Eventually(t, func() bool { return breaker.Allow() == nil }, time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, breaker.Healthy(), ErrNetboxUnavailable)
+
+	// another failure opens the circuit for twice the duration
+	breaker.Failure(errors.New("connection refused"))
+	assert.ErrorIs(t, breaker.Allow(), ErrNetboxUnavailable)
+	assert.Equal(t, 100*time.Millisecond, breaker.backoff)
+
+	breaker.Success()
+	assert.NoError(t, breaker.Allow())
+	assert.NoError(t, breaker.Healthy())
+	assert.Zero(t, breaker.backoff)
+}
+
+func TestCircuitBreaker_MaxOpenDuration(t *testing.T) {
+	breaker := NewCircuitBreaker("netbox.example.com", 1, time.Minute)
+	for range 10 {
+		breaker.Failure(errors.New("connection refused"))
+	}
+	assert.Equal(t, maxCircuitOpenDuration, breaker.backoff)
+}
+
+func TestCircuitBreakerRoundTripper(t *testing.T) {
+	var statusCode atomic.Int32
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(int(statusCode.Load()))
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker("netbox.example.com", 2, time.Hour)
+	httpClient := &http.Client{Transport: &CircuitBreakerRoundTripper{Breaker: breaker, Transport: http.DefaultTransport}}
+
+	// client errors show that NetBox is available
+	statusCode.Store(http.StatusNotFound)
+	for range 3 {
+		resp, err := httpClient.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.NoError(t, breaker.Healthy())
+
+	statusCode.Store(http.StatusBadGateway)
+	for range 2 {
+		resp, err := httpClient.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.ErrorIs(t, breaker.Healthy(), ErrNetboxUnavailable)
+
+	// while the circuit is open no request reaches NetBox
+	_, err := httpClient.Get(server.URL)
+	assert.ErrorIs(t, err, ErrNetboxUnavailable)
+	assert.Equal(t, int32(5), requests.Load())
+}
+
+func TestCircuitBreakerRoundTripper_CanceledRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker("netbox.example.com", 1, time.Hour)
+	httpClient := &http.Client{Transport: &CircuitBreakerRoundTripper{Breaker: breaker, Transport: http.DefaultTransport}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = httpClient.Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, breaker.Healthy())
+}
+
+func TestNetboxCompositeClient_ProbeHealth(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		assert.Equal(t, "/api/status/", r.URL.Path)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(server.Listener.Addr().String(), 1, time.Hour)
+	netboxClient, err := NewNetboxCompositeClientForConnection(&ConnectionConfig{
+		Host:    server.Listener.Addr().String(),
+		Tokens:  NewTokenProvider("0123456789abcdef"),
+		Breaker: breaker,
+	})
+	require.NoError(t, err)
+
+	netboxClient.ProbeHealth(context.Background())
+	assert.ErrorIs(t, netboxClient.Available(), ErrNetboxUnavailable)
+	assert.ErrorIs(t, netboxClient.Healthy(), ErrNetboxUnavailable)
+	assert.Greater(t, netboxClient.RetryAfter(), 59*time.Minute)
+
+	// the probe is skipped while the circuit is open
+	netboxClient.ProbeHealth(context.Background())
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestHealthMonitor_ReadyzCheck(t *testing.T) {
+	breaker := NewCircuitBreaker("netbox.example.com", 1, time.Hour)
+	netboxClient := NewNetboxCompositeClient(&NetboxClientV4{})
+	netboxClient.breaker = breaker
+	monitor := &HealthMonitor{Clients: NewClientRegistry(nil, nil, "", netboxClient), Interval: time.Second}
+
+	assert.NoError(t, monitor.ReadyzCheck(nil))
+	breaker.Failure(errors.New("connection refused"))
+	assert.ErrorIs(t, monitor.ReadyzCheck(nil), ErrNetboxUnavailable)
+
+	// a client without a circuit breaker is always healthy
+	monitor = &HealthMonitor{Clients: NewClientRegistry(nil, nil, "", NewNetboxCompositeClient(&NetboxClientV4{}))}
+	assert.NoError(t, monitor.ReadyzCheck(nil))
+}
@@ -17,15 +17,17 @@ limitations under the License.
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
-	"github.com/go-openapi/runtime"
-	"github.com/go-openapi/strfmt"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 )
 
 type CustomFieldEntry struct {
@@ -38,36 +40,68 @@ type QueryFilter struct {
 	customFields []CustomFieldEntry
 }
 
-func newQueryFilterOperation(netBoxFields map[string]string, customFields []CustomFieldEntry) func(co *runtime.ClientOperation) {
-	return func(co *runtime.ClientOperation) {
-		co.Params = &QueryFilter{
-			netBoxFields: netBoxFields,
-			customFields: customFields,
-		}
-	}
+type queryFilterContextKey struct{}
+
+// withQueryFilter returns a context whose requests additionally filter by the NetBox fields and
+// custom fields. The v4 client has no parameters for custom fields, the filters of the context
+// are added to the query of the requests by the QueryFilterRoundTripper.
+func withQueryFilter(ctx context.Context, netBoxFields map[string]string, customFields []CustomFieldEntry) context.Context {
+	filters := slices.Clone(queryFilters(ctx))
+	filters = append(filters, &QueryFilter{
+		netBoxFields: netBoxFields,
+		customFields: customFields,
+	})
+	return context.WithValue(ctx, queryFilterContextKey{}, filters)
 }
 
-func (o *QueryFilter) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+// withQueryParam returns a context whose requests additionally have the query parameter,
+// e.g. the limit of the available ips, which the v4 client does not support
+func withQueryParam(ctx context.Context, key string, value string) context.Context {
+	return withQueryFilter(ctx, map[string]string{key: value}, nil)
+}
+
+func queryFilters(ctx context.Context) []*QueryFilter {
+	filters, _ := ctx.Value(queryFilterContextKey{}).([]*QueryFilter)
+	return filters
+}
+
+func (o *QueryFilter) writeToQuery(query url.Values) {
 	// We currently write the request by ANDing all the custom fields
 
 	// The idea is to provide filtering of tenant and site here
 	// Doing string filtering on tenant and site doesn't really work though, so we will use tenant_id and site_id instead
 	// The query format is like the following: http://localhost:8080/ipam/prefixes/?q=&site_id=2
 	for key, value := range o.netBoxFields {
-		if err := r.SetQueryParam(url.QueryEscape(key), url.QueryEscape(value)); err != nil {
-			return err
-		}
+		query.Set(key, value)
 	}
 
 	// The custom field query format is like the following: http://localhost:8080/ipam/prefixes/?q=&cf_poolName=Pool+2&cf_environment=Production
 	// The GitHub issue related to supporting multiple custom field in a query: https://github.com/netbox-community/netbox/issues/7163
 	for _, entry := range o.customFields {
-		if err := r.SetQueryParam(fmt.Sprintf("cf_%s", url.QueryEscape(entry.key)), entry.value); err != nil {
-			return err
-		}
+		query.Set(fmt.Sprintf("cf_%s", entry.key), entry.value)
+	}
+}
+
+// QueryFilterRoundTripper adds the query filters of the context of a request to its query
+type QueryFilterRoundTripper struct {
+	Transport http.RoundTripper
+}
+
+func (qrt *QueryFilterRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	filters := queryFilters(req.Context())
+	if len(filters) == 0 {
+		return qrt.Transport.RoundTrip(req)
 	}
 
-	return nil
+	// a RoundTripper must not modify the request, the query is set on a clone
+	req = req.Clone(req.Context())
+	query := req.URL.Query()
+	for _, filter := range filters {
+		filter.writeToQuery(query)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	return qrt.Transport.RoundTrip(req)
 }
 
 func TruncateDescription(description string) string {
@@ -90,6 +124,14 @@ func TruncateDescription(description string) string {
 	return description + warningComment
 }
 
+// metadataStatus returns the status of the metadata, the default status if it has none
+func metadataStatus(metadata *models.NetboxMetadata) string {
+	if metadata == nil || metadata.Status == "" {
+		return defaultStatus
+	}
+	return metadata.Status
+}
+
 func SetIpAddressMask(ip string, ipFamily int64) (string, error) {
 	var ipAddress net.IP
 	var err error
@@ -18,70 +18,104 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
+	v4client "github.com/netbox-community/go-netbox/v4"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 	"github.com/netbox-community/netbox-operator/pkg/config"
 
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
-	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
 )
 
-func (c *NetboxCompositeClient) ReserveOrUpdateIpAddress(ctx context.Context, ipAddress *models.IPAddress, ipAddressV1 *netboxv1.IpAddress) (resp *netboxModels.IPAddress, isUpToDate bool, err error) {
-	responseIpAddress, err := c.getIpAddress(ipAddress)
+func (c *NetboxCompositeClient) ReserveOrUpdateIpAddress(ctx context.Context, ipAddress *models.IPAddress, ipAddressV1 *netboxv1.IpAddress) (resp *v4client.IPAddress, isUpToDate bool, err error) {
+	responseIpAddress, err := c.getIpAddress(ctx, ipAddress)
 	if err != nil {
 		return nil, false, err
 	}
 
-	desiredIPAddress := &netboxModels.WritableIPAddress{
-		Address:     &ipAddress.IpAddress,
-		Description: TruncateDescription(""),
-		Status:      "active",
+	desiredIPAddress := v4client.NewWritableIPAddressRequest(ipAddress.IpAddress)
+	desiredIPAddress.SetDescription(TruncateDescription(""))
+	if ipAddress.DnsName != "" || ipAddress.ClearDnsName {
+		desiredIPAddress.SetDnsName(ipAddress.DnsName)
+	}
+	status, err := v4client.NewPatchedWritableIPAddressRequestStatusFromValue(metadataStatus(ipAddress.Metadata))
+	if err != nil {
+		return nil, false, err
+	}
+	desiredIPAddress.SetStatus(*status)
+	if ipAddress.Metadata != nil && ipAddress.Metadata.Role != "" {
+		role, err := v4client.NewPatchedWritableIPAddressRequestRoleFromValue(ipAddress.Metadata.Role)
+		if err != nil {
+			return nil, false, err
+		}
+		desiredIPAddress.SetRole(*role)
 	}
 
 	if ipAddress.Metadata != nil {
-		desiredIPAddress.CustomFields = ipAddress.Metadata.Custom
-		desiredIPAddress.Comments = ipAddress.Metadata.Comments + warningComment
-		desiredIPAddress.Description = TruncateDescription(ipAddress.Metadata.Description)
+		customFields, err := c.customFieldsRequest(ctx, ipAddress.Metadata.Custom)
+		if err != nil {
+			return nil, false, err
+		}
+		desiredIPAddress.SetCustomFields(customFields)
+		desiredIPAddress.SetComments(ipAddress.Metadata.Comments + warningComment)
+		desiredIPAddress.SetDescription(TruncateDescription(ipAddress.Metadata.Description))
 	}
 
 	if ipAddress.Metadata != nil && ipAddress.Metadata.Tenant != "" {
-		tenantDetails, err := c.getTenantDetails(ipAddress.Metadata.Tenant)
+		tenantDetails, err := c.getTenantDetails(ctx, ipAddress.Metadata.Tenant)
 		if err != nil {
 			return nil, false, err
 		}
-		desiredIPAddress.Tenant = &tenantDetails.Id
+		tenantId := int32(tenantDetails.Id)
+		desiredIPAddress.SetTenant(v4client.Int32AsASNRangeRequestTenant(&tenantId))
+	}
+
+	if ipAddress.Metadata != nil && ipAddress.Metadata.Vrf != "" {
+		vrfId, err := c.getVrfId(ctx, ipAddress.Metadata.Vrf)
+		if err != nil {
+			return nil, false, err
+		}
+		desiredIPAddress.SetVrf(v4client.Int32AsIPAddressRequestVrf(vrfId))
+	}
+
+	if hasTags(ipAddress.Metadata) {
+		tags, err := c.tagsRequest(ctx, ipAddress.Metadata.Tags)
+		if err != nil {
+			return nil, false, err
+		}
+		desiredIPAddress.SetTags(tags)
 	}
 
 	// create ip address since it doesn't exist
-	if len(responseIpAddress.Payload.Results) == 0 {
-		resp, err := c.createIpAddress(desiredIPAddress)
+	if len(responseIpAddress.Results) == 0 {
+		resp, err := c.createIpAddress(ctx, desiredIPAddress)
 		return resp, false, err
 	}
 
-	ipToUpdate := responseIpAddress.Payload.Results[0]
+	ipToUpdate := &responseIpAddress.Results[0]
+	if desiredIPAddress.HasTags() {
+		desiredIPAddress.SetTags(withUnmanagedTags(desiredIPAddress.Tags, ipToUpdate.Tags, ipAddress.Metadata.ManagedTags))
+	}
 
-	if ipToUpdate.LastUpdated.IsZero() {
+	if !ipToUpdate.LastUpdated.IsSet() || ipToUpdate.LastUpdated.Get() == nil {
 		return nil, false, fmt.Errorf("last updated field is not set in Netbox for ip address %s", ipAddress.IpAddress)
 	}
-
-	netboxLastUpdated := time.Time(*ipToUpdate.LastUpdated)
+	netboxLastUpdated := *ipToUpdate.LastUpdated.Get()
 
 	// if the desired ip address has a restoration hash
 	// check that the ip address to update has the same restoration hash
 	restorationHashKey := config.GetOperatorConfig().NetboxRestorationHashFieldName
 	if ipAddress.Metadata != nil {
 		if restorationHash, ok := ipAddress.Metadata.Custom[restorationHashKey]; ok {
-			if ipToUpdate.CustomFields != nil && ipToUpdate.CustomFields.(map[string]interface{})[restorationHashKey] == restorationHash {
+			if ipToUpdate.CustomFields != nil && ipToUpdate.CustomFields[restorationHashKey] == restorationHash {
 				if IsUpToDate(ctx, netboxLastUpdated, ipAddressV1.Status.LastUpdated, ipAddressV1.Status.Conditions, ipAddressV1.Generation) {
 					return ipToUpdate, true, nil
 				}
+
 				//update ip address since it does exist and the restoration hash matches
-				resp, err := c.updateIpAddress(ipToUpdate.ID, desiredIPAddress)
+				resp, err := c.updateIpAddress(ctx, ipToUpdate.Id, desiredIPAddress)
 				if err != nil {
 					return nil, false, err
 				}
@@ -95,66 +129,83 @@ func (c *NetboxCompositeClient) ReserveOrUpdateIpAddress(ctx context.Context, ip
 		return ipToUpdate, true, nil
 	}
 
-	ipAddressId := responseIpAddress.Payload.Results[0].ID
-	resp, err = c.updateIpAddress(ipAddressId, desiredIPAddress)
+	ipAddressId := responseIpAddress.Results[0].Id
+	resp, err = c.updateIpAddress(ctx, ipAddressId, desiredIPAddress)
 	if err != nil {
 		return nil, false, err
 	}
 	return resp, false, nil
 }
 
-func (c *NetboxCompositeClient) getIpAddress(ipAddress *models.IPAddress) (*ipam.IpamIPAddressesListOK, error) {
-
-	requestIpAddress := ipam.
-		NewIpamIPAddressesListParams().
-		WithAddress(&ipAddress.IpAddress)
-	responseIpAddress, err := c.clientV3.Ipam.IpamIPAddressesList(requestIpAddress, nil)
+func (c *NetboxCompositeClient) getIpAddress(ctx context.Context, ipAddress *models.IPAddress) (resp *v4client.PaginatedIPAddressList, err error) {
+	vrfId, err := c.getVrfId(ctx, metadataVrf(ipAddress.Metadata))
 	if err != nil {
-		return nil, utils.NetboxError("failed to fetch IpAddress details", err)
+		return nil, err
+	}
+
+	req := c.clientV4.IpamAPI.IpamIpAddressesList(ctx).
+		Address([]string{ipAddress.IpAddress})
+	if vrfId != nil {
+		req = req.VrfId([]*int32{vrfId})
+	}
+	resp, httpResp, execErr := req.Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "fetch IpAddress details")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
 	}
 
-	return responseIpAddress, err
+	return resp, nil
 }
 
-func (c *NetboxCompositeClient) createIpAddress(ipAddress *netboxModels.WritableIPAddress) (*netboxModels.IPAddress, error) {
-	requestCreateIp := ipam.
-		NewIpamIPAddressesCreateParams().
-		WithDefaults().
-		WithData(ipAddress)
-	responseCreateIp, err := c.clientV3.Ipam.
-		IpamIPAddressesCreate(requestCreateIp, nil)
-	if err != nil {
-		return nil, utils.NetboxError("failed to reserve IP Address", err)
+func (c *NetboxCompositeClient) createIpAddress(ctx context.Context, ipAddress *v4client.WritableIPAddressRequest) (resp *v4client.IPAddress, err error) {
+	req := c.clientV4.IpamAPI.IpamIpAddressesCreate(ctx).WritableIPAddressRequest(*ipAddress)
+	resp, httpResp, execErr := req.Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusCreated, "reserve IP Address")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
 	}
-	return responseCreateIp.Payload, nil
+
+	return resp, nil
 }
 
-func (c *NetboxCompositeClient) updateIpAddress(ipAddressId int64, ipAddress *netboxModels.WritableIPAddress) (*netboxModels.IPAddress, error) {
-	requestUpdateIp := ipam.
-		NewIpamIPAddressesUpdateParams().
-		WithDefaults().
-		WithData(ipAddress).
-		WithID(ipAddressId)
-	responseUpdateIp, err := c.clientV3.Ipam.IpamIPAddressesUpdate(requestUpdateIp, nil)
-	if err != nil {
-		return nil, utils.NetboxError("failed to update IP Address", err)
+func (c *NetboxCompositeClient) updateIpAddress(ctx context.Context, ipAddressId int32, ipAddress *v4client.WritableIPAddressRequest) (resp *v4client.IPAddress, err error) {
+	req := c.clientV4.IpamAPI.IpamIpAddressesUpdate(ctx, ipAddressId).WritableIPAddressRequest(*ipAddress)
+	resp, httpResp, execErr := req.Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "update IP Address")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
 	}
-	return responseUpdateIp.Payload, nil
+
+	return resp, nil
 }
 
-func (c *NetboxCompositeClient) DeleteIpAddress(ipAddressId int64) error {
-	requestDeleteIp := ipam.NewIpamIPAddressesDeleteParams().WithID(ipAddressId)
-	_, err := c.clientV3.Ipam.IpamIPAddressesDelete(requestDeleteIp, nil)
-	if err != nil {
-		switch typedErr := err.(type) {
-		case *ipam.IpamIPAddressesDeleteDefault:
-			if typedErr.IsCode(http.StatusNotFound) {
-				return nil
-			}
-			return utils.NetboxError("Failed to delete ip address from Netbox", err)
-		default:
-			return utils.NetboxError("Failed to delete ip address from Netbox", err)
-		}
+func (c *NetboxCompositeClient) DeleteIpAddress(ctx context.Context, ipAddressId int32) (err error) {
+	req := c.clientV4.IpamAPI.IpamIpAddressesDestroy(ctx, ipAddressId)
+	httpResp, execErr := req.Execute()
+
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusNoContent, "delete ip address from netbox")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
 	}
+	if handleErr != nil {
+		return handleErr
+	}
+
 	return nil
 }
@@ -20,11 +20,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/netip"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
+	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/netbox-community/netbox-operator/pkg/config"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
-	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 )
 
 type IPFamily int64
@@ -40,41 +43,296 @@ const (
 	ipMaskIPv6 = "/128"
 )
 
-func (c *NetboxCompositeClient) RestoreExistingIpByHash(hash string) (*models.IPAddress, error) {
-	customIpSearch := newQueryFilterOperation(nil, []CustomFieldEntry{
+// RestoreExistingIpByHash returns the ip address with the restoration hash, only the ip addresses in the
+// VRF are searched if a VRF is set. The VRF of the ip address is returned in its metadata.
+func (c *NetboxCompositeClient) RestoreExistingIpByHash(ctx context.Context, hash string, vrf string) (*models.IPAddress, error) {
+	vrfCtx, err := c.withVrfQueryFilter(ctx, vrf)
+	if err != nil {
+		return nil, err
+	}
+	customIpSearch := withQueryFilter(vrfCtx, nil, []CustomFieldEntry{
 		{
 			key:   config.GetOperatorConfig().NetboxRestorationHashFieldName,
 			value: hash,
 		},
 	})
-	list, err := c.clientV3.Ipam.IpamIPAddressesList(ipam.NewIpamIPAddressesListParams(), nil, customIpSearch)
+	results, err := listAllPages(func(limit int32, offset int32) (results []v4client.IPAddress, next *string, err error) {
+		list, httpResp, execErr := c.clientV4.IpamAPI.IpamIpAddressesList(customIpSearch).Limit(limit).Offset(offset).Execute()
+		closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list ip addresses")
+		if closeFunc != nil {
+			defer func() { err = errors.Join(err, closeFunc()) }()
+		}
+		if handleErr != nil {
+			return nil, nil, handleErr
+		}
+		return list.Results, list.Next.Get(), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: find a better way?
-	if list.Payload.Count != nil && *list.Payload.Count == 0 {
+	if len(results) == 0 {
 		return nil, nil
 	}
 
 	// We should not have more than 1 result...
-	if len(list.Payload.Results) != 1 {
-		return nil, fmt.Errorf("incorrect number of restoration results, number of results: %v", len(list.Payload.Results))
+	if len(results) != 1 {
+		return nil, fmt.Errorf("incorrect number of restoration results, number of results: %v", len(results))
 	}
-	res := list.Payload.Results[0]
-	if res.Address == nil {
-		return nil, errors.New("ipaddress in netbox is nil")
+	res := results[0]
+	if res.Address == "" {
+		return nil, errors.New("ipaddress in netbox is empty")
 	}
 
 	return &models.IPAddress{
-		IpAddress: *res.Address,
+		IpAddress: res.Address,
+		Metadata:  vrfMetadata(res.Vrf),
 	}, nil
 }
 
-// GetAvailableIpAddressByClaim searches an available IpAddress in Netbox matching IpAddressClaim requirements
+// GetAvailableIpAddressByClaim searches an available IpAddress in Netbox matching IpAddressClaim requirements,
+// the VRF of the parent prefix or parent ip range is returned in the metadata of the IpAddress
 func (c *NetboxCompositeClient) GetAvailableIpAddressByClaim(ctx context.Context, ipAddressClaim *models.IPAddressClaim) (*models.IPAddress, error) {
+	availableIPs, parentMetadata, err := c.listAvailableIpsByClaim(ctx, ipAddressClaim)
+	if err != nil {
+		return nil, err
+	}
+	if len(availableIPs) == 0 {
+		return nil, errParentExhausted(ipAddressClaim)
+	}
+
+	ipAddress, err := SetIpAddressMask(availableIPs[0].Address, int64(availableIPs[0].Family))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.IPAddress{
+		IpAddress: ipAddress,
+		Metadata:  parentMetadata,
+	}, nil
+}
+
+// GetPreferredIpAddressByClaim returns the preferredAddress with the mask of its family if it is available
+// in the parent prefix or parent ip range of the IpAddressClaim, and ErrPreferredAddressNotAvailable otherwise
+func (c *NetboxCompositeClient) GetPreferredIpAddressByClaim(ctx context.Context, ipAddressClaim *models.IPAddressClaim, preferredAddress string) (*models.IPAddress, error) {
+	preferred, err := netip.ParseAddr(preferredAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid preferred ip address %s: %w", preferredAddress, err)
+	}
+
+	availableIPs, parentMetadata, err := c.listAvailableIpsByClaim(ctx, ipAddressClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, availableIP := range availableIPs {
+		available, err := netip.ParsePrefix(availableIP.Address)
+		if err != nil {
+			return nil, err
+		}
+		if available.Addr() == preferred {
+			ipAddress, err := SetIpAddressMask(availableIP.Address, int64(availableIP.Family))
+			if err != nil {
+				return nil, err
+			}
+			return &models.IPAddress{
+				IpAddress: ipAddress,
+				Metadata:  parentMetadata,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s in %s", ErrPreferredAddressNotAvailable, preferredAddress, describeParent(ipAddressClaim))
+}
+
+// GetAvailableIpAddressesByClaim returns count distinct available IpAddresses in the parent prefix or parent ip range
+// of the IpAddressClaim, skipping the excludedIpAddresses which are already assigned but not yet reserved in NetBox
+func (c *NetboxCompositeClient) GetAvailableIpAddressesByClaim(ctx context.Context, ipAddressClaim *models.IPAddressClaim, count int, excludedIpAddresses []string) ([]*models.IPAddress, error) {
+	excluded := make(map[netip.Addr]struct{}, len(excludedIpAddresses))
+	for _, excludedIpAddress := range excludedIpAddresses {
+		prefix, err := netip.ParsePrefix(excludedIpAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid excluded ip address %s: %w", excludedIpAddress, err)
+		}
+		excluded[prefix.Addr()] = struct{}{}
+	}
+
+	availableIPs, parentMetadata, err := c.listAvailableIpsByClaim(ctx, ipAddressClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	ipAddresses := make([]*models.IPAddress, 0, count)
+	for _, availableIP := range availableIPs {
+		if len(ipAddresses) == count {
+			break
+		}
+		available, err := netip.ParsePrefix(availableIP.Address)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := excluded[available.Addr()]; ok {
+			continue
+		}
+		ipAddress, err := SetIpAddressMask(availableIP.Address, int64(availableIP.Family))
+		if err != nil {
+			return nil, err
+		}
+		ipAddresses = append(ipAddresses, &models.IPAddress{
+			IpAddress: ipAddress,
+			Metadata:  parentMetadata,
+		})
+	}
+
+	if len(ipAddresses) < count {
+		return nil, fmt.Errorf("%w, only %d of %d ip addresses are available", errParentExhausted(ipAddressClaim), len(ipAddresses), count)
+	}
+
+	return ipAddresses, nil
+}
+
+// listAvailableIpsByClaim returns the available ip addresses in the parent ip range of the IpAddressClaim
+// if it is set, and in its parent prefix otherwise. The available ips api of NetBox is not paginated
+// with next links, it returns up to NETBOX_PAGE_LIMIT addresses (MAX_PAGE_SIZE of NetBox by default).
+// The returned metadata passes the VRF of the parent on to the claimed ip addresses.
+func (c *NetboxCompositeClient) listAvailableIpsByClaim(ctx context.Context, ipAddressClaim *models.IPAddressClaim) ([]v4client.AvailableIP, *models.NetboxMetadata, error) {
+	if ipAddressClaim.ParentIpRange != nil {
+		parentIpRange, err := c.getIpAddressClaimParentIpRange(ctx, ipAddressClaim)
+		if err != nil {
+			return nil, nil, err
+		}
+		availableIPs, err := c.listAvailableIpsOfIpRange(ctx, parentIpRange.Id)
+		return availableIPs, vrfMetadata(parentIpRange.Vrf), err
+	}
+
+	parentPrefix, err := c.getIpAddressClaimParentPrefix(ctx, ipAddressClaim)
+	if err != nil {
+		return nil, nil, err
+	}
+	availableIPs, err := c.listAvailableIpsOfPrefix(ctx, parentPrefix.Id)
+	return availableIPs, vrfMetadata(parentPrefix.Vrf), err
+}
+
+// listAvailableIpsOfIpRange returns up to NETBOX_PAGE_LIMIT available ip addresses of the ip range
+func (c *NetboxCompositeClient) listAvailableIpsOfIpRange(ctx context.Context, ipRangeId int32) (availableIPs []v4client.AvailableIP, err error) {
+	availableIPs, httpResp, execErr := c.clientV4.IpamAPI.IpamIpRangesAvailableIpsList(withPageLimit(ctx), ipRangeId).Execute()
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list available ips of ip range")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+	return availableIPs, nil
+}
+
+// listAvailableIpsOfPrefix returns up to NETBOX_PAGE_LIMIT available ip addresses of the prefix
+func (c *NetboxCompositeClient) listAvailableIpsOfPrefix(ctx context.Context, prefixId int32) (availableIPs []v4client.AvailableIP, err error) {
+	availableIPs, httpResp, execErr := c.clientV4.IpamAPI.IpamPrefixesAvailableIpsList(withPageLimit(ctx), prefixId).Execute()
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list available ips of prefix")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+	return availableIPs, nil
+}
+
+// getIpAddressClaimParentIpRange returns the parent ip range of the IpAddressClaim in NetBox
+func (c *NetboxCompositeClient) getIpAddressClaimParentIpRange(ctx context.Context, ipAddressClaim *models.IPAddressClaim) (*v4client.IPRange, error) {
+	// fail early if tenant requested in the spec does not exists
+	_, err := c.getTenantDetails(ctx, ipAddressClaim.Metadata.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	responseParentIpRange, err := c.getIpRange(
+		ctx,
+		&models.IpRange{
+			StartAddress: ipAddressClaim.ParentIpRange.StartAddress,
+			EndAddress:   ipAddressClaim.ParentIpRange.EndAddress,
+			Metadata:     ipAddressClaim.Metadata,
+		})
+	if err != nil {
+		return nil, err
+	}
+	if len(responseParentIpRange.Results) == 0 {
+		return nil, ErrParentIpRangeNotFound
+	}
+
+	return &responseParentIpRange.Results[0], nil
+}
+
+// GetParentIpRangePrefix returns the most specific prefix in NetBox which contains the parent ip range
+// of the IpAddressClaim and is in the same VRF. The IP Addresses of the ip range can also be claimed
+// from this prefix, so IpAddressClaims with a parent ip range lock the lease of this prefix.
+// An empty prefix is returned if the ip range is not within a prefix.
+func (c *NetboxCompositeClient) GetParentIpRangePrefix(ctx context.Context, ipAddressClaim *models.IPAddressClaim) (string, error) {
+	parentIpRange, err := c.getIpAddressClaimParentIpRange(ctx, ipAddressClaim)
+	if err != nil {
+		return "", err
+	}
+	startAddress, err := netip.ParsePrefix(parentIpRange.StartAddress)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse start address of ip range: %w", err)
+	}
+	endAddress, err := netip.ParsePrefix(parentIpRange.EndAddress)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse end address of ip range: %w", err)
+	}
+
+	prefixes, err := listAllPages(func(limit int32, offset int32) (results []v4client.Prefix, next *string, err error) {
+		list, httpResp, execErr := c.clientV4.IpamAPI.IpamPrefixesList(ctx).Contains(startAddress.Addr().String()).Limit(limit).Offset(offset).Execute()
+		closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list prefixes containing ip range")
+		if closeFunc != nil {
+			defer func() { err = errors.Join(err, closeFunc()) }()
+		}
+		if handleErr != nil {
+			return nil, nil, handleErr
+		}
+		return list.Results, list.Next.Get(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	parentPrefix, bits := "", -1
+	for _, prefix := range prefixes {
+		if vrfName(prefix.Vrf) != vrfName(parentIpRange.Vrf) {
+			continue
+		}
+		p, err := netip.ParsePrefix(prefix.Prefix)
+		if err != nil || !p.Contains(endAddress.Addr()) {
+			continue
+		}
+		if p.Bits() > bits {
+			parentPrefix, bits = prefix.Prefix, p.Bits()
+		}
+	}
+	return parentPrefix, nil
+}
+
+// errParentExhausted returns the error for an exhausted parent prefix or parent ip range of the IpAddressClaim
+func errParentExhausted(ipAddressClaim *models.IPAddressClaim) error {
+	if ipAddressClaim.ParentIpRange != nil {
+		return ErrParentIpRangeExhausted
+	}
+	return ErrParentPrefixExhausted
+}
+
+// describeParent returns the parent prefix or parent ip range of the IpAddressClaim for error messages
+func describeParent(ipAddressClaim *models.IPAddressClaim) string {
+	if ipAddressClaim.ParentIpRange != nil {
+		return fmt.Sprintf("parent ip range %s-%s", ipAddressClaim.ParentIpRange.StartAddress, ipAddressClaim.ParentIpRange.EndAddress)
+	}
+	return "parent prefix " + ipAddressClaim.ParentPrefix
+}
+
+// getIpAddressClaimParentPrefix returns the parent prefix of the IpAddressClaim in NetBox
+func (c *NetboxCompositeClient) getIpAddressClaimParentPrefix(ctx context.Context, ipAddressClaim *models.IPAddressClaim) (*v4client.Prefix, error) {
 	// fail early if tenant requested in the spec does not exists
-	_, err := c.getTenantDetails(ipAddressClaim.Metadata.Tenant)
+	_, err := c.getTenantDetails(ctx, ipAddressClaim.Metadata.Tenant)
 	if err != nil {
 		return nil, err
 	}
@@ -89,33 +347,53 @@ func (c *NetboxCompositeClient) GetAvailableIpAddressByClaim(ctx context.Context
 		return nil, err
 	}
 	if len(responseParentPrefix.Results) == 0 {
-		return nil, utils.NetboxNotFoundError("parent prefix")
+		return nil, parentPrefixNotFoundError()
 	}
 
-	parentPrefixId := responseParentPrefix.Results[0].Id
-	responseAvailableIPs, err := c.GetAvailableIpAddressesByParentPrefix(parentPrefixId)
+	return &responseParentPrefix.Results[0], nil
+}
+
+// GetAvailableIpAddressParentPrefixesBySelector returns all prefixes matching the parentPrefixSelector
+// from which an ip address can be allocated, in the order returned by NetBox
+func (c *NetboxCompositeClient) GetAvailableIpAddressParentPrefixesBySelector(ctx context.Context, ipAddressClaimSpec *netboxv1.IpAddressClaimSpec) ([]*models.Prefix, error) {
+	parentPrefixes, err := c.listPrefixesByParentPrefixSelector(ctx, ipAddressClaimSpec.ParentPrefixSelector, ipAddressClaimSpec.Vrf)
 	if err != nil {
 		return nil, err
 	}
 
-	ipAddress, err := SetIpAddressMask(responseAvailableIPs.Payload[0].Address, responseAvailableIPs.Payload[0].Family)
-	if err != nil {
-		return nil, err
+	prefixes := make([]*models.Prefix, 0)
+	for _, prefix := range parentPrefixes {
+		if prefix.Prefix != "" {
+			// if we can allocate an ip address from it, we can take it as a parent prefix
+			_, errCandidate := c.GetAvailableIpAddressByClaim(ctx, &models.IPAddressClaim{
+				ParentPrefix: prefix.Prefix,
+				Metadata: &models.NetboxMetadata{
+					Tenant: ipAddressClaimSpec.Tenant,
+					Vrf:    ipAddressClaimSpec.Vrf,
+				},
+			})
+			if errCandidate != nil {
+				err = errors.Join(err, fmt.Errorf("prefix %s is not a valid parent prefix candidate, %w", prefix.Prefix, errCandidate))
+			} else {
+				prefixes = append(prefixes, &models.Prefix{Prefix: prefix.Prefix})
+			}
+		}
 	}
 
-	return &models.IPAddress{
-		IpAddress: ipAddress,
-	}, nil
+	if len(prefixes) == 0 && err != nil {
+		return prefixes, err
+	}
+
+	return prefixes, nil
 }
 
-func (c *NetboxCompositeClient) GetAvailableIpAddressesByParentPrefix(parentPrefixId int32) (*ipam.IpamPrefixesAvailableIpsListOK, error) {
-	requestAvailableIPs := ipam.NewIpamPrefixesAvailableIpsListParams().WithID(int64(parentPrefixId))
-	responseAvailableIPs, err := c.clientV3.Ipam.IpamPrefixesAvailableIpsList(requestAvailableIPs, nil)
+func (c *NetboxCompositeClient) GetAvailableIpAddressesByParentPrefix(ctx context.Context, parentPrefixId int32) ([]v4client.AvailableIP, error) {
+	availableIPs, err := c.listAvailableIpsOfPrefix(ctx, parentPrefixId)
 	if err != nil {
 		return nil, err
 	}
-	if len(responseAvailableIPs.Payload) == 0 {
+	if len(availableIPs) == 0 {
 		return nil, ErrParentPrefixExhausted
 	}
-	return responseAvailableIPs, nil
+	return availableIPs, nil
 }
@@ -22,21 +22,46 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
 	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/config"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 )
 
+// expectAvailableIpsOfPrefix sets up the list of the available ip addresses of the prefix
+func expectAvailableIpsOfPrefix(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, prefixId int32, availableIps []v4client.AvailableIP) {
+	mockAvailableIpsList := mock_interfaces.NewMockIpamPrefixesAvailableIpsListRequest(ctrl)
+	mockIpam.EXPECT().IpamPrefixesAvailableIpsList(gomock.Any(), prefixId).Return(mockAvailableIpsList)
+	mockAvailableIpsList.EXPECT().Execute().
+		Return(availableIps, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+}
+
+// expectPrefixList sets up the list of the prefix by its prefix
+func expectPrefixList(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, prefix string, results []v4client.Prefix) {
+	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+	mockIpam.EXPECT().IpamPrefixesList(gomock.Any()).Return(mockListRequest)
+	mockListRequest.EXPECT().Prefix([]string{prefix}).Return(mockListRequest)
+	mockListRequest.EXPECT().Execute().
+		Return(&v4client.PaginatedPrefixList{Count: int32(len(results)), Results: results}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+}
+
+// expectPrefixListBySelector sets up the single page list of the prefixes matching a parent prefix selector
+func expectPrefixListBySelector(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, results []v4client.Prefix) {
+	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+	mockIpam.EXPECT().IpamPrefixesList(gomock.Any()).Return(mockListRequest)
+	mockListRequest.EXPECT().Limit(pageLimit()).Return(mockListRequest)
+	mockListRequest.EXPECT().Offset(int32(0)).Return(mockListRequest)
+	mockListRequest.EXPECT().Execute().
+		Return(&v4client.PaginatedPrefixList{Count: int32(len(results)), Results: results}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+}
+
 func TestIPAddressClaim(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockIPAddress := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// test data for IPv4 ip address claim
 	parentPrefixIdV4 := int32(3)
@@ -46,15 +71,15 @@ func TestIPAddressClaim(t *testing.T) {
 	singleIpAddressV4_2 := "10.112.140.2/32"
 
 	// example of available IPv4 IP addresses
-	availAddressesIPv4 := func() []*netboxModels.AvailableIP {
-		return []*netboxModels.AvailableIP{
+	availAddressesIPv4 := func() []v4client.AvailableIP {
+		return []v4client.AvailableIP{
 			{
 				Address: ipAddressV4_1,
-				Family:  int64(IPv4Family),
+				Family:  int32(IPv4Family),
 			},
 			{
 				Address: ipAddressV4_2,
-				Family:  int64(IPv4Family),
+				Family:  int32(IPv4Family),
 			},
 		}
 	}
@@ -66,93 +91,48 @@ func TestIPAddressClaim(t *testing.T) {
 	singleIpAddressV6 := "2001:db8:85a3:8d3::2/128"
 
 	// example of tenant
-	tenantId := int64(2)
 	tenantName := "Tenant1"
-	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenants := []v4client.Tenant{{Id: 2, Name: tenantName, Slug: "tenant1"}}
 
 	t.Run("Fetch available IP address's claim by parent prefix.", func(t *testing.T) {
-
-		// ip address mock input
-		input := ipam.NewIpamPrefixesAvailableIpsListParams().WithID(int64(parentPrefixIdV4))
-		// ip address mock output
-		output := &ipam.IpamPrefixesAvailableIpsListOK{
-			Payload: availAddressesIPv4(),
-		}
-
-		mockIPAddress.EXPECT().IpamPrefixesAvailableIpsList(input, nil).Return(output, nil)
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixIdV4, availAddressesIPv4())
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPAddress,
+		clientV4 := &NetboxClientV4{
+			IpamAPI: mockIpamAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
+			clientV4: clientV4,
 		}
 
-		actual, err := compositeClient.GetAvailableIpAddressesByParentPrefix(parentPrefixIdV4)
+		actual, err := compositeClient.GetAvailableIpAddressesByParentPrefix(context.TODO(), parentPrefixIdV4)
 
 		// assert error return
 		AssertNil(t, err)
-		assert.Len(t, actual.Payload, 2)
-		assert.Equal(t, ipAddressV4_1, actual.Payload[0].Address)
-		assert.Equal(t, ipAddressV4_2, actual.Payload[1].Address)
+		assert.Len(t, actual, 2)
+		assert.Equal(t, ipAddressV4_1, actual[0].Address)
+		assert.Equal(t, ipAddressV4_2, actual[1].Address)
 	})
 
 	t.Run("Fetch first available IP address by claim (IPv4).", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-		mockIpamAPI.EXPECT().
-			IpamPrefixesList(gomock.Any()).
-			Return(mockListRequest)
-
-		mockListRequest.EXPECT().
-			Prefix([]string{parentPrefixV4}).
-			Return(mockListRequest)
-
-		expectedPrefix := v4client.Prefix{
-			Id:     parentPrefixIdV4,
-			Prefix: parentPrefixV4,
-		}
-
-		mockListRequest.EXPECT().
-			Execute().
-			Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{expectedPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
-
-		inputIps := ipam.NewIpamPrefixesAvailableIpsListParams().WithID(int64(parentPrefixIdV4))
-		outputIps := &ipam.IpamPrefixesAvailableIpsListOK{
-			Payload: []*netboxModels.AvailableIP{
-				{
-					Address: ipAddressV4_2,
-					Family:  int64(IPv4Family),
-				},
-			}}
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenants, nil)
 
-		mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamPrefixesAvailableIpsList(inputIps, nil).Return(outputIps, nil)
+		expectPrefixList(ctrl, mockIpamAPI, parentPrefixV4, []v4client.Prefix{{Id: parentPrefixIdV4, Prefix: parentPrefixV4}})
+		expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixIdV4, []v4client.AvailableIP{
+			{
+				Address: ipAddressV4_2,
+				Family:  int32(IPv4Family),
+			},
+		})
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-			Ipam:    mockIPAddress,
-		}
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancy,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
 			clientV4: clientV4,
 		}
 
@@ -173,49 +153,22 @@ func TestIPAddressClaim(t *testing.T) {
 
 	t.Run("Fetch first available IP address by claim (IPv6).", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-		inputIps := ipam.NewIpamPrefixesAvailableIpsListParams().WithID(int64(parentPrefixIdV6))
-		outputIps := &ipam.IpamPrefixesAvailableIpsListOK{
-			Payload: []*netboxModels.AvailableIP{
-				{
-					Address: ipAddressV6,
-					Family:  int64(IPv6Family),
-				},
-			}}
-
-		mockIpamAPI.EXPECT().
-			IpamPrefixesList(gomock.Any()).
-			Return(mockListRequest)
-
-		mockListRequest.EXPECT().
-			Prefix([]string{parentPrefixV6}).
-			Return(mockListRequest)
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenants, nil)
 
-		expectedPrefix := v4client.Prefix{
-			Id:     parentPrefixIdV6,
-			Prefix: parentPrefixV6,
-		}
-
-		mockListRequest.EXPECT().
-			Execute().
-			Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{expectedPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
-
-		mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamPrefixesAvailableIpsList(inputIps, nil).Return(outputIps, nil)
+		expectPrefixList(ctrl, mockIpamAPI, parentPrefixV6, []v4client.Prefix{{Id: parentPrefixIdV6, Prefix: parentPrefixV6}})
+		expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixIdV6, []v4client.AvailableIP{
+			{
+				Address: ipAddressV6,
+				Family:  int32(IPv6Family),
+			},
+		})
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-			Ipam:    mockIPAddress,
-		}
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancy,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
 			clientV4: clientV4,
 		}
 
@@ -236,49 +189,22 @@ func TestIPAddressClaim(t *testing.T) {
 
 	t.Run("Fetch first available IP address by claim (invalid IP family).", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenants, nil)
 
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-		inputIps := ipam.NewIpamPrefixesAvailableIpsListParams().WithID(int64(parentPrefixIdV6))
-		outputIps := &ipam.IpamPrefixesAvailableIpsListOK{
-			Payload: []*netboxModels.AvailableIP{
-				{
-					Address: ipAddressV6,
-					Family:  int64(5),
-				},
-			}}
-
-		mockIpamAPI.EXPECT().
-			IpamPrefixesList(gomock.Any()).
-			Return(mockListRequest)
-
-		mockListRequest.EXPECT().
-			Prefix([]string{parentPrefixV6}).
-			Return(mockListRequest)
-
-		expectedPrefix := v4client.Prefix{
-			Id:     parentPrefixIdV6,
-			Prefix: parentPrefixV6,
-		}
-
-		mockListRequest.EXPECT().
-			Execute().
-			Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{expectedPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
-
-		mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamPrefixesAvailableIpsList(inputIps, nil).Return(outputIps, nil)
+		expectPrefixList(ctrl, mockIpamAPI, parentPrefixV6, []v4client.Prefix{{Id: parentPrefixIdV6, Prefix: parentPrefixV6}})
+		expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixIdV6, []v4client.AvailableIP{
+			{
+				Address: ipAddressV6,
+				Family:  int32(5),
+			},
+		})
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-			Ipam:    mockIPAddress,
-		}
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancy,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
 			clientV4: clientV4,
 		}
 
@@ -301,34 +227,16 @@ func TestIPAddressClaim(t *testing.T) {
 
 	t.Run("Fetch IP address's claim with incorrect parent prefix.", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-		mockIpamAPI.EXPECT().
-			IpamPrefixesList(gomock.Any()).
-			Return(mockListRequest)
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenants, nil)
 
-		mockListRequest.EXPECT().
-			Prefix([]string{parentPrefixV4}).
-			Return(mockListRequest)
-
-		mockListRequest.EXPECT().
-			Execute().
-			Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
-
-		mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+		expectPrefixList(ctrl, mockIpamAPI, parentPrefixV4, []v4client.Prefix{})
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-			Ipam:    mockIPAddress,
-		}
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancy,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
 			clientV4: clientV4,
 		}
 
@@ -345,70 +253,62 @@ func TestIPAddressClaim(t *testing.T) {
 
 		// assert error
 		AssertError(t, err, expectedErrMsg)
+		// the parentPrefixes fallback skips the parent prefix
+		assert.ErrorIs(t, err, ErrParentPrefixNotFound)
 		// assert nil output
 		assert.Nil(t, actual)
 	})
 
 	t.Run("Fetch IP address's claim with exhausted parent prefix.", func(t *testing.T) {
-
-		// ip address mock input
-		input := ipam.NewIpamPrefixesAvailableIpsListParams().WithID(int64(parentPrefixIdV4))
-		// ip address mock output
-		output := &ipam.IpamPrefixesAvailableIpsListOK{
-			Payload: []*netboxModels.AvailableIP{},
-		}
-
-		mockIPAddress.EXPECT().IpamPrefixesAvailableIpsList(input, nil).Return(output, nil)
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixIdV4, []v4client.AvailableIP{})
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPAddress,
+		clientV4 := &NetboxClientV4{
+			IpamAPI: mockIpamAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
+			clientV4: clientV4,
 		}
 
-		actual, err := compositeClient.GetAvailableIpAddressesByParentPrefix(parentPrefixIdV4)
+		actual, err := compositeClient.GetAvailableIpAddressesByParentPrefix(context.TODO(), parentPrefixIdV4)
 
 		// assert error
 		AssertError(t, err, ErrParentPrefixExhausted.Error())
 		// assert nil output
-		AssertNil(t, actual)
+		assert.Nil(t, actual)
 	})
 
 	t.Run("Reclaim IP Address", func(t *testing.T) {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		mockListRequest := mock_interfaces.NewMockIpamIpAddressesListRequest(ctrl)
 
 		ipAddressRestore := "10.111.111.111/32"
 
 		input := "403f19fcb98beaf5a25018536ed5275714a132ff"
-		output := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Count:    nil,
-				Next:     nil,
-				Previous: nil,
-				Results: []*netboxModels.IPAddress{
-					{
-						Address: &ipAddressRestore,
-					},
-				},
-			},
-		}
+
+		// the restoration hash is filtered by the query filter in the context of the request
+		mockIpamAPI.EXPECT().IpamIpAddressesList(gomock.Any()).DoAndReturn(func(ctx context.Context) *mock_interfaces.MockIpamIpAddressesListRequest {
+			filters := queryFilters(ctx)
+			assert.Len(t, filters, 1)
+			assert.Equal(t, []CustomFieldEntry{{key: config.GetOperatorConfig().NetboxRestorationHashFieldName, value: input}}, filters[0].customFields)
+			return mockListRequest
+		})
+		mockListRequest.EXPECT().Limit(pageLimit()).Return(mockListRequest)
+		mockListRequest.EXPECT().Offset(int32(0)).Return(mockListRequest)
+		mockListRequest.EXPECT().Execute().Return(
+			&v4client.PaginatedIPAddressList{Count: 1, Results: []v4client.IPAddress{{Address: ipAddressRestore}}},
+			&http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPAddress,
+		clientV4 := &NetboxClientV4{
+			IpamAPI: mockIpamAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
+			clientV4: clientV4,
 		}
 
-		// 3rd parameter should be the variable `customIpSearch` below but go cannot compare functions so this errors.
-		// Using ´gomock.Any()´ to allow this test to pass (shouldn't change the outcome of the tests anyway)
-		//https://github.com/golang/mock/issues/324
-
-		mockIPAddress.EXPECT().IpamIPAddressesList(ipam.NewIpamIPAddressesListParams(), nil, gomock.Any()).Return(output, nil)
-
-		actual, err := compositeClient.RestoreExistingIpByHash(input)
+		actual, err := compositeClient.RestoreExistingIpByHash(context.TODO(), input, "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, ipAddressRestore, actual.IpAddress)
@@ -419,30 +319,23 @@ func TestIPAddressClaim_GetNoAvailableIPAddressWithTenancyChecks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-
 	parentPrefix := "10.112.140.0/24"
 	t.Run("No IP address assigned with an error when getting the tenant list", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 
 		tenantName := "Tenant1"
 
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
 		// expected error
 		expectedErrorMsg := "cannot get the list" // testcase-defined error
 
-		mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(nil, errors.New(expectedErrorMsg)).AnyTimes()
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, nil, errors.New(expectedErrorMsg))
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-		}
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancy,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
 			clientV4: clientV4,
 		}
 
@@ -467,30 +360,18 @@ func TestIPAddressClaim_GetNoAvailableIPAddressWithTenancyChecks(t *testing.T) {
 		// non existing tenant
 		nonExistingTenant := "non-existing-tenant"
 
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&nonExistingTenant)
-
-		// empty tenant list
-		emptyTenantList := &tenancy.TenancyTenantsListOK{
-			Payload: &tenancy.TenancyTenantsListOKBody{
-				Results: []*netboxModels.Tenant{},
-			},
-		}
-
 		// expected error
 		expectedErrorMsg := "failed to fetch tenant 'non-existing-tenant': not found"
 
 		// mock empty list call
-		mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(emptyTenantList, nil).AnyTimes()
+		mockTenancy := mockTenancyAPI(ctrl, nonExistingTenant, []v4client.Tenant{}, nil)
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-		}
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancy,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
 			clientV4: clientV4,
 		}
 
@@ -509,3 +390,319 @@ func TestIPAddressClaim_GetNoAvailableIPAddressWithTenancyChecks(t *testing.T) {
 		assert.Equal(t, actual, (*models.IPAddress)(nil))
 	})
 }
+
+func TestIPAddressClaim_GetAvailableIpAddressParentPrefixesBySelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+
+	ipacSpec := netboxv1.IpAddressClaimSpec{
+		ParentPrefixSelector: map[string]string{
+			"environment": "dev",
+			"family":      "IPv4",
+		},
+		Tenant: "tenant",
+	}
+
+	// tenant
+	tenantName := "tenant"
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, []v4client.Tenant{{Id: 2, Name: tenantName, Slug: "tenant1"}}, nil)
+	mockExtras := mockExtrasAPI(ctrl, "environment")
+
+	// the first prefix matching the selector is exhausted, the second one still has an available ip address
+	exhaustedParentPrefix := "10.112.140.0/30"
+	exhaustedParentPrefixId := int32(1)
+	parentPrefix := "10.112.141.0/24"
+	parentPrefixId := int32(2)
+
+	expectPrefixListBySelector(ctrl, mockIpamAPI, []v4client.Prefix{
+		{Id: exhaustedParentPrefixId, Prefix: exhaustedParentPrefix},
+		{Id: parentPrefixId, Prefix: parentPrefix},
+	})
+
+	expectPrefixList(ctrl, mockIpamAPI, exhaustedParentPrefix, []v4client.Prefix{{Id: exhaustedParentPrefixId, Prefix: exhaustedParentPrefix}})
+	expectPrefixList(ctrl, mockIpamAPI, parentPrefix, []v4client.Prefix{{Id: parentPrefixId, Prefix: parentPrefix}})
+	expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, exhaustedParentPrefixId, []v4client.AvailableIP{})
+	expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixId, []v4client.AvailableIP{{Address: "10.112.141.1/24", Family: int32(IPv4Family)}})
+
+	clientV4 := &NetboxClientV4{
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
+		ExtrasAPI:  mockExtras,
+	}
+	compositeClient := &NetboxCompositeClient{
+		clientV4: clientV4,
+	}
+
+	actual, err := compositeClient.GetAvailableIpAddressParentPrefixesBySelector(context.TODO(), &ipacSpec)
+
+	assert.Nil(t, err)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, parentPrefix, actual[0].Prefix)
+}
+
+func TestIPAddressClaim_GetPreferredIpAddressByClaim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "Tenant1"
+	expectedTenants := []v4client.Tenant{{Id: 2, Name: tenantName, Slug: "tenant1"}}
+
+	parentPrefix := "10.112.140.0/24"
+	parentPrefixId := int32(3)
+
+	newCompositeClient := func() *NetboxCompositeClient {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+
+		expectPrefixList(ctrl, mockIpamAPI, parentPrefix, []v4client.Prefix{{Id: parentPrefixId, Prefix: parentPrefix}})
+
+		// 10.112.140.1 and 10.112.140.3 are available, 10.112.140.2 is already allocated
+		expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixId, []v4client.AvailableIP{
+			{Address: "10.112.140.1/24", Family: int32(IPv4Family)},
+			{Address: "10.112.140.3/24", Family: int32(IPv4Family)},
+		})
+
+		return &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{
+				IpamAPI:    mockIpamAPI,
+				TenancyAPI: mockTenancyAPI(ctrl, tenantName, expectedTenants, nil),
+			},
+		}
+	}
+
+	claim := &models.IPAddressClaim{
+		ParentPrefix: parentPrefix,
+		Metadata: &models.NetboxMetadata{
+			Tenant: tenantName,
+		},
+	}
+
+	t.Run("Preferred IP address is available.", func(t *testing.T) {
+		actual, err := newCompositeClient().GetPreferredIpAddressByClaim(context.TODO(), claim, "10.112.140.3")
+
+		AssertNil(t, err)
+		assert.Equal(t, "10.112.140.3/32", actual.IpAddress)
+	})
+
+	t.Run("Preferred IP address is not available.", func(t *testing.T) {
+		actual, err := newCompositeClient().GetPreferredIpAddressByClaim(context.TODO(), claim, "10.112.140.2")
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, ErrPreferredAddressNotAvailable)
+	})
+
+	t.Run("Preferred IP address is invalid.", func(t *testing.T) {
+		actual, err := (&NetboxCompositeClient{}).GetPreferredIpAddressByClaim(context.TODO(), claim, "10.112.140.300")
+
+		assert.Nil(t, actual)
+		assert.ErrorContains(t, err, "invalid preferred ip address 10.112.140.300")
+	})
+}
+
+func TestIPAddressClaim_GetAvailableIpAddressesByClaim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "Tenant1"
+	expectedTenants := []v4client.Tenant{{Id: 2, Name: tenantName, Slug: "tenant1"}}
+
+	parentPrefix := "10.112.140.0/24"
+	parentPrefixId := int32(3)
+
+	newCompositeClient := func() *NetboxCompositeClient {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+
+		expectPrefixList(ctrl, mockIpamAPI, parentPrefix, []v4client.Prefix{{Id: parentPrefixId, Prefix: parentPrefix}})
+		expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixId, []v4client.AvailableIP{
+			{Address: "10.112.140.1/24", Family: int32(IPv4Family)},
+			{Address: "10.112.140.2/24", Family: int32(IPv4Family)},
+			{Address: "10.112.140.3/24", Family: int32(IPv4Family)},
+		})
+
+		return &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{
+				IpamAPI:    mockIpamAPI,
+				TenancyAPI: mockTenancyAPI(ctrl, tenantName, expectedTenants, nil),
+			},
+		}
+	}
+
+	claim := &models.IPAddressClaim{
+		ParentPrefix: parentPrefix,
+		Metadata: &models.NetboxMetadata{
+			Tenant: tenantName,
+		},
+	}
+
+	t.Run("Enough IP addresses are available.", func(t *testing.T) {
+		// 10.112.140.1 is assigned to a sibling but not yet reserved in NetBox
+		actual, err := newCompositeClient().GetAvailableIpAddressesByClaim(context.TODO(), claim, 2, []string{"10.112.140.1/32"})
+
+		AssertNil(t, err)
+		assert.Len(t, actual, 2)
+		assert.Equal(t, "10.112.140.2/32", actual[0].IpAddress)
+		assert.Equal(t, "10.112.140.3/32", actual[1].IpAddress)
+	})
+
+	t.Run("Not enough IP addresses are available.", func(t *testing.T) {
+		actual, err := newCompositeClient().GetAvailableIpAddressesByClaim(context.TODO(), claim, 3, []string{"10.112.140.1/32"})
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, ErrParentPrefixExhausted)
+		assert.ErrorContains(t, err, "only 2 of 3 ip addresses are available")
+	})
+}
+
+func TestIPAddressClaim_GetAvailableIpAddressByClaimFromIpRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "Tenant1"
+	expectedTenants := []v4client.Tenant{{Id: 2, Name: tenantName, Slug: "tenant1"}}
+
+	startAddress := "10.112.140.10/24"
+	endAddress := "10.112.140.20/24"
+	parentIpRangeId := int32(4)
+
+	newCompositeClient := func(ipRanges []v4client.IPRange, availableIPs []v4client.AvailableIP) *NetboxCompositeClient {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		mockListRequest := mock_interfaces.NewMockIpamIpRangesListRequest(ctrl)
+
+		mockIpamAPI.EXPECT().IpamIpRangesList(gomock.Any()).Return(mockListRequest)
+		mockListRequest.EXPECT().StartAddress([]string{startAddress}).Return(mockListRequest)
+		mockListRequest.EXPECT().EndAddress([]string{endAddress}).Return(mockListRequest)
+		mockListRequest.EXPECT().Execute().Return(
+			&v4client.PaginatedIPRangeList{Results: ipRanges},
+			&http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+
+		if availableIPs != nil {
+			mockAvailableIpsList := mock_interfaces.NewMockIpamIpRangesAvailableIpsListRequest(ctrl)
+			mockIpamAPI.EXPECT().IpamIpRangesAvailableIpsList(gomock.Any(), parentIpRangeId).Return(mockAvailableIpsList)
+			mockAvailableIpsList.EXPECT().Execute().Return(availableIPs, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+		}
+
+		return &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{
+				IpamAPI:    mockIpamAPI,
+				TenancyAPI: mockTenancyAPI(ctrl, tenantName, expectedTenants, nil),
+			},
+		}
+	}
+
+	claim := &models.IPAddressClaim{
+		ParentIpRange: &models.IpRange{
+			StartAddress: startAddress,
+			EndAddress:   endAddress,
+		},
+		Metadata: &models.NetboxMetadata{
+			Tenant: tenantName,
+		},
+	}
+
+	t.Run("IP address is claimed from the IP range.", func(t *testing.T) {
+		compositeClient := newCompositeClient(
+			[]v4client.IPRange{{Id: parentIpRangeId, StartAddress: startAddress, EndAddress: endAddress}},
+			[]v4client.AvailableIP{
+				{Address: "10.112.140.12/24", Family: int32(IPv4Family)},
+				{Address: "10.112.140.13/24", Family: int32(IPv4Family)},
+			})
+
+		actual, err := compositeClient.GetAvailableIpAddressByClaim(context.TODO(), claim)
+
+		AssertNil(t, err)
+		assert.Equal(t, "10.112.140.12/32", actual.IpAddress)
+	})
+
+	t.Run("IP range is exhausted.", func(t *testing.T) {
+		compositeClient := newCompositeClient(
+			[]v4client.IPRange{{Id: parentIpRangeId, StartAddress: startAddress, EndAddress: endAddress}},
+			[]v4client.AvailableIP{})
+
+		actual, err := compositeClient.GetAvailableIpAddressByClaim(context.TODO(), claim)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, ErrParentIpRangeExhausted)
+	})
+
+	t.Run("IP range is not found.", func(t *testing.T) {
+		compositeClient := newCompositeClient([]v4client.IPRange{}, nil)
+
+		actual, err := compositeClient.GetAvailableIpAddressByClaim(context.TODO(), claim)
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, ErrParentIpRangeNotFound)
+	})
+}
+
+func TestIPAddressClaim_GetParentIpRangePrefix(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "Tenant1"
+	expectedTenants := []v4client.Tenant{{Id: 2, Name: tenantName, Slug: "tenant1"}}
+
+	startAddress := "10.112.140.10/24"
+	endAddress := "10.112.140.20/24"
+
+	newCompositeClient := func(prefixes []v4client.Prefix) *NetboxCompositeClient {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		mockIpRangesList := mock_interfaces.NewMockIpamIpRangesListRequest(ctrl)
+		mockIpamAPI.EXPECT().IpamIpRangesList(gomock.Any()).Return(mockIpRangesList)
+		mockIpRangesList.EXPECT().StartAddress([]string{startAddress}).Return(mockIpRangesList)
+		mockIpRangesList.EXPECT().EndAddress([]string{endAddress}).Return(mockIpRangesList)
+		mockIpRangesList.EXPECT().Execute().Return(
+			&v4client.PaginatedIPRangeList{Results: []v4client.IPRange{{Id: 4, StartAddress: startAddress, EndAddress: endAddress}}},
+			&http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+
+		// the prefixes containing the start address of the ip range are listed
+		mockPrefixesList := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+		mockIpamAPI.EXPECT().IpamPrefixesList(gomock.Any()).Return(mockPrefixesList)
+		mockPrefixesList.EXPECT().Contains("10.112.140.10").Return(mockPrefixesList)
+		mockPrefixesList.EXPECT().Limit(gomock.Any()).Return(mockPrefixesList)
+		mockPrefixesList.EXPECT().Offset(gomock.Any()).Return(mockPrefixesList)
+		mockPrefixesList.EXPECT().Execute().Return(
+			&v4client.PaginatedPrefixList{Results: prefixes},
+			&http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+
+		return &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{
+				IpamAPI:    mockIpamAPI,
+				TenancyAPI: mockTenancyAPI(ctrl, tenantName, expectedTenants, nil),
+			},
+		}
+	}
+
+	claim := &models.IPAddressClaim{
+		ParentIpRange: &models.IpRange{
+			StartAddress: startAddress,
+			EndAddress:   endAddress,
+		},
+		Metadata: &models.NetboxMetadata{
+			Tenant: tenantName,
+		},
+	}
+
+	t.Run("The smallest prefix in the VRF of the IP range containing it is returned.", func(t *testing.T) {
+		compositeClient := newCompositeClient([]v4client.Prefix{
+			{Id: 1, Prefix: "10.112.0.0/16"},
+			{Id: 2, Prefix: "10.112.140.0/24"},
+			{Id: 3, Prefix: "10.112.140.0/25", Vrf: *v4client.NewNullableBriefVRF(&v4client.BriefVRF{Id: 1, Name: "customer-a"})},
+			{Id: 4, Prefix: "10.112.140.0/28"},
+		})
+
+		actual, err := compositeClient.GetParentIpRangePrefix(context.TODO(), claim)
+
+		AssertNil(t, err)
+		assert.Equal(t, "10.112.140.0/24", actual)
+	})
+
+	t.Run("IP range is not within a prefix.", func(t *testing.T) {
+		compositeClient := newCompositeClient([]v4client.Prefix{})
+
+		actual, err := compositeClient.GetParentIpRangePrefix(context.TODO(), claim)
+
+		AssertNil(t, err)
+		assert.Equal(t, "", actual)
+	})
+}
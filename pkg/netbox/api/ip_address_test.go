@@ -18,16 +18,13 @@ package api
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
-	"github.com/go-openapi/strfmt"
-	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
-
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
+	v4client "github.com/netbox-community/go-netbox/v4"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
 	"github.com/netbox-community/netbox-operator/pkg/config"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/stretchr/testify/assert"
@@ -39,32 +36,30 @@ const (
 	Comments    = "reservation for test comments"
 	Status      = "active"
 	Description = "reservation for test description"
-	IpAddressId = int64(4)
+	IpAddressId = int32(4)
 )
 
 func TestIPAddress(t *testing.T) {
-	ctrl := gomock.NewController(t, gomock.WithOverridableExpectations())
+	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockIPAddress := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockPrefixTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-	//
-	//outputMock := &ipam.Ipam
+
 	ipAddress := "10.112.140.0"
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	Label := "Status"
-	Value := "active"
+	comments := Comments
+	description := Description
+	statusLabel := v4client.IPADDRESSSTATUSLABEL_ACTIVE
+	statusValue := v4client.IPADDRESSSTATUSVALUE_ACTIVE
 
 	// example input IP address
-	writeableAddress := func() *netboxModels.WritableIPAddress {
-		return &netboxModels.WritableIPAddress{
-			Address:     &ipAddress,
-			Comments:    Comments,
-			Description: Description,
-			Tenant:      &tenantId,
-			Status:      Status,
-		}
+	writeableAddress := func() *v4client.WritableIPAddressRequest {
+		address := v4client.NewWritableIPAddressRequest(ipAddress)
+		address.SetComments(Comments)
+		address.SetDescription(Description)
+		address.SetTenant(v4client.Int32AsASNRangeRequestTenant(&tenantId))
+		address.SetStatus(v4client.PATCHEDWRITABLEIPADDRESSREQUESTSTATUS_ACTIVE)
+		return address
 	}
 
 	expectedHash := "fioaf9289rjfhaeuih"
@@ -74,38 +69,32 @@ func TestIPAddress(t *testing.T) {
 	}
 
 	// example output IP address
-	expectedIPAddress := func() *netboxModels.IPAddress {
-		lastUpdated := strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
-		return &netboxModels.IPAddress{
-			ID:           int64(1),
-			Address:      &ipAddress,
+	expectedIPAddress := func() *v4client.IPAddress {
+		lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		return &v4client.IPAddress{
+			Id:           int32(1),
+			Address:      ipAddress,
 			Display:      ipAddress,
-			Comments:     Comments,
-			Description:  Description,
+			Comments:     &comments,
+			Description:  &description,
 			CustomFields: customFields,
-			Tenant: &netboxModels.NestedTenant{
-				ID: tenantId,
-			},
-			Status: &netboxModels.IPAddressStatus{
-				Label: &Label,
-				Value: &Value,
+			Tenant:       *v4client.NewNullableBriefTenant(v4client.NewBriefTenant(tenantId, "", "", tenantName, tenantOutputSlug)),
+			Status: &v4client.IPAddressStatus{
+				Label: &statusLabel,
+				Value: &statusValue,
 			},
-			LastUpdated: &lastUpdated,
+			LastUpdated: *v4client.NewNullableTime(&lastUpdated),
 		}
 	}
 
-	// example of tenant
-	expectedTenant := func() *tenancy.TenancyTenantsListOK {
-		return &tenancy.TenancyTenantsListOK{
-			Payload: &tenancy.TenancyTenantsListOKBody{
-				Results: []*netboxModels.Tenant{
-					{
-						ID:   tenantId,
-						Name: &tenantName,
-						Slug: &tenantOutputSlug,
-					},
-				},
-			},
+	// listedIPAddress returns the ip address as listed by NetBox, with the given last updated time and custom fields
+	listedIPAddress := func(lastUpdated time.Time, customFields map[string]interface{}) v4client.IPAddress {
+		return v4client.IPAddress{
+			Id:           expectedIPAddress().Id,
+			Address:      expectedIPAddress().Address,
+			Display:      expectedIPAddress().Display,
+			CustomFields: customFields,
+			LastUpdated:  *v4client.NewNullableTime(&lastUpdated),
 		}
 	}
 
@@ -124,47 +113,56 @@ func TestIPAddress(t *testing.T) {
 		return model
 	}
 
-	t.Run("Retrieve Existing static IP Address", func(t *testing.T) {
+	// expectList sets up the list of the ip addresses by address
+	expectList := func(mockIpam *mock_interfaces.MockIpamAPI, results ...v4client.IPAddress) {
+		listRequest := mock_interfaces.NewMockIpamIpAddressesListRequest(ctrl)
+		mockIpam.EXPECT().IpamIpAddressesList(gomock.Any()).Return(listRequest)
+		listRequest.EXPECT().Address([]string{ipAddress}).Return(listRequest)
+		listRequest.EXPECT().Execute().Return(
+			&v4client.PaginatedIPAddressList{Count: int32(len(results)), Results: results},
+			&http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+	}
 
-		// id, address conversion from int64 to string
-		address := ipAddress
-
-		// tenant mock input
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-		// tenant mock output
-
-		// ip address mock input
-		input := ipam.NewIpamIPAddressesListParams().WithAddress(&address)
-		// ip address mock output
-		output := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{
-						ID:          expectedIPAddress().ID,
-						Address:     expectedIPAddress().Address,
-						Comments:    expectedIPAddress().Comments,
-						Description: expectedIPAddress().Description,
-						Display:     expectedIPAddress().Display,
-						Tenant:      expectedIPAddress().Tenant,
-					},
-				},
+	// expectUpdate sets up the update of the ip address, which returns the expected ip address
+	expectUpdate := func(mockIpam *mock_interfaces.MockIpamAPI, id int32, input any) {
+		updateRequest := mock_interfaces.NewMockIpamIpAddressesUpdateRequest(ctrl)
+		mockIpam.EXPECT().IpamIpAddressesUpdate(gomock.Any(), id).Return(updateRequest)
+		updateRequest.EXPECT().WritableIPAddressRequest(input).Return(updateRequest)
+		updateRequest.EXPECT().Execute().Return(expectedIPAddress(), &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+	}
+
+	newClient := func(mockIpam *mock_interfaces.MockIpamAPI) *NetboxCompositeClient {
+		return &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{
+				IpamAPI: mockIpam,
 			},
 		}
+	}
+
+	// assertUpdated asserts that the result is the ip address returned by the update
+	assertUpdated := func(t *testing.T, result *v4client.IPAddress) {
+		t.Helper()
+		assert.Equal(t, expectedIPAddress().Id, result.Id)
+		assert.Equal(t, expectedIPAddress().Comments, result.Comments)
+		assert.Equal(t, expectedIPAddress().Description, result.Description)
+		assert.Equal(t, expectedIPAddress().Display, result.Display)
+		assert.Equal(t, expectedIPAddress().Address, result.Address)
+		assert.Equal(t, expectedIPAddress().GetTenant().Id, result.GetTenant().Id)
+		assert.Equal(t, expectedIPAddress().GetTenant().Name, result.GetTenant().Name)
+		assert.Equal(t, expectedIPAddress().GetTenant().Slug, result.GetTenant().Slug)
+		assert.Equal(t, expectedIPAddress().CustomFields, result.CustomFields)
+		assert.Equal(t, expectedIPAddress().Status.Label, result.Status.Label)
+		assert.Equal(t, expectedIPAddress().Status.Value, result.Status.Value)
+		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
+	}
 
-		mockPrefixTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant(), nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamIPAddressesList(input, nil).Return(output, nil).AnyTimes()
+	t.Run("Retrieve Existing static IP Address", func(t *testing.T) {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, *expectedIPAddress())
 
-		// init client
-		clientV3 := &NetboxClientV3{
-			Ipam:    mockIPAddress,
-			Tenancy: mockPrefixTenancy,
-		}
-		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
-		}
+		compositeClient := newClient(mockIpam)
 
-		actual, err := compositeClient.getIpAddress(&models.IPAddress{
+		actual, err := compositeClient.getIpAddress(context.TODO(), &models.IPAddress{
 			IpAddress: ipAddress,
 			Metadata: &models.NetboxMetadata{
 				Tenant: tenantName,
@@ -173,48 +171,23 @@ func TestIPAddress(t *testing.T) {
 
 		// assert error return
 		AssertNil(t, err)
-		assert.Equal(t, expectedIPAddress().ID, actual.Payload.Results[0].ID)
-		assert.Equal(t, expectedIPAddress().Comments, actual.Payload.Results[0].Comments)
-		assert.Equal(t, expectedIPAddress().Description, actual.Payload.Results[0].Description)
-		assert.Equal(t, expectedIPAddress().Display, actual.Payload.Results[0].Display)
-		assert.Equal(t, expectedIPAddress().Address, actual.Payload.Results[0].Address)
-		assert.Equal(t, expectedIPAddress().Tenant.ID, actual.Payload.Results[0].Tenant.ID)
-		assert.Equal(t, expectedIPAddress().Tenant.Name, actual.Payload.Results[0].Tenant.Name)
-		assert.Equal(t, expectedIPAddress().Tenant.Slug, actual.Payload.Results[0].Tenant.Slug)
+		assert.Equal(t, expectedIPAddress().Id, actual.Results[0].Id)
+		assert.Equal(t, expectedIPAddress().Comments, actual.Results[0].Comments)
+		assert.Equal(t, expectedIPAddress().Description, actual.Results[0].Description)
+		assert.Equal(t, expectedIPAddress().Display, actual.Results[0].Display)
+		assert.Equal(t, expectedIPAddress().Address, actual.Results[0].Address)
+		assert.Equal(t, expectedIPAddress().GetTenant().Id, actual.Results[0].GetTenant().Id)
+		assert.Equal(t, expectedIPAddress().GetTenant().Name, actual.Results[0].GetTenant().Name)
+		assert.Equal(t, expectedIPAddress().GetTenant().Slug, actual.Results[0].GetTenant().Slug)
 	})
 
 	t.Run("Retrieve Non Existing Static IP Address", func(t *testing.T) {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam)
 
-		// id, address conversion from int64 to string
-		address := ipAddress
-
-		// tenant mock input
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
+		compositeClient := newClient(mockIpam)
 
-		// tenant mock output
-
-		// ip address mock input
-		input := ipam.NewIpamIPAddressesListParams().WithAddress(&address)
-		// ip address mock output
-		output := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{},
-			},
-		}
-
-		mockPrefixTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant(), nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamIPAddressesList(input, nil).Return(output, nil).AnyTimes()
-
-		// init client
-		clientV3 := &NetboxClientV3{
-			Ipam:    mockIPAddress,
-			Tenancy: mockPrefixTenancy,
-		}
-		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
-		}
-
-		res, err := compositeClient.getIpAddress(&models.IPAddress{
+		res, err := compositeClient.getIpAddress(context.TODO(), &models.IPAddress{
 			IpAddress: ipAddress,
 			Metadata: &models.NetboxMetadata{
 				Tenant: tenantName,
@@ -223,56 +196,35 @@ func TestIPAddress(t *testing.T) {
 
 		// assert error return
 		AssertNil(t, err)
-		assert.Zero(t, res.Payload.Count)
+		assert.Zero(t, res.Count)
 	})
 
 	t.Run("create Static IP Address", func(t *testing.T) {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		createRequest := mock_interfaces.NewMockIpamIpAddressesCreateRequest(ctrl)
 
-		// ip address mock input
-		input := ipam.NewIpamIPAddressesCreateParams().WithDefaults().WithData(writeableAddress())
-		// ip address mock output
-		output := &ipam.IpamIPAddressesCreateCreated{
-			Payload: expectedIPAddress(),
-		}
-
-		mockIPAddress.EXPECT().IpamIPAddressesCreate(input, nil).Return(output, nil)
+		mockIpam.EXPECT().IpamIpAddressesCreate(gomock.Any()).Return(createRequest)
+		createRequest.EXPECT().WritableIPAddressRequest(*writeableAddress()).Return(createRequest)
+		createRequest.EXPECT().Execute().Return(expectedIPAddress(), &http.Response{StatusCode: 201, Body: http.NoBody}, nil)
 
-		// init client
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPAddress,
-		}
-		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
-		}
+		compositeClient := newClient(mockIpam)
 
-		ipaddress, err := compositeClient.createIpAddress(writeableAddress())
+		ipaddress, err := compositeClient.createIpAddress(context.TODO(), writeableAddress())
 
 		// assert error return
 		AssertNil(t, err)
 
 		// assert address elements
 		AssertIpAddress(t, writeableAddress(), ipaddress)
-
 	})
 
 	t.Run("update of Static IP Address", func(t *testing.T) {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectUpdate(mockIpam, IpAddressId, *writeableAddress())
 
-		input := ipam.NewIpamIPAddressesUpdateParams().WithDefaults().WithData(writeableAddress()).WithID(IpAddressId)
-
-		output := &ipam.IpamIPAddressesUpdateOK{
-			Payload: expectedIPAddress(),
-		}
+		compositeClient := newClient(mockIpam)
 
-		mockIPAddress.EXPECT().IpamIPAddressesUpdate(input, nil).Return(output, nil)
-
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPAddress,
-		}
-		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
-		}
-
-		ipaddress, err := compositeClient.updateIpAddress(IpAddressId, writeableAddress())
+		ipaddress, err := compositeClient.updateIpAddress(context.TODO(), IpAddressId, writeableAddress())
 
 		// assertion for errors
 		AssertNil(t, err)
@@ -282,135 +234,69 @@ func TestIPAddress(t *testing.T) {
 	})
 
 	t.Run("delete IP Address", func(t *testing.T) {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		destroyRequest := mock_interfaces.NewMockIpamIpAddressesDestroyRequest(ctrl)
 
-		input := ipam.NewIpamIPAddressesDeleteParams().WithID(IpAddressId)
-		output := &ipam.IpamIPAddressesDeleteNoContent{}
-
-		mockIPAddress.EXPECT().IpamIPAddressesDelete(input, nil).Return(output, nil)
+		mockIpam.EXPECT().IpamIpAddressesDestroy(gomock.Any(), IpAddressId).Return(destroyRequest)
+		destroyRequest.EXPECT().Execute().Return(&http.Response{StatusCode: 204, Body: http.NoBody}, nil)
 
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPAddress,
-		}
-		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
-		}
+		compositeClient := newClient(mockIpam)
 
-		err := compositeClient.DeleteIpAddress(IpAddressId)
+		err := compositeClient.DeleteIpAddress(context.TODO(), IpAddressId)
 		AssertNil(t, err)
 	})
 
-	t.Run("check without hash", func(t *testing.T) {
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{
-						ID:          expectedIPAddress().ID,
-						Address:     expectedIPAddress().Address,
-						Display:     expectedIPAddress().Display,
-						LastUpdated: expectedIPAddress().LastUpdated,
-					}},
-			},
-		}
+	t.Run("delete already deleted IP Address", func(t *testing.T) {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		destroyRequest := mock_interfaces.NewMockIpamIpAddressesDestroyRequest(ctrl)
 
-		outputUpdate := &ipam.IpamIPAddressesUpdateOK{
-			Payload: expectedIPAddress(),
-		}
+		mockIpam.EXPECT().IpamIpAddressesDestroy(gomock.Any(), IpAddressId).Return(destroyRequest)
+		destroyRequest.EXPECT().Execute().Return(&http.Response{StatusCode: 404, Body: http.NoBody}, nil)
 
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
-		// use gomock.Any() because the input contains a pointer
-		mockIPAddress.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Return(outputUpdate, nil)
+		compositeClient := newClient(mockIpam)
 
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPAddress,
-		}
-		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
-		}
+		err := compositeClient.DeleteIpAddress(context.TODO(), IpAddressId)
+		AssertNil(t, err)
+	})
+
+	t.Run("check without hash", func(t *testing.T) {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(*expectedIPAddress().LastUpdated.Get(), nil))
+		// use gomock.Any() because the input contains pointers
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Any())
+
+		compositeClient := newClient(mockIpam)
 
 		ipAddressModel := ipAddressModel("")
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), ipAddressModel, &netboxv1.IpAddress{})
 		AssertNil(t, err)
 		assert.NotNil(t, result, "expected result when update is performed")
 		assert.False(t, isUpToDate, "expected update to be performed")
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
-		assert.Equal(t, expectedIPAddress().Comments, result.Comments)
-		assert.Equal(t, expectedIPAddress().Description, result.Description)
-		assert.Equal(t, expectedIPAddress().Display, result.Display)
-		assert.Equal(t, expectedIPAddress().Address, result.Address)
-		assert.Equal(t, expectedIPAddress().Tenant.ID, result.Tenant.ID)
-		assert.Equal(t, expectedIPAddress().Tenant.Name, result.Tenant.Name)
-		assert.Equal(t, expectedIPAddress().Tenant.Slug, result.Tenant.Slug)
-		assert.Equal(t, expectedIPAddress().CustomFields, result.CustomFields)
-		assert.Equal(t, expectedIPAddress().Status.Label, result.Status.Label)
-		assert.Equal(t, expectedIPAddress().Status.Value, result.Status.Value)
-		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
+		assertUpdated(t, result)
 	})
 
 	t.Run("check with hash", func(t *testing.T) {
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{
-						ID:           expectedIPAddress().ID,
-						Address:      expectedIPAddress().Address,
-						Display:      expectedIPAddress().Display,
-						CustomFields: expectedIPAddress().CustomFields,
-						LastUpdated:  expectedIPAddress().LastUpdated,
-					}},
-			},
-		}
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(*expectedIPAddress().LastUpdated.Get(), expectedIPAddress().CustomFields))
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Any())
 
-		outputUpdate := &ipam.IpamIPAddressesUpdateOK{
-			Payload: expectedIPAddress(),
-		}
-
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
-		// use gomock.Any() because the input contains a pointer
-		mockIPAddress.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Return(outputUpdate, nil)
-
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPAddress,
-		}
-		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
-		}
+		compositeClient := newClient(mockIpam)
 
 		ipAddressModel := ipAddressModel(expectedHash)
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), ipAddressModel, &netboxv1.IpAddress{})
 		AssertNil(t, err)
 		assert.NotNil(t, result, "expected result when update is performed")
 		assert.False(t, isUpToDate, "expected update to be performed")
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
-		assert.Equal(t, expectedIPAddress().Comments, result.Comments)
-		assert.Equal(t, expectedIPAddress().Description, result.Description)
-		assert.Equal(t, expectedIPAddress().Display, result.Display)
-		assert.Equal(t, expectedIPAddress().Address, result.Address)
-		assert.Equal(t, expectedIPAddress().Tenant.ID, result.Tenant.ID)
-		assert.Equal(t, expectedIPAddress().Tenant.Name, result.Tenant.Name)
-		assert.Equal(t, expectedIPAddress().Tenant.Slug, result.Tenant.Slug)
-		assert.Equal(t, expectedIPAddress().CustomFields, result.CustomFields)
-		assert.Equal(t, expectedIPAddress().Status.Label, result.Status.Label)
-		assert.Equal(t, expectedIPAddress().Status.Value, result.Status.Value)
-		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
+		assertUpdated(t, result)
 	})
 
 	t.Run("skip update when LastUpdated matches and Condition is Ready and Generation matches (no hash)", func(t *testing.T) {
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{ID: expectedIPAddress().ID, Address: expectedIPAddress().Address, LastUpdated: expectedIPAddress().LastUpdated},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(*expectedIPAddress().LastUpdated.Get(), nil))
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		compositeClient := newClient(mockIpam)
 
-		lastUpdatedV1 := metav1.NewTime(time.Time(*expectedIPAddress().LastUpdated))
+		lastUpdatedV1 := metav1.NewTime(*expectedIPAddress().LastUpdated.Get())
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), &models.IPAddress{IpAddress: ipAddress}, &netboxv1.IpAddress{
 			Status: netboxv1.IpAddressStatus{
 				LastUpdated: lastUpdatedV1,
@@ -422,26 +308,17 @@ func TestIPAddress(t *testing.T) {
 		AssertNil(t, err)
 		assert.NotNil(t, result, "expected existing NetBox IP when LastUpdated matches and Condition is Ready and Generation matches")
 		assert.True(t, isUpToDate, "expected skip update when LastUpdated matches and Condition is Ready and Generation matches")
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
+		assert.Equal(t, expectedIPAddress().Id, result.Id)
 		assert.Equal(t, expectedIPAddress().Address, result.Address)
 		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
 	})
 
 	t.Run("update when Condition is not Ready (no hash)", func(t *testing.T) {
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{ID: expectedIPAddress().ID, Address: expectedIPAddress().Address, LastUpdated: expectedIPAddress().LastUpdated},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Return(
-			&ipam.IpamIPAddressesUpdateOK{Payload: expectedIPAddress()}, nil)
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(*expectedIPAddress().LastUpdated.Get(), nil))
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Any())
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		compositeClient := newClient(mockIpam)
 
 		lastUpdatedV1 := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), &models.IPAddress{IpAddress: ipAddress}, &netboxv1.IpAddress{
@@ -455,34 +332,17 @@ func TestIPAddress(t *testing.T) {
 		AssertNil(t, err)
 		assert.NotNil(t, result)
 		assert.False(t, isUpToDate)
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
-		assert.Equal(t, expectedIPAddress().Comments, result.Comments)
-		assert.Equal(t, expectedIPAddress().Description, result.Description)
-		assert.Equal(t, expectedIPAddress().Display, result.Display)
-		assert.Equal(t, expectedIPAddress().Address, result.Address)
-		assert.Equal(t, expectedIPAddress().CustomFields, result.CustomFields)
-		assert.Equal(t, expectedIPAddress().Status.Label, result.Status.Label)
-		assert.Equal(t, expectedIPAddress().Status.Value, result.Status.Value)
-		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
+		assertUpdated(t, result)
 	})
 
 	t.Run("update when LastUpdated differs (no hash)", func(t *testing.T) {
-		lastUpdatedV1 := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{ID: expectedIPAddress().ID, Address: expectedIPAddress().Address, LastUpdated: expectedIPAddress().LastUpdated},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Return(
-			&ipam.IpamIPAddressesUpdateOK{Payload: expectedIPAddress()}, nil)
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(*expectedIPAddress().LastUpdated.Get(), nil))
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Any())
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		compositeClient := newClient(mockIpam)
 
+		lastUpdatedV1 := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), &models.IPAddress{IpAddress: ipAddress}, &netboxv1.IpAddress{
 			Status: netboxv1.IpAddressStatus{
 				LastUpdated: lastUpdatedV1, // different from NetBox
@@ -494,38 +354,18 @@ func TestIPAddress(t *testing.T) {
 		AssertNil(t, err)
 		assert.NotNil(t, result, "expected update when LastUpdated differs")
 		assert.False(t, isUpToDate, "expected update when LastUpdated differs")
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
-		assert.Equal(t, expectedIPAddress().Comments, result.Comments)
-		assert.Equal(t, expectedIPAddress().Description, result.Description)
-		assert.Equal(t, expectedIPAddress().Display, result.Display)
-		assert.Equal(t, expectedIPAddress().Address, result.Address)
-		assert.Equal(t, expectedIPAddress().Tenant.ID, result.Tenant.ID)
-		assert.Equal(t, expectedIPAddress().Tenant.Name, result.Tenant.Name)
-		assert.Equal(t, expectedIPAddress().Tenant.Slug, result.Tenant.Slug)
-		assert.Equal(t, expectedIPAddress().CustomFields, result.CustomFields)
-		assert.Equal(t, expectedIPAddress().Status.Label, result.Status.Label)
-		assert.Equal(t, expectedIPAddress().Status.Value, result.Status.Value)
-		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
+		assertUpdated(t, result)
 	})
 
 	t.Run("update when Generation differs (no hash)", func(t *testing.T) {
-		lastUpdated := strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{ID: expectedIPAddress().ID, Address: expectedIPAddress().Address, LastUpdated: &lastUpdated},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Return(
-			&ipam.IpamIPAddressesUpdateOK{Payload: expectedIPAddress()}, nil)
+		lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(lastUpdated, nil))
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Any())
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		compositeClient := newClient(mockIpam)
 
-		lastUpdatedV1 := metav1.NewTime(time.Time(lastUpdated))
+		lastUpdatedV1 := metav1.NewTime(lastUpdated)
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), &models.IPAddress{IpAddress: ipAddress}, &netboxv1.IpAddress{
 			ObjectMeta: metav1.ObjectMeta{Generation: 2}, // Generation 2
 			Status: netboxv1.IpAddressStatus{
@@ -538,41 +378,17 @@ func TestIPAddress(t *testing.T) {
 		AssertNil(t, err)
 		assert.NotNil(t, result, "expected update when Generation differs")
 		assert.False(t, isUpToDate, "expected update when Generation differs")
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
-		assert.Equal(t, expectedIPAddress().Comments, result.Comments)
-		assert.Equal(t, expectedIPAddress().Description, result.Description)
-		assert.Equal(t, expectedIPAddress().Display, result.Display)
-		assert.Equal(t, expectedIPAddress().Address, result.Address)
-		assert.Equal(t, expectedIPAddress().Tenant.ID, result.Tenant.ID)
-		assert.Equal(t, expectedIPAddress().Tenant.Name, result.Tenant.Name)
-		assert.Equal(t, expectedIPAddress().Tenant.Slug, result.Tenant.Slug)
-		assert.Equal(t, expectedIPAddress().CustomFields, result.CustomFields)
-		assert.Equal(t, expectedIPAddress().Status.Label, result.Status.Label)
-		assert.Equal(t, expectedIPAddress().Status.Value, result.Status.Value)
-		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
+		assertUpdated(t, result)
 	})
 
 	t.Run("skip update when LastUpdated matches and Condition is Ready and Generation matches (with hash)", func(t *testing.T) {
-		lastUpdated := strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{
-						ID:           expectedIPAddress().ID,
-						Address:      expectedIPAddress().Address,
-						LastUpdated:  &lastUpdated,
-						CustomFields: expectedIPAddress().CustomFields, // contains expectedHash
-					},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
+		lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(lastUpdated, expectedIPAddress().CustomFields)) // contains expectedHash
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		compositeClient := newClient(mockIpam)
 
-		lastUpdatedV1 := metav1.NewTime(time.Time(lastUpdated))
+		lastUpdatedV1 := metav1.NewTime(lastUpdated)
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), ipAddressModel(expectedHash), &netboxv1.IpAddress{
 			Status: netboxv1.IpAddressStatus{
 				LastUpdated: lastUpdatedV1,
@@ -584,34 +400,20 @@ func TestIPAddress(t *testing.T) {
 		AssertNil(t, err)
 		assert.NotNil(t, result, "expected existing NetBox IP when LastUpdated matches and Condition is Ready and Generation matches (with hash)")
 		assert.True(t, isUpToDate, "expected skip update when LastUpdated matches and Condition is Ready and Generation matches (with hash)")
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
+		assert.Equal(t, expectedIPAddress().Id, result.Id)
 		assert.Equal(t, expectedIPAddress().Address, result.Address)
 		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
 	})
 
 	t.Run("update when Condition is not Ready (with hash)", func(t *testing.T) {
-		lastUpdated := strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{
-						ID:           expectedIPAddress().ID,
-						Address:      expectedIPAddress().Address,
-						LastUpdated:  &lastUpdated,
-						CustomFields: expectedIPAddress().CustomFields,
-					},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Return(
-			&ipam.IpamIPAddressesUpdateOK{Payload: expectedIPAddress()}, nil)
+		lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(lastUpdated, expectedIPAddress().CustomFields))
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Any())
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		compositeClient := newClient(mockIpam)
 
-		lastUpdatedV1 := metav1.NewTime(time.Time(lastUpdated))
+		lastUpdatedV1 := metav1.NewTime(lastUpdated)
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), ipAddressModel(expectedHash), &netboxv1.IpAddress{
 			Status: netboxv1.IpAddressStatus{
 				LastUpdated: lastUpdatedV1,
@@ -623,43 +425,18 @@ func TestIPAddress(t *testing.T) {
 		AssertNil(t, err)
 		assert.NotNil(t, result, "expected update when Condition is not Ready (with hash)")
 		assert.False(t, isUpToDate, "expected update when Condition is not Ready (with hash)")
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
-		assert.Equal(t, expectedIPAddress().Comments, result.Comments)
-		assert.Equal(t, expectedIPAddress().Description, result.Description)
-		assert.Equal(t, expectedIPAddress().Display, result.Display)
-		assert.Equal(t, expectedIPAddress().Address, result.Address)
-		assert.Equal(t, expectedIPAddress().Tenant.ID, result.Tenant.ID)
-		assert.Equal(t, expectedIPAddress().Tenant.Name, result.Tenant.Name)
-		assert.Equal(t, expectedIPAddress().Tenant.Slug, result.Tenant.Slug)
-		assert.Equal(t, expectedIPAddress().CustomFields, result.CustomFields)
-		assert.Equal(t, expectedIPAddress().Status.Label, result.Status.Label)
-		assert.Equal(t, expectedIPAddress().Status.Value, result.Status.Value)
-		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
+		assertUpdated(t, result)
 	})
 
 	t.Run("update when LastUpdated differs (with hash)", func(t *testing.T) {
-		lastUpdatedNetBox := strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		lastUpdatedNetBox := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 		lastUpdatedV1 := metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
 
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{
-						ID:           expectedIPAddress().ID,
-						Address:      expectedIPAddress().Address,
-						LastUpdated:  &lastUpdatedNetBox,
-						CustomFields: expectedIPAddress().CustomFields,
-					},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Return(
-			&ipam.IpamIPAddressesUpdateOK{Payload: expectedIPAddress()}, nil)
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(lastUpdatedNetBox, expectedIPAddress().CustomFields))
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Any())
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		compositeClient := newClient(mockIpam)
 
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), ipAddressModel(expectedHash), &netboxv1.IpAddress{
 			Status: netboxv1.IpAddressStatus{
@@ -672,44 +449,19 @@ func TestIPAddress(t *testing.T) {
 		AssertNil(t, err)
 		assert.NotNil(t, result, "expected update when LastUpdated differs")
 		assert.False(t, isUpToDate, "expected update when LastUpdated differs")
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
-		assert.Equal(t, expectedIPAddress().Comments, result.Comments)
-		assert.Equal(t, expectedIPAddress().Description, result.Description)
-		assert.Equal(t, expectedIPAddress().Display, result.Display)
-		assert.Equal(t, expectedIPAddress().Address, result.Address)
-		assert.Equal(t, expectedIPAddress().Tenant.ID, result.Tenant.ID)
-		assert.Equal(t, expectedIPAddress().Tenant.Name, result.Tenant.Name)
-		assert.Equal(t, expectedIPAddress().Tenant.Slug, result.Tenant.Slug)
-		assert.Equal(t, expectedIPAddress().CustomFields, result.CustomFields)
-		assert.Equal(t, expectedIPAddress().Status.Label, result.Status.Label)
-		assert.Equal(t, expectedIPAddress().Status.Value, result.Status.Value)
-		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
+		assertUpdated(t, result)
 	})
 
 	t.Run("update when Generation differs (with hash)", func(t *testing.T) {
-		lastUpdated := strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
-
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{
-						ID:           expectedIPAddress().ID,
-						Address:      expectedIPAddress().Address,
-						CustomFields: expectedIPAddress().CustomFields,
-						LastUpdated:  &lastUpdated,
-					},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
-		mockIPAddress.EXPECT().IpamIPAddressesUpdate(gomock.Any(), nil).Return(
-			&ipam.IpamIPAddressesUpdateOK{Payload: expectedIPAddress()}, nil)
+		lastUpdated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(lastUpdated, expectedIPAddress().CustomFields))
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Any())
 
-		lastUpdatedV1 := metav1.NewTime(time.Time(lastUpdated))
+		compositeClient := newClient(mockIpam)
+
+		lastUpdatedV1 := metav1.NewTime(lastUpdated)
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), ipAddressModel(expectedHash), &netboxv1.IpAddress{
 			ObjectMeta: metav1.ObjectMeta{Generation: 2}, // Generation 2
 			Status: netboxv1.IpAddressStatus{
@@ -722,43 +474,14 @@ func TestIPAddress(t *testing.T) {
 		AssertNil(t, err)
 		assert.NotNil(t, result, "expected update when LastUpdated differs")
 		assert.False(t, isUpToDate, "expected update when Generation differs (with hash)")
-		assert.Equal(t, expectedIPAddress().ID, result.ID)
-		assert.Equal(t, expectedIPAddress().Comments, result.Comments)
-		assert.Equal(t, expectedIPAddress().Description, result.Description)
-		assert.Equal(t, expectedIPAddress().Display, result.Display)
-		assert.Equal(t, expectedIPAddress().Address, result.Address)
-		assert.Equal(t, expectedIPAddress().Tenant.ID, result.Tenant.ID)
-		assert.Equal(t, expectedIPAddress().Tenant.Name, result.Tenant.Name)
-		assert.Equal(t, expectedIPAddress().Tenant.Slug, result.Tenant.Slug)
-		assert.Equal(t, expectedIPAddress().CustomFields, result.CustomFields)
-		assert.Equal(t, expectedIPAddress().Status.Label, result.Status.Label)
-		assert.Equal(t, expectedIPAddress().Status.Value, result.Status.Value)
-		assert.Equal(t, expectedIPAddress().LastUpdated, result.LastUpdated)
+		assertUpdated(t, result)
 	})
 
 	t.Run("Check ReserveOrUpdate with hash mismatch", func(t *testing.T) {
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{
-						ID:           expectedIPAddress().ID,
-						Address:      expectedIPAddress().Address,
-						Display:      expectedIPAddress().Display,
-						CustomFields: expectedIPAddress().CustomFields,
-						LastUpdated:  expectedIPAddress().LastUpdated,
-					}},
-			},
-		}
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(*expectedIPAddress().LastUpdated.Get(), expectedIPAddress().CustomFields))
 
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
-
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPAddress,
-		}
-		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
-		}
+		compositeClient := newClient(mockIpam)
 
 		expectedHash := "iwfohs7v82fe9w0"
 		ipAddressModel := ipAddressModel(expectedHash)
@@ -771,21 +494,13 @@ func TestIPAddress(t *testing.T) {
 	t.Run("skip update when NetBox LastUpdated has nanosecond precision and Condition is Ready (no hash)", func(t *testing.T) {
 		// NetBox returns a timestamp with nanoseconds; status stores the same second with zero nanoseconds.
 		// Truncate(time.Second) must be applied before comparison so they are treated as equal.
-		lastUpdatedNetBox := strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 123456789, time.UTC))
+		lastUpdatedNetBox := time.Date(2024, 1, 1, 0, 0, 0, 123456789, time.UTC)
 		lastUpdatedV1 := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
 
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{ID: expectedIPAddress().ID, Address: expectedIPAddress().Address, LastUpdated: &lastUpdatedNetBox},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(lastUpdatedNetBox, nil))
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		compositeClient := newClient(mockIpam)
 
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), &models.IPAddress{IpAddress: ipAddress}, &netboxv1.IpAddress{
 			Status: netboxv1.IpAddressStatus{
@@ -800,26 +515,13 @@ func TestIPAddress(t *testing.T) {
 
 	t.Run("skip update when NetBox LastUpdated has nanosecond precision and Condition is Ready (with hash)", func(t *testing.T) {
 		// Same scenario as above but via the restoration-hash code path.
-		lastUpdatedNetBox := strfmt.DateTime(time.Date(2024, 1, 1, 0, 0, 0, 123456789, time.UTC))
+		lastUpdatedNetBox := time.Date(2024, 1, 1, 0, 0, 0, 123456789, time.UTC)
 		lastUpdatedV1 := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
 
-		inputList := ipam.NewIpamIPAddressesListParams().WithAddress(&ipAddress)
-		outputList := &ipam.IpamIPAddressesListOK{
-			Payload: &ipam.IpamIPAddressesListOKBody{
-				Results: []*netboxModels.IPAddress{
-					{
-						ID:           expectedIPAddress().ID,
-						Address:      expectedIPAddress().Address,
-						LastUpdated:  &lastUpdatedNetBox,
-						CustomFields: expectedIPAddress().CustomFields, // contains expectedHash
-					},
-				},
-			},
-		}
-		mockIPAddress.EXPECT().IpamIPAddressesList(inputList, nil).Return(outputList, nil).AnyTimes()
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(lastUpdatedNetBox, expectedIPAddress().CustomFields)) // contains expectedHash
 
-		clientV3 := &NetboxClientV3{Ipam: mockIPAddress}
-		compositeClient := &NetboxCompositeClient{clientV3: clientV3}
+		compositeClient := newClient(mockIpam)
 
 		result, isUpToDate, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), ipAddressModel(expectedHash), &netboxv1.IpAddress{
 			Status: netboxv1.IpAddressStatus{
@@ -831,4 +533,33 @@ func TestIPAddress(t *testing.T) {
 		assert.NotNil(t, result, "expected existing NetBox IP when timestamps match at second precision (with hash)")
 		assert.True(t, isUpToDate, "expected skip update when NetBox timestamp has sub-second precision matching status at second precision (with hash)")
 	})
+
+	t.Run("clear DNS name which was set by the operator before", func(t *testing.T) {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(*expectedIPAddress().LastUpdated.Get(), nil))
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Cond(func(request v4client.WritableIPAddressRequest) bool {
+			dnsName, ok := request.GetDnsNameOk()
+			return ok && *dnsName == ""
+		}))
+
+		compositeClient := newClient(mockIpam)
+
+		model := ipAddressModel("")
+		model.ClearDnsName = true
+		_, _, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), model, &netboxv1.IpAddress{})
+		AssertNil(t, err)
+	})
+
+	t.Run("keep DNS name which was not set by the operator", func(t *testing.T) {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectList(mockIpam, listedIPAddress(*expectedIPAddress().LastUpdated.Get(), nil))
+		expectUpdate(mockIpam, expectedIPAddress().Id, gomock.Cond(func(request v4client.WritableIPAddressRequest) bool {
+			return !request.HasDnsName()
+		}))
+
+		compositeClient := newClient(mockIpam)
+
+		_, _, err := compositeClient.ReserveOrUpdateIpAddress(context.TODO(), ipAddressModel(""), &netboxv1.IpAddress{})
+		AssertNil(t, err)
+	})
 }
@@ -64,26 +64,51 @@ func (c *NetboxCompositeClient) ReserveOrUpdateIpRange(ctx context.Context, ipRa
 	}
 
 	desiredIpRange := v4client.NewWritableIPRangeRequest(ipRange.StartAddress, ipRange.EndAddress)
-	desiredIpRange.SetStatus("active")
+	status, err := v4client.NewPatchedWritableIPRangeRequestStatusFromValue(metadataStatus(ipRange.Metadata))
+	if err != nil {
+		return nil, false, err
+	}
+	desiredIpRange.SetStatus(*status)
 	desiredIpRange.SetMarkPopulated(true)
 
 	if ipRange.Metadata != nil {
 		desiredIpRange.SetComments(ipRange.Metadata.Comments + warningComment)
-		// Convert map[string]string to map[string]interface{}
-		customFields := make(map[string]interface{}, len(ipRange.Metadata.Custom))
-		for k, v := range ipRange.Metadata.Custom {
-			customFields[k] = v
+		customFields, err := c.customFieldsRequest(ctx, ipRange.Metadata.Custom)
+		if err != nil {
+			return nil, false, err
 		}
 		desiredIpRange.SetCustomFields(customFields)
 		desiredIpRange.SetDescription(ipRange.Metadata.Description)
 		if ipRange.Metadata.Tenant != "" {
-			tenantDetails, err := c.getTenantDetails(ipRange.Metadata.Tenant)
+			tenantDetails, err := c.getTenantDetails(ctx, ipRange.Metadata.Tenant)
 			if err != nil {
 				return nil, false, err
 			}
 			tenantId := int32(tenantDetails.Id)
 			desiredIpRange.SetTenant(v4client.Int32AsASNRangeRequestTenant(&tenantId))
 		}
+		if ipRange.Metadata.Vrf != "" {
+			vrfId, err := c.getVrfId(ctx, ipRange.Metadata.Vrf)
+			if err != nil {
+				return nil, false, err
+			}
+			desiredIpRange.SetVrf(v4client.Int32AsIPAddressRequestVrf(vrfId))
+		}
+		if ipRange.Metadata.Role != "" {
+			role, err := c.roleRequest(ctx, ipRange.Metadata.Role)
+			if err != nil {
+				return nil, false, err
+			}
+			desiredIpRange.SetRole(role)
+		}
+	}
+
+	if hasTags(ipRange.Metadata) {
+		tags, err := c.tagsRequest(ctx, ipRange.Metadata.Tags)
+		if err != nil {
+			return nil, false, err
+		}
+		desiredIpRange.SetTags(tags)
 	}
 
 	// create ip range since it doesn't exist
@@ -93,6 +118,9 @@ func (c *NetboxCompositeClient) ReserveOrUpdateIpRange(ctx context.Context, ipRa
 	}
 
 	ipRangeToUpdate := &responseIpRangeList.Results[0]
+	if desiredIpRange.HasTags() {
+		desiredIpRange.SetTags(withUnmanagedTags(desiredIpRange.Tags, ipRangeToUpdate.Tags, ipRange.Metadata.ManagedTags))
+	}
 
 	if !ipRangeToUpdate.LastUpdated.IsSet() {
 		return nil, false, fmt.Errorf("last updated field is not set in Netbox for ip range %s-%s", ipRange.StartAddress, ipRange.EndAddress)
@@ -133,9 +161,17 @@ func (c *NetboxCompositeClient) ReserveOrUpdateIpRange(ctx context.Context, ipRa
 }
 
 func (c *NetboxCompositeClient) getIpRange(ctx context.Context, ipRange *models.IpRange) (*v4client.PaginatedIPRangeList, error) {
+	vrfId, err := c.getVrfId(ctx, metadataVrf(ipRange.Metadata))
+	if err != nil {
+		return nil, err
+	}
+
 	req := c.clientV4.IpamAPI.IpamIpRangesList(ctx).
 		StartAddress([]string{ipRange.StartAddress}).
 		EndAddress([]string{ipRange.EndAddress})
+	if vrfId != nil {
+		req = req.VrfId([]*int32{vrfId})
+	}
 	resp, httpResp, err := req.Execute()
 
 	var body []byte
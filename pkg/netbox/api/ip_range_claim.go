@@ -23,51 +23,70 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"sort"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
+	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/netbox-community/netbox-operator/pkg/config"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
-	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 )
 
-func (c *NetboxCompositeClient) RestoreExistingIpRangeByHash(hash string) (*models.IpRange, error) {
-	customIpRangeSearch := newQueryFilterOperation(nil, []CustomFieldEntry{
+// RestoreExistingIpRangeByHash returns the ip range with the restoration hash, only the ip ranges in the
+// VRF are searched if a VRF is set. The VRF of the ip range is returned in its metadata.
+func (c *NetboxCompositeClient) RestoreExistingIpRangeByHash(ctx context.Context, hash string, vrf string) (*models.IpRange, error) {
+	vrfCtx, err := c.withVrfQueryFilter(ctx, vrf)
+	if err != nil {
+		return nil, err
+	}
+	customIpRangeSearch := withQueryFilter(vrfCtx, nil, []CustomFieldEntry{
 		{
 			key:   config.GetOperatorConfig().NetboxRestorationHashFieldName,
 			value: hash,
 		},
 	})
-	list, err := c.clientV3.Ipam.IpamIPRangesList(ipam.NewIpamIPRangesListParams(), nil, customIpRangeSearch)
+	results, err := listAllPages(func(limit int32, offset int32) (results []v4client.IPRange, next *string, err error) {
+		list, httpResp, execErr := c.clientV4.IpamAPI.IpamIpRangesList(customIpRangeSearch).Limit(limit).Offset(offset).Execute()
+		closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list ip ranges")
+		if closeFunc != nil {
+			defer func() { err = errors.Join(err, closeFunc()) }()
+		}
+		if handleErr != nil {
+			return nil, nil, handleErr
+		}
+		return list.Results, list.Next.Get(), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: find a better way?
-	if list.Payload.Count != nil && *list.Payload.Count == 0 {
+	if len(results) == 0 {
 		return nil, nil
 	}
 
 	// We should not have more than 1 result...
-	if len(list.Payload.Results) != 1 {
-		return nil, fmt.Errorf("incorrect number of restoration results, number of results: %v", len(list.Payload.Results))
+	if len(results) != 1 {
+		return nil, fmt.Errorf("incorrect number of restoration results, number of results: %v", len(results))
 	}
-	res := list.Payload.Results[0]
-	if res.StartAddress == nil || res.EndAddress == nil {
+	res := results[0]
+	if res.StartAddress == "" || res.EndAddress == "" {
 		return nil, errors.New("invalid IP range")
 	}
 
 	return &models.IpRange{
-		StartAddress: *res.StartAddress,
-		EndAddress:   *res.EndAddress,
-		Id:           res.ID,
-		Size:         int32(res.Size),
+		StartAddress: res.StartAddress,
+		EndAddress:   res.EndAddress,
+		Id:           int64(res.Id),
+		Size:         res.Size,
+		Metadata:     vrfMetadata(res.Vrf),
 	}, nil
 }
 
-// GetAvailableIpRangeByClaim searches an available IpRange in Netbox matching IpRangeClaim requirements
+// GetAvailableIpRangeByClaim searches an available IpRange in Netbox matching IpRangeClaim requirements,
+// the VRF of the parent prefix is returned in the metadata of the IpRange
 func (c *NetboxCompositeClient) GetAvailableIpRangeByClaim(ctx context.Context, ipRangeClaim *models.IpRangeClaim) (*models.IpRange, error) {
-	_, err := c.getTenantDetails(ipRangeClaim.Metadata.Tenant)
+	_, err := c.getTenantDetails(ctx, ipRangeClaim.Metadata.Tenant)
 	if err != nil {
 		return nil, err
 	}
@@ -82,16 +101,16 @@ func (c *NetboxCompositeClient) GetAvailableIpRangeByClaim(ctx context.Context,
 		return nil, err
 	}
 	if len(responseParentPrefix.Results) == 0 {
-		return nil, utils.NetboxNotFoundError("parent prefix")
+		return nil, parentPrefixNotFoundError()
 	}
 
 	parentPrefixId := responseParentPrefix.Results[0].Id
-	responseAvailableIPs, err := c.GetAvailableIpAddressesByParentPrefix(parentPrefixId)
+	responseAvailableIPs, err := c.GetAvailableIpAddressesByParentPrefix(ctx, parentPrefixId)
 	if err != nil {
 		return nil, err
 	}
 
-	startAddress, endAddress, err := searchAvailableIpRange(responseAvailableIPs, ipRangeClaim.Size, responseAvailableIPs.Payload[0].Family)
+	startAddress, endAddress, err := searchAvailableIpRange(responseAvailableIPs, ipRangeClaim.Size, int64(responseAvailableIPs[0].Family))
 	if err != nil {
 		return nil, err
 	}
@@ -99,20 +118,56 @@ func (c *NetboxCompositeClient) GetAvailableIpRangeByClaim(ctx context.Context,
 	return &models.IpRange{
 		StartAddress: startAddress,
 		EndAddress:   endAddress,
+		Metadata:     vrfMetadata(responseParentPrefix.Results[0].Vrf),
 	}, nil
 }
 
-func searchAvailableIpRange(availableIps *ipam.IpamPrefixesAvailableIpsListOK, requiredSize int, family int64) (string, string, error) {
+// GetAvailableIpRangeParentPrefixesBySelector returns all prefixes matching the parentPrefixSelector
+// in which an ip range of the requested size can be allocated, in the order returned by NetBox
+func (c *NetboxCompositeClient) GetAvailableIpRangeParentPrefixesBySelector(ctx context.Context, ipRangeClaimSpec *netboxv1.IpRangeClaimSpec) ([]*models.Prefix, error) {
+	parentPrefixes, err := c.listPrefixesByParentPrefixSelector(ctx, ipRangeClaimSpec.ParentPrefixSelector, ipRangeClaimSpec.Vrf)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make([]*models.Prefix, 0)
+	for _, prefix := range parentPrefixes {
+		if prefix.Prefix != "" {
+			// if we can find enough consecutive available ip addresses in it, we can take it as a parent prefix
+			_, errCandidate := c.GetAvailableIpRangeByClaim(ctx, &models.IpRangeClaim{
+				ParentPrefix: prefix.Prefix,
+				Size:         ipRangeClaimSpec.Size,
+				Metadata: &models.NetboxMetadata{
+					Tenant: ipRangeClaimSpec.Tenant,
+					Vrf:    ipRangeClaimSpec.Vrf,
+				},
+			})
+			if errCandidate != nil {
+				err = errors.Join(err, fmt.Errorf("prefix %s is not a valid parent prefix candidate, %w", prefix.Prefix, errCandidate))
+			} else {
+				prefixes = append(prefixes, &models.Prefix{Prefix: prefix.Prefix})
+			}
+		}
+	}
+
+	if len(prefixes) == 0 && err != nil {
+		return prefixes, err
+	}
+
+	return prefixes, nil
+}
+
+func searchAvailableIpRange(availableIps []v4client.AvailableIP, requiredSize int, family int64) (string, string, error) {
 	// this function receives a list of available IPs it chan have IPv4 or IPv6 IPs
 	// it will search for the first available range of IPs with the required size
 	// it will return the start and end IP of the range
 	var startAddress, endAddress string
 	consecutiveCount := 1
 
-	ips := make([]net.IP, len(availableIps.Payload))
+	ips := make([]net.IP, len(availableIps))
 	var err error
-	for i := 0; i < len(availableIps.Payload); i++ {
-		ips[i], _, err = net.ParseCIDR(availableIps.Payload[i].Address)
+	for i := 0; i < len(availableIps); i++ {
+		ips[i], _, err = net.ParseCIDR(availableIps[i].Address)
 		if err != nil {
 			return "", "", fmt.Errorf("failed to parse IP address: %w", err)
 		}
@@ -140,7 +195,7 @@ func searchAvailableIpRange(availableIps *ipam.IpamPrefixesAvailableIpsListOK, r
 	}
 
 	if consecutiveCount < requiredSize {
-		return "", "", errors.New("not enough consecutive IPs available")
+		return "", "", ErrNotEnoughConsecutiveIps
 	}
 
 	startAddress, err = SetIpAddressMask(startAddress, family)
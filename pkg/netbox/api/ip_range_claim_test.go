@@ -21,24 +21,19 @@ import (
 	"net/http"
 	"testing"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
 	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
 
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 )
 
 func TestIPRangeClaim(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockIPRange := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// test data for IPv4 ip range claim
 	parentPrefixId := int32(3)
@@ -55,31 +50,31 @@ func TestIPRangeClaim(t *testing.T) {
 	expectedIpDot7 := "10.112.140.7/32"
 
 	// example of available IPv4 ip address
-	availableIpAdressesIPv4 := func() []*netboxModels.AvailableIP {
-		return []*netboxModels.AvailableIP{
+	availableIpAdressesIPv4 := func() []v4client.AvailableIP {
+		return []v4client.AvailableIP{
 			{
 				Address: ipRangeV4_1,
-				Family:  int64(IPv4Family),
+				Family:  int32(IPv4Family),
 			},
 			{
 				Address: ipRangeV4_5,
-				Family:  int64(IPv4Family),
+				Family:  int32(IPv4Family),
 			},
 			{
 				Address: ipRangeV4_3,
-				Family:  int64(IPv4Family),
+				Family:  int32(IPv4Family),
 			},
 			{
 				Address: ipRangeV4_7,
-				Family:  int64(IPv4Family),
+				Family:  int32(IPv4Family),
 			},
 			{
 				Address: ipRangeV4_6,
-				Family:  int64(IPv4Family),
+				Family:  int32(IPv4Family),
 			},
 			{
 				Address: ipRangeV4_8,
-				Family:  int64(IPv4Family),
+				Family:  int32(IPv4Family),
 			},
 		}
 	}
@@ -90,59 +85,31 @@ func TestIPRangeClaim(t *testing.T) {
 	ipAddressV6_2 := "2001:db8:85a3:8d3::3/64"
 
 	//example of tenant
-	tenantId := int64(2)
 	tenantName := "Tenant1"
-	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
+	expectedTenants := []v4client.Tenant{{Id: 2, Name: tenantName, Slug: "tenant1"}}
+
+	// expectRestoreList sets up the list of the ip ranges by restoration hash
+	expectRestoreList := func(mockIpamAPI *mock_interfaces.MockIpamAPI, results []v4client.IPRange) {
+		mockListRequest := mock_interfaces.NewMockIpamIpRangesListRequest(ctrl)
+		mockIpamAPI.EXPECT().IpamIpRangesList(gomock.Any()).Return(mockListRequest)
+		mockListRequest.EXPECT().Limit(pageLimit()).Return(mockListRequest)
+		mockListRequest.EXPECT().Offset(int32(0)).Return(mockListRequest)
+		mockListRequest.EXPECT().Execute().Return(
+			&v4client.PaginatedIPRangeList{Count: int32(len(results)), Results: results},
+			&http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 	}
 
 	t.Run("Fetch first available IP range by claim (IPv4).", func(t *testing.T) {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-		inputIps := ipam.NewIpamPrefixesAvailableIpsListParams().WithID(int64(parentPrefixId))
-		outputIps := &ipam.IpamPrefixesAvailableIpsListOK{
-			Payload: availableIpAdressesIPv4(),
-		}
-
-		mockIpamAPI.EXPECT().
-			IpamPrefixesList(gomock.Any()).
-			Return(mockListRequest)
-
-		mockListRequest.EXPECT().
-			Prefix([]string{parentPrefixV4}).
-			Return(mockListRequest)
-
-		expectedPrefix := v4client.Prefix{
-			Id:     parentPrefixId,
-			Prefix: parentPrefixV4,
-		}
-
-		mockListRequest.EXPECT().
-			Execute().
-			Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{expectedPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
-
-		mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
-		mockIPRange.EXPECT().IpamPrefixesAvailableIpsList(inputIps, nil).Return(outputIps, nil)
+		expectPrefixList(ctrl, mockIpamAPI, parentPrefixV4, []v4client.Prefix{{Id: parentPrefixId, Prefix: parentPrefixV4}})
+		expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixId, availableIpAdressesIPv4())
 
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-			Ipam:    mockIPRange,
-		}
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancyAPI(ctrl, tenantName, expectedTenants, nil),
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
 			clientV4: clientV4,
 		}
 
@@ -164,52 +131,26 @@ func TestIPRangeClaim(t *testing.T) {
 	})
 
 	t.Run("Fail first available IP range by claim (IPv6) if not enough consequiteve ips.", func(t *testing.T) {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 
-		inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-		inputIps := ipam.NewIpamPrefixesAvailableIpsListParams().WithID(int64(parentPrefixId))
-		outputIps := &ipam.IpamPrefixesAvailableIpsListOK{
-			Payload: []*netboxModels.AvailableIP{
-				{
-					Address: ipAddressV6_1,
-					Family:  int64(IPv6Family),
-				},
-				{
-					Address: ipAddressV6_2,
-					Family:  int64(IPv6Family),
-				},
-			}}
-
-		mockIpamAPI.EXPECT().
-			IpamPrefixesList(gomock.Any()).
-			Return(mockListRequest)
-
-		mockListRequest.EXPECT().
-			Prefix([]string{parentPrefixV6}).
-			Return(mockListRequest)
-
-		expectedPrefix := v4client.Prefix{
-			Id:     parentPrefixId,
-			Prefix: parentPrefixV6,
-		}
-
-		mockListRequest.EXPECT().
-			Execute().
-			Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{expectedPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
-
-		mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
-		mockIPRange.EXPECT().IpamPrefixesAvailableIpsList(inputIps, nil).Return(outputIps, nil)
+		expectPrefixList(ctrl, mockIpamAPI, parentPrefixV6, []v4client.Prefix{{Id: parentPrefixId, Prefix: parentPrefixV6}})
+		expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixId, []v4client.AvailableIP{
+			{
+				Address: ipAddressV6_1,
+				Family:  int32(IPv6Family),
+			},
+			{
+				Address: ipAddressV6_2,
+				Family:  int32(IPv6Family),
+			},
+		})
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-			Ipam:    mockIPRange,
-		}
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancyAPI(ctrl, tenantName, expectedTenants, nil),
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
 			clientV4: clientV4,
 		}
 
@@ -228,13 +169,11 @@ func TestIPRangeClaim(t *testing.T) {
 	})
 
 	t.Run("Fail with invalid input when searching Available Ip Range", func(t *testing.T) {
-		payload := &ipam.IpamPrefixesAvailableIpsListOK{
-			Payload: []*netboxModels.AvailableIP{
-				{Address: "invalid ip address"},
-			},
+		availableIps := []v4client.AvailableIP{
+			{Address: "invalid ip address"},
 		}
 
-		startAddress, endAddress, err := searchAvailableIpRange(payload, 3, int64(4))
+		startAddress, endAddress, err := searchAvailableIpRange(availableIps, 3, int64(4))
 
 		assert.Equal(t, "", startAddress)
 		assert.Equal(t, "", endAddress)
@@ -242,37 +181,23 @@ func TestIPRangeClaim(t *testing.T) {
 	})
 
 	t.Run("Reclaim IP Range", func(t *testing.T) {
-
-		input := "dummy-hash"
-		output := &ipam.IpamIPRangesListOK{
-			Payload: &ipam.IpamIPRangesListOKBody{
-				Count:    nil,
-				Next:     nil,
-				Previous: nil,
-				Results: []*netboxModels.IPRange{
-					{
-						StartAddress: &expectedIpDot5,
-						EndAddress:   &expectedIpDot7,
-					},
-				},
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectRestoreList(mockIpamAPI, []v4client.IPRange{
+			{
+				StartAddress: expectedIpDot5,
+				EndAddress:   expectedIpDot7,
 			},
-		}
+		})
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPRange,
+		clientV4 := &NetboxClientV4{
+			IpamAPI: mockIpamAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
+			clientV4: clientV4,
 		}
 
-		// 3rd parameter should be the variable `customIpSearch` below but go cannot compare functions so this errors.
-		// Using ´gomock.Any()´ to allow this test to pass (shouldn't change the outcome of the tests anyway)
-		//https://github.com/golang/mock/issues/324
-
-		mockIPRange.EXPECT().IpamIPRangesList(ipam.NewIpamIPRangesListParams(), nil, gomock.Any()).Return(output, nil)
-
-		actual, err := compositeClient.RestoreExistingIpRangeByHash(input)
+		actual, err := compositeClient.RestoreExistingIpRangeByHash(context.TODO(), "dummy-hash", "")
 
 		assert.Nil(t, err)
 		assert.Equal(t, expectedIpDot5, actual.StartAddress)
@@ -280,73 +205,102 @@ func TestIPRangeClaim(t *testing.T) {
 	})
 
 	t.Run("Fail reclaim IP Range if multiple results returned", func(t *testing.T) {
-
-		input := "dummy-hash"
-		output := &ipam.IpamIPRangesListOK{
-			Payload: &ipam.IpamIPRangesListOKBody{
-				Count:    nil,
-				Next:     nil,
-				Previous: nil,
-				Results: []*netboxModels.IPRange{
-					{
-						StartAddress: &expectedIpDot5,
-						EndAddress:   &expectedIpDot7,
-					},
-					{
-						StartAddress: &expectedIpDot5,
-						EndAddress:   &expectedIpDot7,
-					},
-				},
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectRestoreList(mockIpamAPI, []v4client.IPRange{
+			{
+				StartAddress: expectedIpDot5,
+				EndAddress:   expectedIpDot7,
 			},
-		}
+			{
+				StartAddress: expectedIpDot5,
+				EndAddress:   expectedIpDot7,
+			},
+		})
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPRange,
+		clientV4 := &NetboxClientV4{
+			IpamAPI: mockIpamAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
+			clientV4: clientV4,
 		}
 
-		// 3rd parameter should be the variable `customIpSearch` below but go cannot compare functions so this errors.
-		// Using ´gomock.Any()´ to allow this test to pass (shouldn't change the outcome of the tests anyway)
-		//https://github.com/golang/mock/issues/324
-
-		mockIPRange.EXPECT().IpamIPRangesList(ipam.NewIpamIPRangesListParams(), nil, gomock.Any()).Return(output, nil)
-
-		_, err := compositeClient.RestoreExistingIpRangeByHash(input)
+		_, err := compositeClient.RestoreExistingIpRangeByHash(context.TODO(), "dummy-hash", "")
 
 		AssertError(t, err, "incorrect number of restoration results, number of results: 2")
 	})
 
 	t.Run("Fail if returned ip range is invalid", func(t *testing.T) {
-
-		input := "dummy-hash"
-		output := &ipam.IpamIPRangesListOK{
-			Payload: &ipam.IpamIPRangesListOKBody{
-				Count:    nil,
-				Next:     nil,
-				Previous: nil,
-				Results:  []*netboxModels.IPRange{{}},
-			},
-		}
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectRestoreList(mockIpamAPI, []v4client.IPRange{{}})
 
 		// init client
-		clientV3 := &NetboxClientV3{
-			Ipam: mockIPRange,
+		clientV4 := &NetboxClientV4{
+			IpamAPI: mockIpamAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: clientV3,
+			clientV4: clientV4,
 		}
 
-		// 3rd parameter should be the variable `customIpSearch` below but go cannot compare functions so this errors.
-		// Using ´gomock.Any()´ to allow this test to pass (shouldn't change the outcome of the tests anyway)
-		//https://github.com/golang/mock/issues/324
+		_, err := compositeClient.RestoreExistingIpRangeByHash(context.TODO(), "dummy-hash", "")
+
+		AssertError(t, err, "invalid IP range")
+	})
+}
+
+func TestIPRangeClaim_GetAvailableIpRangeParentPrefixesBySelector(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-		mockIPRange.EXPECT().IpamIPRangesList(ipam.NewIpamIPRangesListParams(), nil, gomock.Any()).Return(output, nil)
+	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 
-		_, err := compositeClient.RestoreExistingIpRangeByHash(input)
+	iprcSpec := netboxv1.IpRangeClaimSpec{
+		ParentPrefixSelector: map[string]string{
+			"family": "IPv4",
+		},
+		Size:   3,
+		Tenant: "tenant",
+	}
 
-		AssertError(t, err, "invalid IP range")
+	// tenant
+	tenantName := "tenant"
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, []v4client.Tenant{{Id: 2, Name: tenantName, Slug: "tenant1"}}, nil)
+
+	// the first prefix matching the selector only has non-consecutive available ip addresses,
+	// the second one can hold an ip range of the requested size
+	fragmentedParentPrefix := "10.112.140.0/24"
+	fragmentedParentPrefixId := int32(1)
+	parentPrefix := "10.112.141.0/24"
+	parentPrefixId := int32(2)
+
+	expectPrefixListBySelector(ctrl, mockIpamAPI, []v4client.Prefix{
+		{Id: fragmentedParentPrefixId, Prefix: fragmentedParentPrefix},
+		{Id: parentPrefixId, Prefix: parentPrefix},
+	})
+	expectPrefixList(ctrl, mockIpamAPI, fragmentedParentPrefix, []v4client.Prefix{{Id: fragmentedParentPrefixId, Prefix: fragmentedParentPrefix}})
+	expectPrefixList(ctrl, mockIpamAPI, parentPrefix, []v4client.Prefix{{Id: parentPrefixId, Prefix: parentPrefix}})
+	expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, fragmentedParentPrefixId, []v4client.AvailableIP{
+		{Address: "10.112.140.1/24", Family: int32(IPv4Family)},
+		{Address: "10.112.140.3/24", Family: int32(IPv4Family)},
+		{Address: "10.112.140.5/24", Family: int32(IPv4Family)},
 	})
+	expectAvailableIpsOfPrefix(ctrl, mockIpamAPI, parentPrefixId, []v4client.AvailableIP{
+		{Address: "10.112.141.1/24", Family: int32(IPv4Family)},
+		{Address: "10.112.141.2/24", Family: int32(IPv4Family)},
+		{Address: "10.112.141.3/24", Family: int32(IPv4Family)},
+	})
+
+	clientV4 := &NetboxClientV4{
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
+	}
+	compositeClient := &NetboxCompositeClient{
+		clientV4: clientV4,
+	}
+
+	actual, err := compositeClient.GetAvailableIpRangeParentPrefixesBySelector(context.TODO(), &iprcSpec)
+
+	assert.Nil(t, err)
+	assert.Len(t, actual, 1)
+	assert.Equal(t, parentPrefix, actual[0].Prefix)
 }
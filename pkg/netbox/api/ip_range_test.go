@@ -24,8 +24,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
 	v4client "github.com/netbox-community/go-netbox/v4"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
@@ -127,7 +125,7 @@ func TestIpRange(t *testing.T) {
 
 	t.Run("reserve new ip range", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-		mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, []v4client.Tenant{{Id: int32(tenantId), Name: tenantName, Slug: tenantName}}, nil)
 		mockCreateRequest := mock_interfaces.NewMockIpamIpRangesCreateRequest(ctrl)
 		mockListRequest := mock_interfaces.NewMockIpamIpRangesListRequest(ctrl)
 
@@ -174,29 +172,12 @@ func TestIpRange(t *testing.T) {
 
 		// Create client with mock
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancy,
 		}
 
-		tenancyListInput := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-		tenancyListOutput := &tenancy.TenancyTenantsListOK{
-			Payload: &tenancy.TenancyTenantsListOKBody{
-				Results: []*netboxModels.Tenant{
-					{
-						ID:   tenantId,
-						Name: &tenantName,
-						Slug: &tenantName,
-					},
-				},
-			},
-		}
-
-		mockTenancy.EXPECT().TenancyTenantsList(tenancyListInput, nil).Return(tenancyListOutput, nil).AnyTimes()
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-		}
 		compositeClient := &NetboxCompositeClient{
 			clientV4: clientV4,
-			clientV3: clientV3,
 		}
 
 		// Create request
@@ -230,7 +211,6 @@ func TestIpRange(t *testing.T) {
 
 	t.Run("restoration hash mismatch", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-		mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 		mockListRequest := mock_interfaces.NewMockIpamIpRangesListRequest(ctrl)
 
 		mockIpamAPI.EXPECT().
@@ -259,15 +239,11 @@ func TestIpRange(t *testing.T) {
 		endAddress := "10.0.0.10"
 
 		// Create client with mock
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-		}
 		clientV4 := &NetboxClientV4{
 			IpamAPI: mockIpamAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
 			clientV4: clientV4,
-			clientV3: clientV3,
 		}
 
 		// Create request
@@ -297,7 +273,6 @@ func TestIpRange(t *testing.T) {
 
 	t.Run("ip range overlap", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-		mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 		mockListRequest := mock_interfaces.NewMockIpamIpRangesListRequest(ctrl)
 		mockCreateRequest := mock_interfaces.NewMockIpamIpRangesCreateRequest(ctrl)
 
@@ -343,15 +318,11 @@ func TestIpRange(t *testing.T) {
 		endAddress := "10.0.0.10"
 
 		// Create client with mock
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-		}
 		clientV4 := &NetboxClientV4{
 			IpamAPI: mockIpamAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
 			clientV4: clientV4,
-			clientV3: clientV3,
 		}
 
 		// Create request
@@ -382,25 +353,10 @@ func TestIpRange(t *testing.T) {
 
 	t.Run("update existing ip range", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-		mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, []v4client.Tenant{{Id: int32(tenantId), Name: tenantName, Slug: tenantName}}, nil)
 		mockListRequest := mock_interfaces.NewMockIpamIpRangesListRequest(ctrl)
 		mockUpdateRequest := mock_interfaces.NewMockIpamIpRangesUpdateRequest(ctrl)
 
-		tenancyListInput := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-		tenancyListOutput := &tenancy.TenancyTenantsListOK{
-			Payload: &tenancy.TenancyTenantsListOKBody{
-				Results: []*netboxModels.Tenant{
-					{
-						ID:   tenantId,
-						Name: &tenantName,
-						Slug: &tenantName,
-					},
-				},
-			},
-		}
-
-		mockTenancy.EXPECT().TenancyTenantsList(tenancyListInput, nil).Return(tenancyListOutput, nil).AnyTimes()
-
 		ipRangeId := int32(1)
 
 		mockIpamAPI.EXPECT().
@@ -453,15 +409,12 @@ func TestIpRange(t *testing.T) {
 			Return(expectedResp, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
 		// Create client with mock
-		clientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-		}
 		clientV4 := &NetboxClientV4{
-			IpamAPI: mockIpamAPI,
+			IpamAPI:    mockIpamAPI,
+			TenancyAPI: mockTenancy,
 		}
 		compositeClient := &NetboxCompositeClient{
 			clientV4: clientV4,
-			clientV3: clientV3,
 		}
 
 		// Test
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// kinds of the objects in the lookup cache, used as label values of the lookup cache metric
+const (
+	tenantLookupKind          = "tenant"
+	siteLookupKind            = "site"
+	customFieldLookupKind     = "custom_field"
+	customFieldTypeLookupKind = "custom_field_type"
+	tagLookupKind             = "tag"
+	vrfLookupKind             = "vrf"
+	roleLookupKind            = "role"
+	vlanGroupLookupKind       = "vlan_group"
+)
+
+// relatedObjectNotFoundMessage is part of the response of NetBox to a write which references
+// an object by an id that does not exist, e.g. a tenant which was deleted and created again
+const relatedObjectNotFoundMessage = "Related object not found"
+
+// LookupCache caches the tenants, sites, VRFs, roles, VLAN groups, tags and custom field definitions looked up in NetBox by
+// name. Objects which were not found are cached for the negative TTL, other errors are not cached.
+type LookupCache struct {
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[lookupKey]lookupEntry
+}
+
+type lookupKey struct {
+	kind string
+	name string
+}
+
+type lookupEntry struct {
+	value   any
+	err     error
+	expires time.Time
+}
+
+// NewLookupCache returns an empty lookup cache
+func NewLookupCache(ttl time.Duration, negativeTTL time.Duration) *LookupCache {
+	return &LookupCache{
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+		entries:     map[lookupKey]lookupEntry{},
+	}
+}
+
+// NewLookupCacheFromConfig returns an empty lookup cache with the TTLs of the operator
+// configuration, it returns nil if the lookups are not cached
+func NewLookupCacheFromConfig() *LookupCache {
+	operatorConfig := config.GetOperatorConfig()
+	if operatorConfig.NetboxLookupCacheTTL == 0 && operatorConfig.NetboxLookupCacheNegativeTTL == 0 {
+		return nil
+	}
+	return NewLookupCache(operatorConfig.NetboxLookupCacheTTL, operatorConfig.NetboxLookupCacheNegativeTTL)
+}
+
+// Invalidate drops all cached objects
+func (lc *LookupCache) Invalidate() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	clear(lc.entries)
+}
+
+func (lc *LookupCache) get(key lookupKey) (lookupEntry, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	entry, ok := lc.entries[key]
+	if !ok || !time.Now().Before(entry.expires) {
+		return lookupEntry{}, false
+	}
+	return entry, true
+}
+
+func (lc *LookupCache) set(key lookupKey, value any, err error) {
+	ttl := lc.ttl
+	if err != nil {
+		ttl = lc.negativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.entries[key] = lookupEntry{value: value, err: err, expires: time.Now().Add(ttl)}
+}
+
+// cachedLookup returns the object of the given kind and name from the lookup cache, or looks
+// it up with fetch and caches the result. A nil lookup cache disables the caching.
+func cachedLookup[T any](lc *LookupCache, kind string, name string, fetch func() (T, error)) (T, error) {
+	if lc == nil {
+		return fetch()
+	}
+
+	key := lookupKey{kind: kind, name: name}
+	if entry, ok := lc.get(key); ok {
+		metrics.ObserveLookupCache(kind, true)
+		value, _ := entry.value.(T)
+		return value, entry.err
+	}
+	metrics.ObserveLookupCache(kind, false)
+
+	value, err := fetch()
+	switch {
+	case err == nil:
+		lc.set(key, value, nil)
+	case errors.Is(err, utils.ErrNotFound):
+		lc.set(key, value, err)
+	}
+	return value, err
+}
+
+// LookupCacheRoundTripper invalidates the lookup cache when NetBox rejects a write because
+// a referenced object does not exist, as the cache may hold the id of a deleted object.
+type LookupCacheRoundTripper struct {
+	Lookups   *LookupCache
+	Transport http.RoundTripper
+}
+
+func (lrt *LookupCacheRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := lrt.Transport.RoundTrip(req)
+	if err != nil || lrt.Lookups == nil || resp.StatusCode != http.StatusBadRequest {
+		return resp, err
+	}
+
+	if bytes.Contains(peekBody(resp), []byte(relatedObjectNotFoundMessage)) {
+		log.StandardLogger().Infof("netbox at host %s does not know an object referenced by a request, invalidating the lookup cache", req.URL.Host)
+		lrt.Lookups.Invalidate()
+	}
+	return resp, nil
+}
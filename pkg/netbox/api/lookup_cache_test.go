@@ -0,0 +1,196 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCachedLookup(t *testing.T) {
+	lookups := NewLookupCache(time.Hour, time.Hour)
+
+	fetches := 0
+	fetch := func() (*models.Tenant, error) {
+		fetches++
+		return &models.Tenant{Id: 1, Name: "tenant1", Slug: "tenant1"}, nil
+	}
+
+	for range 2 {
+		tenant, err := cachedLookup(lookups, tenantLookupKind, "tenant1", fetch)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), tenant.Id)
+	}
+	assert.Equal(t, 1, fetches)
+
+	// sites with the same name are cached separately
+	_, err := cachedLookup(lookups, siteLookupKind, "tenant1", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches)
+
+	lookups.Invalidate()
+	_, err = cachedLookup(lookups, tenantLookupKind, "tenant1", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, 3, fetches)
+}
+
+func TestCachedLookup_Errors(t *testing.T) {
+	lookups := NewLookupCache(time.Hour, time.Hour)
+
+	notFoundFetches := 0
+	notFound := func() (*models.Tenant, error) {
+		notFoundFetches++
+		return nil, utils.NetboxNotFoundError("tenant 'missing'")
+	}
+	for range 2 {
+		_, err := cachedLookup(lookups, tenantLookupKind, "missing", notFound)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+	}
+	assert.Equal(t, 1, notFoundFetches, "not found is cached")
+
+	failedFetches := 0
+	failed := func() (*models.Tenant, error) {
+		failedFetches++
+		return nil, errors.New("connection refused")
+	}
+	for range 2 {
+		_, err := cachedLookup(lookups, tenantLookupKind, "tenant1", failed)
+		assert.ErrorContains(t, err, "connection refused")
+	}
+	assert.Equal(t, 2, failedFetches, "other errors are not cached")
+}
+
+func TestCachedLookup_TTL(t *testing.T) {
+	fetches := 0
+	fetch := func() (*models.Tenant, error) {
+		fetches++
+		return nil, utils.NetboxNotFoundError("tenant 'missing'")
+	}
+
+	// a negative TTL of 0 disables the caching of objects which were not found
+	lookups := NewLookupCache(time.Hour, 0)
+	for range 2 {
+		_, _ = cachedLookup(lookups, tenantLookupKind, "missing", fetch)
+	}
+	assert.Equal(t, 2, fetches)
+
+	// a nil lookup cache disables the caching
+	for range 2 {
+		_, _ = cachedLookup(nil, tenantLookupKind, "missing", fetch)
+	}
+	assert.Equal(t, 4, fetches)
+
+	lookups = NewLookupCache(time.Hour, 20*time.Millisecond)
+	_, _ = cachedLookup(lookups, tenantLookupKind, "missing", fetch)
+	time.Sleep(30 * time.Millisecond)
+	_, _ = cachedLookup(lookups, tenantLookupKind, "missing", fetch)
+	assert.Equal(t, 6, fetches)
+}
+
+func TestTenancy_GetTenantDetails_Cached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockTenancy := mock_interfaces.NewMockTenancyAPI(ctrl)
+	mockListRequest := mock_interfaces.NewMockTenancyTenantsListRequest(ctrl)
+
+	tenant := "myTenant"
+	tenantSlug := "mytenant"
+	tenantListOutput := &v4client.PaginatedTenantList{
+		Count:   1,
+		Results: []v4client.Tenant{{Id: 1, Name: tenant, Slug: tenantSlug}},
+	}
+	// the tenant is listed once, the second lookup is served from the cache
+	mockTenancy.EXPECT().TenancyTenantsList(gomock.Any()).Return(mockListRequest).Times(1)
+	mockListRequest.EXPECT().Name([]string{tenant}).Return(mockListRequest).Times(1)
+	mockListRequest.EXPECT().Execute().Return(tenantListOutput, &http.Response{StatusCode: 200, Body: http.NoBody}, nil).Times(1)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{TenancyAPI: mockTenancy},
+		lookups:  NewLookupCache(time.Hour, time.Hour),
+	}
+
+	for range 2 {
+		actual, err := compositeClient.getTenantDetails(context.TODO(), tenant)
+		require.NoError(t, err)
+		assert.Equal(t, &models.Tenant{Id: 1, Name: tenant, Slug: tenantSlug}, actual)
+	}
+}
+
+func TestLookupCacheRoundTripper(t *testing.T) {
+	tests := []struct {
+		name            string
+		statusCode      int
+		body            string
+		wantInvalidated bool
+	}{
+		{
+			name:            "related object not found",
+			statusCode:      http.StatusBadRequest,
+			body:            `{"tenant": ["Related object not found using the provided numeric ID: 5"]}`,
+			wantInvalidated: true,
+		},
+		{
+			name:       "other bad request",
+			statusCode: http.StatusBadRequest,
+			body:       `{"prefix": ["Enter a valid IPv4 or IPv6 address."]}`,
+		},
+		{
+			name:       "success",
+			statusCode: http.StatusCreated,
+			body:       `{"id": 1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			lookups := NewLookupCache(time.Hour, time.Hour)
+			lookups.set(lookupKey{kind: tenantLookupKind, name: "tenant1"}, &models.Tenant{Id: 5}, nil)
+			httpClient := &http.Client{Transport: &LookupCacheRoundTripper{Lookups: lookups, Transport: http.DefaultTransport}}
+
+			resp, err := httpClient.Post(server.URL, "application/json", nil)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			// the body is still readable by the caller
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(body))
+
+			_, ok := lookups.get(lookupKey{kind: tenantLookupKind, name: "tenant1"})
+			assert.Equal(t, tt.wantInvalidated, !ok)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/netbox-community/netbox-operator/pkg/config"
+)
+
+// maxListPages limits the number of pages which are read from a single list, so that a
+// NetBox which keeps returning next links does not block a reconcile forever
+const maxListPages = 1000
+
+// pageLimit returns the NETBOX_PAGE_LIMIT, a limit of 0 requests the MAX_PAGE_SIZE configured in NetBox
+func pageLimit() int32 {
+	return int32(config.GetOperatorConfig().NetboxPageLimit)
+}
+
+// withPageLimit returns a context whose requests are limited to NETBOX_PAGE_LIMIT results. It is
+// used for the lists which the v4 client can't limit, e.g. the available ips.
+func withPageLimit(ctx context.Context) context.Context {
+	return withQueryParam(ctx, "limit", strconv.Itoa(config.GetOperatorConfig().NetboxPageLimit))
+}
+
+// listAllPages reads all pages of a paginated NetBox list. list is called with the limit and
+// offset of a page, it returns the results of the page and the link to the next page.
+// The first page is requested with the pageLimit, the following pages with the limit and
+// offset of the next links, until NetBox returns no next link.
+func listAllPages[T any](list func(limit int32, offset int32) ([]T, *string, error)) ([]T, error) {
+	results, next, err := list(pageLimit(), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for pages := 1; next != nil && *next != ""; pages++ {
+		if pages >= maxListPages {
+			return nil, fmt.Errorf("failed to list all results, netbox returned more than %d pages", maxListPages)
+		}
+
+		limit, offset, err := nextPage(*next)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []T
+		page, next, err = list(limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, page...)
+	}
+
+	return results, nil
+}
+
+// nextPage returns the limit and offset of the page of the next link of a NetBox list
+func nextPage(next string) (limit int32, offset int32, err error) {
+	nextUrl, err := url.Parse(next)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse next page link %q: %w", next, err)
+	}
+
+	query := nextUrl.Query()
+	if query.Get("limit") == "" || query.Get("offset") == "" {
+		return 0, 0, fmt.Errorf("next page link %q has no limit or offset", next)
+	}
+	parsedLimit, err := strconv.ParseInt(query.Get("limit"), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid limit in next page link %q: %w", next, err)
+	}
+	parsedOffset, err := strconv.ParseInt(query.Get("offset"), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid offset in next page link %q: %w", next, err)
+	}
+	return int32(parsedLimit), int32(parsedOffset), nil
+}
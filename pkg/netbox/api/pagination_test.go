@@ -0,0 +1,226 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPaginatedNetbox returns a NetBox which serves the prefixes as a list paginated by limit and
+// offset with next links, and records the query of each request
+func newPaginatedNetbox(t *testing.T, prefixes []string, queries *[]url.Values) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		*queries = append(*queries, query)
+
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		if limit == 0 {
+			limit = 2
+		}
+		end := min(offset+limit, len(prefixes))
+
+		results := make([]string, 0, end-offset)
+		for i, prefix := range prefixes[offset:end] {
+			results = append(results, fmt.Sprintf(`{"id": %[1]d, "url": "http://%[3]s/api/ipam/prefixes/%[1]d/", "display": %[2]q,
+				"family": {"value": 4, "label": "IPv4"}, "prefix": %[2]q, "children": 0, "_depth": 0}`, offset+i+1, prefix, r.Host))
+		}
+		next := "null"
+		if end < len(prefixes) {
+			nextQuery := r.URL.Query()
+			nextQuery.Set("limit", strconv.Itoa(limit))
+			nextQuery.Set("offset", strconv.Itoa(end))
+			next = fmt.Sprintf(`"http://%s%s?%s"`, r.Host, r.URL.Path, nextQuery.Encode())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"count": %d, "next": %s, "previous": null, "results": [%s]}`, len(prefixes), next, strings.Join(results, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newPaginationTestClient(t *testing.T, server *httptest.Server) *NetboxCompositeClient {
+	client, err := NewNetboxCompositeClientForConnection(&ConnectionConfig{
+		Host:   strings.TrimPrefix(server.URL, "http://"),
+		Tokens: NewTokenProvider("0123456789abcdef"),
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestRestoreExistingPrefixByHash_MultiplePages(t *testing.T) {
+	config.ResetForTesting()
+
+	var queries []url.Values
+	server := newPaginatedNetbox(t, []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/28", "10.0.4.0/24"}, &queries)
+	client := newPaginationTestClient(t, server)
+
+	actual, err := client.RestoreExistingPrefixByHash(context.TODO(), "myHash", "/28", "")
+	require.NoError(t, err)
+	assert.Equal(t, &models.Prefix{Prefix: "10.0.3.0/28"}, actual)
+
+	// the restoration hash filter is kept on every page
+	require.Len(t, queries, 3)
+	for _, query := range queries {
+		assert.Equal(t, "myHash", query.Get("cf_"+config.GetOperatorConfig().NetboxRestorationHashFieldName))
+	}
+	// NETBOX_PAGE_LIMIT defaults to 0, the page size is chosen by NetBox
+	assert.Equal(t, "0", queries[0].Get("limit"))
+	assert.Equal(t, "0", queries[0].Get("offset"))
+	assert.Equal(t, "2", queries[1].Get("offset"))
+	assert.Equal(t, "4", queries[2].Get("offset"))
+}
+
+func TestListPrefixesByParentPrefixSelector_MultiplePages(t *testing.T) {
+	t.Setenv("NETBOX_PAGE_LIMIT", "3")
+	config.ResetForTesting()
+	t.Cleanup(config.ResetForTesting)
+
+	var queries []url.Values
+	server := newPaginatedNetbox(t, []string{"10.0.0.0/24", "10.0.1.0/24", "10.0.2.0/24", "10.0.3.0/24", "10.0.4.0/24"}, &queries)
+	client := newPaginationTestClient(t, server)
+
+	actual, err := client.listPrefixesByParentPrefixSelector(context.TODO(), map[string]string{"family": "IPv4"}, "")
+	require.NoError(t, err)
+	require.Len(t, actual, 5)
+	assert.Equal(t, "10.0.4.0/24", actual[4].Prefix)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, "3", queries[0].Get("limit"))
+	assert.Equal(t, "0", queries[0].Get("offset"))
+	assert.Equal(t, "3", queries[1].Get("limit"))
+	assert.Equal(t, "3", queries[1].Get("offset"))
+	for _, query := range queries {
+		assert.Equal(t, "4", query.Get("family"))
+	}
+}
+
+func TestListPrefixesByParentPrefixSelector_VlanId(t *testing.T) {
+	config.ResetForTesting()
+
+	var queries []url.Values
+	server := newPaginatedNetbox(t, []string{"10.0.0.0/24"}, &queries)
+	client := newPaginationTestClient(t, server)
+
+	actual, err := client.listPrefixesByParentPrefixSelector(context.TODO(), map[string]string{"vlan_id": "12"}, "")
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+
+	// vlan_id is a built-in field of the prefixes, not a custom field
+	require.Len(t, queries, 1)
+	assert.Equal(t, "12", queries[0].Get("vlan_id"))
+	assert.False(t, queries[0].Has("cf_vlan_id"))
+
+	_, err = client.listPrefixesByParentPrefixSelector(context.TODO(), map[string]string{"vlan_id": "k8s-nodes"}, "")
+	assert.ErrorIs(t, err, ErrInvalidVlanId)
+	assert.Len(t, queries, 1)
+}
+
+func TestGetAvailableIpAddressesByParentPrefix_PageLimit(t *testing.T) {
+	t.Setenv("NETBOX_PAGE_LIMIT", "500")
+	config.ResetForTesting()
+	t.Cleanup(config.ResetForTesting)
+
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"family": 4, "address": "10.0.0.1/24"}]`))
+	}))
+	defer server.Close()
+	client := newPaginationTestClient(t, server)
+
+	actual, err := client.GetAvailableIpAddressesByParentPrefix(context.TODO(), 1)
+	require.NoError(t, err)
+	require.Len(t, actual, 1)
+	assert.Equal(t, "500", query.Get("limit"))
+}
+
+func TestListAllPages(t *testing.T) {
+	config.ResetForTesting()
+
+	next := func(offset int) *string {
+		link := fmt.Sprintf("http://netbox.example.com/api/ipam/prefixes/?limit=2&offset=%d", offset)
+		return &link
+	}
+
+	t.Run("follows the next links", func(t *testing.T) {
+		var offsets []int32
+		actual, err := listAllPages(func(limit int32, offset int32) ([]int, *string, error) {
+			offsets = append(offsets, offset)
+			if len(offsets) < 3 {
+				return []int{len(offsets)}, next(len(offsets) * 2), nil
+			}
+			return []int{len(offsets)}, nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, actual)
+		assert.Equal(t, []int32{0, 2, 4}, offsets)
+	})
+
+	t.Run("error of a page", func(t *testing.T) {
+		calls := 0
+		_, err := listAllPages(func(limit int32, offset int32) ([]int, *string, error) {
+			calls++
+			if calls == 2 {
+				return nil, nil, errors.New("connection reset")
+			}
+			return []int{calls}, next(2), nil
+		})
+		assert.ErrorContains(t, err, "connection reset")
+	})
+
+	t.Run("endless next links", func(t *testing.T) {
+		_, err := listAllPages(func(limit int32, offset int32) ([]int, *string, error) {
+			return []int{1}, next(2), nil
+		})
+		assert.ErrorContains(t, err, "more than 1000 pages")
+	})
+
+	t.Run("next link without offset", func(t *testing.T) {
+		link := "http://netbox.example.com/api/ipam/prefixes/"
+		_, err := listAllPages(func(limit int32, offset int32) ([]int, *string, error) {
+			return []int{1}, &link, nil
+		})
+		assert.ErrorContains(t, err, "has no limit or offset")
+	})
+}
+
+func TestNextPage(t *testing.T) {
+	// only the page is taken from the next link, the filters are set by the request
+	limit, offset, err := nextPage("http://netbox.example.com/api/ipam/prefixes/?cf_hash=abc&limit=50&offset=100")
+	require.NoError(t, err)
+	assert.Equal(t, int32(50), limit)
+	assert.Equal(t, int32(100), offset)
+
+	_, _, err = nextPage("http://netbox.example.com/api/ipam/prefixes/?limit=50&offset=abc")
+	assert.ErrorContains(t, err, "invalid offset in next page link")
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+)
+
+// listPrefixesByParentPrefixSelector returns all prefixes matching the parentPrefixSelector,
+// in the order returned by NetBox. Only the prefixes in the VRF are returned if a VRF is set.
+func (c *NetboxCompositeClient) listPrefixesByParentPrefixSelector(ctx context.Context, parentPrefixSelector map[string]string, vrf string) ([]v4client.Prefix, error) {
+	fieldEntries := make(map[string]string)
+
+	vrfId, err := c.getVrfId(ctx, vrf)
+	if err != nil {
+		return nil, err
+	}
+	if vrfId != nil {
+		fieldEntries["vrf_id"] = strconv.Itoa(int(*vrfId))
+	}
+
+	if tenant, ok := parentPrefixSelector["tenant"]; ok {
+		details, err := c.getTenantDetails(ctx, tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldEntries["tenant_id"] = strconv.Itoa(int(details.Id))
+	}
+
+	if site, ok := parentPrefixSelector["site"]; ok {
+		details, err := c.getSiteDetails(ctx, site)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldEntries["site_id"] = strconv.Itoa(int(details.Id))
+	}
+
+	if family, ok := parentPrefixSelector["family"]; ok {
+		switch family {
+		case "IPv4":
+			family = "4"
+		case "IPv6":
+			family = "6"
+		default:
+			return nil, ErrInvalidIpFamily
+		}
+		fieldEntries["family"] = family
+	}
+
+	if vlanId, ok := parentPrefixSelector["vlan_id"]; ok {
+		// the vlan_id filter of the prefixes takes the id of the VLAN in NetBox, not its VID
+		if _, err := strconv.ParseInt(vlanId, 10, 32); err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidVlanId, vlanId)
+		}
+		fieldEntries["vlan_id"] = vlanId
+	}
+
+	parentPrefixSelectorCustomFields := make([]CustomFieldEntry, 0, len(parentPrefixSelector))
+	for k, v := range parentPrefixSelector {
+		switch k {
+		case "tenant", "site", "family", "vlan_id":
+			// skip built in fields
+		default:
+			parentPrefixSelectorCustomFields = append(parentPrefixSelectorCustomFields, CustomFieldEntry{
+				key:   k,
+				value: v,
+			})
+		}
+	}
+
+	err = c.customFieldsExistsOrErr(ctx, parentPrefixSelectorCustomFields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent prefix selector, %w", err)
+	}
+
+	conditions := withQueryFilter(ctx, fieldEntries, parentPrefixSelectorCustomFields)
+
+	results, err := c.listPrefixes(conditions)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, errors.New("no parent prefixes found for this selector")
+	}
+
+	return results, nil
+}
+
+// listPrefixes returns the prefixes matching the query filters of the context from all pages of the prefix list
+func (c *NetboxCompositeClient) listPrefixes(ctx context.Context) ([]v4client.Prefix, error) {
+	return listAllPages(func(limit int32, offset int32) (results []v4client.Prefix, next *string, err error) {
+		list, httpResp, execErr := c.clientV4.IpamAPI.IpamPrefixesList(ctx).Limit(limit).Offset(offset).Execute()
+		closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list prefixes")
+		if closeFunc != nil {
+			defer func() { err = errors.Join(err, closeFunc()) }()
+		}
+		if handleErr != nil {
+			return nil, nil, handleErr
+		}
+		return list.Results, list.Next.Get(), nil
+	})
+}
@@ -63,7 +63,7 @@ func (c *NetboxCompositeClient) ReserveOrUpdatePrefix(ctx context.Context, prefi
 				}
 
 				//update prefix since it does exist and the restoration hash matches
-				resp, err := c.updatePrefix(ctx, prefixToUpdate.Id, prefix)
+				resp, err := c.updatePrefix(ctx, prefixToUpdate, prefix)
 				if err != nil {
 					return nil, false, err
 				}
@@ -79,7 +79,7 @@ func (c *NetboxCompositeClient) ReserveOrUpdatePrefix(ctx context.Context, prefi
 	}
 
 	//update prefix since it does exist
-	resp, err = c.updatePrefix(ctx, prefixToUpdate.Id, prefix)
+	resp, err = c.updatePrefix(ctx, prefixToUpdate, prefix)
 	if err != nil {
 		return nil, false, err
 	}
@@ -87,8 +87,16 @@ func (c *NetboxCompositeClient) ReserveOrUpdatePrefix(ctx context.Context, prefi
 }
 
 func (c *NetboxCompositeClient) getPrefix(ctx context.Context, prefix *models.Prefix) (*v4client.PaginatedPrefixList, error) {
+	vrfId, err := c.getVrfId(ctx, metadataVrf(prefix.Metadata))
+	if err != nil {
+		return nil, err
+	}
+
 	req := c.clientV4.IpamAPI.IpamPrefixesList(ctx).
 		Prefix([]string{prefix.Prefix})
+	if vrfId != nil {
+		req = req.VrfId([]*int32{vrfId})
+	}
 	resp, httpResp, err := req.Execute()
 
 	var body []byte
@@ -126,47 +134,43 @@ func (c *NetboxCompositeClient) createPrefix(ctx context.Context, prefix *models
 	}
 
 	if isLegacy {
-		desiredPrefix, err := c.buildWritablePrefixRequestV3(prefix)
+		desiredPrefix, err := c.writablePrefixRequestLegacy(ctx, prefix)
 		if err != nil {
 			return nil, err
 		}
 
-		return c.clientV3.createPrefixV3(desiredPrefix)
+		return c.clientV4.createPrefixV4(ctx, desiredPrefix)
 	}
 
-	desiredPrefix, err := c.writablePrefixRequestV4(prefix)
-	if err != nil {
-		return nil, err
-	}
-	status, err := v4client.NewPatchedWritablePrefixRequestStatusFromValue("active")
+	desiredPrefix, err := c.writablePrefixRequestV4(ctx, prefix)
 	if err != nil {
 		return nil, err
 	}
-	desiredPrefix.SetStatus(*status)
 	return c.clientV4.createPrefixV4(ctx, desiredPrefix)
 }
 
-func (c *NetboxCompositeClient) updatePrefix(ctx context.Context, prefixId int32, prefix *models.Prefix) (resp *v4client.Prefix, err error) {
+// updatePrefix updates the prefix in NetBox, the tags of the prefix which were not assigned
+// by the operator are kept
+func (c *NetboxCompositeClient) updatePrefix(ctx context.Context, prefixToUpdate *v4client.Prefix, prefix *models.Prefix) (resp *v4client.Prefix, err error) {
 	isLegacy, err := c.clientV4.isLegacyNetBox(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	var desiredPrefix *v4client.WritablePrefixRequest
 	if isLegacy {
-		desiredPrefix, err := c.buildWritablePrefixRequestV3(prefix)
-		if err != nil {
-			return nil, err
-		}
-
-		return c.clientV3.updatePrefixV3(int64(prefixId), desiredPrefix)
+		desiredPrefix, err = c.writablePrefixRequestLegacy(ctx, prefix)
+	} else {
+		desiredPrefix, err = c.writablePrefixRequestV4(ctx, prefix)
 	}
-
-	desiredPrefix, err := c.writablePrefixRequestV4(prefix)
 	if err != nil {
 		return nil, err
 	}
 
-	return c.clientV4.updatePrefixV4(ctx, prefixId, desiredPrefix)
+	if desiredPrefix.HasTags() {
+		desiredPrefix.SetTags(withUnmanagedTags(desiredPrefix.Tags, prefixToUpdate.Tags, prefix.Metadata.ManagedTags))
+	}
+	return c.clientV4.updatePrefixV4(ctx, prefixToUpdate.Id, desiredPrefix)
 }
 
 func (c *NetboxCompositeClient) DeletePrefix(ctx context.Context, prefixId int32) (err error) {
@@ -20,13 +20,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"sort"
 	"strconv"
 	"strings"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/extras"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
+	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/netbox-community/netbox-operator/pkg/config"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
 
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 )
@@ -36,29 +41,35 @@ var (
 	ErrNoPrefixMatchsSizeCriteria = errors.New("no available prefix matches size criteria")
 )
 
-func (c *NetboxCompositeClient) RestoreExistingPrefixByHash(hash string, requestedPrefixLength string) (*models.Prefix, error) {
-	customPrefixSearch := newQueryFilterOperation(nil, []CustomFieldEntry{
+// RestoreExistingPrefixByHash returns the prefix with the restoration hash and the requested prefix length,
+// only the prefixes in the VRF are searched if a VRF is set. The VRF of the prefix is returned in its metadata.
+func (c *NetboxCompositeClient) RestoreExistingPrefixByHash(ctx context.Context, hash string, requestedPrefixLength string, vrf string) (*models.Prefix, error) {
+	vrfCtx, err := c.withVrfQueryFilter(ctx, vrf)
+	if err != nil {
+		return nil, err
+	}
+	customPrefixSearch := withQueryFilter(vrfCtx, nil, []CustomFieldEntry{
 		{
 			key:   config.GetOperatorConfig().NetboxRestorationHashFieldName,
 			value: hash,
 		},
 	})
-	list, err := c.clientV3.Ipam.IpamPrefixesList(ipam.NewIpamPrefixesListParams(), nil, customPrefixSearch)
+	results, err := c.listPrefixes(customPrefixSearch)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: find a better way?
-	if list.Payload.Count != nil && *list.Payload.Count == 0 {
+	if len(results) == 0 {
 		return nil, nil
 	}
 
 	// Filter for exact prefix length
 	prefixesWithExactPrefixLength := make([]*models.Prefix, 0)
-	for _, prefix := range list.Payload.Results {
-		if strings.Contains(*prefix.Prefix, requestedPrefixLength) {
+	for _, prefix := range results {
+		if strings.Contains(prefix.Prefix, requestedPrefixLength) {
 			prefixesWithExactPrefixLength = append(prefixesWithExactPrefixLength, &models.Prefix{
-				Prefix: *prefix.Prefix,
+				Prefix:   prefix.Prefix,
+				Metadata: vrfMetadata(prefix.Vrf),
 			})
 		}
 	}
@@ -75,7 +86,8 @@ func (c *NetboxCompositeClient) RestoreExistingPrefixByHash(hash string, request
 	}
 
 	return &models.Prefix{
-		Prefix: res.Prefix,
+		Prefix:   res.Prefix,
+		Metadata: res.Metadata,
 	}, nil
 }
 
@@ -106,79 +118,22 @@ func validatePrefixLengthOrError(prefixClaim *models.PrefixClaim, prefixFamily i
 	return nil
 }
 
-func (c *NetboxCompositeClient) GetAvailablePrefixesByParentPrefixSelector(ctx context.Context, prefixClaimSpec *netboxv1.PrefixClaimSpec) ([]*models.Prefix, error) {
-	fieldEntries := make(map[string]string)
-
-	if tenant, ok := prefixClaimSpec.ParentPrefixSelector["tenant"]; ok {
-		details, err := c.getTenantDetails(tenant)
-		if err != nil {
-			return nil, err
-		}
-
-		fieldEntries["tenant_id"] = strconv.Itoa(int(details.Id))
-	}
-
-	if site, ok := prefixClaimSpec.ParentPrefixSelector["site"]; ok {
-		details, err := c.getSiteDetails(site)
-		if err != nil {
-			return nil, err
-		}
-
-		fieldEntries["site_id"] = strconv.Itoa(int(details.Id))
-	}
-
-	if family, ok := prefixClaimSpec.ParentPrefixSelector["family"]; ok {
-		switch family {
-		case "IPv4":
-			family = "4"
-		case "IPv6":
-			family = "6"
-		default:
-			return nil, ErrInvalidIpFamily
-		}
-		fieldEntries["family"] = family
-	}
-
-	parentPrefixSelectorCustomFields := make([]CustomFieldEntry, 0, len(prefixClaimSpec.ParentPrefixSelector))
-	for k, v := range prefixClaimSpec.ParentPrefixSelector {
-		switch k {
-		case "tenant", "site", "family":
-			// skip built in fields
-		default:
-			parentPrefixSelectorCustomFields = append(parentPrefixSelectorCustomFields, CustomFieldEntry{
-				key:   k,
-				value: v,
-			})
-		}
-	}
-
-	err := c.customFieldsExistsOrErr(parentPrefixSelectorCustomFields)
-	if err != nil {
-		return nil, fmt.Errorf("invalid parent prefix selector, %w", err)
-	}
-
-	conditions := newQueryFilterOperation(fieldEntries, parentPrefixSelectorCustomFields)
-
-	list, err := c.clientV3.Ipam.IpamPrefixesList(ipam.NewIpamPrefixesListParams(), nil, conditions)
+// GetAvailablePrefixesByParentPrefixSelector returns all prefixes matching the parentPrefixSelector
+// from which a prefix of the requested length can be allocated, in the order returned by NetBox
+func (c *NetboxCompositeClient) GetAvailablePrefixesByParentPrefixSelector(ctx context.Context, prefixClaimSpec *netboxv1.PrefixClaimSpec) ([]*models.ParentPrefixCandidate, error) {
+	parentPrefixes, err := c.listPrefixesByParentPrefixSelector(ctx, prefixClaimSpec.ParentPrefixSelector, prefixClaimSpec.Vrf)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: find a better way?
-	if list.Payload.Count != nil && *list.Payload.Count == 0 {
-		return nil, errors.New("no parent prefixes found for this selector")
-	}
-
-	prefixes := make([]*models.Prefix, 0)
-	for _, prefix := range list.Payload.Results {
-		if prefix.Prefix != nil {
-			errCandidate := c.isParentPrefixCandidate(ctx, prefixClaimSpec, *prefix.Prefix)
+	prefixes := make([]*models.ParentPrefixCandidate, 0)
+	for _, prefix := range parentPrefixes {
+		if prefix.Prefix != "" {
+			candidate, errCandidate := c.getParentPrefixCandidate(ctx, prefixClaimSpec, prefix.Prefix)
 			if errCandidate != nil {
-				err = errors.Join(err, fmt.Errorf("prefix %s is not a valid parent prefix candidate, %w", *prefix.Prefix, errCandidate))
+				err = errors.Join(err, fmt.Errorf("prefix %s is not a valid parent prefix candidate, %w", prefix.Prefix, errCandidate))
 			} else {
-				prefixes = append(prefixes, &models.Prefix{
-					Prefix: *prefix.Prefix,
-				})
+				prefixes = append(prefixes, candidate)
 			}
 		}
 	}
@@ -190,7 +145,7 @@ func (c *NetboxCompositeClient) GetAvailablePrefixesByParentPrefixSelector(ctx c
 	return prefixes, nil
 }
 
-func (c *NetboxCompositeClient) customFieldsExistsOrErr(customfieldFilterEntries []CustomFieldEntry) error {
+func (c *NetboxCompositeClient) customFieldsExistsOrErr(ctx context.Context, customfieldFilterEntries []CustomFieldEntry) error {
 	if len(customfieldFilterEntries) == 0 {
 		// as the parent prefix selector does not filter for custom fields
 		// the check can be skipped
@@ -198,21 +153,34 @@ func (c *NetboxCompositeClient) customFieldsExistsOrErr(customfieldFilterEntries
 	}
 
 	for _, entry := range customfieldFilterEntries {
-		existingCustomField, err := c.clientV3.Extras.ExtrasCustomFieldsList(extras.NewExtrasCustomFieldsListParams().WithName(&entry.key), nil)
+		_, err := cachedLookup(c.lookups, customFieldLookupKind, entry.key, func() (struct{}, error) {
+			return struct{}{}, c.customFieldExistsOrErr(ctx, entry.key)
+		})
+		if errors.Is(err, utils.ErrNotFound) {
+			return fmt.Errorf("custom field %s not found", entry.key)
+		}
 		if err != nil {
 			return fmt.Errorf("failed to validate customfield existence, err: %w", err)
 		}
-		if len(existingCustomField.Payload.Results) != 1 {
-			return fmt.Errorf("custom field %s not found", entry.key)
-		}
 	}
 
 	return nil
 }
 
-func (c *NetboxCompositeClient) isParentPrefixCandidate(ctx context.Context, prefixClaimSpec *netboxv1.PrefixClaimSpec, prefix string) error {
+func (c *NetboxCompositeClient) customFieldExistsOrErr(ctx context.Context, name string) error {
+	count, err := c.countCustomFields(ctx, name)
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return utils.NetboxNotFoundError("custom field '" + name + "'")
+	}
+	return nil
+}
+
+func (c *NetboxCompositeClient) getParentPrefixCandidate(ctx context.Context, prefixClaimSpec *netboxv1.PrefixClaimSpec, prefix string) (*models.ParentPrefixCandidate, error) {
 	// if we can allocate a prefix from it, we can take it as a parent prefix
-	if _, err := c.GetAvailablePrefixByClaim(
+	_, responseAvailablePrefixes, err := c.getAvailablePrefixByClaim(
 		ctx,
 		&models.PrefixClaim{
 			ParentPrefix: prefix,
@@ -220,46 +188,72 @@ func (c *NetboxCompositeClient) isParentPrefixCandidate(ctx context.Context, pre
 			Metadata: &models.NetboxMetadata{
 				Tenant: prefixClaimSpec.Tenant,
 				Site:   prefixClaimSpec.Site,
+				Vrf:    prefixClaimSpec.Vrf,
 			},
-		}); err != nil {
-		return err
+		})
+	if err != nil {
+		return nil, err
 	}
-	return nil
-}
 
-// GetAvailablePrefixByClaim searches an available Prefix in Netbox matching PrefixClaim requirements
-func (c *NetboxCompositeClient) GetAvailablePrefixByClaim(ctx context.Context, prefixClaim *models.PrefixClaim) (*models.Prefix, error) {
-	_, err := c.getTenantDetails(prefixClaim.Metadata.Tenant)
+	// the available prefixes were already fetched to check the candidate,
+	// so we reuse them to compute the data needed to rank the candidates
+	smallestFreeBlock, _, err := getSmallestMatchingPrefix(responseAvailablePrefixes, prefixClaimSpec.PrefixLength)
 	if err != nil {
 		return nil, err
 	}
 
-	// Don't assign an prefix if the requested site doesn't exist in netbox
-	if prefixClaim.Metadata.Site != "" {
-		_, err := c.getSiteDetails(prefixClaim.Metadata.Site)
-		if err != nil {
-			return nil, err
-		}
+	utilization, err := computePrefixUtilization(prefix, responseAvailablePrefixes)
+	if err != nil {
+		return nil, err
 	}
 
-	responseParentPrefix, err := c.getPrefix(
-		ctx,
-		&models.Prefix{
-			Prefix:   prefixClaim.ParentPrefix,
-			Metadata: prefixClaim.Metadata,
-		})
+	return &models.ParentPrefixCandidate{
+		Prefix:            prefix,
+		SmallestFreeBlock: smallestFreeBlock,
+		Utilization:       utilization,
+	}, nil
+}
+
+// computePrefixUtilization returns the share (0-1) of the address space of the parent prefix
+// which is not covered by the available prefixes returned by NetBox
+func computePrefixUtilization(parentPrefix string, availablePrefixes []v4client.AvailablePrefix) (float64, error) {
+	_, parentNet, err := net.ParseCIDR(parentPrefix)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	if len(responseParentPrefix.Results) == 0 {
-		return nil, ErrParentPrefixNotFound
+	parentOnes, parentBits := parentNet.Mask.Size()
+	parentSize := math.Ldexp(1, parentBits-parentOnes)
+
+	freeSize := float64(0)
+	for _, availablePrefix := range availablePrefixes {
+		_, availableNet, err := net.ParseCIDR(availablePrefix.Prefix)
+		if err != nil {
+			return 0, err
+		}
+		ones, bits := availableNet.Mask.Size()
+		freeSize += math.Ldexp(1, bits-ones)
 	}
 
-	if err := validatePrefixLengthOrError(prefixClaim, int64(*responseParentPrefix.Results[0].Family.Value)); err != nil {
+	return math.Max(0, 1-freeSize/parentSize), nil
+}
+
+// GetAvailablePrefixByClaim searches an available Prefix in Netbox matching PrefixClaim requirements,
+// the VRF of the parent prefix is returned in the metadata of the Prefix
+func (c *NetboxCompositeClient) GetAvailablePrefixByClaim(ctx context.Context, prefixClaim *models.PrefixClaim) (*models.Prefix, error) {
+	prefix, _, err := c.getAvailablePrefixByClaim(ctx, prefixClaim)
+	if err != nil {
 		return nil, err
 	}
+	return prefix, nil
+}
 
-	parentPrefixId := responseParentPrefix.Results[0].Id
+// getAvailablePrefixByClaim works like GetAvailablePrefixByClaim, but additionally returns the
+// available prefixes of the parent prefix the result was computed from
+func (c *NetboxCompositeClient) getAvailablePrefixByClaim(ctx context.Context, prefixClaim *models.PrefixClaim) (*models.Prefix, []v4client.AvailablePrefix, error) {
+	parentPrefix, err := c.getPrefixClaimParentPrefix(ctx, prefixClaim)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	/* Notes regarding the available prefix returned by netbox
 
@@ -272,27 +266,23 @@ func (c *NetboxCompositeClient) GetAvailablePrefixByClaim(ctx context.Context, p
 	*/
 
 	// step 1: we get available prefixes of the parent prefix from NetBox
-	responseAvailablePrefixes, err := c.GetAvailablePrefixesByParentPrefix(parentPrefixId)
+	responseAvailablePrefixes, err := c.getAvailablePrefixesOfParentPrefix(ctx, parentPrefix.Id, prefixClaim.ParentPrefix)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// step 2: we get the prefix that has the prefix size >= the requested size
 	matchingPrefix, IsMatchingPrefixSizeAsDesired, err := getSmallestMatchingPrefix(responseAvailablePrefixes, prefixClaim.PrefixLength)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if !IsMatchingPrefixSizeAsDesired {
 		// step 3-1: if the matchingPrefix size != the requested size, we will take the smallest matching prefix a.b.c.d/x, and modify it into a.b.c.d/target_size
 		// Do NOT call CreateAvailablePrefixesByParentPrefix, as netbox uses a first-fit algorithm, where we would like to use the best-fit algorithm.
-		// Also, we are using netbox client v3.4.5, which requires a hack for the call to work [1]. The hack was implemented here [2].
-		// Reference:
-		// [1] https://github.com/netbox-community/go-netbox/issues/131
-		// [2] https://github.com/netbox-community/go-netbox/v3/commits/hack/v3.4.5-0/
 		matchingPrefixSplit := strings.Split(matchingPrefix, "/")
 		if len(matchingPrefixSplit) != 2 {
-			return nil, ErrWrongMatchingPrefixSubnetFormat
+			return nil, nil, ErrWrongMatchingPrefixSubnetFormat
 		}
 		matchingPrefix = matchingPrefixSplit[0] + prefixClaim.PrefixLength
 	} // else {
@@ -300,23 +290,219 @@ func (c *NetboxCompositeClient) GetAvailablePrefixByClaim(ctx context.Context, p
 	// }
 
 	return &models.Prefix{
-		Prefix: matchingPrefix,
-	}, nil
+		Prefix:   matchingPrefix,
+		Metadata: vrfMetadata(parentPrefix.Vrf),
+	}, responseAvailablePrefixes, nil
+}
+
+// GetPreferredPrefixByClaim returns the preferredPrefix if it is available in the parent prefix
+// of the PrefixClaim, and ErrPreferredPrefixNotAvailable otherwise
+func (c *NetboxCompositeClient) GetPreferredPrefixByClaim(ctx context.Context, prefixClaim *models.PrefixClaim, preferredPrefix string) (*models.Prefix, error) {
+	preferred, err := netip.ParsePrefix(preferredPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid preferred prefix %s: %w", preferredPrefix, err)
+	}
+	if preferred != preferred.Masked() {
+		return nil, fmt.Errorf("invalid preferred prefix %s: host bits are set, did you mean %s", preferredPrefix, preferred.Masked())
+	}
+	if "/"+strconv.Itoa(preferred.Bits()) != prefixClaim.PrefixLength {
+		return nil, fmt.Errorf("the length of the preferred prefix %s does not match the prefix length %s", preferredPrefix, prefixClaim.PrefixLength)
+	}
+
+	parentPrefix, err := c.getPrefixClaimParentPrefix(ctx, prefixClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	responseAvailablePrefixes, err := c.getAvailablePrefixesOfParentPrefix(ctx, parentPrefix.Id, prefixClaim.ParentPrefix)
+	if err != nil {
+		if errors.Is(err, ErrParentPrefixExhausted) {
+			return nil, fmt.Errorf("%w: %s in parent prefix %s (parent prefix exhausted)", ErrPreferredPrefixNotAvailable, preferredPrefix, prefixClaim.ParentPrefix)
+		}
+		return nil, err
+	}
+
+	// the preferred prefix is available if it fits entirely into one of the available prefixes
+	for _, availablePrefix := range responseAvailablePrefixes {
+		available, err := netip.ParsePrefix(availablePrefix.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		if available.Bits() <= preferred.Bits() && available.Contains(preferred.Addr()) {
+			return &models.Prefix{
+				Prefix:   preferred.String(),
+				Metadata: vrfMetadata(parentPrefix.Vrf),
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s in parent prefix %s", ErrPreferredPrefixNotAvailable, preferredPrefix, prefixClaim.ParentPrefix)
 }
 
-func (c *NetboxCompositeClient) GetAvailablePrefixesByParentPrefix(parentPrefixId int32) (*ipam.IpamPrefixesAvailablePrefixesListOK, error) {
-	requestAvailablePrefixes := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
-	responseAvailablePrefixes, err := c.clientV3.Ipam.IpamPrefixesAvailablePrefixesList(requestAvailablePrefixes, nil)
+// GetAvailablePrefixesByClaim returns count distinct available Prefixes in the parent prefix of the PrefixClaim,
+// skipping the excludedPrefixes which are already assigned but not yet reserved in NetBox. As for a single
+// Prefix, the smallest available prefixes which can hold the requested prefix length are used first.
+func (c *NetboxCompositeClient) GetAvailablePrefixesByClaim(ctx context.Context, prefixClaim *models.PrefixClaim, count int, excludedPrefixes []string) ([]*models.Prefix, error) {
+	prefixLength, err := strconv.Atoi(strings.TrimPrefix(prefixClaim.PrefixLength, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix length %s: %w", prefixClaim.PrefixLength, err)
+	}
+
+	excluded := make([]netip.Prefix, 0, len(excludedPrefixes))
+	for _, excludedPrefix := range excludedPrefixes {
+		prefix, err := netip.ParsePrefix(excludedPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid excluded prefix %s: %w", excludedPrefix, err)
+		}
+		excluded = append(excluded, prefix.Masked())
+	}
+
+	parentPrefix, err := c.getPrefixClaimParentPrefix(ctx, prefixClaim)
+	if err != nil {
+		return nil, err
+	}
+
+	responseAvailablePrefixes, err := c.getAvailablePrefixesOfParentPrefix(ctx, parentPrefix.Id, prefixClaim.ParentPrefix)
 	if err != nil {
 		return nil, err
 	}
-	if len(responseAvailablePrefixes.Payload) == 0 {
+
+	availablePrefixes := make([]netip.Prefix, 0, len(responseAvailablePrefixes))
+	for _, availablePrefix := range responseAvailablePrefixes {
+		available, err := netip.ParsePrefix(availablePrefix.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		if available.Bits() <= prefixLength {
+			availablePrefixes = append(availablePrefixes, available.Masked())
+		}
+	}
+	// best fit: the smallest available prefixes first
+	sort.SliceStable(availablePrefixes, func(i, j int) bool {
+		return availablePrefixes[i].Bits() > availablePrefixes[j].Bits()
+	})
+
+	prefixes := make([]*models.Prefix, 0, count)
+	for _, available := range availablePrefixes {
+		// walk through the prefixes of the requested length within the available prefix
+		for candidate := netip.PrefixFrom(available.Addr(), prefixLength); len(prefixes) < count && available.Contains(candidate.Addr()); {
+			if !overlapsAny(candidate, excluded) {
+				prefixes = append(prefixes, &models.Prefix{
+					Prefix:   candidate.String(),
+					Metadata: vrfMetadata(parentPrefix.Vrf),
+				})
+			}
+
+			next := lastAddr(candidate).Next()
+			if !next.IsValid() {
+				break
+			}
+			candidate = netip.PrefixFrom(next, prefixLength)
+		}
+	}
+
+	if len(prefixes) < count {
+		return nil, fmt.Errorf("%w, only %d of %d prefixes are available", ErrParentPrefixExhausted, len(prefixes), count)
+	}
+
+	return prefixes, nil
+}
+
+// overlapsAny returns true if the prefix overlaps with one of the prefixes
+func overlapsAny(prefix netip.Prefix, prefixes []netip.Prefix) bool {
+	for _, p := range prefixes {
+		if prefix.Overlaps(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastAddr returns the last address of the prefix
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(addr)*8; i++ {
+		addr[i/8] |= 1 << (7 - i%8)
+	}
+	last, _ := netip.AddrFromSlice(addr)
+	return last
+}
+
+// getPrefixClaimParentPrefix returns the parent prefix of the PrefixClaim in NetBox, after
+// verifying that tenant and site exist and that the prefix length fits the parent prefix
+func (c *NetboxCompositeClient) getPrefixClaimParentPrefix(ctx context.Context, prefixClaim *models.PrefixClaim) (*v4client.Prefix, error) {
+	_, err := c.getTenantDetails(ctx, prefixClaim.Metadata.Tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't assign an prefix if the requested site doesn't exist in netbox
+	if prefixClaim.Metadata.Site != "" {
+		_, err := c.getSiteDetails(ctx, prefixClaim.Metadata.Site)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	responseParentPrefix, err := c.getPrefix(
+		ctx,
+		&models.Prefix{
+			Prefix:   prefixClaim.ParentPrefix,
+			Metadata: prefixClaim.Metadata,
+		})
+	if err != nil {
+		return nil, err
+	}
+	if len(responseParentPrefix.Results) == 0 {
+		return nil, ErrParentPrefixNotFound
+	}
+
+	if err := validatePrefixLengthOrError(prefixClaim, int64(*responseParentPrefix.Results[0].Family.Value)); err != nil {
+		return nil, err
+	}
+
+	return &responseParentPrefix.Results[0], nil
+}
+
+// getAvailablePrefixesOfParentPrefix works like GetAvailablePrefixesByParentPrefix and additionally
+// records the capacity of the parent prefix observed from its available prefixes
+func (c *NetboxCompositeClient) getAvailablePrefixesOfParentPrefix(ctx context.Context, parentPrefixId int32, parentPrefix string) ([]v4client.AvailablePrefix, error) {
+	responseAvailablePrefixes, err := c.GetAvailablePrefixesByParentPrefix(ctx, parentPrefixId)
+	if err != nil {
+		if errors.Is(err, ErrParentPrefixExhausted) {
+			recordParentPrefixCapacity(parentPrefix, nil)
+		}
+		return nil, err
+	}
+	recordParentPrefixCapacity(parentPrefix, responseAvailablePrefixes)
+	return responseAvailablePrefixes, nil
+}
+
+func (c *NetboxCompositeClient) GetAvailablePrefixesByParentPrefix(ctx context.Context, parentPrefixId int32) ([]v4client.AvailablePrefix, error) {
+	responseAvailablePrefixes, err := c.listAvailablePrefixes(ctx, parentPrefixId)
+	if err != nil {
+		return nil, err
+	}
+	if len(responseAvailablePrefixes) == 0 {
 		return nil, ErrParentPrefixExhausted
 	}
 	return responseAvailablePrefixes, nil
 }
 
-func getSmallestMatchingPrefix(prefixList *ipam.IpamPrefixesAvailablePrefixesListOK, prefixClaimLengthString string) (string, bool, error) {
+// listAvailablePrefixes returns the available prefixes of the prefix
+func (c *NetboxCompositeClient) listAvailablePrefixes(ctx context.Context, prefixId int32) (availablePrefixes []v4client.AvailablePrefix, err error) {
+	availablePrefixes, httpResp, execErr := c.clientV4.IpamAPI.IpamPrefixesAvailablePrefixesList(ctx, prefixId).Execute()
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list available prefixes")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+	return availablePrefixes, nil
+}
+
+func getSmallestMatchingPrefix(prefixList []v4client.AvailablePrefix, prefixClaimLengthString string) (string, bool, error) {
 	// input validation
 	if len(prefixClaimLengthString) == 0 {
 		return "", false, errors.New("invalid prefixClaimLengthString: empty string")
@@ -333,7 +519,7 @@ func getSmallestMatchingPrefix(prefixList *ipam.IpamPrefixesAvailablePrefixesLis
 
 	candidateIdx := -1
 	candidatePrefixSize := 0
-	for i, prefix := range prefixList.Payload {
+	for i, prefix := range prefixList {
 		_, sizeString, found := strings.Cut(prefix.Prefix, "/")
 		if !found {
 			// TODO: log error
@@ -357,5 +543,5 @@ func getSmallestMatchingPrefix(prefixList *ipam.IpamPrefixesAvailablePrefixesLis
 		return "", false, fmt.Errorf("%w", ErrNoPrefixMatchsSizeCriteria)
 	}
 
-	return prefixList.Payload[candidateIdx].Prefix, false, nil
+	return prefixList[candidateIdx].Prefix, false, nil
 }
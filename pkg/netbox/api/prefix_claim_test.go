@@ -23,11 +23,6 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/dcim"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/extras"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
 	v4client "github.com/netbox-community/go-netbox/v4"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
@@ -39,66 +34,56 @@ import (
 func TestPrefixClaim_GetAvailablePrefixesByParentPrefix(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
+
+	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 
 	//prefix mock input
 	parentPrefixId := int32(3)
-	availablePrefixListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
 
 	//prefix mock output
 	childPrefix1 := "10.112.140.0/24"
 	childPrefix2 := "10.120.180.0/24"
-	availablePrefixListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{
-			{
-				Prefix: childPrefix1,
-				Family: int64(IPv4Family),
-			},
-			{
-				Prefix: childPrefix2,
-				Family: int64(IPv4Family),
-			},
+	expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, []v4client.AvailablePrefix{
+		{
+			Prefix: childPrefix1,
+			Family: int32(IPv4Family),
 		},
-	}
-
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(availablePrefixListInput, nil).Return(availablePrefixListOutput, nil)
+		{
+			Prefix: childPrefix2,
+			Family: int32(IPv4Family),
+		},
+	})
 
-	clientV3 := &NetboxClientV3{
-		Ipam: mockPrefixIpam,
-	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
+		clientV4: &NetboxClientV4{
+			IpamAPI: mockIpamAPI,
+		},
 	}
 
-	actual, err := compositeClient.GetAvailablePrefixesByParentPrefix(parentPrefixId)
+	actual, err := compositeClient.GetAvailablePrefixesByParentPrefix(context.TODO(), parentPrefixId)
 	assert.Nil(t, err)
-	assert.Equal(t, childPrefix1, actual.Payload[0].Prefix)
-	assert.Equal(t, childPrefix2, actual.Payload[1].Prefix)
+	assert.Equal(t, childPrefix1, actual[0].Prefix)
+	assert.Equal(t, childPrefix2, actual[1].Prefix)
 }
 
 func TestPrefixClaim_GetNoAvailablePrefixesByParentPrefix(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
+
+	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 
 	//prefix mock input
 	parentPrefixId := int32(3)
-	availablePrefixListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
 	//prefix mock output
-	availablePrefixListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{},
-	}
-
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(availablePrefixListInput, nil).Return(availablePrefixListOutput, nil)
+	expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, []v4client.AvailablePrefix{})
 
-	clientV3 := &NetboxClientV3{
-		Ipam: mockPrefixIpam,
-	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
+		clientV4: &NetboxClientV4{
+			IpamAPI: mockIpamAPI,
+		},
 	}
 
-	actual, err := compositeClient.GetAvailablePrefixesByParentPrefix(parentPrefixId)
+	actual, err := compositeClient.GetAvailablePrefixesByParentPrefix(context.TODO(), parentPrefixId)
 	assert.Nil(t, actual)
 	assert.ErrorIs(t, err, ErrParentPrefixExhausted)
 }
@@ -109,29 +94,15 @@ func TestPrefixClaim_GetAvailablePrefixByClaim_WithWrongParent(t *testing.T) {
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	prefix := "10.112.140.0/24"
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
 	mockIpamAPI.EXPECT().
 		IpamPrefixesList(gomock.Any()).
 		Return(mockListRequest)
@@ -144,17 +115,13 @@ func TestPrefixClaim_GetAvailablePrefixByClaim_WithWrongParent(t *testing.T) {
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -177,54 +144,26 @@ func TestPrefixClaim_GetBestFitPrefixByClaim(t *testing.T) {
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	// example of site
-	siteId := int64(3)
+	siteId := int32(3)
 	siteName := "Site1"
 	siteOutputSlug := "site1"
-	expectedSite := &dcim.DcimSitesListOK{
-		Payload: &dcim.DcimSitesListOKBody{
-			Results: []*netboxModels.Site{
-				{
-					ID:   siteId,
-					Name: &siteName,
-					Slug: &siteOutputSlug,
-				},
-			},
-		},
-	}
-	inputSite := dcim.NewDcimSitesListParams().WithName(&siteName)
+	expectedSite := []v4client.Site{{Id: siteId, Name: siteName, Slug: siteOutputSlug}}
 
 	parentPrefix := "10.112.140.0/24"
 	parentPrefixId := int32(1)
 	prefix := "10.112.140.14/28"
 
-	prefixAvailableListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
-	prefixAvailableListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{
-			{
-				Prefix: prefix,
-			},
+	availablePrefixes := []v4client.AvailablePrefix{
+		{
+			Prefix: prefix,
 		},
 	}
 
@@ -249,20 +188,16 @@ func TestPrefixClaim_GetBestFitPrefixByClaim(t *testing.T) {
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{expectedPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(prefixAvailableListInput, nil).Return(prefixAvailableListOutput, nil).AnyTimes()
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
-	mockDcim.EXPECT().DcimSitesList(inputSite, nil).Return(expectedSite, nil).AnyTimes()
+	expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, availablePrefixes)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
+	mockDcim := mockDcimAPI(ctrl, siteName, expectedSite, nil)
 
-	clientV3 := &NetboxClientV3{
-		Tenancy: mockTenancy,
-		Dcim:    mockDcim,
-		Ipam:    mockPrefixIpam,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
+		DcimAPI:    mockDcim,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -287,30 +222,16 @@ func TestPrefixClaim_InvalidIPv4PrefixLength(t *testing.T) {
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "10.112.140.0/24"
 	parentPrefixId := int32(1)
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
 	mockIpamAPI.EXPECT().
 		IpamPrefixesList(gomock.Any()).
 		Return(mockListRequest)
@@ -332,17 +253,13 @@ func TestPrefixClaim_InvalidIPv4PrefixLength(t *testing.T) {
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -368,30 +285,16 @@ func TestPrefixClaim_FailWhenRequestingEntirePrefix(t *testing.T) {
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "10.112.140.0/24"
 	parentPrefixId := int32(1)
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
 	mockIpamAPI.EXPECT().
 		IpamPrefixesList(gomock.Any()).
 		Return(mockListRequest)
@@ -413,17 +316,13 @@ func TestPrefixClaim_FailWhenRequestingEntirePrefix(t *testing.T) {
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -449,30 +348,16 @@ func TestPrefixClaim_FailWhenPrefixLargerThanParent(t *testing.T) {
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "10.112.140.0/24"
 	parentPrefixId := int32(1)
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
 	mockIpamAPI.EXPECT().
 		IpamPrefixesList(gomock.Any()).
 		Return(mockListRequest)
@@ -494,17 +379,13 @@ func TestPrefixClaim_FailWhenPrefixLargerThanParent(t *testing.T) {
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -530,37 +411,20 @@ func TestPrefixClaim_ValidIPv6PrefixLength(t *testing.T) {
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "2001:db8:85a3:8d3::/30"
 	parentPrefixId := int32(1)
 	prefix := "2001:db8:85a3:8d3::/33"
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-	prefixAvailableListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
-	prefixAvailableListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{
-			{
-				Prefix: prefix,
-			},
+	availablePrefixes := []v4client.AvailablePrefix{
+		{
+			Prefix: prefix,
 		},
 	}
 
@@ -585,18 +449,14 @@ func TestPrefixClaim_ValidIPv6PrefixLength(t *testing.T) {
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(prefixAvailableListInput, nil).Return(prefixAvailableListOutput, nil).AnyTimes()
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, availablePrefixes)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -620,24 +480,12 @@ func TestPrefixClaim_GetBestFitPrefixByClaimNoAvailablePrefixMatchesSize(t *test
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "10.112.140.0/22"
 	parentPrefixId := int32(1)
@@ -645,20 +493,15 @@ func TestPrefixClaim_GetBestFitPrefixByClaimNoAvailablePrefixMatchesSize(t *test
 	prefix1 := "10.112.142.32/27"
 	prefix2 := "10.112.142.64/26"
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-	prefixAvailableListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
-	prefixAvailableListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{
-			{
-				Prefix: prefix,
-			},
-			{
-				Prefix: prefix1,
-			},
-			{
-				Prefix: prefix2,
-			},
+	availablePrefixes := []v4client.AvailablePrefix{
+		{
+			Prefix: prefix,
+		},
+		{
+			Prefix: prefix1,
+		},
+		{
+			Prefix: prefix2,
 		},
 	}
 
@@ -683,18 +526,14 @@ func TestPrefixClaim_GetBestFitPrefixByClaimNoAvailablePrefixMatchesSize(t *test
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(prefixAvailableListInput, nil).Return(prefixAvailableListOutput, nil).AnyTimes()
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, availablePrefixes)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -718,37 +557,20 @@ func TestPrefixClaim_GetBestFitPrefixByClaimNoAvailablePrefixMatchesSizeCriteria
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "10.112.140.0/24"
 	parentPrefixId := int32(1)
 	prefix := "10.112.140.14/30"
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-	prefixAvailableListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
-	prefixAvailableListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{
-			{
-				Prefix: prefix,
-			},
+	availablePrefixes := []v4client.AvailablePrefix{
+		{
+			Prefix: prefix,
 		},
 	}
 
@@ -773,18 +595,14 @@ func TestPrefixClaim_GetBestFitPrefixByClaimNoAvailablePrefixMatchesSizeCriteria
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(prefixAvailableListInput, nil).Return(prefixAvailableListOutput, nil).AnyTimes()
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, availablePrefixes)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -807,24 +625,12 @@ func TestPrefixClaim_GetBestFitPrefixByClaimInvalidFormatFromNetbox(t *testing.T
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "10.112.140.0/22"
 	parentPrefixId := int32(1)
@@ -832,20 +638,15 @@ func TestPrefixClaim_GetBestFitPrefixByClaimInvalidFormatFromNetbox(t *testing.T
 	prefix2 := "10.112.142.32/27"
 	prefix3 := "10.112.142.64/26."
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
-	prefixAvailableListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
-	prefixAvailableListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{
-			{
-				Prefix: prefix1,
-			},
-			{
-				Prefix: prefix2,
-			},
-			{
-				Prefix: prefix3,
-			},
+	availablePrefixes := []v4client.AvailablePrefix{
+		{
+			Prefix: prefix1,
+		},
+		{
+			Prefix: prefix2,
+		},
+		{
+			Prefix: prefix3,
 		},
 	}
 
@@ -870,18 +671,14 @@ func TestPrefixClaim_GetBestFitPrefixByClaimInvalidFormatFromNetbox(t *testing.T
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(prefixAvailableListInput, nil).Return(prefixAvailableListOutput, nil).AnyTimes()
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, availablePrefixes)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -905,30 +702,15 @@ func TestPrefixClaim_GetBestFitPrefixByClaimInvalidPrefixClaim(t *testing.T) {
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// example of tenant
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantName := "Tenant1"
 	tenantOutputSlug := "tenant1"
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "10.112.140.0/24"
 	parentPrefixId := int32(1)
-	prefix1 := "10.112.140.14/25"
-
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
 
 	mockIpamAPI.EXPECT().
 		IpamPrefixesList(gomock.Any()).
@@ -951,27 +733,13 @@ func TestPrefixClaim_GetBestFitPrefixByClaimInvalidPrefixClaim(t *testing.T) {
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	prefixAvailableListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
-	prefixAvailableListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{
-			{
-				Prefix: prefix1,
-			},
-		},
-	}
-
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(prefixAvailableListInput, nil).Return(prefixAvailableListOutput, nil).AnyTimes()
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -992,33 +760,23 @@ func TestPrefixClaim_GetNoAvailablePrefixesWithNonExistingTenant(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-
 	// non-existing tenant
 	tenantName := "non-existing-tenant"
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
 	// expected error
 	expectedErrorMsg := "failed to fetch tenant 'non-existing-tenant': not found"
 
 	// empty tenant list
-	emptyTenantList := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{},
-		},
-	}
+	emptyTenantList := []v4client.Tenant{}
 
 	parentPrefix := "10.112.140.0/24"
 
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(emptyTenantList, nil).AnyTimes()
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, emptyTenantList, nil)
 
-	clientV3 := &NetboxClientV3{
-		Tenancy: mockTenancy,
+	clientV4 := &NetboxClientV4{
+		TenancyAPI: mockTenancy,
 	}
-	clientV4 := &NetboxClientV4{}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -1040,27 +798,21 @@ func TestPrefixClaim_GetNoAvailablePrefixesWithErrorWhenGettingTenantList(t *tes
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-
 	// non-existing tenant
 	tenantName := "non-existing tenant"
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
 	// expected errors
 	getTenantDetailsErrorMsg := "failed to fetch Tenant details"
 	tenancyTenantsListErrorMsg := "cannot get the list" // testcase defined error
 
 	parentPrefix := "10.112.140.0/24"
 
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(nil, errors.New(tenancyTenantsListErrorMsg)).AnyTimes()
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, nil, errors.New(tenancyTenantsListErrorMsg))
 
-	clientV3 := &NetboxClientV3{
-		Tenancy: mockTenancy,
+	clientV4 := &NetboxClientV4{
+		TenancyAPI: mockTenancy,
 	}
-	clientV4 := &NetboxClientV4{}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -1089,54 +841,33 @@ func TestPrefixClaim_GetNoAvailablePrefixesWithNonExistingSite(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	// tenant
 	tenantName := "tenant"
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantOutputSlug := "tenant1"
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	// non-existing site
 	siteName := "non-existing-site"
-	inputSite := dcim.NewDcimSitesListParams().WithName(&siteName)
 	// empty site list
-	emptySiteList := &dcim.DcimSitesListOK{
-		Payload: &dcim.DcimSitesListOKBody{
-			Results: []*netboxModels.Site{},
-		},
-	}
+	emptySiteList := []v4client.Site{}
 
-	mockDcim.EXPECT().DcimSitesList(inputSite, nil).Return(emptySiteList, nil).AnyTimes()
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	mockDcim := mockDcimAPI(ctrl, siteName, emptySiteList, nil)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
 	// expected error
 	expectedErrorMsg := "failed to fetch site 'non-existing-site': not found"
 
 	parentPrefix := "10.112.140.0/24"
 
-	clientV3 := &NetboxClientV3{
-		Dcim:    mockDcim,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
+		DcimAPI:    mockDcim,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -1161,37 +892,21 @@ func TestPrefixClaim_GetAvailablePrefixIfNoSiteInSpec(t *testing.T) {
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
 
 	// tenant
 	tenantName := "tenant"
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantOutputSlug := "tenant1"
 
-	inputTenant := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "10.112.140.0/24"
 	parentPrefixId := int32(1)
 	prefix := "10.112.140.14/28"
 
-	prefixAvailableListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
-	prefixAvailableListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{
-			{
-				Prefix: prefix,
-			},
+	availablePrefixes := []v4client.AvailablePrefix{
+		{
+			Prefix: prefix,
 		},
 	}
 
@@ -1216,18 +931,14 @@ func TestPrefixClaim_GetAvailablePrefixIfNoSiteInSpec(t *testing.T) {
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(prefixAvailableListInput, nil).Return(prefixAvailableListOutput, nil).AnyTimes()
-	mockTenancy.EXPECT().TenancyTenantsList(inputTenant, nil).Return(expectedTenant, nil).AnyTimes()
+	expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, availablePrefixes)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-	}
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -1252,10 +963,6 @@ func TestPrefixClaim_GetAvailablePrefixByParentPrefixSelector(t *testing.T) {
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 	mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockExtras := mock_interfaces.NewMockExtrasInterface(ctrl)
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
 
 	pxcSpec := netboxv1.PrefixClaimSpec{
 		ParentPrefixSelector: map[string]string{
@@ -1265,79 +972,33 @@ func TestPrefixClaim_GetAvailablePrefixByParentPrefixSelector(t *testing.T) {
 			"site":        "Site1",
 		},
 		PrefixLength: "/32",
+		Tenant:       "tenant",
 	}
 
 	// example of site
-	siteId := int64(3)
+	siteId := int32(3)
 	siteName := "Site1"
 	siteOutputSlug := "site1"
-	expectedSite := &dcim.DcimSitesListOK{
-		Payload: &dcim.DcimSitesListOKBody{
-			Results: []*netboxModels.Site{
-				{
-					ID:   siteId,
-					Name: &siteName,
-					Slug: &siteOutputSlug,
-				},
-			},
-		},
-	}
-	inputSite := dcim.NewDcimSitesListParams().WithName(&siteName)
+	expectedSite := []v4client.Site{{Id: siteId, Name: siteName, Slug: siteOutputSlug}}
 
 	// tenant
 	tenantName := "tenant"
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantOutputSlug := "tenant1"
 
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	parentPrefix := "10.112.140.0/24"
 	parentPrefixId := int32(1)
 
-	// get prefix to check if it's a candidate
-	expectedCustomFieldName := "environment"
-	expectedCustomFieldParams := extras.NewExtrasCustomFieldsListParams().WithName(&expectedCustomFieldName)
-	expectedCustomFields := &extras.ExtrasCustomFieldsListOK{
-		Payload: &extras.ExtrasCustomFieldsListOKBody{
-			Results: []*netboxModels.CustomField{
-				{
-					Name: &expectedCustomFieldName,
-				},
-			},
-		},
-	}
+	prefixFamily := int32(IPv4Family)
+	expectPrefixListBySelector(ctrl, mockIpamAPI, []v4client.Prefix{{Id: parentPrefixId, Prefix: parentPrefix}})
 
-	prefixFamily := int64(IPv4Family)
-	prefixFamilyLabel := netboxModels.PrefixFamilyLabelIPV4
-	prefixListInputWithParam := ipam.NewIpamPrefixesListParams()
-	prefixListOutputWithParam := &ipam.IpamPrefixesListOK{
-		Payload: &ipam.IpamPrefixesListOKBody{
-			Results: []*netboxModels.Prefix{
-				{
-					Prefix: &parentPrefix,
-					ID:     int64(parentPrefixId),
-					Family: &netboxModels.PrefixFamily{Label: &prefixFamilyLabel, Value: &prefixFamily},
-				},
-			},
-		},
-	}
-	prefixAvailableListInput := ipam.NewIpamPrefixesAvailablePrefixesListParams().WithID(int64(parentPrefixId))
-	prefixAvailableListOutput := &ipam.IpamPrefixesAvailablePrefixesListOK{
-		Payload: []*netboxModels.AvailablePrefix{
-			{
-				Family: prefixFamily,
-				Prefix: parentPrefix,
-			},
+	// get prefix to check if it's a candidate
+	availablePrefixes := []v4client.AvailablePrefix{
+		{
+			Family: prefixFamily,
+			Prefix: parentPrefix,
 		},
 	}
 
@@ -1362,23 +1023,18 @@ func TestPrefixClaim_GetAvailablePrefixByParentPrefixSelector(t *testing.T) {
 		Execute().
 		Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-	mockPrefixIpam.EXPECT().IpamPrefixesList(prefixListInputWithParam, nil, gomock.Any()).Return(prefixListOutputWithParam, nil).Times(1)
-	mockPrefixIpam.EXPECT().IpamPrefixesAvailablePrefixesList(prefixAvailableListInput, nil).Return(prefixAvailableListOutput, nil).AnyTimes()
-	mockTenancy.EXPECT().TenancyTenantsList(gomock.Any(), nil).Return(expectedTenant, nil).AnyTimes()
-	mockDcim.EXPECT().DcimSitesList(inputSite, nil).Return(expectedSite, nil).AnyTimes()
-	mockExtras.EXPECT().ExtrasCustomFieldsList(expectedCustomFieldParams, nil).Return(expectedCustomFields, nil).AnyTimes()
-
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-		Extras:  mockExtras,
-		Dcim:    mockDcim,
-	}
+	expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, availablePrefixes)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
+	mockDcim := mockDcimAPI(ctrl, siteName, expectedSite, nil)
+	mockExtras := mockExtrasAPI(ctrl, "environment")
+
 	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
+		DcimAPI:    mockDcim,
+		ExtrasAPI:  mockExtras,
 	}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
 		clientV4: clientV4,
 	}
 
@@ -1386,6 +1042,8 @@ func TestPrefixClaim_GetAvailablePrefixByParentPrefixSelector(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.Equal(t, parentPrefix, actual[0].Prefix)
+	assert.Equal(t, parentPrefix, actual[0].SmallestFreeBlock)
+	assert.Equal(t, float64(0), actual[0].Utilization)
 }
 
 func TestPrefixClaim_GetAvailablePrefixByParentPrefixSelectorFailIfNonExistingFieldInParentPrefixSelector(t *testing.T) {
@@ -1393,10 +1051,6 @@ func TestPrefixClaim_GetAvailablePrefixByParentPrefixSelectorFailIfNonExistingFi
 	defer ctrl.Finish()
 
 	mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-	mockPrefixIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-	mockExtras := mock_interfaces.NewMockExtrasInterface(ctrl)
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
 
 	pxcSpec := netboxv1.PrefixClaimSpec{
 		ParentPrefixSelector: map[string]string{
@@ -1410,80 +1064,231 @@ func TestPrefixClaim_GetAvailablePrefixByParentPrefixSelectorFailIfNonExistingFi
 	}
 
 	// example of site
-	siteId := int64(3)
+	siteId := int32(3)
 	siteName := "Site1"
 	siteOutputSlug := "site1"
-	expectedSite := &dcim.DcimSitesListOK{
-		Payload: &dcim.DcimSitesListOKBody{
-			Results: []*netboxModels.Site{
-				{
-					ID:   siteId,
-					Name: &siteName,
-					Slug: &siteOutputSlug,
-				},
-			},
-		},
-	}
-	inputSite := dcim.NewDcimSitesListParams().WithName(&siteName)
+	expectedSite := []v4client.Site{{Id: siteId, Name: siteName, Slug: siteOutputSlug}}
 
 	// tenant
 	tenantName := "tenant"
-	tenantId := int64(2)
+	tenantId := int32(2)
 	tenantOutputSlug := "tenant1"
 
-	expectedTenant := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
+	expectedTenant := []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantOutputSlug}}
+
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
+	mockDcim := mockDcimAPI(ctrl, siteName, expectedSite, nil)
+	mockExtras := mockExtrasAPI(ctrl, "environment")
+
+	clientV4 := &NetboxClientV4{
+		IpamAPI:    mockIpamAPI,
+		TenancyAPI: mockTenancy,
+		DcimAPI:    mockDcim,
+		ExtrasAPI:  mockExtras,
+	}
+	compositeClient := &NetboxCompositeClient{
+		clientV4: clientV4,
+	}
+
+	actual, err := compositeClient.GetAvailablePrefixesByParentPrefixSelector(context.TODO(), &pxcSpec)
+
+	assert.Nil(t, actual)
+	assert.ErrorContains(t, err, "custom field non-existing not found")
+}
+
+func TestPrefixClaim_ComputePrefixUtilization(t *testing.T) {
+	tests := []struct {
+		name              string
+		parentPrefix      string
+		availablePrefixes []string
+		expected          float64
+	}{
+		{
+			name:              "unused parent prefix",
+			parentPrefix:      "10.0.0.0/24",
+			availablePrefixes: []string{"10.0.0.0/24"},
+			expected:          0,
+		},
+		{
+			name:              "half used parent prefix",
+			parentPrefix:      "10.0.0.0/24",
+			availablePrefixes: []string{"10.0.0.128/26", "10.0.0.192/26"},
+			expected:          0.5,
+		},
+		{
+			name:              "exhausted parent prefix",
+			parentPrefix:      "10.0.0.0/24",
+			availablePrefixes: []string{},
+			expected:          1,
+		},
+		{
+			name:              "ipv6 parent prefix",
+			parentPrefix:      "2001:db8::/48",
+			availablePrefixes: []string{"2001:db8:0:8000::/49", "2001:db8:0:4000::/50"},
+			expected:          0.25,
 		},
 	}
 
-	// get prefix to check if it's a candidate
-	expectedCustomFieldName := "environment"
-	expectedCustomFieldParams := extras.NewExtrasCustomFieldsListParams().WithName(&expectedCustomFieldName)
-	nonExistingCustomFieldName := "non-existing"
-	nonExistingCustomFieldParams := extras.NewExtrasCustomFieldsListParams().WithName(&nonExistingCustomFieldName)
-	expectedCustomFields := &extras.ExtrasCustomFieldsListOK{
-		Payload: &extras.ExtrasCustomFieldsListOKBody{
-			Results: []*netboxModels.CustomField{
-				{
-					Name: &expectedCustomFieldName,
-				},
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			availablePrefixes := make([]v4client.AvailablePrefix, 0, len(tt.availablePrefixes))
+			for _, prefix := range tt.availablePrefixes {
+				availablePrefixes = append(availablePrefixes, v4client.AvailablePrefix{Prefix: prefix})
+			}
+
+			actual, err := computePrefixUtilization(tt.parentPrefix, availablePrefixes)
+			assert.Nil(t, err)
+			assert.InDelta(t, tt.expected, actual, 1e-9)
+		})
+	}
+}
+
+func TestPrefixClaim_ComputePrefixUtilizationInvalidPrefix(t *testing.T) {
+	_, err := computePrefixUtilization("10.0.0.0", nil)
+	assert.Error(t, err)
+
+	_, err = computePrefixUtilization("10.0.0.0/24", []v4client.AvailablePrefix{{Prefix: "invalid"}})
+	assert.Error(t, err)
+}
+
+func TestPrefixClaim_GetPreferredPrefixByClaim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "Tenant1"
+	tenantOutputSlug := "tenant1"
+	expectedTenant := []v4client.Tenant{{Id: 2, Name: tenantName, Slug: tenantOutputSlug}}
+
+	parentPrefix := "10.112.140.0/24"
+	parentPrefixId := int32(1)
+
+	newCompositeClient := func() *NetboxCompositeClient {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+
+		aggregateFamily := v4client.NewAggregateFamily()
+		aggregateFamily.SetValue(v4client.AggregateFamilyValue(IPv4Family))
+
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
+		mockIpamAPI.EXPECT().IpamPrefixesList(gomock.Any()).Return(mockListRequest)
+		mockListRequest.EXPECT().Prefix([]string{parentPrefix}).Return(mockListRequest)
+		mockListRequest.EXPECT().Execute().Return(
+			&v4client.PaginatedPrefixList{Results: []v4client.Prefix{{Id: parentPrefixId, Prefix: parentPrefix, Family: *aggregateFamily}}},
+			&http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+
+		// 10.112.140.0/25 is already allocated
+		expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, []v4client.AvailablePrefix{
+			{Prefix: "10.112.140.128/25"},
+		})
+
+		return &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{
+				IpamAPI:    mockIpamAPI,
+				TenancyAPI: mockTenancy,
 			},
-		},
+		}
 	}
-	nonExistingCustomFields := &extras.ExtrasCustomFieldsListOK{
-		Payload: &extras.ExtrasCustomFieldsListOKBody{
-			Results: []*netboxModels.CustomField{},
+
+	claim := &models.PrefixClaim{
+		ParentPrefix: parentPrefix,
+		PrefixLength: "/28",
+		Metadata: &models.NetboxMetadata{
+			Tenant: tenantName,
 		},
 	}
 
-	mockTenancy.EXPECT().TenancyTenantsList(gomock.Any(), nil).Return(expectedTenant, nil).AnyTimes()
-	mockDcim.EXPECT().DcimSitesList(inputSite, nil).Return(expectedSite, nil).AnyTimes()
-	mockExtras.EXPECT().ExtrasCustomFieldsList(expectedCustomFieldParams, nil).Return(expectedCustomFields, nil).AnyTimes()
-	mockExtras.EXPECT().ExtrasCustomFieldsList(nonExistingCustomFieldParams, nil).Return(nonExistingCustomFields, nil).AnyTimes()
+	t.Run("Preferred prefix is available.", func(t *testing.T) {
+		actual, err := newCompositeClient().GetPreferredPrefixByClaim(context.TODO(), claim, "10.112.140.192/28")
 
-	clientV3 := &NetboxClientV3{
-		Ipam:    mockPrefixIpam,
-		Tenancy: mockTenancy,
-		Extras:  mockExtras,
-		Dcim:    mockDcim,
-	}
-	clientV4 := &NetboxClientV4{
-		IpamAPI: mockIpamAPI,
+		assert.Nil(t, err)
+		assert.Equal(t, "10.112.140.192/28", actual.Prefix)
+	})
+
+	t.Run("Preferred prefix is not available.", func(t *testing.T) {
+		actual, err := newCompositeClient().GetPreferredPrefixByClaim(context.TODO(), claim, "10.112.140.16/28")
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, ErrPreferredPrefixNotAvailable)
+	})
+
+	t.Run("Preferred prefix does not match the prefix length.", func(t *testing.T) {
+		actual, err := (&NetboxCompositeClient{}).GetPreferredPrefixByClaim(context.TODO(), claim, "10.112.140.192/27")
+
+		assert.Nil(t, actual)
+		assert.ErrorContains(t, err, "does not match the prefix length /28")
+	})
+
+	t.Run("Preferred prefix has host bits set.", func(t *testing.T) {
+		actual, err := (&NetboxCompositeClient{}).GetPreferredPrefixByClaim(context.TODO(), claim, "10.112.140.193/28")
+
+		assert.Nil(t, actual)
+		assert.ErrorContains(t, err, "did you mean 10.112.140.192/28")
+	})
+}
+
+func TestPrefixClaim_GetAvailablePrefixesByClaim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "Tenant1"
+	tenantOutputSlug := "tenant1"
+	expectedTenant := []v4client.Tenant{{Id: 2, Name: tenantName, Slug: tenantOutputSlug}}
+
+	parentPrefix := "10.112.140.0/24"
+	parentPrefixId := int32(1)
+
+	newCompositeClient := func() *NetboxCompositeClient {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+
+		aggregateFamily := v4client.NewAggregateFamily()
+		aggregateFamily.SetValue(v4client.AggregateFamilyValue(IPv4Family))
+
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, expectedTenant, nil)
+		mockIpamAPI.EXPECT().IpamPrefixesList(gomock.Any()).Return(mockListRequest)
+		mockListRequest.EXPECT().Prefix([]string{parentPrefix}).Return(mockListRequest)
+		mockListRequest.EXPECT().Execute().Return(
+			&v4client.PaginatedPrefixList{Results: []v4client.Prefix{{Id: parentPrefixId, Prefix: parentPrefix, Family: *aggregateFamily}}},
+			&http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+
+		expectAvailablePrefixesList(ctrl, mockIpamAPI, parentPrefixId, []v4client.AvailablePrefix{
+			{Prefix: "10.112.140.128/25"},
+			{Prefix: "10.112.140.32/27"},
+		})
+
+		return &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{
+				IpamAPI:    mockIpamAPI,
+				TenancyAPI: mockTenancy,
+			},
+		}
 	}
-	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
-		clientV4: clientV4,
+
+	claim := &models.PrefixClaim{
+		ParentPrefix: parentPrefix,
+		PrefixLength: "/28",
+		Metadata: &models.NetboxMetadata{
+			Tenant: tenantName,
+		},
 	}
 
-	actual, err := compositeClient.GetAvailablePrefixesByParentPrefixSelector(context.TODO(), &pxcSpec)
+	t.Run("Enough prefixes are available.", func(t *testing.T) {
+		// 10.112.140.32/28 is assigned to a sibling but not yet reserved in NetBox,
+		// the smallest available prefix is used first
+		actual, err := newCompositeClient().GetAvailablePrefixesByClaim(context.TODO(), claim, 3, []string{"10.112.140.32/28"})
 
-	assert.Nil(t, actual)
-	assert.ErrorContains(t, err, "custom field non-existing not found")
+		assert.Nil(t, err)
+		assert.Len(t, actual, 3)
+		assert.Equal(t, "10.112.140.48/28", actual[0].Prefix)
+		assert.Equal(t, "10.112.140.128/28", actual[1].Prefix)
+		assert.Equal(t, "10.112.140.144/28", actual[2].Prefix)
+	})
+
+	t.Run("Not enough prefixes are available.", func(t *testing.T) {
+		actual, err := newCompositeClient().GetAvailablePrefixesByClaim(context.TODO(), claim, 20, []string{"10.112.140.32/28"})
+
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, ErrParentPrefixExhausted)
+		assert.ErrorContains(t, err, "only 9 of 20 prefixes are available")
+	})
 }
@@ -0,0 +1,41 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+)
+
+// writablePrefixRequestLegacy returns the prefix request for NetBox versions before 4.2, which
+// assign a prefix to a site instead of a scope. The v4 client has no site field, so the site is
+// sent as additional property of the request.
+func (c *NetboxCompositeClient) writablePrefixRequestLegacy(ctx context.Context, prefix *models.Prefix) (*v4client.WritablePrefixRequest, error) {
+	desiredPrefix, err := c.writablePrefixRequestV4(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if siteId, ok := desiredPrefix.GetScopeIdOk(); ok && siteId != nil {
+		desiredPrefix.AdditionalProperties = map[string]interface{}{"site": *siteId}
+		desiredPrefix.UnsetScopeType()
+		desiredPrefix.UnsetScopeId()
+	}
+	return desiredPrefix, nil
+}
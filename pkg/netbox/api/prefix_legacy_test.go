@@ -0,0 +1,220 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWritablePrefixRequestLegacy_NoTenantNoSite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{ExtrasAPI: mockExtrasAPI(ctrl, "key1")},
+	}
+
+	prefix := "10.0.0.0/24"
+	comments := "my comment"
+	description := "my description"
+	customFields := map[string]string{"key1": "val1"}
+
+	result, err := compositeClient.writablePrefixRequestLegacy(context.TODO(), &models.Prefix{
+		Prefix: prefix,
+		Metadata: &models.NetboxMetadata{
+			Comments:    comments,
+			Description: description,
+			Custom:      customFields,
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, prefix, result.Prefix)
+	assert.Equal(t, comments+warningComment, result.GetComments())
+	assert.Equal(t, TruncateDescription(description), result.GetDescription())
+	assert.Equal(t, "val1", result.GetCustomFields()["key1"])
+	assert.Equal(t, v4client.PATCHEDWRITABLEPREFIXREQUESTSTATUS_ACTIVE, result.GetStatus())
+	assert.False(t, result.HasTenant())
+	assert.Nil(t, result.AdditionalProperties["site"])
+}
+
+func TestWritablePrefixRequestLegacy_WithTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "Tenant1"
+	tenantId := int32(7)
+	tenantSlug := "tenant1"
+
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantSlug}}, nil)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{TenancyAPI: mockTenancy},
+	}
+
+	result, err := compositeClient.writablePrefixRequestLegacy(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Metadata: &models.NetboxMetadata{
+			Tenant: tenantName,
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, tenantId, *result.GetTenant().Int32)
+	assert.Nil(t, result.AdditionalProperties["site"])
+}
+
+func TestWritablePrefixRequestLegacy_WithSite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	siteName := "Site1"
+	siteId := int32(3)
+	siteSlug := "site1"
+
+	mockDcim := mockDcimAPI(ctrl, siteName, []v4client.Site{{Id: siteId, Name: siteName, Slug: siteSlug}}, nil)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{DcimAPI: mockDcim},
+	}
+
+	result, err := compositeClient.writablePrefixRequestLegacy(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Metadata: &models.NetboxMetadata{
+			Site: siteName,
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	// NetBox versions before 4.2 assign the prefix to the site instead of a scope
+	assert.Equal(t, siteId, result.AdditionalProperties["site"])
+	assert.False(t, result.HasScopeType())
+	assert.False(t, result.HasScopeId())
+	assert.False(t, result.HasTenant())
+}
+
+func TestWritablePrefixRequestLegacy_WithTenantAndSite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "Tenant1"
+	tenantId := int32(7)
+	tenantSlug := "tenant1"
+	siteName := "Site1"
+	siteId := int32(3)
+	siteSlug := "site1"
+
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantSlug}}, nil)
+	mockDcim := mockDcimAPI(ctrl, siteName, []v4client.Site{{Id: siteId, Name: siteName, Slug: siteSlug}}, nil)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{
+			TenancyAPI: mockTenancy,
+			DcimAPI:    mockDcim,
+		},
+	}
+
+	result, err := compositeClient.writablePrefixRequestLegacy(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Metadata: &models.NetboxMetadata{
+			Tenant: tenantName,
+			Site:   siteName,
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, tenantId, *result.GetTenant().Int32)
+	assert.Equal(t, siteId, result.AdditionalProperties["site"])
+	assert.False(t, result.HasScopeId())
+}
+
+func TestWritablePrefixRequestLegacy_TenantError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "NonExistentTenant"
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, nil, errors.New("tenant not found"))
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{TenancyAPI: mockTenancy},
+	}
+
+	result, err := compositeClient.writablePrefixRequestLegacy(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Metadata: &models.NetboxMetadata{
+			Tenant: tenantName,
+		},
+	})
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestWritablePrefixRequestLegacy_SiteError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	siteName := "NonExistentSite"
+	mockDcim := mockDcimAPI(ctrl, siteName, nil, errors.New("site not found"))
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{DcimAPI: mockDcim},
+	}
+
+	result, err := compositeClient.writablePrefixRequestLegacy(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Metadata: &models.NetboxMetadata{
+			Site: siteName,
+		},
+	})
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestWritablePrefixRequestLegacy_WithVlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectVlansList(ctrl, mockIpam, newVlan(13, 100, "k8s-nodes", nil))
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	result, err := compositeClient.writablePrefixRequestLegacy(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Vlan:   &models.Vlan{Vid: 100},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(13), *result.GetVlan().Int32)
+	assert.Nil(t, result.AdditionalProperties["site"])
+}
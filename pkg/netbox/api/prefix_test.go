@@ -22,13 +22,11 @@ import (
 	"testing"
 	"time"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/dcim"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
 	v4client "github.com/netbox-community/go-netbox/v4"
 	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
 	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -203,43 +201,17 @@ func TestPrefix_ReserveOrUpdate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	// tenant mock input
-	tenantName := "Tenant1"
-	tenantListRequestInput := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-
 	// tenant mock output
-	tenantOutputId := int64(1)
+	tenantName := "Tenant1"
+	tenantOutputId := int32(1)
 	tenantOutputSlug := "tenant1"
-	tenantListRequestOutput := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantOutputId,
-					Name: &tenantName,
-					Slug: &tenantOutputSlug,
-				},
-			},
-		},
-	}
-
-	// site mock input
-	site := "Site3"
-	siteListRequestInput := dcim.NewDcimSitesListParams().WithName(&site)
+	tenantListOutput := []v4client.Tenant{{Id: tenantOutputId, Name: tenantName, Slug: tenantOutputSlug}}
 
 	// site mock output
-	siteOutputId := int64(3)
+	site := "Site3"
+	siteOutputId := int32(3)
 	siteOutputSlug := "site3"
-	siteListRequestOutput := &dcim.DcimSitesListOK{
-		Payload: &dcim.DcimSitesListOKBody{
-			Results: []*netboxModels.Site{
-				{
-					ID:   siteOutputId,
-					Name: &site,
-					Slug: &siteOutputSlug,
-				},
-			},
-		},
-	}
+	siteListOutput := []v4client.Site{{Id: siteOutputId, Name: site, Slug: siteOutputSlug}}
 
 	// prefix mock input
 	prefix := "10.112.140.0/24"
@@ -269,8 +241,6 @@ func TestPrefix_ReserveOrUpdate(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
 		mockCreateRequest := mock_interfaces.NewMockIpamPrefixesCreateRequest(ctrl)
-		mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-		mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
 		mockStatusAPI, mockStatusRequest := GetNetBoxVersionMock(ctrl, "4.4.10")
 		_ = mockStatusRequest
 
@@ -296,8 +266,8 @@ func TestPrefix_ReserveOrUpdate(t *testing.T) {
 			Tenant:      *v4client.NewNullableBriefTenant(expectedTenant),
 		}
 
-		mockTenancy.EXPECT().TenancyTenantsList(tenantListRequestInput, nil).Return(tenantListRequestOutput, nil).AnyTimes()
-		mockDcim.EXPECT().DcimSitesList(siteListRequestInput, nil).Return(siteListRequestOutput, nil).AnyTimes()
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, tenantListOutput, nil)
+		mockDcim := mockDcimAPI(ctrl, site, siteListOutput, nil)
 
 		mockIpamAPI.EXPECT().
 			IpamPrefixesList(gomock.Any()).
@@ -323,16 +293,13 @@ func TestPrefix_ReserveOrUpdate(t *testing.T) {
 			Execute().
 			Return(createPrefixOutput, &http.Response{StatusCode: 201, Body: http.NoBody}, nil)
 
-		netboxClientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-			Dcim:    mockDcim,
-		}
 		netboxClientV4 := &NetboxClientV4{
-			IpamAPI:   mockIpamAPI,
-			StatusAPI: mockStatusAPI,
+			IpamAPI:    mockIpamAPI,
+			StatusAPI:  mockStatusAPI,
+			TenancyAPI: mockTenancy,
+			DcimAPI:    mockDcim,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: netboxClientV3,
 			clientV4: netboxClientV4,
 		}
 
@@ -356,12 +323,75 @@ func TestPrefix_ReserveOrUpdate(t *testing.T) {
 		assert.False(t, isUpToDate)
 	})
 
+	t.Run("reserve with tenant and site (NetBox before 4.2)", func(t *testing.T) {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+		mockCreateRequest := mock_interfaces.NewMockIpamPrefixesCreateRequest(ctrl)
+		mockStatusAPI, _ := GetNetBoxVersionMock(ctrl, "4.1.11")
+
+		mockTenancy := mockTenancyAPI(ctrl, tenantName, tenantListOutput, nil)
+		mockDcim := mockDcimAPI(ctrl, site, siteListOutput, nil)
+
+		mockIpamAPI.EXPECT().
+			IpamPrefixesList(gomock.Any()).
+			Return(mockListRequest)
+
+		mockListRequest.EXPECT().
+			Prefix([]string{prefix}).
+			Return(mockListRequest)
+
+		mockListRequest.EXPECT().
+			Execute().
+			Return(emptyPrefixListOutput, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+
+		mockIpamAPI.EXPECT().
+			IpamPrefixesCreate(gomock.Any()).
+			Return(mockCreateRequest)
+
+		// the prefix is assigned to the site instead of a scope
+		mockCreateRequest.EXPECT().
+			WritablePrefixRequest(gomock.Any()).
+			DoAndReturn(func(request v4client.WritablePrefixRequest) interfaces.IpamPrefixesCreateRequest {
+				assert.Equal(t, siteOutputId, request.AdditionalProperties["site"])
+				assert.False(t, request.HasScopeType())
+				assert.False(t, request.HasScopeId())
+				assert.Equal(t, tenantOutputId, *request.GetTenant().Int32)
+				assert.Equal(t, v4client.PATCHEDWRITABLEPREFIXREQUESTSTATUS_ACTIVE, request.GetStatus())
+				return mockCreateRequest
+			})
+
+		mockCreateRequest.EXPECT().
+			Execute().
+			Return(&v4client.Prefix{Id: int32(1), Display: prefix, Prefix: prefix}, &http.Response{StatusCode: 201, Body: http.NoBody}, nil)
+
+		compositeClient := &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{
+				IpamAPI:    mockIpamAPI,
+				StatusAPI:  mockStatusAPI,
+				TenancyAPI: mockTenancy,
+				DcimAPI:    mockDcim,
+			},
+		}
+
+		prefixModel := models.Prefix{
+			Prefix: prefix,
+			Metadata: &models.NetboxMetadata{
+				Site:   site,
+				Tenant: tenantName,
+			},
+		}
+
+		_, isUpToDate, err := compositeClient.ReserveOrUpdatePrefix(
+			context.TODO(),
+			&prefixModel, &netboxv1.Prefix{})
+		assert.Nil(t, err)
+		assert.False(t, isUpToDate)
+	})
+
 	t.Run("update without tenant and site (v4 netbox client)", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
 		mockUpdateRequest := mock_interfaces.NewMockIpamPrefixesUpdateRequest(ctrl)
-		mockIpam := mock_interfaces.NewMockIpamInterface(ctrl)
-		mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
 		mockStatusAPI, mockStatusRequest := GetNetBoxVersionMock(ctrl, "4.2.0")
 		_ = mockStatusRequest
 
@@ -402,16 +432,11 @@ func TestPrefix_ReserveOrUpdate(t *testing.T) {
 			Execute().
 			Return(&prefixOutput, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-		netboxClientV3 := &NetboxClientV3{
-			Tenancy: mockTenancy,
-			Ipam:    mockIpam,
-		}
 		netboxClientV4 := &NetboxClientV4{
 			IpamAPI:   mockIpamAPI,
 			StatusAPI: mockStatusAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: netboxClientV3,
 			clientV4: netboxClientV4,
 		}
 
@@ -433,6 +458,69 @@ func TestPrefix_ReserveOrUpdate(t *testing.T) {
 		assert.False(t, isUpToDate)
 	})
 
+	t.Run("update keeps the tags assigned in NetBox", func(t *testing.T) {
+		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
+		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+		mockUpdateRequest := mock_interfaces.NewMockIpamPrefixesUpdateRequest(ctrl)
+		mockStatusAPI, _ := GetNetBoxVersionMock(ctrl, "4.2.0")
+		mockExtras := mockExtrasTagsAPI(ctrl, v4client.Tag{Id: 1, Name: "Production", Slug: "production"})
+
+		existing := expectedPrefix()
+		existing.Tags = []v4client.NestedTag{
+			{Name: "Staging", Slug: "staging"},
+			{Name: "Manual", Slug: "manual"},
+		}
+
+		mockIpamAPI.EXPECT().
+			IpamPrefixesList(gomock.Any()).
+			Return(mockListRequest)
+
+		mockListRequest.EXPECT().
+			Prefix([]string{prefix}).
+			Return(mockListRequest)
+
+		mockListRequest.EXPECT().
+			Execute().
+			Return(&v4client.PaginatedPrefixList{Results: []v4client.Prefix{existing}}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+
+		mockIpamAPI.EXPECT().
+			IpamPrefixesUpdate(gomock.Any(), prefixId).
+			Return(mockUpdateRequest)
+
+		// the staging tag was assigned by the operator before and is removed
+		mockUpdateRequest.EXPECT().
+			WritablePrefixRequest(gomock.Any()).
+			DoAndReturn(func(request v4client.WritablePrefixRequest) interfaces.IpamPrefixesUpdateRequest {
+				assert.Equal(t, []v4client.NestedTagRequest{
+					*v4client.NewNestedTagRequest("Production", "production"),
+					*v4client.NewNestedTagRequest("Manual", "manual"),
+				}, request.Tags)
+				return mockUpdateRequest
+			})
+
+		mockUpdateRequest.EXPECT().
+			Execute().
+			Return(&existing, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+
+		clientV4 := &NetboxClientV4{IpamAPI: mockIpamAPI, StatusAPI: mockStatusAPI, ExtrasAPI: mockExtras}
+		compositeClient := &NetboxCompositeClient{clientV4: clientV4}
+
+		_, isUpToDate, err := compositeClient.ReserveOrUpdatePrefix(
+			context.TODO(),
+			&models.Prefix{
+				Prefix: prefix,
+				Metadata: &models.NetboxMetadata{
+					Tags:        []string{"Production"},
+					ManagedTags: []string{"staging"},
+				},
+			},
+			&netboxv1.Prefix{},
+		)
+
+		AssertNil(t, err)
+		assert.False(t, isUpToDate)
+	})
+
 	t.Run("restoration hash mismatch", func(t *testing.T) {
 		mockIpamAPI := mock_interfaces.NewMockIpamAPI(ctrl)
 		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
@@ -464,12 +552,10 @@ func TestPrefix_ReserveOrUpdate(t *testing.T) {
 			Execute().
 			Return(prefixListOutput, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 
-		netboxClientV3 := &NetboxClientV3{}
 		netboxClientV4 := &NetboxClientV4{
 			IpamAPI: mockIpamAPI,
 		}
 		compositeClient := &NetboxCompositeClient{
-			clientV3: netboxClientV3,
 			clientV4: netboxClientV4,
 		}
 
@@ -0,0 +1,182 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+)
+
+// GetPrefixUtilization returns the utilization of the prefix with the given id in NetBox.
+// The free addresses of a prefix with child prefixes are the addresses of its available prefixes,
+// the ones of a prefix without child prefixes are its usable addresses which are not assigned
+// to an IP Address, as the available-ips endpoint only returns a limited number of addresses.
+// Only the child prefixes and IP Addresses in the VRF of the prefix are counted if it has one.
+func (c *NetboxCompositeClient) GetPrefixUtilization(ctx context.Context, prefixId int32, prefix string, vrf string) (*models.PrefixUtilization, error) {
+	_, prefixNet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	vrfCtx, err := c.withVrfQueryFilter(ctx, vrf)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the count of the list responses is used, so a single result is enough
+	limit := int32(1)
+
+	childPrefixes, err := c.countChildPrefixes(vrfCtx, prefix, limit)
+	if err != nil {
+		return nil, utils.NetboxError("failed to list child prefixes of "+prefix, err)
+	}
+
+	childIpAddresses, err := c.countChildIpAddresses(vrfCtx, prefix, limit)
+	if err != nil {
+		return nil, utils.NetboxError("failed to list ip addresses of "+prefix, err)
+	}
+
+	utilization := &models.PrefixUtilization{
+		ChildPrefixes:    int64(childPrefixes),
+		ChildIpAddresses: int64(childIpAddresses),
+	}
+
+	var total *big.Int
+	if utilization.ChildPrefixes > 0 {
+		responseAvailablePrefixes, err := c.listAvailablePrefixes(ctx, prefixId)
+		if err != nil {
+			return nil, utils.NetboxError("failed to list available prefixes of "+prefix, err)
+		}
+
+		total = prefixSize(prefixNet)
+		utilization.FreeAddresses, err = sumPrefixSizes(responseAvailablePrefixes)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		total = usablePrefixSize(prefixNet)
+		utilization.FreeAddresses = new(big.Int).Sub(total, big.NewInt(utilization.ChildIpAddresses))
+		if utilization.FreeAddresses.Sign() < 0 {
+			// addresses which are not usable, e.g. the network address, can be assigned as well
+			utilization.FreeAddresses.SetInt64(0)
+		}
+	}
+
+	utilization.UsedPercent = usedPercent(total, utilization.FreeAddresses)
+
+	// the prefix can be the parent prefix of claims, so its capacity is recorded as well
+	metrics.SetParentPrefixCapacity(prefix, utilization.FreeAddresses, new(big.Int).Sub(total, utilization.FreeAddresses))
+
+	return utilization, nil
+}
+
+// countChildPrefixes returns the number of prefixes within the prefix
+func (c *NetboxCompositeClient) countChildPrefixes(ctx context.Context, prefix string, limit int32) (count int32, err error) {
+	list, httpResp, execErr := c.clientV4.IpamAPI.IpamPrefixesList(ctx).Within(prefix).Limit(limit).Execute()
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list prefixes")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return 0, handleErr
+	}
+	return list.Count, nil
+}
+
+// countChildIpAddresses returns the number of ip addresses in the prefix
+func (c *NetboxCompositeClient) countChildIpAddresses(ctx context.Context, prefix string, limit int32) (count int32, err error) {
+	list, httpResp, execErr := c.clientV4.IpamAPI.IpamIpAddressesList(ctx).Parent([]string{prefix}).Limit(limit).Execute()
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list ip addresses")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return 0, handleErr
+	}
+	return list.Count, nil
+}
+
+// recordParentPrefixCapacity records the capacity of the parent prefix observed from its available prefixes
+func recordParentPrefixCapacity(parentPrefix string, availablePrefixes []v4client.AvailablePrefix) {
+	_, parentNet, err := net.ParseCIDR(parentPrefix)
+	if err != nil {
+		return
+	}
+	free, err := sumPrefixSizes(availablePrefixes)
+	if err != nil {
+		return
+	}
+	metrics.SetParentPrefixCapacity(parentPrefix, free, new(big.Int).Sub(prefixSize(parentNet), free))
+}
+
+// prefixSize returns the number of addresses of the prefix
+func prefixSize(prefixNet *net.IPNet) *big.Int {
+	ones, bits := prefixNet.Mask.Size()
+	return new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+}
+
+// usablePrefixSize returns the number of addresses of the prefix which are returned by the
+// available-ips endpoint of NetBox for a prefix which is not a pool: the network and broadcast
+// addresses of IPv4 prefixes and the subnet-router anycast address of IPv6 prefixes are excluded.
+func usablePrefixSize(prefixNet *net.IPNet) *big.Int {
+	size := prefixSize(prefixNet)
+	ones, bits := prefixNet.Mask.Size()
+	switch {
+	case bits-ones < 2:
+		// point-to-point and host prefixes don't have reserved addresses
+	case bits == net.IPv4len*8:
+		size.Sub(size, big.NewInt(2))
+	default:
+		size.Sub(size, big.NewInt(1))
+	}
+	return size
+}
+
+// sumPrefixSizes returns the number of addresses of all prefixes
+func sumPrefixSizes(prefixes []v4client.AvailablePrefix) (*big.Int, error) {
+	sum := new(big.Int)
+	for _, prefix := range prefixes {
+		_, prefixNet, err := net.ParseCIDR(prefix.Prefix)
+		if err != nil {
+			return nil, err
+		}
+		sum.Add(sum, prefixSize(prefixNet))
+	}
+	return sum, nil
+}
+
+// usedPercent returns the share of total which is not free in percent, rounded down
+func usedPercent(total *big.Int, free *big.Int) int32 {
+	if total.Sign() <= 0 {
+		return 0
+	}
+	used := new(big.Int).Sub(total, free)
+	if used.Sign() <= 0 {
+		return 0
+	}
+	percent := used.Mul(used, big.NewInt(100))
+	percent.Quo(percent, total)
+	return int32(percent.Int64())
+}
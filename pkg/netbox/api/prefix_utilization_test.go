@@ -0,0 +1,146 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"net/http"
+	"testing"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func expectPrefixUtilizationCounts(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, prefix string, childPrefixes int32, childIpAddresses int32) {
+	mockPrefixesList := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+	mockIpam.EXPECT().IpamPrefixesList(gomock.Any()).Return(mockPrefixesList)
+	mockPrefixesList.EXPECT().Within(prefix).Return(mockPrefixesList)
+	mockPrefixesList.EXPECT().Limit(int32(1)).Return(mockPrefixesList)
+	mockPrefixesList.EXPECT().Execute().
+		Return(&v4client.PaginatedPrefixList{Count: childPrefixes}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+
+	mockIpAddressesList := mock_interfaces.NewMockIpamIpAddressesListRequest(ctrl)
+	mockIpam.EXPECT().IpamIpAddressesList(gomock.Any()).Return(mockIpAddressesList)
+	mockIpAddressesList.EXPECT().Parent([]string{prefix}).Return(mockIpAddressesList)
+	mockIpAddressesList.EXPECT().Limit(int32(1)).Return(mockIpAddressesList)
+	mockIpAddressesList.EXPECT().Execute().
+		Return(&v4client.PaginatedIPAddressList{Count: childIpAddresses}, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+}
+
+func expectAvailablePrefixesList(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, prefixId int32, availablePrefixes []v4client.AvailablePrefix) {
+	mockAvailablePrefixesList := mock_interfaces.NewMockIpamPrefixesAvailablePrefixesListRequest(ctrl)
+	mockIpam.EXPECT().IpamPrefixesAvailablePrefixesList(gomock.Any(), prefixId).Return(mockAvailablePrefixesList)
+	mockAvailablePrefixesList.EXPECT().Execute().
+		Return(availablePrefixes, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+}
+
+func TestPrefixUtilization_WithChildPrefixes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+
+	prefixId := int32(3)
+	prefix := "10.0.0.0/24"
+	expectPrefixUtilizationCounts(ctrl, mockIpam, prefix, 2, 5)
+	expectAvailablePrefixesList(ctrl, mockIpam, prefixId, []v4client.AvailablePrefix{
+		{Prefix: "10.0.0.128/26", Family: int32(IPv4Family)},
+		{Prefix: "10.0.0.192/27", Family: int32(IPv4Family)},
+	})
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	actual, err := compositeClient.GetPrefixUtilization(context.TODO(), prefixId, prefix, "")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), actual.ChildPrefixes)
+	assert.Equal(t, int64(5), actual.ChildIpAddresses)
+	assert.Equal(t, big.NewInt(96), actual.FreeAddresses)
+	assert.Equal(t, int32(62), actual.UsedPercent)
+}
+
+func TestPrefixUtilization_FullyAllocatedChildPrefixes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+
+	prefixId := int32(3)
+	prefix := "2001:db8::/48"
+	expectPrefixUtilizationCounts(ctrl, mockIpam, prefix, 1, 0)
+	expectAvailablePrefixesList(ctrl, mockIpam, prefixId, []v4client.AvailablePrefix{})
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	actual, err := compositeClient.GetPrefixUtilization(context.TODO(), prefixId, prefix, "")
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), actual.FreeAddresses)
+	assert.Equal(t, int32(100), actual.UsedPercent)
+}
+
+func TestPrefixUtilization_WithoutChildPrefixes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+
+	prefix := "10.0.0.0/28"
+	expectPrefixUtilizationCounts(ctrl, mockIpam, prefix, 0, 7)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	// 14 usable addresses, 7 of them are assigned
+	actual, err := compositeClient.GetPrefixUtilization(context.TODO(), 3, prefix, "")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), actual.ChildPrefixes)
+	assert.Equal(t, int64(7), actual.ChildIpAddresses)
+	assert.Equal(t, big.NewInt(7), actual.FreeAddresses)
+	assert.Equal(t, int32(50), actual.UsedPercent)
+}
+
+func TestUsablePrefixSize(t *testing.T) {
+	tests := map[string]int64{
+		"10.0.0.0/24":    254,
+		"10.0.0.0/31":    2,
+		"10.0.0.1/32":    1,
+		"2001:db8::/120": 255,
+		"2001:db8::/127": 2,
+	}
+	for prefix, expected := range tests {
+		_, prefixNet, err := net.ParseCIDR(prefix)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(expected), usablePrefixSize(prefixNet), prefix)
+	}
+}
+
+func TestUsedPercent(t *testing.T) {
+	assert.Equal(t, int32(0), usedPercent(big.NewInt(0), big.NewInt(0)))
+	assert.Equal(t, int32(0), usedPercent(big.NewInt(256), big.NewInt(256)))
+	assert.Equal(t, int32(99), usedPercent(big.NewInt(256), big.NewInt(1)))
+	assert.Equal(t, int32(100), usedPercent(big.NewInt(256), big.NewInt(0)))
+
+	// the address space of an IPv6 /48 exceeds an int64
+	total := new(big.Int).Lsh(big.NewInt(1), 80)
+	free := new(big.Int).Rsh(total, 2)
+	assert.Equal(t, int32(75), usedPercent(total, free))
+}
@@ -55,29 +55,47 @@ func (c *NetboxClientV4) updatePrefixV4(ctx context.Context, prefixId int32, pre
 	return resp, nil
 }
 
-func (c *NetboxCompositeClient) writablePrefixRequestV4(prefix *models.Prefix) (*v4client.WritablePrefixRequest, error) {
+func (c *NetboxCompositeClient) writablePrefixRequestV4(ctx context.Context, prefix *models.Prefix) (*v4client.WritablePrefixRequest, error) {
 	desiredPrefix := v4client.NewWritablePrefixRequest(prefix.Prefix)
+	status, err := v4client.NewPatchedWritablePrefixRequestStatusFromValue(metadataStatus(prefix.Metadata))
+	if err != nil {
+		return nil, err
+	}
+	desiredPrefix.SetStatus(*status)
 
 	if prefix.Metadata != nil {
 		desiredPrefix.SetComments(prefix.Metadata.Comments + warningComment)
-		// Convert map[string]string to map[string]interface{}
-		customFields := make(map[string]interface{}, len(prefix.Metadata.Custom))
-		for k, v := range prefix.Metadata.Custom {
-			customFields[k] = v
+		customFields, err := c.customFieldsRequest(ctx, prefix.Metadata.Custom)
+		if err != nil {
+			return nil, err
 		}
 		desiredPrefix.SetCustomFields(customFields)
 		desiredPrefix.SetDescription(TruncateDescription(prefix.Metadata.Description))
 
 		if prefix.Metadata.Tenant != "" {
-			tenantDetails, err := c.getTenantDetails(prefix.Metadata.Tenant)
+			tenantDetails, err := c.getTenantDetails(ctx, prefix.Metadata.Tenant)
 			if err != nil {
 				return nil, err
 			}
 			tenantId := int32(tenantDetails.Id)
 			desiredPrefix.SetTenant(v4client.Int32AsASNRangeRequestTenant(&tenantId))
 		}
+		if prefix.Metadata.Vrf != "" {
+			vrfId, err := c.getVrfId(ctx, prefix.Metadata.Vrf)
+			if err != nil {
+				return nil, err
+			}
+			desiredPrefix.SetVrf(v4client.Int32AsIPAddressRequestVrf(vrfId))
+		}
+		if prefix.Metadata.Role != "" {
+			role, err := c.roleRequest(ctx, prefix.Metadata.Role)
+			if err != nil {
+				return nil, err
+			}
+			desiredPrefix.SetRole(role)
+		}
 		if prefix.Metadata.Site != "" {
-			siteDetails, err := c.getSiteDetails(prefix.Metadata.Site)
+			siteDetails, err := c.getSiteDetails(ctx, prefix.Metadata.Site)
 			if err != nil {
 				return nil, err
 			}
@@ -85,5 +103,23 @@ func (c *NetboxCompositeClient) writablePrefixRequestV4(prefix *models.Prefix) (
 			desiredPrefix.SetScopeId(int32(siteDetails.Id))
 		}
 	}
+
+	if prefix.Vlan != nil {
+		vlanId, err := c.getVlanId(ctx, prefix.Vlan)
+		if err != nil {
+			return nil, err
+		}
+		desiredPrefix.SetVlan(v4client.Int32AsInterfaceRequestUntaggedVlan(&vlanId))
+	} else if prefix.ClearVlan {
+		desiredPrefix.SetVlanNil()
+	}
+
+	if hasTags(prefix.Metadata) {
+		tags, err := c.tagsRequest(ctx, prefix.Metadata.Tags)
+		if err != nil {
+			return nil, err
+		}
+		desiredPrefix.SetTags(tags)
+	}
 	return desiredPrefix, nil
 }
@@ -17,12 +17,11 @@ limitations under the License.
 package api
 
 import (
+	"context"
 	"errors"
 	"testing"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/dcim"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
+	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/stretchr/testify/assert"
@@ -34,7 +33,7 @@ import (
 func TestWritablePrefixRequestV4_NoMetadata(t *testing.T) {
 	compositeClient := &NetboxCompositeClient{}
 
-	result, err := compositeClient.writablePrefixRequestV4(&models.Prefix{
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
 		Prefix: "10.0.0.0/24",
 	})
 
@@ -44,13 +43,21 @@ func TestWritablePrefixRequestV4_NoMetadata(t *testing.T) {
 }
 
 func TestWritablePrefixRequestV4_MetadataNoTenantNoSite(t *testing.T) {
-	compositeClient := &NetboxCompositeClient{}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{ExtrasAPI: mockExtrasAPIWithTypes(ctrl, map[string]v4client.CustomFieldTypeValue{
+			"key1": v4client.CUSTOMFIELDTYPEVALUE_TEXT,
+			"key2": v4client.CUSTOMFIELDTYPEVALUE_INTEGER,
+		})},
+	}
 
 	comments := "my comment"
 	description := "my description"
-	customFields := map[string]string{"key1": "val1", "key2": "val2"}
+	customFields := map[string]string{"key1": "val1", "key2": "42"}
 
-	result, err := compositeClient.writablePrefixRequestV4(&models.Prefix{
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
 		Prefix: "10.0.0.0/24",
 		Metadata: &models.NetboxMetadata{
 			Comments:    comments,
@@ -65,7 +72,28 @@ func TestWritablePrefixRequestV4_MetadataNoTenantNoSite(t *testing.T) {
 	assert.Equal(t, comments+warningComment, result.GetComments())
 	assert.Equal(t, TruncateDescription(description), result.GetDescription())
 	assert.Equal(t, "val1", result.GetCustomFields()["key1"])
-	assert.Equal(t, "val2", result.GetCustomFields()["key2"])
+	assert.Equal(t, int64(42), result.GetCustomFields()["key2"])
+}
+
+func TestWritablePrefixRequestV4_InvalidCustomFieldValue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{ExtrasAPI: mockExtrasAPIWithTypes(ctrl, map[string]v4client.CustomFieldTypeValue{
+			"vlan_id": v4client.CUSTOMFIELDTYPEVALUE_INTEGER,
+		})},
+	}
+
+	_, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Metadata: &models.NetboxMetadata{
+			Custom: map[string]string{"vlan_id": "one hundred"},
+		},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidCustomFieldValue)
+	assert.ErrorContains(t, err, `custom field vlan_id has type integer, "one hundred" is not an integer`)
 }
 
 func TestWritablePrefixRequestV4_WithTenant(t *testing.T) {
@@ -73,30 +101,16 @@ func TestWritablePrefixRequestV4_WithTenant(t *testing.T) {
 	defer ctrl.Finish()
 
 	tenantName := "Tenant1"
-	tenantId := int64(7)
+	tenantId := int32(7)
 	tenantSlug := "tenant1"
 
-	tenantListRequestInput := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-	tenantListRequestOutput := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantSlug,
-				},
-			},
-		},
-	}
-
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-	mockTenancy.EXPECT().TenancyTenantsList(tenantListRequestInput, nil).Return(tenantListRequestOutput, nil)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantSlug}}, nil)
 
 	compositeClient := &NetboxCompositeClient{
-		clientV3: &NetboxClientV3{Tenancy: mockTenancy},
+		clientV4: &NetboxClientV4{TenancyAPI: mockTenancy},
 	}
 
-	result, err := compositeClient.writablePrefixRequestV4(&models.Prefix{
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
 		Prefix: "10.0.0.0/24",
 		Metadata: &models.NetboxMetadata{
 			Tenant: tenantName,
@@ -105,7 +119,7 @@ func TestWritablePrefixRequestV4_WithTenant(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, int32(tenantId), *result.GetTenant().Int32)
+	assert.Equal(t, tenantId, *result.GetTenant().Int32)
 }
 
 func TestWritablePrefixRequestV4_WithSite(t *testing.T) {
@@ -113,30 +127,16 @@ func TestWritablePrefixRequestV4_WithSite(t *testing.T) {
 	defer ctrl.Finish()
 
 	siteName := "Site1"
-	siteId := int64(3)
+	siteId := int32(3)
 	siteSlug := "site1"
 
-	siteListRequestInput := dcim.NewDcimSitesListParams().WithName(&siteName)
-	siteListRequestOutput := &dcim.DcimSitesListOK{
-		Payload: &dcim.DcimSitesListOKBody{
-			Results: []*netboxModels.Site{
-				{
-					ID:   siteId,
-					Name: &siteName,
-					Slug: &siteSlug,
-				},
-			},
-		},
-	}
-
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
-	mockDcim.EXPECT().DcimSitesList(siteListRequestInput, nil).Return(siteListRequestOutput, nil)
+	mockDcim := mockDcimAPI(ctrl, siteName, []v4client.Site{{Id: siteId, Name: siteName, Slug: siteSlug}}, nil)
 
 	compositeClient := &NetboxCompositeClient{
-		clientV3: &NetboxClientV3{Dcim: mockDcim},
+		clientV4: &NetboxClientV4{DcimAPI: mockDcim},
 	}
 
-	result, err := compositeClient.writablePrefixRequestV4(&models.Prefix{
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
 		Prefix: "10.0.0.0/24",
 		Metadata: &models.NetboxMetadata{
 			Site: siteName,
@@ -146,7 +146,7 @@ func TestWritablePrefixRequestV4_WithSite(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, "dcim.site", result.GetScopeType())
-	assert.Equal(t, int32(siteId), result.GetScopeId())
+	assert.Equal(t, siteId, result.GetScopeId())
 }
 
 func TestWritablePrefixRequestV4_WithTenantAndSite(t *testing.T) {
@@ -154,50 +154,23 @@ func TestWritablePrefixRequestV4_WithTenantAndSite(t *testing.T) {
 	defer ctrl.Finish()
 
 	tenantName := "Tenant1"
-	tenantId := int64(7)
+	tenantId := int32(7)
 	tenantSlug := "tenant1"
 	siteName := "Site1"
-	siteId := int64(3)
+	siteId := int32(3)
 	siteSlug := "site1"
 
-	tenantListRequestInput := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
-	tenantListRequestOutput := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantId,
-					Name: &tenantName,
-					Slug: &tenantSlug,
-				},
-			},
-		},
-	}
-	siteListRequestInput := dcim.NewDcimSitesListParams().WithName(&siteName)
-	siteListRequestOutput := &dcim.DcimSitesListOK{
-		Payload: &dcim.DcimSitesListOKBody{
-			Results: []*netboxModels.Site{
-				{
-					ID:   siteId,
-					Name: &siteName,
-					Slug: &siteSlug,
-				},
-			},
-		},
-	}
-
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-	mockTenancy.EXPECT().TenancyTenantsList(tenantListRequestInput, nil).Return(tenantListRequestOutput, nil)
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
-	mockDcim.EXPECT().DcimSitesList(siteListRequestInput, nil).Return(siteListRequestOutput, nil)
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, []v4client.Tenant{{Id: tenantId, Name: tenantName, Slug: tenantSlug}}, nil)
+	mockDcim := mockDcimAPI(ctrl, siteName, []v4client.Site{{Id: siteId, Name: siteName, Slug: siteSlug}}, nil)
 
 	compositeClient := &NetboxCompositeClient{
-		clientV3: &NetboxClientV3{
-			Tenancy: mockTenancy,
-			Dcim:    mockDcim,
+		clientV4: &NetboxClientV4{
+			TenancyAPI: mockTenancy,
+			DcimAPI:    mockDcim,
 		},
 	}
 
-	result, err := compositeClient.writablePrefixRequestV4(&models.Prefix{
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
 		Prefix: "10.0.0.0/24",
 		Metadata: &models.NetboxMetadata{
 			Tenant: tenantName,
@@ -207,9 +180,9 @@ func TestWritablePrefixRequestV4_WithTenantAndSite(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, int32(tenantId), *result.GetTenant().Int32)
+	assert.Equal(t, tenantId, *result.GetTenant().Int32)
 	assert.Equal(t, "dcim.site", result.GetScopeType())
-	assert.Equal(t, int32(siteId), result.GetScopeId())
+	assert.Equal(t, siteId, result.GetScopeId())
 }
 
 func TestWritablePrefixRequestV4_TenantError(t *testing.T) {
@@ -217,16 +190,14 @@ func TestWritablePrefixRequestV4_TenantError(t *testing.T) {
 	defer ctrl.Finish()
 
 	tenantName := "NonExistentTenant"
-	tenantListRequestInput := tenancy.NewTenancyTenantsListParams().WithName(&tenantName)
 
-	mockTenancy := mock_interfaces.NewMockTenancyInterface(ctrl)
-	mockTenancy.EXPECT().TenancyTenantsList(tenantListRequestInput, nil).Return(nil, errors.New("tenant not found"))
+	mockTenancy := mockTenancyAPI(ctrl, tenantName, nil, errors.New("tenant not found"))
 
 	compositeClient := &NetboxCompositeClient{
-		clientV3: &NetboxClientV3{Tenancy: mockTenancy},
+		clientV4: &NetboxClientV4{TenancyAPI: mockTenancy},
 	}
 
-	result, err := compositeClient.writablePrefixRequestV4(&models.Prefix{
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
 		Prefix: "10.0.0.0/24",
 		Metadata: &models.NetboxMetadata{
 			Tenant: tenantName,
@@ -242,16 +213,14 @@ func TestWritablePrefixRequestV4_SiteError(t *testing.T) {
 	defer ctrl.Finish()
 
 	siteName := "NonExistentSite"
-	siteListRequestInput := dcim.NewDcimSitesListParams().WithName(&siteName)
 
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
-	mockDcim.EXPECT().DcimSitesList(siteListRequestInput, nil).Return(nil, errors.New("site not found"))
+	mockDcim := mockDcimAPI(ctrl, siteName, nil, errors.New("site not found"))
 
 	compositeClient := &NetboxCompositeClient{
-		clientV3: &NetboxClientV3{Dcim: mockDcim},
+		clientV4: &NetboxClientV4{DcimAPI: mockDcim},
 	}
 
-	result, err := compositeClient.writablePrefixRequestV4(&models.Prefix{
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
 		Prefix: "10.0.0.0/24",
 		Metadata: &models.NetboxMetadata{
 			Site: siteName,
@@ -261,3 +230,105 @@ func TestWritablePrefixRequestV4_SiteError(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Error(t, err)
 }
+
+func TestWritablePrefixRequestV4_WithStatusAndRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectRolesList(ctrl, mockIpam, v4client.Role{Id: 4, Name: "Kubernetes Pods", Slug: "k8s-pods"})
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Metadata: &models.NetboxMetadata{
+			Status: "reserved",
+			Role:   "k8s-pods",
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, v4client.PATCHEDWRITABLEPREFIXREQUESTSTATUS_RESERVED, result.GetStatus())
+	assert.Equal(t, int32(4), *result.GetRole().Int32)
+}
+
+func TestWritablePrefixRequestV4_DefaultStatus(t *testing.T) {
+	compositeClient := &NetboxCompositeClient{}
+
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, v4client.PATCHEDWRITABLEPREFIXREQUESTSTATUS_ACTIVE, result.GetStatus())
+	assert.False(t, result.HasRole())
+}
+
+func TestWritablePrefixRequestV4_WithVlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prodVlans := v4client.VLANGroup{Id: 7, Name: "Production VLANs", Slug: "prod-vlans"}
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectVlanGroupsList(ctrl, mockIpam, prodVlans)
+	expectVlansList(ctrl, mockIpam, newVlan(11, 100, "k8s-nodes", &prodVlans))
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Vlan:   &models.Vlan{Vid: 100, VlanGroup: "prod-vlans"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(11), *result.GetVlan().Int32)
+}
+
+func TestWritablePrefixRequestV4_ClearVlan(t *testing.T) {
+	compositeClient := &NetboxCompositeClient{}
+
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
+		Prefix:    "10.0.0.0/24",
+		ClearVlan: true,
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, result.Vlan.IsSet(), "expected the vlan to be sent to unassign the prefix from it")
+	assert.Nil(t, result.Vlan.Get())
+}
+
+func TestWritablePrefixRequestV4_WithoutVlan(t *testing.T) {
+	compositeClient := &NetboxCompositeClient{}
+
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+	})
+
+	assert.Nil(t, err)
+	assert.False(t, result.Vlan.IsSet(), "expected the vlan which was not set by the operator to be kept")
+}
+
+func TestWritablePrefixRequestV4_VlanNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectVlansList(ctrl, mockIpam)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	result, err := compositeClient.writablePrefixRequestV4(context.TODO(), &models.Prefix{
+		Prefix: "10.0.0.0/24",
+		Vlan:   &models.Vlan{Name: "k8s-nodes"},
+	})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "failed to fetch vlan 'k8s-nodes': not found")
+}
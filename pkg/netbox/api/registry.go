@@ -0,0 +1,243 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClientRegistry builds and caches a composite client for each NetBoxConnection.
+// Resources without a connection use the default client, which connects to the
+// NetBox instance of the operator configuration.
+type ClientRegistry struct {
+	// connectionReader reads the NetBoxConnections, usually the cached client of the manager
+	connectionReader client.Reader
+	// secretReader reads the Secrets referenced by the NetBoxConnections, usually the
+	// api reader of the manager so that the operator doesn't cache all Secrets of the cluster
+	secretReader client.Reader
+	// secretNamespace is the namespace of the operator, only the Secrets in it can be referenced
+	// by a NetBoxConnection, so that its token can't be read from the Secrets of other namespaces
+	secretNamespace string
+	defaultClient   *NetboxCompositeClient
+
+	mu      sync.Mutex
+	clients map[string]*connectionClient
+}
+
+type connectionClient struct {
+	generation int64
+	caCert     []byte
+	client     *NetboxCompositeClient
+}
+
+// NewClientRegistry creates a client registry, defaultClient is used for resources
+// without a connection. The NetBoxConnections can only reference Secrets in secretNamespace.
+func NewClientRegistry(connectionReader client.Reader, secretReader client.Reader, secretNamespace string, defaultClient *NetboxCompositeClient) *ClientRegistry {
+	return &ClientRegistry{
+		connectionReader: connectionReader,
+		secretReader:     secretReader,
+		secretNamespace:  secretNamespace,
+		defaultClient:    defaultClient,
+		clients:          make(map[string]*connectionClient),
+	}
+}
+
+// DefaultClient returns the client of the NetBox instance of the operator configuration
+func (r *ClientRegistry) DefaultClient() *NetboxCompositeClient {
+	return r.defaultClient
+}
+
+// Clients returns the default client and the cached clients of the NetBoxConnections
+func (r *ClientRegistry) Clients() []*NetboxCompositeClient {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clients := make([]*NetboxCompositeClient, 0, len(r.clients)+1)
+	if r.defaultClient != nil {
+		clients = append(clients, r.defaultClient)
+	}
+	for _, cached := range r.clients {
+		clients = append(clients, cached.client)
+	}
+	return clients
+}
+
+// ClientFor returns the client of the NetBoxConnection with the name connectionName,
+// or the default client if connectionName is empty. The client is built on first use
+// and rebuilt when the spec of the NetBoxConnection changes.
+func (r *ClientRegistry) ClientFor(ctx context.Context, connectionName string) (*NetboxCompositeClient, error) {
+	if connectionName == "" {
+		return r.defaultClient, nil
+	}
+
+	connection, err := r.getConnection(ctx, connectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached := r.cachedClient(connectionName, connection.Generation); cached != nil {
+		return cached, nil
+	}
+
+	// the Secrets are read without holding the lock, so that a missing or slow Secret
+	// doesn't block the reconciles of the resources of other connections
+	connectionConfig, err := r.connectionConfigOf(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// the client may have been built by another reconcile while the Secrets were read
+	if cached, ok := r.clients[connectionName]; ok && cached.generation == connection.Generation {
+		return cached.client, nil
+	}
+	return r.build(connection, connectionConfig)
+}
+
+// cachedClient returns the cached client of the NetBoxConnection if it was built for the
+// generation, nil otherwise
+func (r *ClientRegistry) cachedClient(connectionName string, generation int64) *NetboxCompositeClient {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cached, ok := r.clients[connectionName]; ok && cached.generation == generation {
+		return cached.client
+	}
+	return nil
+}
+
+// Refresh re-reads the Secrets referenced by the NetBoxConnection with the name connectionName.
+// A rotated token is swapped into the cached client, which keeps being used, a changed CA
+// certificate or spec causes the client to be rebuilt.
+func (r *ClientRegistry) Refresh(ctx context.Context, connectionName string) (*NetboxCompositeClient, error) {
+	connection, err := r.getConnection(ctx, connectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionConfig, err := r.connectionConfigOf(ctx, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cached, ok := r.clients[connectionName]
+	if !ok || cached.generation != connection.Generation || !bytes.Equal(cached.caCert, connectionConfig.CaCert) {
+		return r.build(connection, connectionConfig)
+	}
+
+	if cached.client.Tokens().SetToken(connectionConfig.Tokens.Token()) {
+		log.StandardLogger().Infof("swapped rotated api token of NetBoxConnection %s", connectionName)
+	}
+	return cached.client, nil
+}
+
+// Forget removes the cached client of the NetBoxConnection with the name connectionName
+func (r *ClientRegistry) Forget(connectionName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.clients, connectionName)
+}
+
+func (r *ClientRegistry) getConnection(ctx context.Context, connectionName string) (*netboxv1.NetBoxConnection, error) {
+	connection := &netboxv1.NetBoxConnection{}
+	if err := r.connectionReader.Get(ctx, types.NamespacedName{Name: connectionName}, connection); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Forget(connectionName)
+		}
+		return nil, fmt.Errorf("failed to get NetBoxConnection %s: %w", connectionName, err)
+	}
+	return connection, nil
+}
+
+// build creates the client of a NetBoxConnection and caches it, the caller must hold the lock
+func (r *ClientRegistry) build(connection *netboxv1.NetBoxConnection, connectionConfig *ConnectionConfig) (*NetboxCompositeClient, error) {
+	compositeClient, err := NewNetboxCompositeClientForConnection(connectionConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create netbox client for NetBoxConnection %s: %w", connection.Name, err)
+	}
+
+	r.clients[connection.Name] = &connectionClient{
+		generation: connection.Generation,
+		caCert:     connectionConfig.CaCert,
+		client:     compositeClient,
+	}
+	return compositeClient, nil
+}
+
+func (r *ClientRegistry) connectionConfigOf(ctx context.Context, connection *netboxv1.NetBoxConnection) (*ConnectionConfig, error) {
+	token, err := r.secretValue(ctx, connection.Spec.TokenSecretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	connectionConfig := &ConnectionConfig{
+		Host:        connection.Spec.Host,
+		Tokens:      NewTokenProvider(strings.TrimSpace(string(token))),
+		HttpsEnable: connection.Spec.HttpsEnable == nil || *connection.Spec.HttpsEnable,
+		Retry:       GetRetryConfig(),
+		RateLimiter: NewRateLimiter(),
+		Breaker:     NewCircuitBreakerFromConfig(connection.Spec.Host),
+		Versions:    NewVersionCacheFromConfig(connection.Spec.Host),
+		Lookups:     NewLookupCacheFromConfig(),
+	}
+
+	if connection.Spec.CaCertSecretRef != nil {
+		connectionConfig.CaCert, err = r.secretValue(ctx, *connection.Spec.CaCertSecretRef)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return connectionConfig, nil
+}
+
+func (r *ClientRegistry) secretValue(ctx context.Context, ref netboxv1.SecretKeyReference) ([]byte, error) {
+	if ref.Namespace == "" {
+		ref.Namespace = r.secretNamespace
+	}
+	if ref.Namespace != r.secretNamespace {
+		return nil, fmt.Errorf("secret %s/%s is not in the namespace %s of the operator", ref.Namespace, ref.Name, r.secretNamespace)
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.secretReader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok || len(value) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no value for key %s", ref.Namespace, ref.Name, ref.Key)
+	}
+	return value, nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newRegistryTestClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, netboxv1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func registryTestObjects() (*netboxv1.NetBoxConnection, *corev1.Secret) {
+	httpsEnable := false
+	connection := &netboxv1.NetBoxConnection{
+		ObjectMeta: metav1.ObjectMeta{Name: "netbox-lab", Generation: 1},
+		Spec: netboxv1.NetBoxConnectionSpec{
+			Host:        "netbox-lab.example.com",
+			HttpsEnable: &httpsEnable,
+			// the Secret is read from the namespace of the operator
+			TokenSecretRef: netboxv1.SecretKeyReference{
+				Name: "netbox-lab-token",
+				Key:  "token",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "netbox-lab-token", Namespace: "netbox-operator-system"},
+		Data:       map[string][]byte{"token": []byte("0123456789abcdef\n")},
+	}
+	return connection, secret
+}
+
+func TestClientRegistry_ClientFor_DefaultClient(t *testing.T) {
+	defaultClient := NewNetboxCompositeClient(&NetboxClientV4{})
+	registry := NewClientRegistry(nil, nil, "", defaultClient)
+
+	actual, err := registry.ClientFor(context.Background(), "")
+	assert.NoError(t, err)
+	assert.Same(t, defaultClient, actual)
+}
+
+func TestClientRegistry_ClientFor_Connection(t *testing.T) {
+	connection, secret := registryTestObjects()
+	k8sClient := newRegistryTestClient(t, connection, secret)
+	registry := NewClientRegistry(k8sClient, k8sClient, "netbox-operator-system", nil)
+
+	actual, err := registry.ClientFor(context.Background(), "netbox-lab")
+	require.NoError(t, err)
+	assert.Equal(t, "http://netbox-lab.example.com", actual.BaseUrl())
+	// the trailing newline of the token in the Secret is trimmed
+	assert.Equal(t, "0123456789abcdef", actual.Tokens().Token())
+
+	cached, err := registry.ClientFor(context.Background(), "netbox-lab")
+	require.NoError(t, err)
+	assert.Same(t, actual, cached)
+}
+
+// blockingSecretReader blocks the reads of the Secret with the name until unblock is closed
+type blockingSecretReader struct {
+	client.Reader
+	name    string
+	blocked chan struct{}
+	unblock chan struct{}
+}
+
+func (r *blockingSecretReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if key.Name == r.name {
+		close(r.blocked)
+		<-r.unblock
+	}
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+func TestClientRegistry_ClientFor_SlowSecretDoesNotBlockOtherConnections(t *testing.T) {
+	connection, secret := registryTestObjects()
+	slowConnection := connection.DeepCopy()
+	slowConnection.Name = "netbox-slow"
+	slowConnection.Spec.TokenSecretRef.Name = "netbox-slow-token"
+	k8sClient := newRegistryTestClient(t, connection, secret, slowConnection)
+	secretReader := &blockingSecretReader{Reader: k8sClient, name: "netbox-slow-token", blocked: make(chan struct{}), unblock: make(chan struct{})}
+	registry := NewClientRegistry(k8sClient, secretReader, "netbox-operator-system", nil)
+
+	cached, err := registry.ClientFor(context.Background(), "netbox-lab")
+	require.NoError(t, err)
+
+	slowErr := make(chan error)
+	go func() {
+		_, err := registry.ClientFor(context.Background(), "netbox-slow")
+		slowErr <- err
+	}()
+	<-secretReader.blocked
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		actual, err := registry.ClientFor(context.Background(), "netbox-lab")
+		assert.NoError(t, err)
+		assert.Same(t, cached, actual)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the cached client was blocked by the Secret read of another connection")
+	}
+
+	close(secretReader.unblock)
+	assert.ErrorContains(t, <-slowErr, "failed to get secret netbox-operator-system/netbox-slow-token")
+}
+
+func TestClientRegistry_ClientFor_RebuildsOnSpecChange(t *testing.T) {
+	connection, secret := registryTestObjects()
+	k8sClient := newRegistryTestClient(t, connection, secret)
+	registry := NewClientRegistry(k8sClient, k8sClient, "netbox-operator-system", nil)
+
+	first, err := registry.ClientFor(context.Background(), "netbox-lab")
+	require.NoError(t, err)
+
+	updated := &netboxv1.NetBoxConnection{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(connection), updated))
+	updated.Spec.Host = "netbox-lab-2.example.com"
+	updated.Generation = 2
+	require.NoError(t, k8sClient.Update(context.Background(), updated))
+
+	second, err := registry.ClientFor(context.Background(), "netbox-lab")
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, "http://netbox-lab-2.example.com", second.BaseUrl())
+}
+
+func TestClientRegistry_Refresh_SwapsRotatedToken(t *testing.T) {
+	connection, secret := registryTestObjects()
+	k8sClient := newRegistryTestClient(t, connection, secret)
+	registry := NewClientRegistry(k8sClient, k8sClient, "netbox-operator-system", nil)
+
+	first, err := registry.ClientFor(context.Background(), "netbox-lab")
+	require.NoError(t, err)
+
+	rotated := &corev1.Secret{}
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(secret), rotated))
+	rotated.Data["token"] = []byte("fedcba9876543210")
+	require.NoError(t, k8sClient.Update(context.Background(), rotated))
+
+	refreshed, err := registry.Refresh(context.Background(), "netbox-lab")
+	require.NoError(t, err)
+	assert.Same(t, first, refreshed)
+	assert.Equal(t, "fedcba9876543210", refreshed.Tokens().Token())
+}
+
+func TestClientRegistry_ClientFor_Errors(t *testing.T) {
+	connection, _ := registryTestObjects()
+	foreignSecretConnection := connection.DeepCopy()
+	foreignSecretConnection.Spec.TokenSecretRef.Namespace = "kube-system"
+
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		wantErr string
+	}{
+		{
+			name:    "connection not found",
+			wantErr: "failed to get NetBoxConnection netbox-lab",
+		},
+		{
+			name:    "token secret not found",
+			objs:    []client.Object{connection},
+			wantErr: "failed to get secret netbox-operator-system/netbox-lab-token",
+		},
+		{
+			name: "token key missing",
+			objs: []client.Object{connection, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "netbox-lab-token", Namespace: "netbox-operator-system"},
+				Data:       map[string][]byte{"other": []byte("value")},
+			}},
+			wantErr: "secret netbox-operator-system/netbox-lab-token has no value for key token",
+		},
+		{
+			name: "token secret in another namespace",
+			objs: []client.Object{foreignSecretConnection, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "netbox-lab-token", Namespace: "kube-system"},
+				Data:       map[string][]byte{"token": []byte("0123456789abcdef")},
+			}},
+			wantErr: "secret kube-system/netbox-lab-token is not in the namespace netbox-operator-system of the operator",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sClient := newRegistryTestClient(t, tt.objs...)
+			registry := NewClientRegistry(k8sClient, k8sClient, "netbox-operator-system", nil)
+
+			_, err := registry.ClientFor(context.Background(), "netbox-lab")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
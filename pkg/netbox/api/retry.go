@@ -0,0 +1,202 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	operatormetrics "github.com/netbox-community/netbox-operator/pkg/metrics"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+// maxDrainBodySize limits how much of the body of a failed attempt is read before the
+// connection is reused for the retry
+const maxDrainBodySize = 4096
+
+// RetryConfig holds the retry settings of the requests to a NetBox instance,
+// the zero value disables retries
+type RetryConfig struct {
+	// MaxRetries is the number of times a failed request is retried
+	MaxRetries int
+	// InitialBackoff is the backoff before the first retry, it is doubled with every retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the backoff, a response asking for a longer Retry-After is not retried
+	MaxBackoff time.Duration
+}
+
+// GetRetryConfig returns the retry settings of the operator configuration
+func GetRetryConfig() RetryConfig {
+	operatorConfig := config.GetOperatorConfig()
+	return RetryConfig{
+		MaxRetries:     operatorConfig.NetboxRetryMax,
+		InitialBackoff: operatorConfig.NetboxRetryInitialBackoff,
+		MaxBackoff:     operatorConfig.NetboxRetryMaxBackoff,
+	}
+}
+
+// NewRateLimiter returns a token bucket rate limiter with the settings of the operator
+// configuration, it returns nil if rate limiting is disabled
+func NewRateLimiter() *rate.Limiter {
+	operatorConfig := config.GetOperatorConfig()
+	if operatorConfig.NetboxRateLimitQPS <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(operatorConfig.NetboxRateLimitQPS), operatorConfig.NetboxRateLimitBurst)
+}
+
+// RetryRoundTripper waits for the rate limiter before each attempt of a request and retries
+// failed requests with an exponential backoff with jitter. Requests with an idempotent method
+// are retried on network errors and 5xx responses, all requests are retried on 429 responses
+// since NetBox did not process them. A request whose body cannot be rewound is not retried.
+type RetryRoundTripper struct {
+	Config RetryConfig
+	// Limiter is shared by all clients of a NetBox instance, nil disables rate limiting
+	Limiter   *rate.Limiter
+	Transport http.RoundTripper
+}
+
+func (rrt *RetryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	attemptReq := req
+
+	for attempt := 0; ; attempt++ {
+		if rrt.Limiter != nil {
+			if err := rrt.Limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := rrt.Transport.RoundTrip(attemptReq)
+		if attempt >= rrt.Config.MaxRetries || ctx.Err() != nil || !isRetryable(req, resp, err) {
+			return resp, err
+		}
+
+		backoff, ok := rrt.backoff(attempt, resp)
+		if !ok {
+			return resp, err
+		}
+
+		// the request is cloned before the body of the failed attempt is discarded, so that
+		// the response and error of the attempt are returned if the body cannot be rewound
+		rewound, rewindErr := rewind(req)
+		if rewindErr != nil {
+			return resp, err
+		}
+		attemptReq = rewound
+
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBodySize))
+			resp.Body.Close()
+		}
+		log.StandardLogger().Debugf("retrying %s request to netbox at host %s in %v, attempt %d failed with status %d", req.Method, req.URL.Host, backoff, attempt+1, statusCode)
+		operatormetrics.ObserveNetboxRequestRetry(req.Method, statusCode)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff returns the time to wait before the retry after attempt, it returns false if
+// the response asks for a Retry-After beyond the maximum backoff
+func (rrt *RetryRoundTripper) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return retryAfter, retryAfter <= rrt.Config.MaxBackoff
+		}
+	}
+
+	backoff := rrt.Config.InitialBackoff
+	for i := 0; i < attempt && backoff < rrt.Config.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, rrt.Config.MaxBackoff)
+
+	// equal jitter, spread the retries of concurrent requests over the second half of the backoff
+	half := backoff / 2
+	return half + rand.N(backoff-half+1), true
+}
+
+// isRetryable returns true if the attempt of req failed in a way that is worth a retry
+func isRetryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return isIdempotent(req.Method)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError && isIdempotent(req.Method)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// rewind returns a clone of req with a fresh body for the next attempt
+func rewind(req *http.Request) (*http.Request, error) {
+	retryReq := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return retryReq, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("request body can't be rewound")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	retryReq.Body = body
+	return retryReq, nil
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+var testRetryConfig = RetryConfig{
+	MaxRetries:     3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     10 * time.Millisecond,
+}
+
+func TestRetryRoundTripper(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		statusCodes  []int
+		retryAfter   string
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{
+			name:         "success",
+			method:       http.MethodGet,
+			statusCodes:  []int{http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 1,
+		},
+		{
+			name:         "get retried on bad gateway",
+			method:       http.MethodGet,
+			statusCodes:  []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		{
+			name:         "post not retried on bad gateway",
+			method:       http.MethodPost,
+			statusCodes:  []int{http.StatusBadGateway, http.StatusOK},
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 1,
+		},
+		{
+			name:         "post retried on too many requests",
+			method:       http.MethodPost,
+			statusCodes:  []int{http.StatusTooManyRequests, http.StatusCreated},
+			retryAfter:   "0",
+			wantStatus:   http.StatusCreated,
+			wantAttempts: 2,
+		},
+		{
+			name:         "not retried beyond max retries",
+			method:       http.MethodDelete,
+			statusCodes:  []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 4,
+		},
+		{
+			name:         "not retried if retry after exceeds max backoff",
+			method:       http.MethodGet,
+			statusCodes:  []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:   "120",
+			wantStatus:   http.StatusTooManyRequests,
+			wantAttempts: 1,
+		},
+		{
+			name:         "client error not retried",
+			method:       http.MethodGet,
+			statusCodes:  []int{http.StatusBadRequest, http.StatusOK},
+			wantStatus:   http.StatusBadRequest,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				assert.Equal(t, `{"prefix": "10.0.0.0/24"}`, string(body))
+
+				attempt := attempts.Add(1)
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statusCodes[attempt-1])
+			}))
+			defer server.Close()
+
+			httpClient := &http.Client{Transport: &RetryRoundTripper{Config: testRetryConfig, Transport: http.DefaultTransport}}
+			req, err := http.NewRequest(tt.method, server.URL, strings.NewReader(`{"prefix": "10.0.0.0/24"}`))
+			require.NoError(t, err)
+
+			resp, err := httpClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+		})
+	}
+}
+
+// roundTripperFunc is a transport which calls the function for each request
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRetryRoundTripper_BodyCannotBeRewound(t *testing.T) {
+	networkErr := errors.New("connection reset by peer")
+	var attempts int
+	rrt := &RetryRoundTripper{Config: testRetryConfig, Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, networkErr
+	})}
+
+	req, err := http.NewRequest(http.MethodPut, "http://netbox.example.com/api/ipam/prefixes/1/", strings.NewReader(`{"prefix": "10.0.0.0/24"}`))
+	require.NoError(t, err)
+	req.GetBody = func() (io.ReadCloser, error) { return nil, errors.New("body already consumed") }
+
+	// the error of the attempt is returned, not a nil response without error
+	resp, err := rrt.RoundTrip(req)
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, networkErr)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryRoundTripper_RateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// a burst of 1 at 20 requests per second lets the first request pass and delays the following ones by 50ms each
+	httpClient := &http.Client{Transport: &RetryRoundTripper{Limiter: rate.NewLimiter(20, 1), Transport: http.DefaultTransport}}
+
+	start := time.Now()
+	for range 3 {
+		resp, err := httpClient.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestRetryRoundTripper_Backoff(t *testing.T) {
+	rrt := &RetryRoundTripper{Config: RetryConfig{MaxRetries: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}}
+
+	for attempt, maxBackoff := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second} {
+		backoff, ok := rrt.backoff(attempt, nil)
+		assert.True(t, ok)
+		assert.GreaterOrEqual(t, backoff, maxBackoff/2)
+		assert.LessOrEqual(t, backoff, maxBackoff)
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"1"}}}
+	backoff, ok := rrt.backoff(0, resp)
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, backoff)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	retryAfter, ok := parseRetryAfter("30")
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	retryAfter, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.InDelta(t, time.Hour, retryAfter, float64(2*time.Second))
+
+	retryAfter, ok = parseRetryAfter(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Zero(t, retryAfter)
+
+	for _, value := range []string{"", "-1", "soon"} {
+		_, ok = parseRetryAfter(value)
+		assert.False(t, ok, value)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+)
+
+// roleRequest returns the IPAM role with the name or slug as role of a write request
+func (c *NetboxCompositeClient) roleRequest(ctx context.Context, nameOrSlug string) (v4client.IPRangeRequestRole, error) {
+	role, err := c.getRoleDetails(ctx, nameOrSlug)
+	if err != nil {
+		return v4client.IPRangeRequestRole{}, err
+	}
+	roleId := int32(role.Id)
+	return v4client.Int32AsIPRangeRequestRole(&roleId), nil
+}
+
+// getRoleDetails returns the IPAM role with the name or slug, it is served from the lookup cache if possible
+func (c *NetboxCompositeClient) getRoleDetails(ctx context.Context, nameOrSlug string) (*models.Role, error) {
+	return cachedLookup(c.lookups, roleLookupKind, nameOrSlug, func() (*models.Role, error) {
+		return c.fetchRoleDetails(ctx, nameOrSlug)
+	})
+}
+
+// fetchRoleDetails returns the IPAM role with the name, or if there is none, the role with the slug
+func (c *NetboxCompositeClient) fetchRoleDetails(ctx context.Context, nameOrSlug string) (*models.Role, error) {
+	roles, err := listRoles(c.clientV4.IpamAPI.IpamRolesList(ctx).Name([]string{nameOrSlug}))
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		roles, err = listRoles(c.clientV4.IpamAPI.IpamRolesList(ctx).Slug([]string{nameOrSlug}))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(roles) == 0 {
+		return nil, utils.NetboxNotFoundError("role '" + nameOrSlug + "'")
+	}
+
+	return &models.Role{
+		Id:   int64(roles[0].Id),
+		Name: roles[0].Name,
+		Slug: roles[0].Slug,
+	}, nil
+}
+
+func listRoles(req interfaces.IpamRolesListRequest) (roles []v4client.Role, err error) {
+	response, httpResp, execErr := req.Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "fetch Role details")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+
+	return response.Results, nil
+}
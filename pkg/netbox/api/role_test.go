@@ -0,0 +1,90 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// expectRolesList expects any number of lookups of roles on the IpamAPI, in which only the
+// roles exist, they are looked up by name or slug
+func expectRolesList(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, roles ...v4client.Role) {
+	mockIpam.EXPECT().IpamRolesList(gomock.Any()).DoAndReturn(func(_ context.Context) interfaces.IpamRolesListRequest {
+		list := &v4client.PaginatedRoleList{Results: []v4client.Role{}}
+		filter := func(matches func(role v4client.Role) bool) {
+			for _, role := range roles {
+				if matches(role) {
+					list.Results = append(list.Results, role)
+				}
+			}
+			list.Count = int32(len(list.Results))
+		}
+
+		mockListRequest := mock_interfaces.NewMockIpamRolesListRequest(ctrl)
+		mockListRequest.EXPECT().Name(gomock.Any()).DoAndReturn(func(name []string) interfaces.IpamRolesListRequest {
+			filter(func(role v4client.Role) bool { return role.Name == name[0] })
+			return mockListRequest
+		}).AnyTimes()
+		mockListRequest.EXPECT().Slug(gomock.Any()).DoAndReturn(func(slug []string) interfaces.IpamRolesListRequest {
+			filter(func(role v4client.Role) bool { return role.Slug == slug[0] })
+			return mockListRequest
+		}).AnyTimes()
+		mockListRequest.EXPECT().Execute().DoAndReturn(func() (*v4client.PaginatedRoleList, *http.Response, error) {
+			return list, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})
+		return mockListRequest
+	}).AnyTimes()
+}
+
+func TestRole_GetRoleDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectRolesList(ctrl, mockIpam, v4client.Role{Id: 4, Name: "Kubernetes Pods", Slug: "k8s-pods"})
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+	expected := &models.Role{Id: 4, Name: "Kubernetes Pods", Slug: "k8s-pods"}
+
+	t.Run("Role is found by its name.", func(t *testing.T) {
+		actual, err := compositeClient.getRoleDetails(context.TODO(), "Kubernetes Pods")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Role is found by its slug.", func(t *testing.T) {
+		actual, err := compositeClient.getRoleDetails(context.TODO(), "k8s-pods")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Role does not exist.", func(t *testing.T) {
+		actual, err := compositeClient.getRoleDetails(context.TODO(), "k8s-services")
+		assert.Nil(t, actual)
+		assert.EqualError(t, err, "failed to fetch role 'k8s-services': not found")
+	})
+}
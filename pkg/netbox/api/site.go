@@ -17,25 +17,39 @@ limitations under the License.
 package api
 
 import (
-	"github.com/netbox-community/go-netbox/v3/netbox/client/dcim"
+	"context"
+	"errors"
+	"net/http"
 
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
 )
 
-func (c *NetboxCompositeClient) getSiteDetails(name string) (*models.Site, error) {
-	request := dcim.NewDcimSitesListParams().WithName(&name)
-	response, err := c.clientV3.Dcim.DcimSitesList(request, nil)
-	if err != nil {
-		return nil, utils.NetboxError("failed to fetch Site details", err)
+// getSiteDetails returns the site with the name, it is served from the lookup cache if possible
+func (c *NetboxCompositeClient) getSiteDetails(ctx context.Context, name string) (*models.Site, error) {
+	return cachedLookup(c.lookups, siteLookupKind, name, func() (*models.Site, error) {
+		return c.fetchSiteDetails(ctx, name)
+	})
+}
+
+func (c *NetboxCompositeClient) fetchSiteDetails(ctx context.Context, name string) (details *models.Site, err error) {
+	response, httpResp, execErr := c.clientV4.DcimAPI.DcimSitesList(ctx).Name([]string{name}).Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "fetch Site details")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
 	}
-	if len(response.Payload.Results) == 0 {
+	if handleErr != nil {
+		return nil, handleErr
+	}
+
+	if len(response.Results) == 0 {
 		return nil, utils.NetboxNotFoundError("site '" + name + "'")
 	}
 
 	return &models.Site{
-		Id:   response.Payload.Results[0].ID,
-		Slug: *response.Payload.Results[0].Slug,
-		Name: *response.Payload.Results[0].Name,
+		Id:   int64(response.Results[0].Id),
+		Slug: response.Results[0].Slug,
+		Name: response.Results[0].Name,
 	}, nil
 }
@@ -17,75 +17,81 @@ limitations under the License.
 package api
 
 import (
+	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/dcim"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
+	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
+// mockDcimAPI returns a DcimAPI which lists the sites, or fails with err, for any number of
+// lookups of the site with the name
+func mockDcimAPI(ctrl *gomock.Controller, name string, sites []v4client.Site, err error) *mock_interfaces.MockDcimAPI {
+	mockDcim := mock_interfaces.NewMockDcimAPI(ctrl)
+	mockListRequest := mock_interfaces.NewMockDcimSitesListRequest(ctrl)
+	mockDcim.EXPECT().DcimSitesList(gomock.Any()).Return(mockListRequest).AnyTimes()
+	mockListRequest.EXPECT().Name([]string{name}).Return(mockListRequest).AnyTimes()
+	if err != nil {
+		mockListRequest.EXPECT().Execute().Return(nil, nil, err).AnyTimes()
+	} else {
+		mockListRequest.EXPECT().Execute().DoAndReturn(func() (*v4client.PaginatedSiteList, *http.Response, error) {
+			return &v4client.PaginatedSiteList{Count: int32(len(sites)), Results: sites}, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}).AnyTimes()
+	}
+	return mockDcim
+}
+
 func TestSite_GetSiteDetails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
+	mockDcim := mock_interfaces.NewMockDcimAPI(ctrl)
+	mockListRequest := mock_interfaces.NewMockDcimSitesListRequest(ctrl)
 
 	site := "mySite"
 
-	siteListRequestInput := dcim.NewDcimSitesListParams().WithName(&site)
-
-	siteOutputId := int64(1)
+	siteOutputId := int32(1)
 	siteOutputSlug := "mysite"
-	siteListOutput := &dcim.DcimSitesListOK{
-		Payload: &dcim.DcimSitesListOKBody{
-			Results: []*netboxModels.Site{
-				{
-					ID:   siteOutputId,
-					Name: &site,
-					Slug: &siteOutputSlug,
-				},
+	siteListOutput := &v4client.PaginatedSiteList{
+		Count: 1,
+		Results: []v4client.Site{
+			{
+				Id:   siteOutputId,
+				Name: site,
+				Slug: siteOutputSlug,
 			},
 		},
 	}
 
-	mockDcim.EXPECT().DcimSitesList(siteListRequestInput, nil).Return(siteListOutput, nil)
-	clientV3 := &NetboxClientV3{Dcim: mockDcim}
+	mockDcim.EXPECT().DcimSitesList(gomock.Any()).Return(mockListRequest)
+	mockListRequest.EXPECT().Name([]string{site}).Return(mockListRequest)
+	mockListRequest.EXPECT().Execute().Return(siteListOutput, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
+		clientV4: &NetboxClientV4{DcimAPI: mockDcim},
 	}
 
-	actual, err := compositeClient.getSiteDetails(site)
+	actual, err := compositeClient.getSiteDetails(context.TODO(), site)
 	assert.NoError(t, err)
 	assert.Equal(t, site, actual.Name)
-	assert.Equal(t, siteOutputId, actual.Id)
+	assert.Equal(t, int64(siteOutputId), actual.Id)
 	assert.Equal(t, siteOutputSlug, actual.Slug)
 }
 
 func TestSite_GetEmptyResult(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
 
 	site := "mySite"
 
-	siteListRequestInput := dcim.NewDcimSitesListParams().WithName(&site)
-
-	emptyListSiteOutput := &dcim.DcimSitesListOK{
-		Payload: &dcim.DcimSitesListOKBody{
-			Results: []*netboxModels.Site{},
-		},
-	}
-
-	mockDcim.EXPECT().DcimSitesList(siteListRequestInput, nil).Return(emptyListSiteOutput, nil)
-	clientV3 := &NetboxClientV3{Dcim: mockDcim}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
+		clientV4: &NetboxClientV4{DcimAPI: mockDcimAPI(ctrl, site, nil, nil)},
 	}
 
-	actual, err := compositeClient.getSiteDetails(site)
+	actual, err := compositeClient.getSiteDetails(context.TODO(), site)
 	assert.Nil(t, actual)
 	assert.EqualError(t, err, "failed to fetch site 'mySite': not found")
 }
@@ -93,21 +99,15 @@ func TestSite_GetEmptyResult(t *testing.T) {
 func TestSite_GetError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockDcim := mock_interfaces.NewMockDcimInterface(ctrl)
 
 	site := "mySite"
-
-	siteListRequestInput := dcim.NewDcimSitesListParams().WithName(&site)
-
 	expectedErr := "error getting sites list"
 
-	mockDcim.EXPECT().DcimSitesList(siteListRequestInput, nil).Return(nil, errors.New(expectedErr))
-	clientV3 := &NetboxClientV3{Dcim: mockDcim}
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
+		clientV4: &NetboxClientV4{DcimAPI: mockDcimAPI(ctrl, site, nil, errors.New(expectedErr))},
 	}
 
-	actual, err := compositeClient.getSiteDetails(site)
+	actual, err := compositeClient.getSiteDetails(context.TODO(), site)
 	assert.Nil(t, actual)
 	assert.EqualError(t, err, "failed to fetch Site details: "+expectedErr)
 }
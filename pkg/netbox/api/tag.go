@@ -0,0 +1,169 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+)
+
+// invalidSlugCharacters matches the characters which NetBox doesn't allow in a slug
+var invalidSlugCharacters = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// tagsRequest returns the tags with the names or slugs as tags of a write request. Tags which
+// don't exist in NetBox are created if NETBOX_AUTO_CREATE_TAGS is set.
+func (c *NetboxCompositeClient) tagsRequest(ctx context.Context, tags []string) ([]v4client.NestedTagRequest, error) {
+	tagsRequest := make([]v4client.NestedTagRequest, 0, len(tags))
+	for _, nameOrSlug := range tags {
+		tag, err := c.getTagDetails(ctx, nameOrSlug)
+		if errors.Is(err, utils.ErrNotFound) && config.GetOperatorConfig().NetboxAutoCreateTags {
+			tag, err = c.createTag(ctx, nameOrSlug)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.ContainsFunc(tagsRequest, func(t v4client.NestedTagRequest) bool { return t.Slug == tag.Slug }) {
+			continue
+		}
+		tagsRequest = append(tagsRequest, *v4client.NewNestedTagRequest(tag.Name, tag.Slug))
+	}
+	return tagsRequest, nil
+}
+
+// withUnmanagedTags returns the desired tags together with the current tags of the resource in
+// NetBox which were not assigned by the operator, so that tags assigned in NetBox are kept.
+// Current tags which are in managedTags but not desired anymore are left out and thus removed.
+func withUnmanagedTags(desired []v4client.NestedTagRequest, current []v4client.NestedTag, managedTags []string) []v4client.NestedTagRequest {
+	tags := slices.Clone(desired)
+	for _, tag := range current {
+		if slices.Contains(managedTags, tag.Name) || slices.Contains(managedTags, tag.Slug) {
+			continue
+		}
+		if slices.ContainsFunc(tags, func(t v4client.NestedTagRequest) bool { return t.Slug == tag.Slug }) {
+			continue
+		}
+		tags = append(tags, *v4client.NewNestedTagRequest(tag.Name, tag.Slug))
+	}
+	return tags
+}
+
+// hasTags returns true if the tags of the resource in NetBox are managed by the operator,
+// i.e. tags are assigned or were assigned before. Otherwise the tags are not written,
+// so that NetBox keeps the tags of the resource.
+func hasTags(metadata *models.NetboxMetadata) bool {
+	return metadata != nil && (len(metadata.Tags) > 0 || len(metadata.ManagedTags) > 0)
+}
+
+// getTagDetails returns the tag with the name or slug, it is served from the lookup cache if possible
+func (c *NetboxCompositeClient) getTagDetails(ctx context.Context, nameOrSlug string) (*models.Tag, error) {
+	return cachedLookup(c.lookups, tagLookupKind, nameOrSlug, func() (*models.Tag, error) {
+		return c.fetchTagDetails(ctx, nameOrSlug)
+	})
+}
+
+// fetchTagDetails returns the tag with the name, or if there is none, the tag with the slug
+func (c *NetboxCompositeClient) fetchTagDetails(ctx context.Context, nameOrSlug string) (*models.Tag, error) {
+	tags, err := listTags(c.clientV4.ExtrasAPI.ExtrasTagsList(ctx).Name([]string{nameOrSlug}))
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		tags, err = listTags(c.clientV4.ExtrasAPI.ExtrasTagsList(ctx).Slug([]string{nameOrSlug}))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(tags) == 0 {
+		return nil, utils.NetboxNotFoundError("tag '" + nameOrSlug + "'")
+	}
+
+	return &models.Tag{
+		Id:   int64(tags[0].Id),
+		Name: tags[0].Name,
+		Slug: tags[0].Slug,
+	}, nil
+}
+
+func listTags(req interfaces.ExtrasTagsListRequest) (tags []v4client.Tag, err error) {
+	response, httpResp, execErr := req.Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "fetch Tag details")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+
+	return response.Results, nil
+}
+
+// createTag creates a tag with the name and a slug derived from it. If the tag was created
+// concurrently, e.g. by the reconcile of another resource, the existing tag is returned.
+func (c *NetboxCompositeClient) createTag(ctx context.Context, name string) (tag *models.Tag, err error) {
+	slug := tagSlug(name)
+	if slug == "" {
+		return nil, fmt.Errorf("failed to create tag '%s', no slug can be derived from the name", name)
+	}
+
+	resp, httpResp, execErr := c.clientV4.ExtrasAPI.ExtrasTagsCreate(ctx).TagRequest(*v4client.NewTagRequest(name, slug)).Execute()
+	if httpResp != nil && httpResp.StatusCode == http.StatusBadRequest {
+		if existing, fetchErr := c.fetchTagDetails(ctx, name); fetchErr == nil {
+			if httpResp.Body != nil {
+				_ = httpResp.Body.Close()
+			}
+			return existing, nil
+		}
+	}
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusCreated, "create tag")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+
+	tag = &models.Tag{
+		Id:   int64(resp.Id),
+		Name: resp.Name,
+		Slug: resp.Slug,
+	}
+	// replace the cached miss of the tag, so that it is not created again
+	if c.lookups != nil {
+		c.lookups.set(lookupKey{kind: tagLookupKind, name: name}, tag, nil)
+	}
+	return tag, nil
+}
+
+// tagSlug returns the slug NetBox derives from the name of a tag, e.g. "Team Network" becomes "team-network"
+func tagSlug(name string) string {
+	return strings.Trim(invalidSlugCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
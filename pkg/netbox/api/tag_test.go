@@ -0,0 +1,174 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newTagsNetbox returns a NetBox which serves the tags by name and slug and creates tags,
+// a create of a tag which exists is rejected like NetBox does
+func newTagsNetbox(t *testing.T, tags map[string]string) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	created := &[]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, "/api/extras/tags/", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		tagJSON := func(name string, slug string) string {
+			return fmt.Sprintf(`{"id": 1, "url": "http://%s/api/extras/tags/1/", "display": %q, "name": %q, "slug": %q}`, r.Host, name, name, slug)
+		}
+
+		if r.Method == http.MethodPost {
+			var request struct {
+				Name string `json:"name"`
+				Slug string `json:"slug"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			if _, ok := tags[request.Name]; ok {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"name": ["tag with this name already exists."]}`))
+				return
+			}
+			tags[request.Name] = request.Slug
+			*created = append(*created, request.Name)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(tagJSON(request.Name, request.Slug)))
+			return
+		}
+
+		results := []string{}
+		for name, slug := range tags {
+			if name == r.URL.Query().Get("name") || slug == r.URL.Query().Get("slug") {
+				results = append(results, tagJSON(name, slug))
+			}
+		}
+		_, _ = fmt.Fprintf(w, `{"count": %d, "next": null, "previous": null, "results": [%s]}`, len(results), strings.Join(results, ","))
+	}))
+	t.Cleanup(server.Close)
+	return server, created
+}
+
+// mockExtrasTagsAPI returns an extras api in which only the tags exist, they are looked up by name
+func mockExtrasTagsAPI(ctrl *gomock.Controller, tags ...v4client.Tag) *mock_interfaces.MockExtrasAPI {
+	mockExtras := mock_interfaces.NewMockExtrasAPI(ctrl)
+	mockExtras.EXPECT().ExtrasTagsList(gomock.Any()).DoAndReturn(func(_ context.Context) interfaces.ExtrasTagsListRequest {
+		list := &v4client.PaginatedTagList{Results: []v4client.Tag{}}
+		mockListRequest := mock_interfaces.NewMockExtrasTagsListRequest(ctrl)
+		mockListRequest.EXPECT().Name(gomock.Any()).DoAndReturn(func(name []string) interfaces.ExtrasTagsListRequest {
+			for _, tag := range tags {
+				if tag.Name == name[0] {
+					list.Results = append(list.Results, tag)
+				}
+			}
+			list.Count = int32(len(list.Results))
+			return mockListRequest
+		})
+		mockListRequest.EXPECT().Execute().DoAndReturn(func() (*v4client.PaginatedTagList, *http.Response, error) {
+			return list, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})
+		return mockListRequest
+	}).AnyTimes()
+	return mockExtras
+}
+
+func TestTagsRequest(t *testing.T) {
+	config.ResetForTesting()
+	server, created := newTagsNetbox(t, map[string]string{"Production": "production", "Team Network": "team-network"})
+	client := newPaginationTestClient(t, server)
+
+	tags, err := client.tagsRequest(context.TODO(), []string{"Production", "team-network", "production"})
+	require.NoError(t, err)
+	assert.Equal(t, []v4client.NestedTagRequest{
+		*v4client.NewNestedTagRequest("Production", "production"),
+		*v4client.NewNestedTagRequest("Team Network", "team-network"),
+	}, tags)
+
+	_, err = client.tagsRequest(context.TODO(), []string{"Staging"})
+	assert.ErrorIs(t, err, utils.ErrNotFound)
+	assert.ErrorContains(t, err, "failed to fetch tag 'Staging'")
+	assert.Empty(t, *created)
+}
+
+func TestTagsRequest_AutoCreate(t *testing.T) {
+	t.Setenv("NETBOX_AUTO_CREATE_TAGS", "true")
+	config.ResetForTesting()
+	t.Cleanup(config.ResetForTesting)
+
+	server, created := newTagsNetbox(t, map[string]string{})
+	client := newPaginationTestClient(t, server)
+	client.lookups = NewLookupCache(time.Minute, time.Minute)
+
+	tags, err := client.tagsRequest(context.TODO(), []string{"Team Network"})
+	require.NoError(t, err)
+	assert.Equal(t, []v4client.NestedTagRequest{*v4client.NewNestedTagRequest("Team Network", "team-network")}, tags)
+	assert.Equal(t, []string{"Team Network"}, *created)
+
+	// the cached miss of the tag was replaced by the created tag
+	_, err = client.tagsRequest(context.TODO(), []string{"Team Network"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Team Network"}, *created)
+
+	// a tag which was created in the meantime is looked up instead
+	tag, err := client.createTag(context.TODO(), "Team Network")
+	require.NoError(t, err)
+	assert.Equal(t, "team-network", tag.Slug)
+	assert.Equal(t, []string{"Team Network"}, *created)
+}
+
+func TestWithUnmanagedTags(t *testing.T) {
+	desired := []v4client.NestedTagRequest{*v4client.NewNestedTagRequest("Production", "production")}
+	current := []v4client.NestedTag{
+		{Name: "Production", Slug: "production"},
+		{Name: "Staging", Slug: "staging"},
+		{Name: "Manual", Slug: "manual"},
+	}
+
+	// staging was assigned by the operator before and is removed, the manual tag is kept
+	tags := withUnmanagedTags(desired, current, []string{"production", "Staging"})
+	assert.Equal(t, []v4client.NestedTagRequest{
+		*v4client.NewNestedTagRequest("Production", "production"),
+		*v4client.NewNestedTagRequest("Manual", "manual"),
+	}, tags)
+
+	assert.Empty(t, withUnmanagedTags([]v4client.NestedTagRequest{}, current[:2], []string{"production", "staging"}))
+}
+
+func TestTagSlug(t *testing.T) {
+	assert.Equal(t, "team-network", tagSlug("Team Network"))
+	assert.Equal(t, "prod_eu-1", tagSlug(" prod_EU-1! "))
+	assert.Equal(t, "", tagSlug("!!"))
+}
@@ -17,25 +17,39 @@ limitations under the License.
 package api
 
 import (
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
+	"context"
+	"errors"
+	"net/http"
 
 	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
 )
 
-func (c *NetboxCompositeClient) getTenantDetails(name string) (*models.Tenant, error) {
-	request := tenancy.NewTenancyTenantsListParams().WithName(&name)
-	response, err := c.clientV3.Tenancy.TenancyTenantsList(request, nil)
-	if err != nil {
-		return nil, utils.NetboxError("failed to fetch Tenant details", err)
+// getTenantDetails returns the tenant with the name, it is served from the lookup cache if possible
+func (c *NetboxCompositeClient) getTenantDetails(ctx context.Context, name string) (*models.Tenant, error) {
+	return cachedLookup(c.lookups, tenantLookupKind, name, func() (*models.Tenant, error) {
+		return c.fetchTenantDetails(ctx, name)
+	})
+}
+
+func (c *NetboxCompositeClient) fetchTenantDetails(ctx context.Context, name string) (details *models.Tenant, err error) {
+	response, httpResp, execErr := c.clientV4.TenancyAPI.TenancyTenantsList(ctx).Name([]string{name}).Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "fetch Tenant details")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
 	}
-	if len(response.Payload.Results) == 0 {
+	if handleErr != nil {
+		return nil, handleErr
+	}
+
+	if len(response.Results) == 0 {
 		return nil, utils.NetboxNotFoundError("tenant '" + name + "'")
 	}
 
 	return &models.Tenant{
-		Id:   response.Payload.Results[0].ID,
-		Slug: *response.Payload.Results[0].Slug,
-		Name: *response.Payload.Results[0].Name,
+		Id:   int64(response.Results[0].Id),
+		Slug: response.Results[0].Slug,
+		Name: response.Results[0].Name,
 	}, nil
 }
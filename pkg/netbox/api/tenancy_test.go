@@ -17,74 +17,97 @@ limitations under the License.
 package api
 
 import (
+	"context"
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
 
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
-	netboxModels "github.com/netbox-community/go-netbox/v3/netbox/models"
+	v4client "github.com/netbox-community/go-netbox/v4"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
+// mockTenancyAPI returns a TenancyAPI which lists the tenants, or fails with err, for any number of
+// lookups of the tenant with the name
+func mockTenancyAPI(ctrl *gomock.Controller, name string, tenants []v4client.Tenant, err error) *mock_interfaces.MockTenancyAPI {
+	mockTenancy := mock_interfaces.NewMockTenancyAPI(ctrl)
+	mockListRequest := mock_interfaces.NewMockTenancyTenantsListRequest(ctrl)
+	mockTenancy.EXPECT().TenancyTenantsList(gomock.Any()).Return(mockListRequest).AnyTimes()
+	mockListRequest.EXPECT().Name([]string{name}).Return(mockListRequest).AnyTimes()
+	if err != nil {
+		mockListRequest.EXPECT().Execute().Return(nil, nil, err).AnyTimes()
+	} else {
+		mockListRequest.EXPECT().Execute().DoAndReturn(func() (*v4client.PaginatedTenantList, *http.Response, error) {
+			return &v4client.PaginatedTenantList{Count: int32(len(tenants)), Results: tenants}, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}).AnyTimes()
+	}
+	return mockTenancy
+}
+
 func TestTenancy_GetTenantDetails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockPrefix := mock_interfaces.NewMockTenancyInterface(ctrl)
+	mockTenancy := mock_interfaces.NewMockTenancyAPI(ctrl)
+	mockListRequest := mock_interfaces.NewMockTenancyTenantsListRequest(ctrl)
 
 	tenant := "myTenant"
 
-	tenantListRequestInput := tenancy.NewTenancyTenantsListParams().WithName(&tenant)
-
-	tenantOutputId := int64(1)
+	tenantOutputId := int32(1)
 	tenantOutputSlug := "mytenant"
-	tenantListOutput := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{
-				{
-					ID:   tenantOutputId,
-					Name: &tenant,
-					Slug: &tenantOutputSlug,
-				},
+	tenantListOutput := &v4client.PaginatedTenantList{
+		Count: 1,
+		Results: []v4client.Tenant{
+			{
+				Id:   tenantOutputId,
+				Name: tenant,
+				Slug: tenantOutputSlug,
 			},
 		},
 	}
 
-	mockPrefix.EXPECT().TenancyTenantsList(tenantListRequestInput, nil).Return(tenantListOutput, nil)
-	clientV3 := &NetboxClientV3{Tenancy: mockPrefix}
+	mockTenancy.EXPECT().TenancyTenantsList(gomock.Any()).Return(mockListRequest)
+	mockListRequest.EXPECT().Name([]string{tenant}).Return(mockListRequest)
+	mockListRequest.EXPECT().Execute().Return(tenantListOutput, &http.Response{StatusCode: 200, Body: http.NoBody}, nil)
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
+		clientV4: &NetboxClientV4{TenancyAPI: mockTenancy},
 	}
 
-	actual, err := compositeClient.getTenantDetails(tenant)
+	actual, err := compositeClient.getTenantDetails(context.TODO(), tenant)
 	assert.NoError(t, err)
 	assert.Equal(t, tenant, actual.Name)
-	assert.Equal(t, tenantOutputId, actual.Id)
+	assert.Equal(t, int64(tenantOutputId), actual.Id)
 	assert.Equal(t, tenantOutputSlug, actual.Slug)
 }
 
 func TestTenancy_GetWrongTenantDetails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	mockPrefix := mock_interfaces.NewMockTenancyInterface(ctrl)
 
 	wrongTenant := "wrongTenant"
 
-	wrongTenantListRequestInput := tenancy.NewTenancyTenantsListParams().WithName(&wrongTenant)
-
-	emptyListTenantOutput := &tenancy.TenancyTenantsListOK{
-		Payload: &tenancy.TenancyTenantsListOKBody{
-			Results: []*netboxModels.Tenant{},
-		},
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{TenancyAPI: mockTenancyAPI(ctrl, wrongTenant, nil, nil)},
 	}
 
-	mockPrefix.EXPECT().TenancyTenantsList(wrongTenantListRequestInput, nil).Return(emptyListTenantOutput, nil)
-	clientV3 := &NetboxClientV3{Tenancy: mockPrefix}
+	actual, err := compositeClient.getTenantDetails(context.TODO(), wrongTenant)
+	assert.Nil(t, actual)
+	assert.EqualError(t, err, "failed to fetch tenant 'wrongTenant': not found")
+}
+
+func TestTenancy_GetError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenant := "myTenant"
+	expectedErr := "error getting tenants list"
+
 	compositeClient := &NetboxCompositeClient{
-		clientV3: clientV3,
+		clientV4: &NetboxClientV4{TenancyAPI: mockTenancyAPI(ctrl, tenant, nil, errors.New(expectedErr))},
 	}
 
-	actual, err := compositeClient.getTenantDetails(wrongTenant)
+	actual, err := compositeClient.getTenantDetails(context.TODO(), tenant)
 	assert.Nil(t, actual)
-	assert.EqualError(t, err, "failed to fetch tenant 'wrongTenant': not found")
+	assert.EqualError(t, err, "failed to fetch Tenant details: "+expectedErr)
 }
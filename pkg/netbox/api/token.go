@@ -0,0 +1,200 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TokenFilePollInterval is the interval at which a token file is re-read
+const TokenFilePollInterval = 10 * time.Second
+
+// maxRejectionBodySize limits how much of the body of a rejected request is inspected
+const maxRejectionBodySize = 4096
+
+// staleTokenMessages are the details NetBox responds with when the token itself is rejected,
+// as opposed to a valid token which lacks the permission for a request
+var staleTokenMessages = []string{"Invalid token", "Token expired"}
+
+// TokenProvider holds the NetBox API token of a client. The token can be swapped while the
+// client is in use, the next request is sent with the new token.
+type TokenProvider struct {
+	mu       sync.RWMutex
+	token    string
+	rejected bool
+}
+
+// NewTokenProvider returns a token provider holding token
+func NewTokenProvider(token string) *TokenProvider {
+	return &TokenProvider{token: token}
+}
+
+// Token returns the current token
+func (p *TokenProvider) Token() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.token
+}
+
+// SetToken swaps the token, it returns true if the token changed
+func (p *TokenProvider) SetToken(token string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token == token {
+		return false
+	}
+	p.token = token
+	p.rejected = false
+	return true
+}
+
+// Rejected returns true if NetBox rejected the current token as invalid or expired
+func (p *TokenProvider) Rejected() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.rejected
+}
+
+// reject marks token as rejected, unless it was swapped in the meantime
+func (p *TokenProvider) reject(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token == token {
+		p.rejected = true
+	}
+}
+
+// accept clears the rejection of token after NetBox accepted it again
+func (p *TokenProvider) accept(token string) {
+	if !p.Rejected() {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token == token {
+		p.rejected = false
+	}
+}
+
+// TokenRoundTripper sets the Authorization header of each request to the current token
+// of the token provider and marks the token as rejected if NetBox responds that it is invalid.
+type TokenRoundTripper struct {
+	Tokens    *TokenProvider
+	Transport http.RoundTripper
+}
+
+func (trt *TokenRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token := trt.Tokens.Token()
+
+	// a RoundTripper must not modify the request, the headers are set on a clone
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Token %v", token))
+
+	resp, err := trt.Transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		if resp.StatusCode < http.StatusBadRequest {
+			trt.Tokens.accept(token)
+		}
+		return resp, nil
+	}
+
+	if isStaleTokenResponse(resp) {
+		log.StandardLogger().Warnf("netbox at host %s rejected the api token, the token is invalid or expired", req.URL.Host)
+		trt.Tokens.reject(token)
+	}
+	return resp, nil
+}
+
+// isStaleTokenResponse checks whether a 403 response is caused by an invalid or expired token
+func isStaleTokenResponse(resp *http.Response) bool {
+	head := peekBody(resp)
+	for _, msg := range staleTokenMessages {
+		if bytes.Contains(head, []byte(msg)) {
+			return true
+		}
+	}
+	return false
+}
+
+// peekBody returns the beginning of the body of resp and puts it back, so that the caller
+// can read the whole response. It returns nil if the body cannot be read.
+func peekBody(resp *http.Response) []byte {
+	if resp.Body == nil {
+		return nil
+	}
+	head, err := io.ReadAll(io.LimitReader(resp.Body, maxRejectionBodySize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	if err != nil {
+		return nil
+	}
+	return head
+}
+
+// ReadTokenFile returns the token in the file at path without surrounding whitespace
+func ReadTokenFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file %s: %w", path, err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// WatchTokenFile re-reads the token file at path every interval and swaps a changed token
+// into the token provider until ctx is done. Kubernetes updates the files of a mounted Secret
+// in place when the Secret changes, so a rotated token is picked up without a restart.
+func WatchTokenFile(ctx context.Context, path string, interval time.Duration, tokens *TokenProvider) error {
+	logger := log.StandardLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			token, err := ReadTokenFile(path)
+			if err != nil {
+				// keep the current token, the file may be in the middle of an update
+				logger.Warnf("failed to reload netbox api token: %v", err)
+				continue
+			}
+			if tokens.SetToken(token) {
+				logger.Infof("reloaded netbox api token from %s", path)
+			}
+		}
+	}
+}
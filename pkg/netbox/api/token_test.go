@@ -0,0 +1,163 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenProvider_SetToken(t *testing.T) {
+	tokens := NewTokenProvider("old")
+	tokens.reject("old")
+	assert.True(t, tokens.Rejected())
+
+	assert.False(t, tokens.SetToken("old"))
+	assert.True(t, tokens.Rejected())
+
+	assert.True(t, tokens.SetToken("new"))
+	assert.Equal(t, "new", tokens.Token())
+	assert.False(t, tokens.Rejected())
+
+	// a rejection of a token which was swapped in the meantime is ignored
+	tokens.reject("old")
+	assert.False(t, tokens.Rejected())
+
+	tokens.reject("new")
+	assert.True(t, tokens.Rejected())
+	tokens.accept("new")
+	assert.False(t, tokens.Rejected())
+}
+
+func TestTokenRoundTripper(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		body         string
+		wantRejected bool
+	}{
+		{
+			name:       "accepted token",
+			statusCode: http.StatusOK,
+			body:       `{"count": 0}`,
+		},
+		{
+			name:         "invalid token",
+			statusCode:   http.StatusForbidden,
+			body:         `{"detail": "Invalid token"}`,
+			wantRejected: true,
+		},
+		{
+			name:         "expired token",
+			statusCode:   http.StatusForbidden,
+			body:         `{"detail": "Token expired"}`,
+			wantRejected: true,
+		},
+		{
+			name:       "missing permission",
+			statusCode: http.StatusForbidden,
+			body:       `{"detail": "You do not have permission to perform this action."}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			tokens := NewTokenProvider("0123456789abcdef")
+			httpClient := &http.Client{Transport: &TokenRoundTripper{Tokens: tokens, Transport: http.DefaultTransport}}
+
+			resp, err := httpClient.Get(server.URL)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(body))
+			assert.Equal(t, "Token 0123456789abcdef", authorization)
+			assert.Equal(t, tt.wantRejected, tokens.Rejected())
+		})
+	}
+}
+
+func TestTokenRoundTripper_SwappedToken(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	tokens := NewTokenProvider("old")
+	httpClient := &http.Client{Transport: &TokenRoundTripper{Tokens: tokens, Transport: http.DefaultTransport}}
+
+	tokens.SetToken("new")
+	resp, err := httpClient.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "Token new", authorization)
+}
+
+func TestReadTokenFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(path, []byte("0123456789abcdef\n"), 0o600))
+	token, err := ReadTokenFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", token)
+
+	emptyPath := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyPath, []byte("\n"), 0o600))
+	_, err = ReadTokenFile(emptyPath)
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = ReadTokenFile(filepath.Join(dir, "missing"))
+	assert.ErrorContains(t, err, "failed to read token file")
+}
+
+func TestWatchTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("old"), 0o600))
+
+	tokens := NewTokenProvider("old")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- WatchTokenFile(ctx, path, 10*time.Millisecond, tokens)
+	}()
+
+	require.NoError(t, os.WriteFile(path, []byte("new"), 0o600))
+	assert.Eventually(t, func() bool { return tokens.Token() == "new" }, time.Second, 10*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -26,6 +26,77 @@ import (
 
 // Adapter implementations for v4 API request objects
 
+// ipamIpAddressesListRequestAdapter adapts the v4 list request to the interface
+type ipamIpAddressesListRequestAdapter struct {
+	req v4client.ApiIpamIpAddressesListRequest
+}
+
+func (a *ipamIpAddressesListRequestAdapter) Address(address []string) interfaces.IpamIpAddressesListRequest {
+	a.req = a.req.Address(address)
+	return a
+}
+
+func (a *ipamIpAddressesListRequestAdapter) Parent(parent []string) interfaces.IpamIpAddressesListRequest {
+	a.req = a.req.Parent(parent)
+	return a
+}
+
+func (a *ipamIpAddressesListRequestAdapter) VrfId(vrfId []*int32) interfaces.IpamIpAddressesListRequest {
+	a.req = a.req.VrfId(vrfId)
+	return a
+}
+
+func (a *ipamIpAddressesListRequestAdapter) Limit(limit int32) interfaces.IpamIpAddressesListRequest {
+	a.req = a.req.Limit(limit)
+	return a
+}
+
+func (a *ipamIpAddressesListRequestAdapter) Offset(offset int32) interfaces.IpamIpAddressesListRequest {
+	a.req = a.req.Offset(offset)
+	return a
+}
+
+func (a *ipamIpAddressesListRequestAdapter) Execute() (*v4client.PaginatedIPAddressList, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// ipamIpAddressesCreateRequestAdapter adapts the v4 create request to the interface
+type ipamIpAddressesCreateRequestAdapter struct {
+	req v4client.ApiIpamIpAddressesCreateRequest
+}
+
+func (a *ipamIpAddressesCreateRequestAdapter) WritableIPAddressRequest(writableIPAddressRequest v4client.WritableIPAddressRequest) interfaces.IpamIpAddressesCreateRequest {
+	a.req = a.req.WritableIPAddressRequest(writableIPAddressRequest)
+	return a
+}
+
+func (a *ipamIpAddressesCreateRequestAdapter) Execute() (*v4client.IPAddress, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// ipamIpAddressesUpdateRequestAdapter adapts the v4 update request to the interface
+type ipamIpAddressesUpdateRequestAdapter struct {
+	req v4client.ApiIpamIpAddressesUpdateRequest
+}
+
+func (a *ipamIpAddressesUpdateRequestAdapter) WritableIPAddressRequest(writableIPAddressRequest v4client.WritableIPAddressRequest) interfaces.IpamIpAddressesUpdateRequest {
+	a.req = a.req.WritableIPAddressRequest(writableIPAddressRequest)
+	return a
+}
+
+func (a *ipamIpAddressesUpdateRequestAdapter) Execute() (*v4client.IPAddress, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// ipamIpAddressesDestroyRequestAdapter adapts the v4 destroy request to the interface
+type ipamIpAddressesDestroyRequestAdapter struct {
+	req v4client.ApiIpamIpAddressesDestroyRequest
+}
+
+func (a *ipamIpAddressesDestroyRequestAdapter) Execute() (*http.Response, error) {
+	return a.req.Execute()
+}
+
 // ipamIpRangesListRequestAdapter adapts the v4 list request to the interface
 type ipamIpRangesListRequestAdapter struct {
 	req v4client.ApiIpamIpRangesListRequest
@@ -41,6 +112,21 @@ func (a *ipamIpRangesListRequestAdapter) EndAddress(endAddress []string) interfa
 	return a
 }
 
+func (a *ipamIpRangesListRequestAdapter) VrfId(vrfId []*int32) interfaces.IpamIpRangesListRequest {
+	a.req = a.req.VrfId(vrfId)
+	return a
+}
+
+func (a *ipamIpRangesListRequestAdapter) Limit(limit int32) interfaces.IpamIpRangesListRequest {
+	a.req = a.req.Limit(limit)
+	return a
+}
+
+func (a *ipamIpRangesListRequestAdapter) Offset(offset int32) interfaces.IpamIpRangesListRequest {
+	a.req = a.req.Offset(offset)
+	return a
+}
+
 func (a *ipamIpRangesListRequestAdapter) Execute() (*v4client.PaginatedIPRangeList, *http.Response, error) {
 	return a.req.Execute()
 }
@@ -82,11 +168,36 @@ func (a *ipamIpRangesDestroyRequestAdapter) Execute() (*http.Response, error) {
 	return a.req.Execute()
 }
 
+// ipamIpRangesAvailableIpsListRequestAdapter adapts the v4 available ips request to the interface
+type ipamIpRangesAvailableIpsListRequestAdapter struct {
+	req v4client.ApiIpamIpRangesAvailableIpsListRequest
+}
+
+func (a *ipamIpRangesAvailableIpsListRequestAdapter) Execute() ([]v4client.AvailableIP, *http.Response, error) {
+	return a.req.Execute()
+}
+
 // ipamV4APIAdapter adapts the v4 IpamAPI to the interface
 type ipamV4APIAdapter struct {
 	api v4client.IpamAPI
 }
 
+func (a *ipamV4APIAdapter) IpamIpAddressesList(ctx context.Context) interfaces.IpamIpAddressesListRequest {
+	return &ipamIpAddressesListRequestAdapter{req: a.api.IpamIpAddressesList(ctx)}
+}
+
+func (a *ipamV4APIAdapter) IpamIpAddressesCreate(ctx context.Context) interfaces.IpamIpAddressesCreateRequest {
+	return &ipamIpAddressesCreateRequestAdapter{req: a.api.IpamIpAddressesCreate(ctx)}
+}
+
+func (a *ipamV4APIAdapter) IpamIpAddressesUpdate(ctx context.Context, id int32) interfaces.IpamIpAddressesUpdateRequest {
+	return &ipamIpAddressesUpdateRequestAdapter{req: a.api.IpamIpAddressesUpdate(ctx, id)}
+}
+
+func (a *ipamV4APIAdapter) IpamIpAddressesDestroy(ctx context.Context, id int32) interfaces.IpamIpAddressesDestroyRequest {
+	return &ipamIpAddressesDestroyRequestAdapter{req: a.api.IpamIpAddressesDestroy(ctx, id)}
+}
+
 func (a *ipamV4APIAdapter) IpamIpRangesList(ctx context.Context) interfaces.IpamIpRangesListRequest {
 	return &ipamIpRangesListRequestAdapter{req: a.api.IpamIpRangesList(ctx)}
 }
@@ -103,6 +214,10 @@ func (a *ipamV4APIAdapter) IpamIpRangesDestroy(ctx context.Context, id int32) in
 	return &ipamIpRangesDestroyRequestAdapter{req: a.api.IpamIpRangesDestroy(ctx, id)}
 }
 
+func (a *ipamV4APIAdapter) IpamIpRangesAvailableIpsList(ctx context.Context, id int32) interfaces.IpamIpRangesAvailableIpsListRequest {
+	return &ipamIpRangesAvailableIpsListRequestAdapter{req: a.api.IpamIpRangesAvailableIpsList(ctx, id)}
+}
+
 // ipamPrefixesListRequestAdapter adapts the v4 list request to the interface
 type ipamPrefixesListRequestAdapter struct {
 	req v4client.ApiIpamPrefixesListRequest
@@ -113,6 +228,31 @@ func (a *ipamPrefixesListRequestAdapter) Prefix(prefix []string) interfaces.Ipam
 	return a
 }
 
+func (a *ipamPrefixesListRequestAdapter) Within(within string) interfaces.IpamPrefixesListRequest {
+	a.req = a.req.Within(within)
+	return a
+}
+
+func (a *ipamPrefixesListRequestAdapter) Contains(contains string) interfaces.IpamPrefixesListRequest {
+	a.req = a.req.Contains(contains)
+	return a
+}
+
+func (a *ipamPrefixesListRequestAdapter) VrfId(vrfId []*int32) interfaces.IpamPrefixesListRequest {
+	a.req = a.req.VrfId(vrfId)
+	return a
+}
+
+func (a *ipamPrefixesListRequestAdapter) Limit(limit int32) interfaces.IpamPrefixesListRequest {
+	a.req = a.req.Limit(limit)
+	return a
+}
+
+func (a *ipamPrefixesListRequestAdapter) Offset(offset int32) interfaces.IpamPrefixesListRequest {
+	a.req = a.req.Offset(offset)
+	return a
+}
+
 func (a *ipamPrefixesListRequestAdapter) Execute() (*v4client.PaginatedPrefixList, *http.Response, error) {
 	return a.req.Execute()
 }
@@ -154,6 +294,24 @@ func (a *ipamPrefixesDestroyRequestAdapter) Execute() (*http.Response, error) {
 	return a.req.Execute()
 }
 
+// ipamPrefixesAvailableIpsListRequestAdapter adapts the v4 available ips request to the interface
+type ipamPrefixesAvailableIpsListRequestAdapter struct {
+	req v4client.ApiIpamPrefixesAvailableIpsListRequest
+}
+
+func (a *ipamPrefixesAvailableIpsListRequestAdapter) Execute() ([]v4client.AvailableIP, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// ipamPrefixesAvailablePrefixesListRequestAdapter adapts the v4 available prefixes request to the interface
+type ipamPrefixesAvailablePrefixesListRequestAdapter struct {
+	req v4client.ApiIpamPrefixesAvailablePrefixesListRequest
+}
+
+func (a *ipamPrefixesAvailablePrefixesListRequestAdapter) Execute() ([]v4client.AvailablePrefix, *http.Response, error) {
+	return a.req.Execute()
+}
+
 func (a *ipamV4APIAdapter) IpamPrefixesList(ctx context.Context) interfaces.IpamPrefixesListRequest {
 	return &ipamPrefixesListRequestAdapter{req: a.api.IpamPrefixesList(ctx)}
 }
@@ -170,6 +328,298 @@ func (a *ipamV4APIAdapter) IpamPrefixesDestroy(ctx context.Context, id int32) in
 	return &ipamPrefixesDestroyRequestAdapter{req: a.api.IpamPrefixesDestroy(ctx, id)}
 }
 
+func (a *ipamV4APIAdapter) IpamPrefixesAvailableIpsList(ctx context.Context, id int32) interfaces.IpamPrefixesAvailableIpsListRequest {
+	return &ipamPrefixesAvailableIpsListRequestAdapter{req: a.api.IpamPrefixesAvailableIpsList(ctx, id)}
+}
+
+func (a *ipamV4APIAdapter) IpamPrefixesAvailablePrefixesList(ctx context.Context, id int32) interfaces.IpamPrefixesAvailablePrefixesListRequest {
+	return &ipamPrefixesAvailablePrefixesListRequestAdapter{req: a.api.IpamPrefixesAvailablePrefixesList(ctx, id)}
+}
+
+// ipamVrfsListRequestAdapter adapts the v4 list request to the interface
+type ipamVrfsListRequestAdapter struct {
+	req v4client.ApiIpamVrfsListRequest
+}
+
+func (a *ipamVrfsListRequestAdapter) Name(name []string) interfaces.IpamVrfsListRequest {
+	a.req = a.req.Name(name)
+	return a
+}
+
+func (a *ipamVrfsListRequestAdapter) Execute() (*v4client.PaginatedVRFList, *http.Response, error) {
+	return a.req.Execute()
+}
+
+func (a *ipamV4APIAdapter) IpamVrfsList(ctx context.Context) interfaces.IpamVrfsListRequest {
+	return &ipamVrfsListRequestAdapter{req: a.api.IpamVrfsList(ctx)}
+}
+
+// ipamRolesListRequestAdapter adapts the v4 list request to the interface
+type ipamRolesListRequestAdapter struct {
+	req v4client.ApiIpamRolesListRequest
+}
+
+func (a *ipamRolesListRequestAdapter) Name(name []string) interfaces.IpamRolesListRequest {
+	a.req = a.req.Name(name)
+	return a
+}
+
+func (a *ipamRolesListRequestAdapter) Slug(slug []string) interfaces.IpamRolesListRequest {
+	a.req = a.req.Slug(slug)
+	return a
+}
+
+func (a *ipamRolesListRequestAdapter) Execute() (*v4client.PaginatedRoleList, *http.Response, error) {
+	return a.req.Execute()
+}
+
+func (a *ipamV4APIAdapter) IpamRolesList(ctx context.Context) interfaces.IpamRolesListRequest {
+	return &ipamRolesListRequestAdapter{req: a.api.IpamRolesList(ctx)}
+}
+
+// ipamVlansListRequestAdapter adapts the v4 list request to the interface
+type ipamVlansListRequestAdapter struct {
+	req v4client.ApiIpamVlansListRequest
+}
+
+func (a *ipamVlansListRequestAdapter) Vid(vid []int32) interfaces.IpamVlansListRequest {
+	a.req = a.req.Vid(vid)
+	return a
+}
+
+func (a *ipamVlansListRequestAdapter) Name(name []string) interfaces.IpamVlansListRequest {
+	a.req = a.req.Name(name)
+	return a
+}
+
+func (a *ipamVlansListRequestAdapter) GroupId(groupId []*int32) interfaces.IpamVlansListRequest {
+	a.req = a.req.GroupId(groupId)
+	return a
+}
+
+func (a *ipamVlansListRequestAdapter) Limit(limit int32) interfaces.IpamVlansListRequest {
+	a.req = a.req.Limit(limit)
+	return a
+}
+
+func (a *ipamVlansListRequestAdapter) Offset(offset int32) interfaces.IpamVlansListRequest {
+	a.req = a.req.Offset(offset)
+	return a
+}
+
+func (a *ipamVlansListRequestAdapter) Execute() (*v4client.PaginatedVLANList, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// ipamVlansCreateRequestAdapter adapts the v4 create request to the interface
+type ipamVlansCreateRequestAdapter struct {
+	req v4client.ApiIpamVlansCreateRequest
+}
+
+func (a *ipamVlansCreateRequestAdapter) WritableVLANRequest(writableVLANRequest v4client.WritableVLANRequest) interfaces.IpamVlansCreateRequest {
+	a.req = a.req.WritableVLANRequest(writableVLANRequest)
+	return a
+}
+
+func (a *ipamVlansCreateRequestAdapter) Execute() (*v4client.VLAN, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// ipamVlansUpdateRequestAdapter adapts the v4 update request to the interface
+type ipamVlansUpdateRequestAdapter struct {
+	req v4client.ApiIpamVlansUpdateRequest
+}
+
+func (a *ipamVlansUpdateRequestAdapter) WritableVLANRequest(writableVLANRequest v4client.WritableVLANRequest) interfaces.IpamVlansUpdateRequest {
+	a.req = a.req.WritableVLANRequest(writableVLANRequest)
+	return a
+}
+
+func (a *ipamVlansUpdateRequestAdapter) Execute() (*v4client.VLAN, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// ipamVlansDestroyRequestAdapter adapts the v4 destroy request to the interface
+type ipamVlansDestroyRequestAdapter struct {
+	req v4client.ApiIpamVlansDestroyRequest
+}
+
+func (a *ipamVlansDestroyRequestAdapter) Execute() (*http.Response, error) {
+	return a.req.Execute()
+}
+
+// ipamVlanGroupsListRequestAdapter adapts the v4 list request to the interface
+type ipamVlanGroupsListRequestAdapter struct {
+	req v4client.ApiIpamVlanGroupsListRequest
+}
+
+func (a *ipamVlanGroupsListRequestAdapter) Name(name []string) interfaces.IpamVlanGroupsListRequest {
+	a.req = a.req.Name(name)
+	return a
+}
+
+func (a *ipamVlanGroupsListRequestAdapter) Slug(slug []string) interfaces.IpamVlanGroupsListRequest {
+	a.req = a.req.Slug(slug)
+	return a
+}
+
+func (a *ipamVlanGroupsListRequestAdapter) Limit(limit int32) interfaces.IpamVlanGroupsListRequest {
+	a.req = a.req.Limit(limit)
+	return a
+}
+
+func (a *ipamVlanGroupsListRequestAdapter) Offset(offset int32) interfaces.IpamVlanGroupsListRequest {
+	a.req = a.req.Offset(offset)
+	return a
+}
+
+func (a *ipamVlanGroupsListRequestAdapter) Execute() (*v4client.PaginatedVLANGroupList, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// ipamVlanGroupsAvailableVlansListRequestAdapter adapts the v4 available vlans request to the interface
+type ipamVlanGroupsAvailableVlansListRequestAdapter struct {
+	req v4client.ApiIpamVlanGroupsAvailableVlansListRequest
+}
+
+func (a *ipamVlanGroupsAvailableVlansListRequestAdapter) Execute() ([]v4client.AvailableVLAN, *http.Response, error) {
+	return a.req.Execute()
+}
+
+func (a *ipamV4APIAdapter) IpamVlansList(ctx context.Context) interfaces.IpamVlansListRequest {
+	return &ipamVlansListRequestAdapter{req: a.api.IpamVlansList(ctx)}
+}
+
+func (a *ipamV4APIAdapter) IpamVlansCreate(ctx context.Context) interfaces.IpamVlansCreateRequest {
+	return &ipamVlansCreateRequestAdapter{req: a.api.IpamVlansCreate(ctx)}
+}
+
+func (a *ipamV4APIAdapter) IpamVlansUpdate(ctx context.Context, id int32) interfaces.IpamVlansUpdateRequest {
+	return &ipamVlansUpdateRequestAdapter{req: a.api.IpamVlansUpdate(ctx, id)}
+}
+
+func (a *ipamV4APIAdapter) IpamVlansDestroy(ctx context.Context, id int32) interfaces.IpamVlansDestroyRequest {
+	return &ipamVlansDestroyRequestAdapter{req: a.api.IpamVlansDestroy(ctx, id)}
+}
+
+func (a *ipamV4APIAdapter) IpamVlanGroupsList(ctx context.Context) interfaces.IpamVlanGroupsListRequest {
+	return &ipamVlanGroupsListRequestAdapter{req: a.api.IpamVlanGroupsList(ctx)}
+}
+
+func (a *ipamV4APIAdapter) IpamVlanGroupsAvailableVlansList(ctx context.Context, id int32) interfaces.IpamVlanGroupsAvailableVlansListRequest {
+	return &ipamVlanGroupsAvailableVlansListRequestAdapter{req: a.api.IpamVlanGroupsAvailableVlansList(ctx, id)}
+}
+
+// tenancyTenantsListRequestAdapter adapts the v4 list request to the interface
+type tenancyTenantsListRequestAdapter struct {
+	req v4client.ApiTenancyTenantsListRequest
+}
+
+func (a *tenancyTenantsListRequestAdapter) Name(name []string) interfaces.TenancyTenantsListRequest {
+	a.req = a.req.Name(name)
+	return a
+}
+
+func (a *tenancyTenantsListRequestAdapter) Execute() (*v4client.PaginatedTenantList, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// tenancyV4APIAdapter adapts the v4 TenancyAPI to the interface
+type tenancyV4APIAdapter struct {
+	api v4client.TenancyAPI
+}
+
+func (a *tenancyV4APIAdapter) TenancyTenantsList(ctx context.Context) interfaces.TenancyTenantsListRequest {
+	return &tenancyTenantsListRequestAdapter{req: a.api.TenancyTenantsList(ctx)}
+}
+
+// dcimSitesListRequestAdapter adapts the v4 list request to the interface
+type dcimSitesListRequestAdapter struct {
+	req v4client.ApiDcimSitesListRequest
+}
+
+func (a *dcimSitesListRequestAdapter) Name(name []string) interfaces.DcimSitesListRequest {
+	a.req = a.req.Name(name)
+	return a
+}
+
+func (a *dcimSitesListRequestAdapter) Execute() (*v4client.PaginatedSiteList, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// dcimV4APIAdapter adapts the v4 DcimAPI to the interface
+type dcimV4APIAdapter struct {
+	api v4client.DcimAPI
+}
+
+func (a *dcimV4APIAdapter) DcimSitesList(ctx context.Context) interfaces.DcimSitesListRequest {
+	return &dcimSitesListRequestAdapter{req: a.api.DcimSitesList(ctx)}
+}
+
+// extrasCustomFieldsListRequestAdapter adapts the v4 list request to the interface
+type extrasCustomFieldsListRequestAdapter struct {
+	req v4client.ApiExtrasCustomFieldsListRequest
+}
+
+func (a *extrasCustomFieldsListRequestAdapter) Name(name []string) interfaces.ExtrasCustomFieldsListRequest {
+	a.req = a.req.Name(name)
+	return a
+}
+
+func (a *extrasCustomFieldsListRequestAdapter) Execute() (*v4client.PaginatedCustomFieldList, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// extrasV4APIAdapter adapts the v4 ExtrasAPI to the interface
+type extrasV4APIAdapter struct {
+	api v4client.ExtrasAPI
+}
+
+func (a *extrasV4APIAdapter) ExtrasCustomFieldsList(ctx context.Context) interfaces.ExtrasCustomFieldsListRequest {
+	return &extrasCustomFieldsListRequestAdapter{req: a.api.ExtrasCustomFieldsList(ctx)}
+}
+
+func (a *extrasV4APIAdapter) ExtrasTagsList(ctx context.Context) interfaces.ExtrasTagsListRequest {
+	return &extrasTagsListRequestAdapter{req: a.api.ExtrasTagsList(ctx)}
+}
+
+func (a *extrasV4APIAdapter) ExtrasTagsCreate(ctx context.Context) interfaces.ExtrasTagsCreateRequest {
+	return &extrasTagsCreateRequestAdapter{req: a.api.ExtrasTagsCreate(ctx)}
+}
+
+// extrasTagsListRequestAdapter adapts the v4 list request to the interface
+type extrasTagsListRequestAdapter struct {
+	req v4client.ApiExtrasTagsListRequest
+}
+
+func (a *extrasTagsListRequestAdapter) Name(name []string) interfaces.ExtrasTagsListRequest {
+	a.req = a.req.Name(name)
+	return a
+}
+
+func (a *extrasTagsListRequestAdapter) Slug(slug []string) interfaces.ExtrasTagsListRequest {
+	a.req = a.req.Slug(slug)
+	return a
+}
+
+func (a *extrasTagsListRequestAdapter) Execute() (*v4client.PaginatedTagList, *http.Response, error) {
+	return a.req.Execute()
+}
+
+// extrasTagsCreateRequestAdapter adapts the v4 create request to the interface
+type extrasTagsCreateRequestAdapter struct {
+	req v4client.ApiExtrasTagsCreateRequest
+}
+
+func (a *extrasTagsCreateRequestAdapter) TagRequest(tagRequest v4client.TagRequest) interfaces.ExtrasTagsCreateRequest {
+	a.req = a.req.TagRequest(tagRequest)
+	return a
+}
+
+func (a *extrasTagsCreateRequestAdapter) Execute() (*v4client.Tag, *http.Response, error) {
+	return a.req.Execute()
+}
+
 type statusRetrieveRequestAdapter struct {
 	req v4client.ApiStatusRetrieveRequest
 }
@@ -22,12 +22,119 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/metrics"
 	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/mod/semver"
 )
 
+// VersionCache holds the detected version of a NetBox instance for a TTL, so that the
+// version is not requested from the status api before every write
+type VersionCache struct {
+	host string
+	ttl  time.Duration
+
+	mu         sync.Mutex
+	version    string
+	detectedAt time.Time
+}
+
+// NewVersionCache returns an empty version cache of the NetBox instance at host
+func NewVersionCache(host string, ttl time.Duration) *VersionCache {
+	return &VersionCache{host: host, ttl: ttl}
+}
+
+// NewVersionCacheFromConfig returns an empty version cache of the NetBox instance at host
+// with the TTL of the operator configuration
+func NewVersionCacheFromConfig(host string) *VersionCache {
+	return NewVersionCache(host, config.GetOperatorConfig().NetboxVersionCacheTTL)
+}
+
+// get returns the cached version, it returns false if no version is cached or the TTL expired
+func (vc *VersionCache) get() (string, bool) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	if vc.version == "" || time.Since(vc.detectedAt) >= vc.ttl {
+		return "", false
+	}
+	return vc.version, true
+}
+
+// set caches the detected version and exposes it as metric
+func (vc *VersionCache) set(version string) {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	if vc.version != version {
+		log.StandardLogger().Infof("detected netbox version %s at host %s", version, vc.host)
+		metrics.SetNetboxVersion(vc.host, version)
+	}
+	vc.version = version
+	vc.detectedAt = time.Now()
+}
+
+// Invalidate drops the cached version, the version is detected again before the next write
+func (vc *VersionCache) Invalidate() {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	vc.detectedAt = time.Time{}
+}
+
+// outdated returns true if the api version of a response does not match the cached version
+func (vc *VersionCache) outdated(apiVersion string) bool {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	if vc.version == "" || vc.detectedAt.IsZero() {
+		return false
+	}
+	return semver.MajorMinor(canonicalVersion(vc.version)) != semver.MajorMinor(canonicalVersion(apiVersion))
+}
+
+// VersionRoundTripper invalidates the cached NetBox version when the API-Version header NetBox
+// sets on every response changed, which happens after an upgrade of NetBox. A write rejected as
+// bad request does not invalidate it, NetBox rejects invalid values the same way, e.g. of a
+// custom field, and ignores the fields which are unknown to its version.
+type VersionRoundTripper struct {
+	Versions  *VersionCache
+	Transport http.RoundTripper
+}
+
+func (vrt *VersionRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := vrt.Transport.RoundTrip(req)
+	if err != nil || vrt.Versions == nil {
+		return resp, err
+	}
+
+	if apiVersion := resp.Header.Get("API-Version"); apiVersion != "" && vrt.Versions.outdated(apiVersion) {
+		log.StandardLogger().Infof("netbox at host %s responded with api version %s, detecting the netbox version again", req.URL.Host, apiVersion)
+		vrt.Versions.Invalidate()
+	}
+	return resp, nil
+}
+
+// getNetBoxVersion returns the version of NetBox, it is requested from the status api
+// if it is not cached
 func (c *NetboxClientV4) getNetBoxVersion(ctx context.Context) (version string, err error) {
+	if c.versions != nil {
+		if version, ok := c.versions.get(); ok {
+			return version, nil
+		}
+	}
+
+	version, err = c.fetchNetBoxVersion(ctx)
+	if err != nil {
+		return "", err
+	}
+	if c.versions != nil {
+		c.versions.set(version)
+	}
+	return version, nil
+}
+
+func (c *NetboxClientV4) fetchNetBoxVersion(ctx context.Context) (version string, err error) {
 
 	req := c.StatusAPI.StatusRetrieve(ctx)
 	resp, httpResp, err := req.Execute()
@@ -67,13 +174,17 @@ func (c *NetboxClientV4) getNetBoxVersion(ctx context.Context) (version string,
 	return version, nil
 }
 
-func isLegacyVersion(version string) bool {
-	v := version
-	if v != "" && v[0] != 'v' {
-		v = "v" + v
+// canonicalVersion prefixes a NetBox version with "v" as required by the semver package
+func canonicalVersion(version string) string {
+	if version != "" && version[0] != 'v' {
+		return "v" + version
 	}
+	return version
+}
+
+func isLegacyVersion(version string) bool {
 	// v4+ uses scope; v3 uses site
-	return semver.Compare(v, "v4.2.0") < 0
+	return semver.Compare(canonicalVersion(version), "v4.2.0") < 0
 }
 
 func (c *NetboxClientV4) isLegacyNetBox(ctx context.Context) (bool, error) {
@@ -83,3 +194,8 @@ func (c *NetboxClientV4) isLegacyNetBox(ctx context.Context) (bool, error) {
 	}
 	return isLegacyVersion(version), nil
 }
+
+// NetBoxVersion returns the version of NetBox, it is cached for the TTL of the operator configuration
+func (c *NetboxCompositeClient) NetBoxVersion(ctx context.Context) (string, error) {
+	return c.clientV4.getNetBoxVersion(ctx)
+}
@@ -17,9 +17,15 @@ limitations under the License.
 package api
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
 
 	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -37,3 +43,109 @@ func GetNetBoxVersionMock(ctrl *gomock.Controller, version string) (*mock_interf
 
 	return mockStatusAPI, mockStatusRequest
 }
+
+func TestVersionCache(t *testing.T) {
+	versions := NewVersionCache("netbox.example.com", 50*time.Millisecond)
+	_, ok := versions.get()
+	assert.False(t, ok)
+
+	versions.set("4.2.3")
+	version, ok := versions.get()
+	assert.True(t, ok)
+	assert.Equal(t, "4.2.3", version)
+
+	assert.False(t, versions.outdated("4.2"))
+	assert.True(t, versions.outdated("4.3"))
+
+	versions.Invalidate()
+	_, ok = versions.get()
+	assert.False(t, ok)
+
+	versions.set("4.2.3")
+	assert.Eventually(t, func() bool {
+		_, ok := versions.get()
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGetNetBoxVersion_Cached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the version is requested once, the second call is served from the cache
+	mockStatusAPI, _ := GetNetBoxVersionMock(ctrl, "4.2.3")
+	clientV4 := &NetboxClientV4{StatusAPI: mockStatusAPI, versions: NewVersionCache("netbox.example.com", time.Hour)}
+
+	for range 2 {
+		version, err := clientV4.getNetBoxVersion(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "4.2.3", version)
+	}
+}
+
+func TestVersionRoundTripper(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		statusCode      int
+		apiVersion      string
+		wantInvalidated bool
+	}{
+		{
+			name:       "same api version",
+			method:     http.MethodGet,
+			statusCode: http.StatusOK,
+			apiVersion: "4.2",
+		},
+		{
+			name:            "upgraded api version",
+			method:          http.MethodGet,
+			statusCode:      http.StatusOK,
+			apiVersion:      "4.3",
+			wantInvalidated: true,
+		},
+		{
+			name:       "rejected write",
+			method:     http.MethodPost,
+			statusCode: http.StatusBadRequest,
+			apiVersion: "4.2",
+		},
+		{
+			name:            "rejected write of upgraded api version",
+			method:          http.MethodPost,
+			statusCode:      http.StatusBadRequest,
+			apiVersion:      "4.3",
+			wantInvalidated: true,
+		},
+		{
+			name:       "rejected read",
+			method:     http.MethodGet,
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.apiVersion != "" {
+					w.Header().Set("API-Version", tt.apiVersion)
+				}
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			versions := NewVersionCache("netbox.example.com", time.Hour)
+			versions.set("4.2.3")
+			httpClient := &http.Client{Transport: &VersionRoundTripper{Versions: versions, Transport: http.DefaultTransport}}
+
+			req, err := http.NewRequest(tt.method, server.URL, nil)
+			require.NoError(t, err)
+			resp, err := httpClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			_, ok := versions.get()
+			assert.Equal(t, tt.wantInvalidated, !ok)
+		})
+	}
+}
@@ -0,0 +1,306 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+)
+
+func (c *NetboxCompositeClient) ReserveOrUpdateVlan(ctx context.Context, vlan *models.Vlan, vlanV1 *netboxv1.Vlan) (resp *v4client.VLAN, isUpToDate bool, err error) {
+	vlanGroupId, err := c.getVlanGroupId(ctx, vlan.VlanGroup)
+	if err != nil {
+		return nil, false, err
+	}
+
+	responseVlans, err := c.getVlans(ctx, vlan.Vid, vlanGroupId)
+	if err != nil {
+		return nil, false, err
+	}
+
+	desiredVlan := v4client.NewWritableVLANRequest(vlan.Vid, vlan.Name)
+	status, err := v4client.NewPatchedWritableVLANRequestStatusFromValue(metadataStatus(vlan.Metadata))
+	if err != nil {
+		return nil, false, err
+	}
+	desiredVlan.SetStatus(*status)
+	if vlanGroupId != nil {
+		desiredVlan.SetGroup(v4client.Int32AsPatchedWritableVLANRequestGroup(vlanGroupId))
+	}
+
+	if vlan.Metadata != nil {
+		desiredVlan.SetComments(vlan.Metadata.Comments + warningComment)
+		customFields, err := c.customFieldsRequest(ctx, vlan.Metadata.Custom)
+		if err != nil {
+			return nil, false, err
+		}
+		desiredVlan.SetCustomFields(customFields)
+		desiredVlan.SetDescription(TruncateDescription(vlan.Metadata.Description))
+		if vlan.Metadata.Tenant != "" {
+			tenantDetails, err := c.getTenantDetails(ctx, vlan.Metadata.Tenant)
+			if err != nil {
+				return nil, false, err
+			}
+			tenantId := int32(tenantDetails.Id)
+			desiredVlan.SetTenant(v4client.Int32AsASNRangeRequestTenant(&tenantId))
+		}
+		if vlan.Metadata.Role != "" {
+			role, err := c.roleRequest(ctx, vlan.Metadata.Role)
+			if err != nil {
+				return nil, false, err
+			}
+			desiredVlan.SetRole(role)
+		}
+	}
+
+	if hasTags(vlan.Metadata) {
+		tags, err := c.tagsRequest(ctx, vlan.Metadata.Tags)
+		if err != nil {
+			return nil, false, err
+		}
+		desiredVlan.SetTags(tags)
+	}
+
+	// create vlan since it doesn't exist
+	if len(responseVlans) == 0 {
+		resp, err := c.createVlan(ctx, desiredVlan)
+		return resp, false, err
+	}
+
+	vlanToUpdate := &responseVlans[0]
+	if desiredVlan.HasTags() {
+		desiredVlan.SetTags(withUnmanagedTags(desiredVlan.Tags, vlanToUpdate.Tags, vlan.Metadata.ManagedTags))
+	}
+
+	if !vlanToUpdate.LastUpdated.IsSet() {
+		return nil, false, fmt.Errorf("last updated field is not set in Netbox for vlan %d", vlan.Vid)
+	}
+
+	// if the desired vlan has a restoration hash
+	// check that the vlan to update has the same restoration hash
+	restorationHashKey := config.GetOperatorConfig().NetboxRestorationHashFieldName
+	if vlan.Metadata != nil {
+		if restorationHash, ok := vlan.Metadata.Custom[restorationHashKey]; ok {
+			if vlanToUpdate.CustomFields == nil || vlanToUpdate.CustomFields[restorationHashKey] != restorationHash {
+				return nil, false, fmt.Errorf("%w, assigned vlan %d", ErrRestorationHashMismatch, vlan.Vid)
+			}
+		}
+	}
+
+	if IsUpToDate(ctx, *vlanToUpdate.LastUpdated.Get(), vlanV1.Status.LastUpdated, vlanV1.Status.Conditions, vlanV1.Generation) {
+		return nil, true, nil
+	}
+
+	// update vlan since it does exist
+	resp, err = c.updateVlan(ctx, vlanToUpdate.Id, desiredVlan)
+	if err != nil {
+		return nil, false, err
+	}
+	return resp, false, nil
+}
+
+// getVlans returns the vlans with the vid in the vlan group, or the vlans with the vid without
+// vlan group if the vlan group id is nil
+func (c *NetboxCompositeClient) getVlans(ctx context.Context, vid int32, vlanGroupId *int32) ([]v4client.VLAN, error) {
+	req := c.clientV4.IpamAPI.IpamVlansList(ctx).Vid([]int32{vid})
+	if vlanGroupId != nil {
+		req = req.GroupId([]*int32{vlanGroupId})
+	}
+	results, err := listVlans(req)
+	if err != nil {
+		return nil, err
+	}
+
+	vlans := make([]v4client.VLAN, 0, len(results))
+	for _, vlan := range results {
+		// without vlan group filter NetBox also returns the vlans with the vid of all vlan groups
+		if vlanGroupId == nil && vlan.Group.Get() != nil {
+			continue
+		}
+		vlans = append(vlans, vlan)
+	}
+	return vlans, nil
+}
+
+// getVlanId returns the id of the vlan referenced by its vid and vlan group, or by its name,
+// which is looked up in the vlan group if it is set
+func (c *NetboxCompositeClient) getVlanId(ctx context.Context, vlan *models.Vlan) (int32, error) {
+	vlanGroupId, err := c.getVlanGroupId(ctx, vlan.VlanGroup)
+	if err != nil {
+		return 0, err
+	}
+
+	var vlans []v4client.VLAN
+	var description string
+	if vlan.Vid != 0 {
+		vlans, err = c.getVlans(ctx, vlan.Vid, vlanGroupId)
+		description = fmt.Sprintf("vlan %d", vlan.Vid)
+	} else {
+		req := c.clientV4.IpamAPI.IpamVlansList(ctx).Name([]string{vlan.Name})
+		if vlanGroupId != nil {
+			req = req.GroupId([]*int32{vlanGroupId})
+		}
+		vlans, err = listVlans(req)
+		description = "vlan '" + vlan.Name + "'"
+	}
+	if err != nil {
+		return 0, err
+	}
+	if vlan.VlanGroup != "" {
+		description += " of vlan group '" + vlan.VlanGroup + "'"
+	}
+
+	if len(vlans) == 0 {
+		return 0, utils.NetboxNotFoundError(description)
+	}
+	if len(vlans) > 1 {
+		return 0, fmt.Errorf("%s is not unique, number of vlans: %d", description, len(vlans))
+	}
+	return vlans[0].Id, nil
+}
+
+func listVlans(req interfaces.IpamVlansListRequest) ([]v4client.VLAN, error) {
+	return listAllPages(func(limit int32, offset int32) (results []v4client.VLAN, next *string, err error) {
+		list, httpResp, execErr := req.Limit(limit).Offset(offset).Execute()
+		closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list vlans")
+		if closeFunc != nil {
+			defer func() { err = errors.Join(err, closeFunc()) }()
+		}
+		if handleErr != nil {
+			return nil, nil, handleErr
+		}
+		return list.Results, list.Next.Get(), nil
+	})
+}
+
+func (c *NetboxCompositeClient) createVlan(ctx context.Context, vlan *v4client.WritableVLANRequest) (resp *v4client.VLAN, err error) {
+	req := c.clientV4.IpamAPI.IpamVlansCreate(ctx).WritableVLANRequest(*vlan)
+	resp, httpResp, execErr := req.Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusCreated, "reserve vlan")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+
+	return resp, nil
+}
+
+func (c *NetboxCompositeClient) updateVlan(ctx context.Context, vlanId int32, vlan *v4client.WritableVLANRequest) (resp *v4client.VLAN, err error) {
+	req := c.clientV4.IpamAPI.IpamVlansUpdate(ctx, vlanId).WritableVLANRequest(*vlan)
+	resp, httpResp, execErr := req.Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "update vlan")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+
+	return resp, nil
+}
+
+func (c *NetboxCompositeClient) DeleteVlan(ctx context.Context, vlanId int32) (err error) {
+	req := c.clientV4.IpamAPI.IpamVlansDestroy(ctx, vlanId)
+	httpResp, execErr := req.Execute()
+
+	if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusNoContent, "delete vlan from netbox")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return handleErr
+	}
+
+	return nil
+}
+
+// getVlanGroupDetails returns the vlan group with the name or slug, it is served from the lookup cache if possible
+func (c *NetboxCompositeClient) getVlanGroupDetails(ctx context.Context, nameOrSlug string) (*models.VlanGroup, error) {
+	return cachedLookup(c.lookups, vlanGroupLookupKind, nameOrSlug, func() (*models.VlanGroup, error) {
+		return c.fetchVlanGroupDetails(ctx, nameOrSlug)
+	})
+}
+
+// fetchVlanGroupDetails returns the vlan group with the name, or if there is none, the vlan group with the slug
+func (c *NetboxCompositeClient) fetchVlanGroupDetails(ctx context.Context, nameOrSlug string) (*models.VlanGroup, error) {
+	vlanGroups, err := listVlanGroups(c.clientV4.IpamAPI.IpamVlanGroupsList(ctx).Name([]string{nameOrSlug}))
+	if err != nil {
+		return nil, err
+	}
+	if len(vlanGroups) == 0 {
+		vlanGroups, err = listVlanGroups(c.clientV4.IpamAPI.IpamVlanGroupsList(ctx).Slug([]string{nameOrSlug}))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(vlanGroups) == 0 {
+		return nil, utils.NetboxNotFoundError("vlan group '" + nameOrSlug + "'")
+	}
+
+	return &models.VlanGroup{
+		Id:   int64(vlanGroups[0].Id),
+		Name: vlanGroups[0].Name,
+		Slug: vlanGroups[0].Slug,
+	}, nil
+}
+
+// getVlanGroupId returns the id of the vlan group with the name or slug, or nil if it is empty,
+// which stands for a vlan without vlan group
+func (c *NetboxCompositeClient) getVlanGroupId(ctx context.Context, nameOrSlug string) (*int32, error) {
+	if nameOrSlug == "" {
+		return nil, nil
+	}
+
+	details, err := c.getVlanGroupDetails(ctx, nameOrSlug)
+	if err != nil {
+		return nil, err
+	}
+	vlanGroupId := int32(details.Id)
+	return &vlanGroupId, nil
+}
+
+func listVlanGroups(req interfaces.IpamVlanGroupsListRequest) ([]v4client.VLANGroup, error) {
+	return listAllPages(func(limit int32, offset int32) (results []v4client.VLANGroup, next *string, err error) {
+		list, httpResp, execErr := req.Limit(limit).Offset(offset).Execute()
+		closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "fetch VLAN Group details")
+		if closeFunc != nil {
+			defer func() { err = errors.Join(err, closeFunc()) }()
+		}
+		if handleErr != nil {
+			return nil, nil, handleErr
+		}
+		return list.Results, list.Next.Get(), nil
+	})
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/pkg/config"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+)
+
+// RestoreExistingVlanByHash returns the vlan with the restoration hash, the name of its vlan group
+// is returned in the VlanGroup of the vlan
+func (c *NetboxCompositeClient) RestoreExistingVlanByHash(ctx context.Context, hash string) (*models.Vlan, error) {
+	customVlanSearch := withQueryFilter(ctx, nil, []CustomFieldEntry{
+		{
+			key:   config.GetOperatorConfig().NetboxRestorationHashFieldName,
+			value: hash,
+		},
+	})
+	results, err := listVlans(c.clientV4.IpamAPI.IpamVlansList(customVlanSearch))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	// We should not have more than 1 result...
+	if len(results) != 1 {
+		return nil, fmt.Errorf("incorrect number of restoration results, number of results: %v", len(results))
+	}
+	res := results[0]
+
+	vlan := &models.Vlan{
+		Vid:  res.Vid,
+		Name: res.Name,
+		Id:   int64(res.Id),
+	}
+	if res.Group.Get() != nil {
+		vlan.VlanGroup = res.Group.Get().Name
+	}
+	return vlan, nil
+}
+
+// GetAvailableVlanByClaim returns the next available vlan of the vlan group of the VlanClaim,
+// the vlan group is returned in the VlanGroup of the vlan
+func (c *NetboxCompositeClient) GetAvailableVlanByClaim(ctx context.Context, vlanClaim *models.VlanClaim) (*models.Vlan, error) {
+	if vlanClaim.Metadata != nil && vlanClaim.Metadata.Tenant != "" {
+		_, err := c.getTenantDetails(ctx, vlanClaim.Metadata.Tenant)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vlanGroup, err := c.getVlanGroupDetails(ctx, vlanClaim.VlanGroup)
+	if err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %w", ErrVlanGroupNotFound, err)
+		}
+		return nil, err
+	}
+
+	availableVlans, err := c.listAvailableVlansOfVlanGroup(ctx, int32(vlanGroup.Id))
+	if err != nil {
+		return nil, err
+	}
+	if len(availableVlans) == 0 {
+		return nil, ErrVlanGroupExhausted
+	}
+
+	return &models.Vlan{
+		Vid:       availableVlans[0].Vid,
+		VlanGroup: vlanClaim.VlanGroup,
+	}, nil
+}
+
+func (c *NetboxCompositeClient) listAvailableVlansOfVlanGroup(ctx context.Context, vlanGroupId int32) (availableVlans []v4client.AvailableVLAN, err error) {
+	availableVlans, httpResp, execErr := c.clientV4.IpamAPI.IpamVlanGroupsAvailableVlansList(withPageLimit(ctx), vlanGroupId).Execute()
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "list available vlans of vlan group")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+	return availableVlans, nil
+}
+
+// GetAvailableVlanGroupsBySelector returns all vlan groups matching the vlanGroupSelector
+// which have an available vlan, in the order returned by NetBox
+func (c *NetboxCompositeClient) GetAvailableVlanGroupsBySelector(ctx context.Context, vlanClaimSpec *netboxv1.VlanClaimSpec) ([]*models.VlanGroup, error) {
+	vlanGroups, err := c.listVlanGroupsByVlanGroupSelector(ctx, vlanClaimSpec.VlanGroupSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*models.VlanGroup, 0)
+	for _, vlanGroup := range vlanGroups {
+		availableVlans, errCandidate := c.listAvailableVlansOfVlanGroup(ctx, vlanGroup.Id)
+		if errCandidate == nil && len(availableVlans) == 0 {
+			errCandidate = ErrVlanGroupExhausted
+		}
+		if errCandidate != nil {
+			err = errors.Join(err, fmt.Errorf("vlan group %s is not a valid vlan group candidate, %w", vlanGroup.Name, errCandidate))
+		} else {
+			candidates = append(candidates, &models.VlanGroup{
+				Id:   int64(vlanGroup.Id),
+				Name: vlanGroup.Name,
+				Slug: vlanGroup.Slug,
+			})
+		}
+	}
+
+	if len(candidates) == 0 && err != nil {
+		return candidates, err
+	}
+
+	return candidates, nil
+}
+
+// listVlanGroupsByVlanGroupSelector returns all vlan groups matching the vlanGroupSelector,
+// in the order returned by NetBox
+func (c *NetboxCompositeClient) listVlanGroupsByVlanGroupSelector(ctx context.Context, vlanGroupSelector map[string]string) ([]v4client.VLANGroup, error) {
+	fieldEntries := make(map[string]string)
+
+	if tenant, ok := vlanGroupSelector["tenant"]; ok {
+		details, err := c.getTenantDetails(ctx, tenant)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldEntries["tenant_id"] = strconv.Itoa(int(details.Id))
+	}
+
+	if site, ok := vlanGroupSelector["site"]; ok {
+		details, err := c.getSiteDetails(ctx, site)
+		if err != nil {
+			return nil, err
+		}
+
+		// the site filter of the vlan groups takes the id of the site the vlan groups are scoped to
+		fieldEntries["site"] = strconv.Itoa(int(details.Id))
+	}
+
+	vlanGroupSelectorCustomFields := make([]CustomFieldEntry, 0, len(vlanGroupSelector))
+	for k, v := range vlanGroupSelector {
+		switch k {
+		case "tenant", "site":
+			// skip built in fields
+		default:
+			vlanGroupSelectorCustomFields = append(vlanGroupSelectorCustomFields, CustomFieldEntry{
+				key:   k,
+				value: v,
+			})
+		}
+	}
+
+	err := c.customFieldsExistsOrErr(ctx, vlanGroupSelectorCustomFields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vlan group selector, %w", err)
+	}
+
+	conditions := withQueryFilter(ctx, fieldEntries, vlanGroupSelectorCustomFields)
+
+	results, err := listVlanGroups(c.clientV4.IpamAPI.IpamVlanGroupsList(conditions))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, errors.New("no vlan groups found for this selector")
+	}
+
+	return results, nil
+}
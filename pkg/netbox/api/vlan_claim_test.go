@@ -0,0 +1,183 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// expectVlanGroupsList expects any number of lookups of vlan groups on the IpamAPI, in which
+// only the vlan groups exist, they are looked up by name or slug
+func expectVlanGroupsList(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, vlanGroups ...v4client.VLANGroup) {
+	mockIpam.EXPECT().IpamVlanGroupsList(gomock.Any()).DoAndReturn(func(_ context.Context) interfaces.IpamVlanGroupsListRequest {
+		list := &v4client.PaginatedVLANGroupList{Results: []v4client.VLANGroup{}}
+		filter := func(matches func(vlanGroup v4client.VLANGroup) bool) {
+			for _, vlanGroup := range vlanGroups {
+				if matches(vlanGroup) {
+					list.Results = append(list.Results, vlanGroup)
+				}
+			}
+			list.Count = int32(len(list.Results))
+		}
+
+		mockListRequest := mock_interfaces.NewMockIpamVlanGroupsListRequest(ctrl)
+		mockListRequest.EXPECT().Name(gomock.Any()).DoAndReturn(func(name []string) interfaces.IpamVlanGroupsListRequest {
+			filter(func(vlanGroup v4client.VLANGroup) bool { return vlanGroup.Name == name[0] })
+			return mockListRequest
+		}).AnyTimes()
+		mockListRequest.EXPECT().Slug(gomock.Any()).DoAndReturn(func(slug []string) interfaces.IpamVlanGroupsListRequest {
+			filter(func(vlanGroup v4client.VLANGroup) bool { return vlanGroup.Slug == slug[0] })
+			return mockListRequest
+		}).AnyTimes()
+		mockListRequest.EXPECT().Limit(gomock.Any()).Return(mockListRequest).AnyTimes()
+		mockListRequest.EXPECT().Offset(gomock.Any()).Return(mockListRequest).AnyTimes()
+		mockListRequest.EXPECT().Execute().DoAndReturn(func() (*v4client.PaginatedVLANGroupList, *http.Response, error) {
+			return list, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})
+		return mockListRequest
+	}).AnyTimes()
+}
+
+// expectAvailableVlans expects a list of the available vlans of the vlan group
+func expectAvailableVlans(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, vlanGroupId int32, vids ...int32) {
+	availableVlans := make([]v4client.AvailableVLAN, 0, len(vids))
+	for _, vid := range vids {
+		availableVlans = append(availableVlans, v4client.AvailableVLAN{Vid: vid})
+	}
+
+	mockListRequest := mock_interfaces.NewMockIpamVlanGroupsAvailableVlansListRequest(ctrl)
+	mockListRequest.EXPECT().Execute().Return(availableVlans, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil)
+	mockIpam.EXPECT().IpamVlanGroupsAvailableVlansList(gomock.Any(), vlanGroupId).Return(mockListRequest)
+}
+
+func TestVlanGroup_GetVlanGroupDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectVlanGroupsList(ctrl, mockIpam, v4client.VLANGroup{Id: 7, Name: "Production VLANs", Slug: "prod-vlans"})
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+	expected := &models.VlanGroup{Id: 7, Name: "Production VLANs", Slug: "prod-vlans"}
+
+	t.Run("Vlan group is found by its name.", func(t *testing.T) {
+		actual, err := compositeClient.getVlanGroupDetails(context.TODO(), "Production VLANs")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Vlan group is found by its slug.", func(t *testing.T) {
+		actual, err := compositeClient.getVlanGroupDetails(context.TODO(), "prod-vlans")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+
+	t.Run("Vlan group does not exist.", func(t *testing.T) {
+		actual, err := compositeClient.getVlanGroupDetails(context.TODO(), "test-vlans")
+		assert.Nil(t, actual)
+		assert.EqualError(t, err, "failed to fetch vlan group 'test-vlans': not found")
+	})
+
+	t.Run("Empty vlan group has no id.", func(t *testing.T) {
+		actual, err := compositeClient.getVlanGroupId(context.TODO(), "")
+		assert.NoError(t, err)
+		assert.Nil(t, actual)
+	})
+}
+
+func TestVlanClaim_GetAvailableVlanByClaim(t *testing.T) {
+	vlanGroup := v4client.VLANGroup{Id: 7, Name: "Production VLANs", Slug: "prod-vlans"}
+
+	t.Run("Next available vid of the vlan group.", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectVlanGroupsList(ctrl, mockIpam, vlanGroup)
+		expectAvailableVlans(ctrl, mockIpam, 7, 102, 103, 104)
+
+		compositeClient := &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+		}
+
+		actual, err := compositeClient.GetAvailableVlanByClaim(context.TODO(), &models.VlanClaim{VlanGroup: "prod-vlans"})
+		require.NoError(t, err)
+		assert.Equal(t, &models.Vlan{Vid: 102, VlanGroup: "prod-vlans"}, actual)
+	})
+
+	t.Run("Vlan group without available vids is exhausted.", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectVlanGroupsList(ctrl, mockIpam, vlanGroup)
+		expectAvailableVlans(ctrl, mockIpam, 7)
+
+		compositeClient := &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+		}
+
+		actual, err := compositeClient.GetAvailableVlanByClaim(context.TODO(), &models.VlanClaim{VlanGroup: "prod-vlans"})
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, ErrVlanGroupExhausted)
+	})
+
+	t.Run("Vlan group does not exist.", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		expectVlanGroupsList(ctrl, mockIpam, vlanGroup)
+
+		compositeClient := &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+		}
+
+		actual, err := compositeClient.GetAvailableVlanByClaim(context.TODO(), &models.VlanClaim{VlanGroup: "test-vlans"})
+		assert.Nil(t, actual)
+		assert.ErrorIs(t, err, ErrVlanGroupNotFound)
+	})
+}
+
+func TestVlanClaim_RestoreExistingVlanByHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	restoredVlan := v4client.VLAN{Id: 12, Vid: 102, Name: "k8s-nodes"}
+	restoredVlan.Group.Set(&v4client.BriefVLANGroup{Id: 7, Name: "Production VLANs", Slug: "prod-vlans"})
+
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	mockListRequest := mock_interfaces.NewMockIpamVlansListRequest(ctrl)
+	mockIpam.EXPECT().IpamVlansList(gomock.Any()).Return(mockListRequest)
+	mockListRequest.EXPECT().Limit(gomock.Any()).Return(mockListRequest)
+	mockListRequest.EXPECT().Offset(gomock.Any()).Return(mockListRequest)
+	mockListRequest.EXPECT().Execute().Return(&v4client.PaginatedVLANList{Count: 1, Results: []v4client.VLAN{restoredVlan}}, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	actual, err := compositeClient.RestoreExistingVlanByHash(context.TODO(), "myHash")
+	require.NoError(t, err)
+	// the vlan group of the restored vlan is returned by its name
+	assert.Equal(t, &models.Vlan{Vid: 102, Name: "k8s-nodes", VlanGroup: "Production VLANs", Id: 12}, actual)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	netboxv1 "github.com/netbox-community/netbox-operator/api/v1"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// newVlan returns a vlan with the vid and name, in the vlan group if it is not nil
+func newVlan(id int32, vid int32, name string, vlanGroup *v4client.VLANGroup) v4client.VLAN {
+	vlan := v4client.VLAN{Id: id, Vid: vid, Name: name}
+	if vlanGroup != nil {
+		vlan.Group.Set(&v4client.BriefVLANGroup{Id: vlanGroup.Id, Name: vlanGroup.Name, Slug: vlanGroup.Slug})
+	}
+	return vlan
+}
+
+// expectVlansList expects any number of lists of vlans on the IpamAPI, in which only the
+// vlans exist, they are filtered by vid, name and vlan group id
+func expectVlansList(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, vlans ...v4client.VLAN) {
+	mockIpam.EXPECT().IpamVlansList(gomock.Any()).DoAndReturn(func(_ context.Context) interfaces.IpamVlansListRequest {
+		list := &v4client.PaginatedVLANList{Results: slices.Clone(vlans)}
+		filter := func(matches func(vlan v4client.VLAN) bool) {
+			results := []v4client.VLAN{}
+			for _, vlan := range list.Results {
+				if matches(vlan) {
+					results = append(results, vlan)
+				}
+			}
+			list.Results = results
+			list.Count = int32(len(list.Results))
+		}
+
+		mockListRequest := mock_interfaces.NewMockIpamVlansListRequest(ctrl)
+		mockListRequest.EXPECT().Vid(gomock.Any()).DoAndReturn(func(vid []int32) interfaces.IpamVlansListRequest {
+			filter(func(vlan v4client.VLAN) bool { return vlan.Vid == vid[0] })
+			return mockListRequest
+		}).AnyTimes()
+		mockListRequest.EXPECT().Name(gomock.Any()).DoAndReturn(func(name []string) interfaces.IpamVlansListRequest {
+			filter(func(vlan v4client.VLAN) bool { return vlan.Name == name[0] })
+			return mockListRequest
+		}).AnyTimes()
+		mockListRequest.EXPECT().GroupId(gomock.Any()).DoAndReturn(func(groupId []*int32) interfaces.IpamVlansListRequest {
+			filter(func(vlan v4client.VLAN) bool { return vlan.Group.Get() != nil && vlan.Group.Get().Id == *groupId[0] })
+			return mockListRequest
+		}).AnyTimes()
+		mockListRequest.EXPECT().Limit(gomock.Any()).Return(mockListRequest).AnyTimes()
+		mockListRequest.EXPECT().Offset(gomock.Any()).Return(mockListRequest).AnyTimes()
+		mockListRequest.EXPECT().Execute().DoAndReturn(func() (*v4client.PaginatedVLANList, *http.Response, error) {
+			return list, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		})
+		return mockListRequest
+	}).AnyTimes()
+}
+
+func TestVlan_GetVlanId(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prodVlans := v4client.VLANGroup{Id: 7, Name: "Production VLANs", Slug: "prod-vlans"}
+	testVlans := v4client.VLANGroup{Id: 8, Name: "Test VLANs", Slug: "test-vlans"}
+
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectVlanGroupsList(ctrl, mockIpam, prodVlans, testVlans)
+	expectVlansList(ctrl, mockIpam,
+		newVlan(11, 100, "k8s-nodes", &prodVlans),
+		newVlan(12, 100, "k8s-nodes", &testVlans),
+		newVlan(13, 100, "legacy", nil),
+		newVlan(14, 200, "k8s-pods", &prodVlans),
+	)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	t.Run("Vlan is found by its vid in the vlan group.", func(t *testing.T) {
+		actual, err := compositeClient.getVlanId(context.TODO(), &models.Vlan{Vid: 100, VlanGroup: "test-vlans"})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(12), actual)
+	})
+
+	t.Run("Vlan without vlan group is found by its vid.", func(t *testing.T) {
+		actual, err := compositeClient.getVlanId(context.TODO(), &models.Vlan{Vid: 100})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(13), actual)
+	})
+
+	t.Run("Vlan is found by its name.", func(t *testing.T) {
+		actual, err := compositeClient.getVlanId(context.TODO(), &models.Vlan{Name: "k8s-pods"})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(14), actual)
+	})
+
+	t.Run("Vlan is found by its name in the vlan group.", func(t *testing.T) {
+		actual, err := compositeClient.getVlanId(context.TODO(), &models.Vlan{Name: "k8s-nodes", VlanGroup: "Production VLANs"})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(11), actual)
+	})
+
+	t.Run("Vlan name is not unique.", func(t *testing.T) {
+		_, err := compositeClient.getVlanId(context.TODO(), &models.Vlan{Name: "k8s-nodes"})
+		assert.EqualError(t, err, "vlan 'k8s-nodes' is not unique, number of vlans: 2")
+	})
+
+	t.Run("Vlan does not exist in the vlan group.", func(t *testing.T) {
+		_, err := compositeClient.getVlanId(context.TODO(), &models.Vlan{Vid: 200, VlanGroup: "test-vlans"})
+		assert.EqualError(t, err, "failed to fetch vlan 200 of vlan group 'test-vlans': not found")
+	})
+}
+
+func TestVlan_ReserveVlanWithTruncatedDescription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	description := strings.Repeat("a", maxAllowedDescriptionLength)
+
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectVlansList(ctrl, mockIpam)
+	createRequest := mock_interfaces.NewMockIpamVlansCreateRequest(ctrl)
+	mockIpam.EXPECT().IpamVlansCreate(gomock.Any()).Return(createRequest)
+	createRequest.EXPECT().WritableVLANRequest(gomock.Cond(func(request v4client.WritableVLANRequest) bool {
+		return request.GetDescription() == TruncateDescription(description)
+	})).Return(createRequest)
+	createRequest.EXPECT().Execute().Return(&v4client.VLAN{Id: 11, Vid: 100, Name: "k8s-nodes"}, &http.Response{StatusCode: http.StatusCreated, Body: http.NoBody}, nil)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	result, isUpToDate, err := compositeClient.ReserveOrUpdateVlan(context.TODO(), &models.Vlan{
+		Vid:  100,
+		Name: "k8s-nodes",
+		Metadata: &models.NetboxMetadata{
+			Description: description,
+		},
+	}, &netboxv1.Vlan{})
+	assert.Nil(t, err)
+	assert.False(t, isUpToDate)
+	assert.Equal(t, int32(11), result.Id)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/utils"
+)
+
+// getVrfDetails returns the VRF with the name, it is served from the lookup cache if possible
+func (c *NetboxCompositeClient) getVrfDetails(ctx context.Context, name string) (*models.Vrf, error) {
+	return cachedLookup(c.lookups, vrfLookupKind, name, func() (*models.Vrf, error) {
+		return c.fetchVrfDetails(ctx, name)
+	})
+}
+
+func (c *NetboxCompositeClient) fetchVrfDetails(ctx context.Context, name string) (details *models.Vrf, err error) {
+	response, httpResp, execErr := c.clientV4.IpamAPI.IpamVrfsList(ctx).Name([]string{name}).Execute()
+
+	closeFunc, handleErr := handleHTTPResponse(httpResp, execErr, http.StatusOK, "fetch VRF details")
+	if closeFunc != nil {
+		defer func() { err = errors.Join(err, closeFunc()) }()
+	}
+	if handleErr != nil {
+		return nil, handleErr
+	}
+
+	if len(response.Results) == 0 {
+		return nil, utils.NetboxNotFoundError("vrf '" + name + "'")
+	}
+
+	return &models.Vrf{
+		Id:   int64(response.Results[0].Id),
+		Name: response.Results[0].Name,
+	}, nil
+}
+
+// getVrfId returns the id of the VRF with the name, or nil if the name is empty, which
+// stands for the global table
+func (c *NetboxCompositeClient) getVrfId(ctx context.Context, name string) (*int32, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	details, err := c.getVrfDetails(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	vrfId := int32(details.Id)
+	return &vrfId, nil
+}
+
+// withVrfQueryFilter returns a context whose list requests only return objects in the VRF with
+// the name, the context is returned unchanged if the name is empty
+func (c *NetboxCompositeClient) withVrfQueryFilter(ctx context.Context, name string) (context.Context, error) {
+	vrfId, err := c.getVrfId(ctx, name)
+	if err != nil || vrfId == nil {
+		return ctx, err
+	}
+	return withQueryParam(ctx, "vrf_id", strconv.Itoa(int(*vrfId))), nil
+}
+
+// metadataVrf returns the name of the VRF of the metadata, an empty name if it has none
+func metadataVrf(metadata *models.NetboxMetadata) string {
+	if metadata == nil {
+		return ""
+	}
+	return metadata.Vrf
+}
+
+// vrfName returns the name of the VRF an object is assigned to in NetBox, an empty name if
+// the object is in the global table
+func vrfName(vrf v4client.NullableBriefVRF) string {
+	if vrf.Get() == nil {
+		return ""
+	}
+	return vrf.Get().Name
+}
+
+// vrfMetadata returns the metadata passing the VRF of a parent object in NetBox on to the
+// object claimed from it, it returns nil if the parent object is in the global table
+func vrfMetadata(vrf v4client.NullableBriefVRF) *models.NetboxMetadata {
+	name := vrfName(vrf)
+	if name == "" {
+		return nil
+	}
+	return &models.NetboxMetadata{Vrf: name}
+}
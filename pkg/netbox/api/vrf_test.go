@@ -0,0 +1,178 @@
+/*
+Copyright 2026 Swisscom (Schweiz) AG.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	v4client "github.com/netbox-community/go-netbox/v4"
+	"github.com/netbox-community/netbox-operator/gen/mock_interfaces"
+	"github.com/netbox-community/netbox-operator/pkg/netbox/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// expectVrfsList expects any number of lookups of the VRF with the name on the IpamAPI, which
+// list the vrfs or fail with err
+func expectVrfsList(ctrl *gomock.Controller, mockIpam *mock_interfaces.MockIpamAPI, name string, vrfs []v4client.VRF, err error) {
+	mockListRequest := mock_interfaces.NewMockIpamVrfsListRequest(ctrl)
+	mockIpam.EXPECT().IpamVrfsList(gomock.Any()).Return(mockListRequest).AnyTimes()
+	mockListRequest.EXPECT().Name([]string{name}).Return(mockListRequest).AnyTimes()
+	if err != nil {
+		mockListRequest.EXPECT().Execute().Return(nil, nil, err).AnyTimes()
+	} else {
+		mockListRequest.EXPECT().Execute().DoAndReturn(func() (*v4client.PaginatedVRFList, *http.Response, error) {
+			return &v4client.PaginatedVRFList{Count: int32(len(vrfs)), Results: vrfs}, &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}).AnyTimes()
+	}
+}
+
+func TestVrf_GetVrfDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectVrfsList(ctrl, mockIpam, "customer-a", []v4client.VRF{{Id: 7, Name: "customer-a"}}, nil)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	actual, err := compositeClient.getVrfDetails(context.TODO(), "customer-a")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Vrf{Id: 7, Name: "customer-a"}, actual)
+
+	vrfId, err := compositeClient.getVrfId(context.TODO(), "customer-a")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), *vrfId)
+}
+
+func TestVrf_GetEmptyResult(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectVrfsList(ctrl, mockIpam, "customer-a", nil, nil)
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	actual, err := compositeClient.getVrfDetails(context.TODO(), "customer-a")
+	assert.Nil(t, actual)
+	assert.EqualError(t, err, "failed to fetch vrf 'customer-a': not found")
+}
+
+func TestVrf_GetError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+	expectedErr := "error getting vrfs list"
+	expectVrfsList(ctrl, mockIpam, "customer-a", nil, errors.New(expectedErr))
+
+	compositeClient := &NetboxCompositeClient{
+		clientV4: &NetboxClientV4{IpamAPI: mockIpam},
+	}
+
+	actual, err := compositeClient.getVrfId(context.TODO(), "customer-a")
+	assert.Nil(t, actual)
+	assert.EqualError(t, err, "failed to fetch VRF details: "+expectedErr)
+}
+
+func TestVrf_GetVrfIdOfGlobalTable(t *testing.T) {
+	// the global table is not looked up in NetBox
+	compositeClient := &NetboxCompositeClient{}
+
+	vrfId, err := compositeClient.getVrfId(context.TODO(), "")
+	assert.NoError(t, err)
+	assert.Nil(t, vrfId)
+}
+
+func TestVrf_GetAvailablePrefixByClaim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tenantName := "tenant"
+	expectedTenant := []v4client.Tenant{{Id: 2, Name: tenantName, Slug: "tenant"}}
+
+	parentPrefix := "10.112.140.0/24"
+	parentPrefixId := int32(1)
+	vrfId := int32(7)
+
+	newCompositeClient := func(vrfFilter []*int32) *NetboxCompositeClient {
+		mockIpam := mock_interfaces.NewMockIpamAPI(ctrl)
+		mockListRequest := mock_interfaces.NewMockIpamPrefixesListRequest(ctrl)
+		expectVrfsList(ctrl, mockIpam, "customer-a", []v4client.VRF{{Id: vrfId, Name: "customer-a"}}, nil)
+
+		aggregateFamily := v4client.NewAggregateFamily()
+		aggregateFamily.SetValue(v4client.AggregateFamilyValue(IPv4Family))
+		outputPrefix := v4client.Prefix{
+			Id:     parentPrefixId,
+			Prefix: parentPrefix,
+			Family: *aggregateFamily,
+			Vrf:    *v4client.NewNullableBriefVRF(&v4client.BriefVRF{Id: vrfId, Name: "customer-a"}),
+		}
+
+		mockIpam.EXPECT().IpamPrefixesList(gomock.Any()).Return(mockListRequest)
+		mockListRequest.EXPECT().Prefix([]string{parentPrefix}).Return(mockListRequest)
+		if vrfFilter != nil {
+			mockListRequest.EXPECT().VrfId(vrfFilter).Return(mockListRequest)
+		}
+		mockListRequest.EXPECT().Execute().Return(
+			&v4client.PaginatedPrefixList{Results: []v4client.Prefix{outputPrefix}},
+			&http.Response{StatusCode: 200, Body: http.NoBody}, nil)
+		expectAvailablePrefixesList(ctrl, mockIpam, parentPrefixId, []v4client.AvailablePrefix{
+			{Prefix: "10.112.140.16/28"},
+		})
+
+		return &NetboxCompositeClient{
+			clientV4: &NetboxClientV4{
+				IpamAPI:    mockIpam,
+				TenancyAPI: mockTenancyAPI(ctrl, tenantName, expectedTenant, nil),
+			},
+		}
+	}
+
+	t.Run("Parent prefix is looked up in the VRF of the claim.", func(t *testing.T) {
+		actual, err := newCompositeClient([]*int32{&vrfId}).GetAvailablePrefixByClaim(
+			context.TODO(),
+			&models.PrefixClaim{
+				ParentPrefix: parentPrefix,
+				PrefixLength: "/28",
+				Metadata:     &models.NetboxMetadata{Tenant: tenantName, Vrf: "customer-a"},
+			})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "10.112.140.16/28", actual.Prefix)
+		assert.Equal(t, "customer-a", actual.Metadata.Vrf)
+	})
+
+	t.Run("VRF of the parent prefix is returned for a claim without VRF.", func(t *testing.T) {
+		actual, err := newCompositeClient(nil).GetAvailablePrefixByClaim(
+			context.TODO(),
+			&models.PrefixClaim{
+				ParentPrefix: parentPrefix,
+				PrefixLength: "/28",
+				Metadata:     &models.NetboxMetadata{Tenant: tenantName},
+			})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "10.112.140.16/28", actual.Prefix)
+		assert.Equal(t, "customer-a", actual.Metadata.Vrf)
+	})
+}
@@ -20,51 +20,40 @@ import (
 	"context"
 	"net/http"
 
-	"github.com/go-openapi/runtime"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/dcim"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/extras"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/ipam"
-	"github.com/netbox-community/go-netbox/v3/netbox/client/tenancy"
 	v4client "github.com/netbox-community/go-netbox/v4"
 )
 
-type IpamInterface interface {
-	IpamIPAddressesList(params *ipam.IpamIPAddressesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPAddressesListOK, error)
-	IpamIPAddressesCreate(params *ipam.IpamIPAddressesCreateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPAddressesCreateCreated, error)
-	IpamIPAddressesUpdate(params *ipam.IpamIPAddressesUpdateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPAddressesUpdateOK, error)
-	IpamIPAddressesDelete(params *ipam.IpamIPAddressesDeleteParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPAddressesDeleteNoContent, error)
-	IpamPrefixesAvailableIpsList(params *ipam.IpamPrefixesAvailableIpsListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesAvailableIpsListOK, error)
-
-	IpamPrefixesList(params *ipam.IpamPrefixesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesListOK, error)
-	IpamPrefixesCreate(params *ipam.IpamPrefixesCreateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesCreateCreated, error)
-	IpamPrefixesUpdate(params *ipam.IpamPrefixesUpdateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesUpdateOK, error)
-	IpamPrefixesDelete(params *ipam.IpamPrefixesDeleteParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesDeleteNoContent, error)
-	IpamPrefixesAvailablePrefixesList(params *ipam.IpamPrefixesAvailablePrefixesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamPrefixesAvailablePrefixesListOK, error)
+// V4 API Interfaces - Request Objects
 
-	IpamIPRangesList(params *ipam.IpamIPRangesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesListOK, error)
-	IpamIPRangesCreate(params *ipam.IpamIPRangesCreateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesCreateCreated, error)
-	IpamIPRangesUpdate(params *ipam.IpamIPRangesUpdateParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesUpdateOK, error)
-	IpamIPRangesDelete(params *ipam.IpamIPRangesDeleteParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesDeleteNoContent, error)
-	IpamIPRangesAvailableIpsList(params *ipam.IpamIPRangesAvailableIpsListParams, authInfo runtime.ClientAuthInfoWriter, opts ...ipam.ClientOption) (*ipam.IpamIPRangesAvailableIpsListOK, error)
+type IpamIpAddressesListRequest interface {
+	Address(address []string) IpamIpAddressesListRequest
+	Parent(parent []string) IpamIpAddressesListRequest
+	VrfId(vrfId []*int32) IpamIpAddressesListRequest
+	Limit(limit int32) IpamIpAddressesListRequest
+	Offset(offset int32) IpamIpAddressesListRequest
+	Execute() (*v4client.PaginatedIPAddressList, *http.Response, error)
 }
 
-type TenancyInterface interface {
-	TenancyTenantsList(params *tenancy.TenancyTenantsListParams, authInfo runtime.ClientAuthInfoWriter, opts ...tenancy.ClientOption) (*tenancy.TenancyTenantsListOK, error)
+type IpamIpAddressesCreateRequest interface {
+	WritableIPAddressRequest(writableIPAddressRequest v4client.WritableIPAddressRequest) IpamIpAddressesCreateRequest
+	Execute() (*v4client.IPAddress, *http.Response, error)
 }
 
-type ExtrasInterface interface {
-	ExtrasCustomFieldsList(params *extras.ExtrasCustomFieldsListParams, authInfo runtime.ClientAuthInfoWriter, opts ...extras.ClientOption) (*extras.ExtrasCustomFieldsListOK, error)
+type IpamIpAddressesUpdateRequest interface {
+	WritableIPAddressRequest(writableIPAddressRequest v4client.WritableIPAddressRequest) IpamIpAddressesUpdateRequest
+	Execute() (*v4client.IPAddress, *http.Response, error)
 }
 
-type DcimInterface interface {
-	DcimSitesList(params *dcim.DcimSitesListParams, authInfo runtime.ClientAuthInfoWriter, opts ...dcim.ClientOption) (*dcim.DcimSitesListOK, error)
+type IpamIpAddressesDestroyRequest interface {
+	Execute() (*http.Response, error)
 }
 
-// V4 API Interfaces - Request Objects
-
 type IpamIpRangesListRequest interface {
 	StartAddress(startAddress []string) IpamIpRangesListRequest
 	EndAddress(endAddress []string) IpamIpRangesListRequest
+	VrfId(vrfId []*int32) IpamIpRangesListRequest
+	Limit(limit int32) IpamIpRangesListRequest
+	Offset(offset int32) IpamIpRangesListRequest
 	Execute() (*v4client.PaginatedIPRangeList, *http.Response, error)
 }
 
@@ -82,8 +71,17 @@ type IpamIpRangesDestroyRequest interface {
 	Execute() (*http.Response, error)
 }
 
+type IpamIpRangesAvailableIpsListRequest interface {
+	Execute() ([]v4client.AvailableIP, *http.Response, error)
+}
+
 type IpamPrefixesListRequest interface {
 	Prefix(prefix []string) IpamPrefixesListRequest
+	Within(within string) IpamPrefixesListRequest
+	Contains(contains string) IpamPrefixesListRequest
+	VrfId(vrfId []*int32) IpamPrefixesListRequest
+	Limit(limit int32) IpamPrefixesListRequest
+	Offset(offset int32) IpamPrefixesListRequest
 	Execute() (*v4client.PaginatedPrefixList, *http.Response, error)
 }
 
@@ -101,15 +99,124 @@ type IpamPrefixesDestroyRequest interface {
 	Execute() (*http.Response, error)
 }
 
+type IpamPrefixesAvailableIpsListRequest interface {
+	Execute() ([]v4client.AvailableIP, *http.Response, error)
+}
+
+type IpamPrefixesAvailablePrefixesListRequest interface {
+	Execute() ([]v4client.AvailablePrefix, *http.Response, error)
+}
+
+type IpamVrfsListRequest interface {
+	Name(name []string) IpamVrfsListRequest
+	Execute() (*v4client.PaginatedVRFList, *http.Response, error)
+}
+
+type IpamRolesListRequest interface {
+	Name(name []string) IpamRolesListRequest
+	Slug(slug []string) IpamRolesListRequest
+	Execute() (*v4client.PaginatedRoleList, *http.Response, error)
+}
+
+type IpamVlansListRequest interface {
+	Vid(vid []int32) IpamVlansListRequest
+	Name(name []string) IpamVlansListRequest
+	GroupId(groupId []*int32) IpamVlansListRequest
+	Limit(limit int32) IpamVlansListRequest
+	Offset(offset int32) IpamVlansListRequest
+	Execute() (*v4client.PaginatedVLANList, *http.Response, error)
+}
+
+type IpamVlansCreateRequest interface {
+	WritableVLANRequest(writableVLANRequest v4client.WritableVLANRequest) IpamVlansCreateRequest
+	Execute() (*v4client.VLAN, *http.Response, error)
+}
+
+type IpamVlansUpdateRequest interface {
+	WritableVLANRequest(writableVLANRequest v4client.WritableVLANRequest) IpamVlansUpdateRequest
+	Execute() (*v4client.VLAN, *http.Response, error)
+}
+
+type IpamVlansDestroyRequest interface {
+	Execute() (*http.Response, error)
+}
+
+type IpamVlanGroupsListRequest interface {
+	Name(name []string) IpamVlanGroupsListRequest
+	Slug(slug []string) IpamVlanGroupsListRequest
+	Limit(limit int32) IpamVlanGroupsListRequest
+	Offset(offset int32) IpamVlanGroupsListRequest
+	Execute() (*v4client.PaginatedVLANGroupList, *http.Response, error)
+}
+
+type IpamVlanGroupsAvailableVlansListRequest interface {
+	Execute() ([]v4client.AvailableVLAN, *http.Response, error)
+}
+
 type IpamAPI interface {
+	IpamIpAddressesList(ctx context.Context) IpamIpAddressesListRequest
+	IpamIpAddressesCreate(ctx context.Context) IpamIpAddressesCreateRequest
+	IpamIpAddressesUpdate(ctx context.Context, id int32) IpamIpAddressesUpdateRequest
+	IpamIpAddressesDestroy(ctx context.Context, id int32) IpamIpAddressesDestroyRequest
 	IpamIpRangesList(ctx context.Context) IpamIpRangesListRequest
 	IpamIpRangesCreate(ctx context.Context) IpamIpRangesCreateRequest
 	IpamIpRangesUpdate(ctx context.Context, id int32) IpamIpRangesUpdateRequest
 	IpamIpRangesDestroy(ctx context.Context, id int32) IpamIpRangesDestroyRequest
+	IpamIpRangesAvailableIpsList(ctx context.Context, id int32) IpamIpRangesAvailableIpsListRequest
 	IpamPrefixesList(ctx context.Context) IpamPrefixesListRequest
 	IpamPrefixesCreate(ctx context.Context) IpamPrefixesCreateRequest
 	IpamPrefixesUpdate(ctx context.Context, id int32) IpamPrefixesUpdateRequest
 	IpamPrefixesDestroy(ctx context.Context, id int32) IpamPrefixesDestroyRequest
+	IpamPrefixesAvailableIpsList(ctx context.Context, id int32) IpamPrefixesAvailableIpsListRequest
+	IpamPrefixesAvailablePrefixesList(ctx context.Context, id int32) IpamPrefixesAvailablePrefixesListRequest
+	IpamVrfsList(ctx context.Context) IpamVrfsListRequest
+	IpamRolesList(ctx context.Context) IpamRolesListRequest
+	IpamVlansList(ctx context.Context) IpamVlansListRequest
+	IpamVlansCreate(ctx context.Context) IpamVlansCreateRequest
+	IpamVlansUpdate(ctx context.Context, id int32) IpamVlansUpdateRequest
+	IpamVlansDestroy(ctx context.Context, id int32) IpamVlansDestroyRequest
+	IpamVlanGroupsList(ctx context.Context) IpamVlanGroupsListRequest
+	IpamVlanGroupsAvailableVlansList(ctx context.Context, id int32) IpamVlanGroupsAvailableVlansListRequest
+}
+
+type TenancyTenantsListRequest interface {
+	Name(name []string) TenancyTenantsListRequest
+	Execute() (*v4client.PaginatedTenantList, *http.Response, error)
+}
+
+type TenancyAPI interface {
+	TenancyTenantsList(ctx context.Context) TenancyTenantsListRequest
+}
+
+type DcimSitesListRequest interface {
+	Name(name []string) DcimSitesListRequest
+	Execute() (*v4client.PaginatedSiteList, *http.Response, error)
+}
+
+type DcimAPI interface {
+	DcimSitesList(ctx context.Context) DcimSitesListRequest
+}
+
+type ExtrasCustomFieldsListRequest interface {
+	Name(name []string) ExtrasCustomFieldsListRequest
+	Execute() (*v4client.PaginatedCustomFieldList, *http.Response, error)
+}
+
+type ExtrasTagsListRequest interface {
+	Name(name []string) ExtrasTagsListRequest
+	Slug(slug []string) ExtrasTagsListRequest
+	Execute() (*v4client.PaginatedTagList, *http.Response, error)
+}
+
+type ExtrasTagsCreateRequest interface {
+	TagRequest(tagRequest v4client.TagRequest) ExtrasTagsCreateRequest
+	Execute() (*v4client.Tag, *http.Response, error)
+}
+
+type ExtrasAPI interface {
+	ExtrasCustomFieldsList(ctx context.Context) ExtrasCustomFieldsListRequest
+	ExtrasTagsList(ctx context.Context) ExtrasTagsListRequest
+	ExtrasTagsCreate(ctx context.Context) ExtrasTagsCreateRequest
 }
 
 type APIStatusRetrieveRequest interface {
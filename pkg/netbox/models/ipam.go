@@ -16,6 +16,8 @@ limitations under the License.
 
 package models
 
+import "math/big"
+
 type Tenant struct {
 	Id   int64  `json:"id,omitempty"`
 	Name string `json:"name,omitempty"`
@@ -28,6 +30,29 @@ type Site struct {
 	Slug string `json:"slug,omitempty"`
 }
 
+type Vrf struct {
+	Id   int64  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type Role struct {
+	Id   int64  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Slug string `json:"slug,omitempty"`
+}
+
+type VlanGroup struct {
+	Id   int64  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Slug string `json:"slug,omitempty"`
+}
+
+type Tag struct {
+	Id   int64  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Slug string `json:"slug,omitempty"`
+}
+
 type NetboxMetadata struct {
 	Comments    string            `json:"comments,omitempty"`
 	Custom      map[string]string `json:"customFields,omitempty"`
@@ -35,21 +60,43 @@ type NetboxMetadata struct {
 	Region      string            `json:"region,omitempty"`
 	Site        string            `json:"site,omitempty"`
 	Tenant      string            `json:"tenant,omitempty"`
+	// The name of the VRF, an empty name is the global table
+	Vrf string `json:"vrf,omitempty"`
+	// The status of the resource, an empty status is active
+	Status string `json:"status,omitempty"`
+	// The name or slug of the IPAM role of a Prefix or IP Range, the role value of an IP Address
+	Role string `json:"role,omitempty"`
+	// The names or slugs of the tags to assign
+	Tags []string `json:"tags,omitempty"`
+	// The names or slugs of the tags which were assigned by the operator before, they are
+	// removed from the resource if they are not in Tags anymore
+	ManagedTags []string `json:"managedTags,omitempty"`
 }
 
 type IPAddress struct {
-	IpAddress string          `json:"ipAddress,omitempty"`
-	Metadata  *NetboxMetadata `json:"metadata,omitempty"`
+	IpAddress string `json:"ipAddress,omitempty"`
+	// The DNS name of the IP Address, an empty DNS name is only written if ClearDnsName is set
+	DnsName string `json:"dnsName,omitempty"`
+	// The DNS name was set by the operator before and is cleared if DnsName is empty
+	ClearDnsName bool            `json:"clearDnsName,omitempty"`
+	Metadata     *NetboxMetadata `json:"metadata,omitempty"`
 }
 
 type IPAddressClaim struct {
-	ParentPrefix string          `json:"parentPrefix,omitempty"`
-	Metadata     *NetboxMetadata `json:"metadata,omitempty"`
+	ParentPrefix string `json:"parentPrefix,omitempty"`
+	// The IP Range the IP Address is claimed from instead of the ParentPrefix, if set
+	ParentIpRange *IpRange        `json:"parentIpRange,omitempty"`
+	Metadata      *NetboxMetadata `json:"metadata,omitempty"`
 }
 
 type Prefix struct {
-	Prefix   string          `json:"prefix,omitempty"`
-	Metadata *NetboxMetadata `json:"metadata,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+	// The VLAN the prefix is assigned to, referenced by its Vid and VlanGroup or by its Name,
+	// the VLAN of the prefix is not changed if it is nil and ClearVlan is not set
+	Vlan *Vlan `json:"vlan,omitempty"`
+	// The VLAN was set by the operator before and the prefix is unassigned from it if Vlan is nil
+	ClearVlan bool            `json:"clearVlan,omitempty"`
+	Metadata  *NetboxMetadata `json:"metadata,omitempty"`
 }
 
 type PrefixClaim struct {
@@ -58,6 +105,26 @@ type PrefixClaim struct {
 	Metadata     *NetboxMetadata `json:"metadata,omitempty"`
 }
 
+// ParentPrefixCandidate is a prefix matching a parent prefix selector which can hold
+// the requested prefix, together with the data used to rank it against other candidates
+type ParentPrefixCandidate struct {
+	Prefix string `json:"prefix,omitempty"`
+	// The smallest available block in the candidate which can hold the requested prefix
+	SmallestFreeBlock string `json:"smallestFreeBlock,omitempty"`
+	// The share (0-1) of the candidate's address space which is already in use
+	Utilization float64 `json:"utilization,omitempty"`
+}
+
+// PrefixUtilization describes how much of the address space of a prefix is in use in NetBox
+type PrefixUtilization struct {
+	ChildPrefixes    int64 `json:"childPrefixes,omitempty"`
+	ChildIpAddresses int64 `json:"childIpAddresses,omitempty"`
+	// The number of free addresses, it can exceed an int64 for IPv6 prefixes
+	FreeAddresses *big.Int `json:"freeAddresses,omitempty"`
+	// The share of the address space which is in use, in percent rounded down
+	UsedPercent int32 `json:"usedPercent,omitempty"`
+}
+
 type IpRange struct {
 	StartAddress string          `json:"startAddress,omitempty"`
 	EndAddress   string          `json:"endAddress,omitempty"`
@@ -71,3 +138,17 @@ type IpRangeClaim struct {
 	Size         int             `json:"size,omitempty"`
 	Metadata     *NetboxMetadata `json:"metadata,omitempty"`
 }
+
+type Vlan struct {
+	Vid  int32  `json:"vid,omitempty"`
+	Name string `json:"name,omitempty"`
+	// The name or slug of the VLAN Group, an empty VLAN Group is a VLAN without group
+	VlanGroup string          `json:"vlanGroup,omitempty"`
+	Id        int64           `json:"id,omitempty"`
+	Metadata  *NetboxMetadata `json:"metadata,omitempty"`
+}
+
+type VlanClaim struct {
+	VlanGroup string          `json:"vlanGroup,omitempty"`
+	Metadata  *NetboxMetadata `json:"metadata,omitempty"`
+}